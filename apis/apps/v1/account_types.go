@@ -0,0 +1,198 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=acct
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="cluster name"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName",description="component name"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".status.userName",description="user name in the database"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Account declares a database user of a Component, which is managed by the application teams in a self-service way,
+// in contrast to the system accounts defined by the ComponentDefinition.
+//
+// The user is created and granted by the `accountProvision` lifecycle action of the Component,
+// and it is re-provisioned periodically to correct the drift, e.g., the user is dropped manually.
+// The user is dropped by the same action with the drop statement when the Account is deleted.
+//
+// The credential of the user is saved in a Secret with the same name as the Account.
+type Account struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountSpec   `json:"spec,omitempty"`
+	Status AccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountList contains a list of Account.
+type AccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Account `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Account{}, &AccountList{})
+}
+
+// AccountSpec defines the desired state of Account.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.passwordConfig) && has(self.secretRef))",message="passwordConfig and secretRef are mutually exclusive"
+type AccountSpec struct {
+	// Specifies the name of the Cluster that the user belongs to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component that the user belongs to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the name of the user in the database. Defaults to the name of the Account.
+	//
+	// The name should not conflict with the system accounts of the Component.
+	//
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.userName"
+	// +optional
+	UserName string `json:"userName,omitempty"`
+
+	// Specifies the policy to generate the password of the user.
+	//
+	// The password is generated once, and it is kept in the Secret of the Account.
+	//
+	// +optional
+	PasswordConfig *PasswordConfig `json:"passwordConfig,omitempty"`
+
+	// Refers to the Secret from which the password of the user will be copied.
+	//
+	// The Secret must be in the same namespace as the Account.
+	// The user is re-provisioned when the password in the referenced Secret is changed.
+	//
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Specifies the statements to manage the user, in the dialect of the database engine.
+	//
+	// +kubebuilder:validation:Required
+	Statements AccountStatements `json:"statements"`
+}
+
+// AccountStatements defines the statements to manage the user.
+//
+// The statements are passed to the `accountProvision` action as is, in the same way as the statements of the system accounts,
+// along with the name and password of the user in the KB_ACCOUNT_NAME and KB_ACCOUNT_PASSWORD variables.
+// The placeholders in the statements, e.g. $(USERNAME) and $(PASSWORD), are replaced by the action,
+// so that the password never appears in the statement.
+//
+// The create and grant statements are executed again to correct the drift or on changes,
+// so they should be idempotent, e.g., `CREATE USER IF NOT EXISTS` for MySQL.
+type AccountStatements struct {
+	// The statement to create the user, e.g., "CREATE USER IF NOT EXISTS $(USERNAME) IDENTIFIED BY '$(PASSWORD)'".
+	//
+	// +kubebuilder:validation:Required
+	Create string `json:"create"`
+
+	// The statements to grant the roles or privileges to the user, they are executed in order after the user is created.
+	//
+	// +optional
+	Grants []string `json:"grants,omitempty"`
+
+	// The statement to drop the user when the Account is deleted, e.g., "DROP USER IF EXISTS $(USERNAME)".
+	//
+	// The user is kept in the database if it is not specified.
+	//
+	// +optional
+	Drop string `json:"drop,omitempty"`
+}
+
+// AccountPhase defines the phase of the Account.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Provisioned,Failed,Deleting}
+type AccountPhase string
+
+const (
+	// AccountPendingPhase indicates that the user is waiting to be provisioned, e.g. the Component is not running yet.
+	AccountPendingPhase AccountPhase = "Pending"
+
+	// AccountProvisionedPhase indicates that the user is provisioned with the latest statements and password.
+	AccountProvisionedPhase AccountPhase = "Provisioned"
+
+	// AccountFailedPhase indicates that the provisioning of the user is failed, it will be retried.
+	AccountFailedPhase AccountPhase = "Failed"
+
+	// AccountDeletingPhase indicates that the user is being dropped.
+	AccountDeletingPhase AccountPhase = "Deleting"
+)
+
+// AccountStatus defines the observed state of Account.
+type AccountStatus struct {
+	// Represents the generation number that has been processed by the controller.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The current phase of the Account.
+	//
+	// +optional
+	Phase AccountPhase `json:"phase,omitempty"`
+
+	// Provides a human-readable explanation detailing the reason for the current phase of the Account.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The name of the user in the database.
+	//
+	// +optional
+	UserName string `json:"userName,omitempty"`
+
+	// The name of the Secret that holds the credential of the user.
+	//
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// The hash of the statements and the password that have been provisioned,
+	// it is used to decide whether the user needs to be re-provisioned on changes.
+	//
+	// +optional
+	ProvisionedHash string `json:"provisionedHash,omitempty"`
+
+	// The last time the user was provisioned successfully.
+	//
+	// +optional
+	LastProvisionedTime *metav1.Time `json:"lastProvisionedTime,omitempty"`
+}
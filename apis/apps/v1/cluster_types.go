@@ -197,6 +197,15 @@ type ClusterSpec struct {
 	//
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
+
+	// Specifies how to place the Cluster across the member k8s clusters in the multi-cluster mode.
+	//
+	// If not specified, all the member clusters are candidates, and the Cluster is placed on as many member clusters
+	// as the max replicas of its components.
+	// The placement is decided once the Cluster is created, and it is changed only when a chosen member cluster is drained.
+	//
+	// +optional
+	Placement *ClusterPlacement `json:"placement,omitempty"`
 }
 
 // ClusterStatus defines the observed state of the Cluster.
@@ -236,6 +245,11 @@ type ClusterStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the placement of the Cluster across the member k8s clusters in the multi-cluster mode.
+	//
+	// +optional
+	Placement *ClusterPlacementStatus `json:"placement,omitempty"`
 }
 
 // TerminationPolicyType defines termination policy types.
@@ -465,6 +479,18 @@ type ClusterComponentSpec struct {
 	// +listMapKey=name
 	Instances []InstanceTemplate `json:"instances,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 
+	// Specifies how the replicas are distributed across availability zones.
+	//
+	// If specified, an InstanceTemplate is generated for each zone to spread the replicas that are not claimed by
+	// the user-defined InstanceTemplates, and the generated templates are kept balanced as the Component scales.
+	// The names of the zones must not conflict with the names of the user-defined InstanceTemplates.
+	//
+	// The zone distribution can't be enabled or disabled once the Component is provisioned,
+	// since the Pods are named after the generated InstanceTemplates and all of them would be renamed.
+	//
+	// +optional
+	ZoneDistribution *ZoneDistribution `json:"zoneDistribution,omitempty"`
+
 	// Specifies the names of instances to be transitioned to offline status.
 	//
 	// Marking an instance as offline results in the following:
@@ -681,6 +707,123 @@ type ClusterBackup struct {
 	PITREnabled *bool `json:"pitrEnabled,omitempty"`
 }
 
+// ClusterPlacement defines how to place the Cluster across the member k8s clusters.
+//
+// The member clusters are selected by the labels of the MemberCluster objects,
+// the region of a member cluster is matched as the label `topology.kubernetes.io/region`.
+type ClusterPlacement struct {
+	// Specifies the member clusters that the Cluster can be placed on.
+	// If not specified, all the member clusters are candidates.
+	//
+	// +optional
+	RequiredContexts *metav1.LabelSelector `json:"requiredContexts,omitempty"`
+
+	// Specifies the preferences to choose the member clusters.
+	// The member clusters are scored by the sum of the weights of the preferences they match,
+	// the ones with higher scores are chosen first.
+	//
+	// +optional
+	PreferredContexts []WeightedContextSelector `json:"preferredContexts,omitempty"`
+
+	// Specifies the minimum number of replicas of a component placed on each member cluster.
+	//
+	// A component is spread across as many member clusters as possible, while each of them holds at least
+	// `minReplicasPerContext` replicas of the component.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicasPerContext int32 `json:"minReplicasPerContext,omitempty"`
+
+	// Specifies the maximum number of member clusters that the Cluster can be placed on.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxContexts *int32 `json:"maxContexts,omitempty"`
+
+	// Specifies what to do when a member cluster that the Cluster is placed on is drained.
+	//
+	// - `None`: keeps the placement and reports the drained member cluster in the status.
+	// - `Replace`: replaces the drained member cluster with another candidate, one at a time and only when the Cluster is running.
+	//   The replicas on the drained member cluster are re-created on the new one.
+	//
+	// +kubebuilder:default=None
+	// +optional
+	ReplacementPolicy PlacementReplacementPolicy `json:"replacementPolicy,omitempty"`
+}
+
+// WeightedContextSelector defines a preference to choose the member clusters.
+type WeightedContextSelector struct {
+	// The weight of the preference, in the range of 1-100.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Selects the member clusters preferred.
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// PlacementReplacementPolicy defines what to do when a member cluster that the Cluster is placed on is drained.
+//
+// +enum
+// +kubebuilder:validation:Enum={None,Replace}
+type PlacementReplacementPolicy string
+
+const (
+	NonePlacementReplacementPolicy    PlacementReplacementPolicy = "None"
+	ReplacePlacementReplacementPolicy PlacementReplacementPolicy = "Replace"
+)
+
+// ClusterPlacementStatus records the placement of the Cluster across the member k8s clusters.
+type ClusterPlacementStatus struct {
+	// The member clusters that the Cluster is placed on.
+	//
+	// +optional
+	Contexts []string `json:"contexts,omitempty"`
+
+	// The member clusters that each component is placed on, if it is placed on a subset of `contexts`.
+	//
+	// +optional
+	Components []ComponentPlacementStatus `json:"components,omitempty"`
+
+	// The member clusters placed on that have been drained.
+	//
+	// +optional
+	DrainedContexts []string `json:"drainedContexts,omitempty"`
+
+	// The history of the member clusters replaced, the latest one comes last.
+	//
+	// +optional
+	Replacements []PlacementReplacement `json:"replacements,omitempty"`
+
+	// Provides a human-readable explanation of the placement.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentPlacementStatus records the member clusters that a component is placed on.
+type ComponentPlacementStatus struct {
+	// The name of the component.
+	Name string `json:"name"`
+
+	// The member clusters that the component is placed on.
+	Contexts []string `json:"contexts"`
+}
+
+// PlacementReplacement records a member cluster replaced.
+type PlacementReplacement struct {
+	// The member cluster replaced.
+	From string `json:"from"`
+
+	// The member cluster that replaces the drained one.
+	To string `json:"to"`
+
+	// The time when the replacement happened.
+	Time metav1.Time `json:"time"`
+}
+
 // ClusterPhase defines the phase of the Cluster within the .status.phase field.
 //
 // +enum
@@ -244,6 +244,18 @@ type ComponentSpec struct {
 	// +optional
 	Instances []InstanceTemplate `json:"instances,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 
+	// Specifies how the replicas are distributed across availability zones.
+	//
+	// If specified, an InstanceTemplate is generated for each zone to spread the replicas that are not claimed by
+	// the user-defined InstanceTemplates, and the generated templates are kept balanced as the Component scales.
+	// The names of the zones must not conflict with the names of the user-defined InstanceTemplates.
+	//
+	// The zone distribution can't be enabled or disabled once the Component is provisioned,
+	// since the Pods are named after the generated InstanceTemplates and all of them would be renamed.
+	//
+	// +optional
+	ZoneDistribution *ZoneDistribution `json:"zoneDistribution,omitempty"`
+
 	// Specifies the names of instances to be transitioned to offline status.
 	//
 	// Marking an instance as offline results in the following:
@@ -325,4 +337,40 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message map[string]string `json:"message,omitempty"`
+
+	// Records the status of the TLS certificate used by the Component, it is absent if the TLS is not enabled.
+	//
+	// +optional
+	TLS *ComponentTLSStatus `json:"tls,omitempty"`
+}
+
+// ComponentTLSStatus records the status of the TLS certificate used by the Component.
+type ComponentTLSStatus struct {
+	// The issuer of the certificate.
+	//
+	// +optional
+	Issuer IssuerName `json:"issuer,omitempty"`
+
+	// The name of the Secret that holds the certificate.
+	//
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// The time before which the certificate is not valid.
+	//
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// The time after which the certificate is expired.
+	//
+	// The certificate issued by KubeBlocks is renewed automatically before it expires,
+	// while the certificate provided by the user should be renewed by the user.
+	//
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// The hash of the content of the certificate, the Pods are restarted to load the new certificate when it changes.
+	//
+	// +optional
+	CertificateHash string `json:"certificateHash,omitempty"`
 }
@@ -1532,6 +1532,20 @@ type ComponentLifecycleActions struct {
 	// +optional
 	Reconfigure *Action `json:"reconfigure,omitempty"`
 
+	// Defines the procedure to dump the effective parameters of the running engine, e.g. `SHOW GLOBAL VARIABLES`.
+	//
+	// The output of the action should list the parameters one per line, formatted as "name=value".
+	// The parameter names should be the same as those in the configuration files.
+	//
+	// It is used to detect the parameters that drift from the rendered configuration,
+	// e.g. the parameters modified by `SET GLOBAL` manually.
+	// The detection is enabled by the `driftPolicy` of the configuration items.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ParametersDump *Action `json:"parametersDump,omitempty"`
+
 	// Defines the procedure to generate a new database account.
 	//
 	// Use Case:
@@ -1548,6 +1562,32 @@ type ComponentLifecycleActions struct {
 	//
 	// +optional
 	AccountProvision *Action `json:"accountProvision,omitempty"`
+
+	// Defines the procedure to change the password of an existing system account.
+	//
+	// Use Case:
+	// This action is designed to rotate the passwords of the system accounts, on demand by an OpsRequest,
+	// or periodically as specified by the `rotationPolicy` of the account's password config.
+	//
+	// The rotation is carried out in two stages:
+	//
+	// - Rotate: The new password takes effect, and the previous one should remain valid
+	//   until the grace period is over, if the engine supports dual passwords (e.g., MySQL `RETAIN CURRENT PASSWORD`).
+	// - Discard: The grace period is over, and the previous password should be discarded.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_ACCOUNT_NAME: The name of the system account.
+	// - KB_ACCOUNT_PASSWORD: The new password for the system account.
+	// - KB_ACCOUNT_PREVIOUS_PASSWORD: The previous password for the system account.
+	// - KB_ACCOUNT_ROTATION_STAGE: The stage of the rotation, either "Rotate" or "Discard".
+	//
+	// The action may be retried with the same variables, so it should be idempotent.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	AccountRotate *Action `json:"accountRotate,omitempty"`
 }
 
 // Action defines a customizable hook or procedure tailored for different database engines,
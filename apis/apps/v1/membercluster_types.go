@@ -0,0 +1,217 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster,shortName=kbmc
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.region",description="region of the member cluster"
+// +kubebuilder:printcolumn:name="DISABLED",type="boolean",JSONPath=".spec.disabled",description="whether the member cluster is disabled"
+// +kubebuilder:printcolumn:name="DRAIN",type="boolean",JSONPath=".spec.drain",description="whether the member cluster is drained"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.serverVersion",description="kubernetes version of the member cluster"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MemberCluster describes a data-plane Kubernetes cluster that KubeBlocks manages in the multi-cluster mode.
+//
+// The name of the MemberCluster is used as the context name in the placement of Clusters.
+// Member clusters are registered and retired at runtime, without restarting the operator,
+// and they are probed periodically, a member cluster that fails the probes is marked as unavailable automatically.
+type MemberCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MemberClusterSpec   `json:"spec,omitempty"`
+	Status MemberClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MemberClusterList contains a list of MemberCluster.
+type MemberClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MemberCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MemberCluster{}, &MemberClusterList{})
+}
+
+// MemberClusterSpec defines the desired state of MemberCluster.
+type MemberClusterSpec struct {
+	// Specifies the Secret holding the kubeconfig to access the member cluster.
+	//
+	// +kubebuilder:validation:Required
+	KubeConfigSecretRef MemberClusterKubeConfigRef `json:"kubeConfigSecretRef"`
+
+	// Specifies the region where the member cluster is located.
+	//
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Specifies whether the member cluster is disabled.
+	//
+	// A disabled member cluster is still known to KubeBlocks, but all the requests to it are rejected as unavailable.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Specifies whether the member cluster is drained.
+	//
+	// No new Cluster is placed on a drained member cluster, and the Clusters placed on it are re-placed
+	// according to their replacement policies.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Drain bool `json:"drain,omitempty"`
+
+	// Specifies how to probe the health of the member cluster.
+	//
+	// +optional
+	HealthCheck *MemberClusterHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// MemberClusterKubeConfigRef references a kubeconfig stored in a Secret.
+type MemberClusterKubeConfigRef struct {
+	// The namespace of the Secret.
+	//
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// The name of the Secret.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The key of the kubeconfig in the Secret.
+	//
+	// +kubebuilder:default=kubeconfig
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// The context in the kubeconfig to use. Defaults to the current context of the kubeconfig.
+	//
+	// +optional
+	Context string `json:"context,omitempty"`
+}
+
+// MemberClusterHealthCheck defines how to probe the health of the member cluster.
+type MemberClusterHealthCheck struct {
+	// Specifies how often (in seconds) to probe the member cluster.
+	//
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies the number of seconds after which the probe times out.
+	//
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the number of consecutive failed probes after which the member cluster is marked as unavailable.
+	//
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// MemberClusterPhase defines the phase of the MemberCluster.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Ready,Unavailable,Disabled,Failed}
+type MemberClusterPhase string
+
+const (
+	// PendingMemberClusterPhase indicates the member cluster is registered but not probed yet.
+	PendingMemberClusterPhase MemberClusterPhase = "Pending"
+
+	// ReadyMemberClusterPhase indicates the member cluster is available.
+	ReadyMemberClusterPhase MemberClusterPhase = "Ready"
+
+	// UnavailableMemberClusterPhase indicates the member cluster fails the probes and is marked as unavailable.
+	UnavailableMemberClusterPhase MemberClusterPhase = "Unavailable"
+
+	// DisabledMemberClusterPhase indicates the member cluster is disabled.
+	DisabledMemberClusterPhase MemberClusterPhase = "Disabled"
+
+	// FailedMemberClusterPhase indicates the member cluster can not be registered, e.g., the kubeconfig is invalid.
+	FailedMemberClusterPhase MemberClusterPhase = "Failed"
+)
+
+// MemberClusterStatus defines the observed state of MemberCluster.
+type MemberClusterStatus struct {
+	// Represents the generation number that has been processed by the controller.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The current phase of the MemberCluster.
+	//
+	// +optional
+	Phase MemberClusterPhase `json:"phase,omitempty"`
+
+	// Provides a human-readable explanation detailing the reason for the current phase.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The Kubernetes version of the member cluster.
+	//
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// Records the last time the member cluster was probed.
+	//
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// The total allocatable resources of the ready and schedulable nodes in the member cluster, updated by the probes.
+	//
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+
+	// The number of consecutive failed probes.
+	//
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Represents the latest available observations of the member cluster.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
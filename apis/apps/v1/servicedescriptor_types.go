@@ -112,6 +112,117 @@ type ServiceDescriptorSpec struct {
 	//
 	// +optional
 	Auth *ConnectionCredentialAuth `json:"auth,omitempty"`
+
+	// Specifies an optional connectivity check against the external service.
+	//
+	// When set, the controller periodically probes the resolved endpoint (or host and port) and reports
+	// the result in the `status.conditions`, which are in turn propagated to the referencing Clusters.
+	//
+	// +optional
+	HealthCheck *ServiceDescriptorHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// ServiceDescriptorHealthCheckType defines the kind of connectivity check performed against the external service.
+//
+// +enum
+// +kubebuilder:validation:Enum={TCP,TLS}
+type ServiceDescriptorHealthCheckType string
+
+const (
+	// ServiceDescriptorTCPHealthCheck opens a TCP connection to the external service.
+	ServiceDescriptorTCPHealthCheck ServiceDescriptorHealthCheckType = "TCP"
+
+	// ServiceDescriptorTLSHealthCheck opens a TCP connection and completes a TLS handshake with the external service.
+	ServiceDescriptorTLSHealthCheck ServiceDescriptorHealthCheckType = "TLS"
+)
+
+// ServiceDescriptorHealthCheck defines how to check the connectivity of the external service.
+type ServiceDescriptorHealthCheck struct {
+	// Specifies the type of the connectivity check.
+	//
+	// +kubebuilder:default=TCP
+	// +optional
+	Type ServiceDescriptorHealthCheckType `json:"type,omitempty"`
+
+	// Specifies how often (in seconds) to perform the check.
+	//
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies the number of seconds after which the check times out.
+	//
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the TLS settings used by the `TLS` check.
+	//
+	// +optional
+	TLS *ServiceDescriptorTLSCheck `json:"tls,omitempty"`
+
+	// Specifies an optional action to verify the credentials of the external service.
+	//
+	// The action runs as a Job once the service is reachable, and is executed once per generation of the ServiceDescriptor.
+	//
+	// +optional
+	AuthAction *ServiceDescriptorAuthAction `json:"authAction,omitempty"`
+}
+
+// ServiceDescriptorTLSCheck defines the TLS settings of the connectivity check.
+type ServiceDescriptorTLSCheck struct {
+	// Specifies the server name used to verify the certificate of the external service.
+	// Defaults to the host of the external service.
+	//
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Specifies whether to skip the verification of the certificate of the external service.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// Specifies the Secret key holding the PEM-encoded CA bundle used to verify the external service.
+	// The Secret must be in the same namespace as the ServiceDescriptor.
+	// Defaults to the system trust store.
+	//
+	// +optional
+	CA *corev1.SecretKeySelector `json:"ca,omitempty"`
+}
+
+// ServiceDescriptorAuthAction defines a Job-based action to verify the credentials of the external service.
+//
+// The following environment variables are injected into the container:
+//
+// - SERVICE_ENDPOINT, SERVICE_HOST, SERVICE_PORT: the address of the external service.
+// - SERVICE_USER, SERVICE_PASSWORD: the credentials of the external service.
+//
+// The action is considered successful if the container exits with code 0.
+type ServiceDescriptorAuthAction struct {
+	// Specifies the container image to run.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Specifies the command to execute.
+	//
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+
+	// Specifies the arguments of the command.
+	//
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Specifies the maximum duration in seconds that the action is allowed to run.
+	//
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // ServiceDescriptorStatus defines the observed state of ServiceDescriptor
@@ -130,8 +241,30 @@ type ServiceDescriptorStatus struct {
 	//
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Represents the latest results of the health check, including the `Reachable` and `Authenticated` conditions.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Records the last time the health check was performed with a changed result.
+	//
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
 }
 
+const (
+	// ConditionTypeReachable indicates whether the external service is reachable.
+	ConditionTypeReachable = "Reachable"
+
+	// ConditionTypeAuthenticated indicates whether the credentials of the external service are accepted.
+	ConditionTypeAuthenticated = "Authenticated"
+)
+
 // ConnectionCredentialAuth specifies the authentication credentials required for accessing an external service.
 type ConnectionCredentialAuth struct {
 	// Specifies the username for the external service.
@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -81,6 +82,9 @@ const (
 	ConditionTypeProvisioningStarted = "ProvisioningStarted" // ConditionTypeProvisioningStarted the operator starts resource provisioning to create or change the cluster
 	ConditionTypeApplyResources      = "ApplyResources"      // ConditionTypeApplyResources the operator start to apply resources to create or change the cluster
 	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components and shardings are running
+
+	ConditionTypeTLSCertificateReady = "TLSCertificateReady" // ConditionTypeTLSCertificateReady the TLS certificate of the component is valid and not about to expire
+	ConditionTypeServiceRefsReady    = "ServiceRefsReady"    // ConditionTypeServiceRefsReady all external services referenced via ServiceDescriptors pass their health checks
 )
 
 type ServiceRef struct {
@@ -147,6 +151,22 @@ type ServiceRefClusterSelector struct {
 	// +kubebuilder:validation:Required
 	Cluster string `json:"cluster"`
 
+	// Specifies the multi-cluster contexts (data-plane Kubernetes clusters) in which the referenced Cluster runs,
+	// separated by commas.
+	//
+	// Leave it empty to use the placement of the referenced Cluster.
+	// It only takes effect when KubeBlocks manages multiple data-plane Kubernetes clusters.
+	//
+	// If the referencing Cluster is placed in contexts that the referenced Cluster does not run in,
+	// the in-cluster names are not reachable, and the values are resolved from the exposed Services instead:
+	//
+	// - host and port: the LoadBalancer ingress address, or a node address with the node-port of the Service.
+	// - pod FQDNs: the exposed address of the pod-service of each pod.
+	// - credentials: the values are copied from the account Secret.
+	//
+	// +optional
+	Context string `json:"context,omitempty"`
+
 	// Identifies a ClusterService from the list of Services defined in `cluster.spec.services` of the referenced Cluster.
 	//
 	// +optional
@@ -434,6 +454,31 @@ type PasswordConfig struct {
 	//
 	// +optional
 	Seed string `json:"seed,omitempty"`
+
+	// Specifies the policy to rotate the password.
+	// The password can be rotated on demand by an OpsRequest, and periodically if the period is set.
+	//
+	// Rotation requires the `accountRotate` lifecycle action to be defined,
+	// and the seed is ignored when generating the rotated passwords.
+	//
+	// +optional
+	RotationPolicy *PasswordRotationPolicy `json:"rotationPolicy,omitempty"`
+}
+
+// PasswordRotationPolicy defines the policy to rotate the password of a system account.
+type PasswordRotationPolicy struct {
+	// Specifies the period to rotate the password automatically, e.g., "720h".
+	// If not set, the password is rotated only on demand by an OpsRequest.
+	//
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+
+	// Specifies the duration for which the previous password remains valid after a rotation, e.g., "1h".
+	// The clients that still use the previous password should be updated within this duration.
+	// Defaults to zero, that is the previous password will be discarded immediately.
+	//
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // LetterCase defines the available cases to be used in password generation.
@@ -550,6 +595,61 @@ type SchedulingPolicy struct {
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
+// ZoneDistribution describes how the replicas of a Component are spread across availability zones.
+//
+// When specified, KubeBlocks generates one InstanceTemplate per zone, named after the zone,
+// which pins its Pods to the zone through a node selector on `topologyKey`.
+// The replicas not claimed by user-defined InstanceTemplates are distributed among the zones
+// according to their weights, after each zone has been assigned its minimum replicas.
+type ZoneDistribution struct {
+	// The node label key that identifies the zone a node belongs to.
+	//
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// The zones to spread the replicas across.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:Required
+	Zones []Zone `json:"zones"`
+
+	// The zone in which the leader replica is preferred to be placed.
+	// It must be one of the zones listed in `zones`.
+	//
+	// Replicas in the primary zone are preferred as the switchover candidate when the current leader
+	// is moved out, e.g., during a scale-in. And the leader is switched back to the primary zone
+	// if it is placed in other zones, e.g., after a failover, once all the replicas are ready.
+	//
+	// +optional
+	PrimaryZone string `json:"primaryZone,omitempty"`
+}
+
+// Zone describes an availability zone used by ZoneDistribution.
+type Zone struct {
+	// The name of the zone, which must match the value of the `topologyKey` label on the nodes of the zone.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=54
+	Name string `json:"name"`
+
+	// The relative weight of the zone when distributing replicas.
+	// A zone with weight 0 only receives its minimum replicas.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// The minimum number of replicas to be placed in the zone.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+}
+
 type TLSConfig struct {
 	// A boolean flag that indicates whether the Component should use Transport Layer Security (TLS)
 	// for secure communication.
@@ -573,16 +673,20 @@ type TLSConfig struct {
 }
 
 // Issuer defines the TLS certificates issuer for the Cluster.
+//
+// +kubebuilder:validation:XValidation:rule="self.name != 'CertManager' || has(self.issuerRef)",message="issuerRef is required when the issuer is CertManager"
 type Issuer struct {
 	// The issuer for TLS certificates.
-	// It only allows two enum values: `KubeBlocks` and `UserProvided`.
+	// It allows three enum values: `KubeBlocks`, `UserProvided` and `CertManager`.
 	//
 	// - `KubeBlocks` indicates that the self-signed TLS certificates generated by the KubeBlocks Operator will be used.
 	// - `UserProvided` means that the user is responsible for providing their own CA, Cert, and Key.
 	//   In this case, the user-provided CA certificate, server certificate, and private key will be used
 	//   for TLS communication.
+	// - `CertManager` means that the certificates are issued by the cert-manager Issuer or ClusterIssuer
+	//   referenced by the `issuerRef`, and they are renewed by cert-manager.
 	//
-	// +kubebuilder:validation:Enum={KubeBlocks, UserProvided}
+	// +kubebuilder:validation:Enum={KubeBlocks, UserProvided, CertManager}
 	// +kubebuilder:default=KubeBlocks
 	// +kubebuilder:validation:Required
 	Name IssuerName `json:"name"`
@@ -592,11 +696,17 @@ type Issuer struct {
 	//
 	// +optional
 	SecretRef *TLSSecretRef `json:"secretRef,omitempty"`
+
+	// IssuerRef is the reference to the cert-manager Issuer or ClusterIssuer that issues the certificates.
+	// It is required when the issuer is set to `CertManager`.
+	//
+	// +optional
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
 }
 
 // IssuerName defines the name of the TLS certificates issuer.
 // +enum
-// +kubebuilder:validation:Enum={KubeBlocks,UserProvided}
+// +kubebuilder:validation:Enum={KubeBlocks,UserProvided,CertManager}
 type IssuerName string
 
 const (
@@ -605,8 +715,33 @@ const (
 
 	// IssuerUserProvided indicates that the user has provided their own CA-signed certificates.
 	IssuerUserProvided IssuerName = "UserProvided"
+
+	// IssuerCertManager indicates that the certificates are issued by cert-manager.
+	IssuerCertManager IssuerName = "CertManager"
 )
 
+// CertManagerIssuerRef defines the reference to a cert-manager Issuer or ClusterIssuer.
+type CertManagerIssuerRef struct {
+	// The name of the Issuer or ClusterIssuer.
+	// The Issuer should be in the same namespace as the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The kind of the issuer, `Issuer` or `ClusterIssuer`.
+	//
+	// +kubebuilder:validation:Enum={Issuer,ClusterIssuer}
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// The group of the issuer, it should be set for the external issuers of cert-manager.
+	//
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
 // TLSSecretRef defines Secret contains Tls certs
 type TLSSecretRef struct {
 	// Name of the Secret that contains user-provided certificates.
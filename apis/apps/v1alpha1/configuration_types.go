@@ -83,8 +83,44 @@ type ConfigurationItemDetail struct {
 	//
 	// +optional
 	ConfigFileParams map[string]ConfigParams `json:"configFileParams,omitempty"`
+
+	// Specifies how to handle the parameters of the running engine that drift from the rendered configuration.
+	//
+	// The effective parameters are dumped by the `parametersDump` lifecycle action of the ComponentDefinition,
+	// and the drifted parameters are reported by the `ParametersDrifted` condition.
+	//
+	// - None (default): The drift detection is disabled.
+	// - Report: Only reports the drifted parameters.
+	// - Reapply: Reports the drifted parameters, and re-applies the desired values of the dynamic parameters
+	//   through the dynamic reload action.
+	//
+	// +optional
+	DriftPolicy ParametersDriftPolicy `json:"driftPolicy,omitempty"`
 }
 
+// ParametersDriftPolicy defines how to handle the parameters that drift from the rendered configuration.
+//
+// +enum
+// +kubebuilder:validation:Enum={None,Report,Reapply}
+type ParametersDriftPolicy string
+
+const (
+	NoneDriftPolicy    ParametersDriftPolicy = "None"
+	ReportDriftPolicy  ParametersDriftPolicy = "Report"
+	ReapplyDriftPolicy ParametersDriftPolicy = "Reapply"
+)
+
+const (
+	// ConditionTypeParametersDrifted indicates whether the effective parameters of the running engine drift from
+	// the rendered configuration.
+	ConditionTypeParametersDrifted = "ParametersDrifted"
+
+	ReasonParametersDrifted   = "ParametersDrifted"
+	ReasonParametersReapplied = "ParametersReapplied"
+	ReasonParametersNoDrift   = "NoDrift"
+	ReasonParametersDumpError = "ParametersDumpError"
+)
+
 // ConfigurationSpec defines the desired state of a Configuration resource.
 type ConfigurationSpec struct {
 	// Specifies the name of the Cluster that this configuration is associated with.
@@ -194,6 +230,100 @@ type ConfigurationItemDetailStatus struct {
 	//
 	// +optional
 	ReconcileDetail *ReconcileDetail `json:"reconcileDetail,omitempty"`
+
+	// Records the retained revisions of the configuration item, ordered from the oldest to the newest.
+	// Each revision can be used as the target of a rollback.
+	//
+	// +optional
+	RevisionHistory []ConfigurationRevisionHistory `json:"revisionHistory,omitempty"`
+
+	// Reports the parameter-level diff between the two revisions requested by the annotation
+	// `config.kubeblocks.io/revision-diff: "<from>,<to>"` of the ConfigMap of the configuration item.
+	//
+	// +optional
+	RevisionDiff *ConfigurationRevisionDiff `json:"revisionDiff,omitempty"`
+}
+
+// ConfigurationRevisionDiff describes the parameter-level diff between two revisions of a configuration item.
+type ConfigurationRevisionDiff struct {
+	// The base revision.
+	//
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+
+	// The target revision.
+	//
+	// +kubebuilder:validation:Required
+	To string `json:"to"`
+
+	// The parameters changed from the base revision to the target revision.
+	//
+	// +optional
+	Changes []ParameterChange `json:"changes,omitempty"`
+
+	// The reason why the diff can't be computed, e.g., the revision has been garbage collected.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ConfigurationRevisionHistory describes a retained revision of a configuration item.
+type ConfigurationRevisionHistory struct {
+	// The revision of the configuration item.
+	//
+	// +kubebuilder:validation:Required
+	Revision string `json:"revision"`
+
+	// The reconcile phase of the revision.
+	//
+	// +optional
+	Phase ConfigurationPhase `json:"phase,omitempty"`
+
+	// The parameters changed in the revision, compared to its previous retained revision.
+	//
+	// +optional
+	Changes []ParameterChange `json:"changes,omitempty"`
+}
+
+// ParameterChangeType defines the type of a parameter change.
+//
+// +enum
+// +kubebuilder:validation:Enum={Added,Updated,Deleted}
+type ParameterChangeType string
+
+const (
+	ParameterAdded   ParameterChangeType = "Added"
+	ParameterUpdated ParameterChangeType = "Updated"
+	ParameterDeleted ParameterChangeType = "Deleted"
+)
+
+// ParameterChange describes a change of a parameter between two revisions of a configuration item.
+type ParameterChange struct {
+	// The configuration file (the key of the ConfigMap) that the parameter belongs to.
+	//
+	// +kubebuilder:validation:Required
+	File string `json:"file"`
+
+	// The name of the parameter.
+	// An empty name indicates that the whole content of the file is changed.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The type of the change.
+	//
+	// +kubebuilder:validation:Required
+	Type ParameterChangeType `json:"type"`
+
+	// The value of the parameter in the base revision.
+	//
+	// +optional
+	OldValue *string `json:"oldValue,omitempty"`
+
+	// The value of the parameter in the target revision.
+	//
+	// +optional
+	NewValue *string `json:"newValue,omitempty"`
 }
 
 // ConfigurationStatus represents the observed state of a Configuration resource.
@@ -204,6 +204,16 @@ type ReloadAction struct {
 	// +optional
 	TPLScriptTrigger *TPLScriptTrigger `json:"tplScriptTrigger"`
 
+	// Allows to reload the process by sending HTTP requests to the reload endpoint exposed by the engine.
+	//
+	// +optional
+	HTTPTrigger *HTTPTrigger `json:"httpTrigger,omitempty"`
+
+	// Allows to apply the updated parameters by executing SQL statements, e.g. `SET GLOBAL`.
+	//
+	// +optional
+	SQLTrigger *SQLTrigger `json:"sqlTrigger,omitempty"`
+
 	// Automatically perform the reload when specified conditions are met.
 	//
 	// +optional
@@ -486,6 +496,124 @@ type TPLScriptTrigger struct {
 	Sync *bool `json:"sync,omitempty"`
 }
 
+// HTTPTrigger allows to reload the process by sending HTTP requests to the reload endpoint exposed by the engine.
+type HTTPTrigger struct {
+	// Specifies the URL of the reload endpoint, e.g. "http://127.0.0.1:8080/reload".
+	//
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// Specifies the HTTP method of the request.
+	//
+	// +kubebuilder:validation:Enum={GET,POST,PUT,PATCH}
+	// +kubebuilder:default="POST"
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Specifies the headers of the request.
+	//
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Specifies a Go template string for formatting the request body.
+	// The template accesses key-value pairs of updated parameters via the '$' variable.
+	//
+	// Example template:
+	//
+	// ```yaml
+	// bodyTemplate: |-
+	// {{- range $pKey, $pValue := $ }}
+	// {{ printf "%s=%s" $pKey $pValue }}
+	// {{- end }}
+	// ```
+	//
+	// If not specified, the body is a JSON object of the updated parameters.
+	//
+	// +optional
+	BodyTemplate string `json:"bodyTemplate,omitempty"`
+
+	// Controls whether parameter updates are processed individually or collectively in a batch:
+	//
+	// - 'True': Sends all changes in one request.
+	// - 'False': Sends a request for each change.
+	//
+	// Defaults to 'False' if unspecified.
+	//
+	// +optional
+	BatchReload *bool `json:"batchReload,omitempty"`
+
+	// Specifies the name of the system account of the Component, whose credential is used for the basic authentication.
+	//
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// Determines the synchronization mode of parameter updates with "config-manager".
+	//
+	// - 'True': Executes reload actions synchronously, pausing until completion.
+	// - 'False': Executes reload actions asynchronously, without waiting for completion.
+	//
+	// +optional
+	Sync *bool `json:"sync,omitempty"`
+}
+
+// SQLTrigger allows to apply the updated parameters by executing SQL statements.
+type SQLTrigger struct {
+	// Specifies the type of the database engine.
+	//
+	// +kubebuilder:validation:Enum={mysql}
+	// +kubebuilder:default="mysql"
+	// +optional
+	DataType string `json:"dataType,omitempty"`
+
+	// Specifies the address of the database, e.g. "127.0.0.1:3306".
+	//
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// Specifies a Go template string for formatting the SQL statement.
+	// The template accesses key-value pairs of updated parameters via the '$' variable.
+	//
+	// Example template:
+	//
+	// ```yaml
+	// statementTemplate: |-
+	// {{- range $pKey, $pValue := $ }}
+	// SET GLOBAL {{ $pKey }} = {{ $pValue }}
+	// {{- end }}
+	// ```
+	//
+	// The template above is suitable for the individual mode only, since a statement is expected for each execution.
+	//
+	// If not specified, the statement is formatted as "SET GLOBAL key1 = value1, key2 = value2",
+	// and the values are quoted unless they are numbers.
+	//
+	// +optional
+	StatementTemplate string `json:"statementTemplate,omitempty"`
+
+	// Controls whether parameter updates are processed individually or collectively in a batch:
+	//
+	// - 'True': Applies all changes in one statement.
+	// - 'False': Applies each change in a separate statement.
+	//
+	// Defaults to 'False' if unspecified.
+	//
+	// +optional
+	BatchReload *bool `json:"batchReload,omitempty"`
+
+	// Specifies the name of the system account of the Component, whose credential is used to connect to the database.
+	//
+	// +kubebuilder:validation:Required
+	AccountName string `json:"accountName"`
+
+	// Determines the synchronization mode of parameter updates with "config-manager".
+	//
+	// - 'True': Executes reload actions synchronously, pausing until completion.
+	// - 'False': Executes reload actions asynchronously, without waiting for completion.
+	//
+	// +optional
+	Sync *bool `json:"sync,omitempty"`
+}
+
 // AutoTrigger automatically perform the reload when specified conditions are met.
 type AutoTrigger struct {
 	// The name of the process.
@@ -248,6 +248,38 @@ func (in *FormatterAction) DeepCopy() *FormatterAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BatchReload != nil {
+		in, out := &in.BatchReload, &out.BatchReload
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTrigger.
+func (in *HTTPTrigger) DeepCopy() *HTTPTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IniConfig) DeepCopyInto(out *IniConfig) {
 	*out = *in
@@ -300,6 +332,16 @@ func (in *ReloadAction) DeepCopyInto(out *ReloadAction) {
 		*out = new(TPLScriptTrigger)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTPTrigger != nil {
+		in, out := &in.HTTPTrigger, &out.HTTPTrigger
+		*out = new(HTTPTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQLTrigger != nil {
+		in, out := &in.SQLTrigger, &out.SQLTrigger
+		*out = new(SQLTrigger)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AutoTrigger != nil {
 		in, out := &in.AutoTrigger, &out.AutoTrigger
 		*out = new(AutoTrigger)
@@ -322,6 +364,31 @@ func (in *ReloadAction) DeepCopy() *ReloadAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLTrigger) DeepCopyInto(out *SQLTrigger) {
+	*out = *in
+	if in.BatchReload != nil {
+		in, out := &in.BatchReload, &out.BatchReload
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLTrigger.
+func (in *SQLTrigger) DeepCopy() *SQLTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptConfig) DeepCopyInto(out *ScriptConfig) {
 	*out = *in
@@ -0,0 +1,516 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ComponentAutoscalerSpec defines the desired state of ComponentAutoscaler
+type ComponentAutoscalerSpec struct {
+	// Specified the target Cluster name this autoscaler applies to.
+	TargetClusterName string `json:"targetClusterName"`
+
+	// Specified the target Component name this autoscaler applies to.
+	TargetComponentName string `json:"targetComponentName"`
+
+	// Specifies how to scale the number of instances of the Component.
+	//
+	// +optional
+	Horizontal *HorizontalAutoscaling `json:"horizontal,omitempty"`
+
+	// Specifies how to scale the compute resources of the instances of the Component.
+	//
+	// +optional
+	Vertical *VerticalAutoscaling `json:"vertical,omitempty"`
+
+	// Specifies how to expand the volumes of the instances of the Component.
+	//
+	// +optional
+	Storage *StorageAutoscaling `json:"storage,omitempty"`
+
+	// Specifies the time windows in which the horizontal and vertical scaling OpsRequests are allowed to be created.
+	// They can be created at any time if not set.
+	// The volume expansions are not restricted by the windows, since they are online and usually urgent.
+	//
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Specifies the URL of the Prometheus server to evaluate the Prometheus metrics and read the volume usages against,
+	// e.g. "http://prometheus-server.monitoring:9090".
+	// The endpoint configured for the KubeBlocks controller manager is used if not set.
+	//
+	// +optional
+	PrometheusEndpoint string `json:"prometheusEndpoint,omitempty"`
+}
+
+// HorizontalAutoscaling defines how to scale the number of instances of a Component.
+type HorizontalAutoscaling struct {
+	// The lower bound of the number of instances.
+	// The minimum replicas of the ReplicasLimit in the ComponentDefinition takes precedence if it is greater.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The upper bound of the number of instances.
+	// The maximum replicas of the ReplicasLimit in the ComponentDefinition takes precedence if it is less.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Specifies the metrics to calculate the desired number of instances.
+	// The largest number of instances recommended by the metrics is used.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Metrics []MetricSource `json:"metrics"`
+
+	// Specifies the rules to scale out.
+	//
+	// +optional
+	ScaleUp *ScalingRules `json:"scaleUp,omitempty"`
+
+	// Specifies the rules to scale in.
+	//
+	// +optional
+	ScaleDown *ScalingRules `json:"scaleDown,omitempty"`
+}
+
+// VerticalAutoscaling defines how to scale the compute resources of the instances of a Component.
+type VerticalAutoscaling struct {
+	// Specifies the policies of the resources to scale.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Resources []VerticalResourcePolicy `json:"resources"`
+
+	// Specifies the rules to increase the resources.
+	//
+	// +optional
+	ScaleUp *ScalingRules `json:"scaleUp,omitempty"`
+
+	// Specifies the rules to decrease the resources.
+	//
+	// +optional
+	ScaleDown *ScalingRules `json:"scaleDown,omitempty"`
+}
+
+// VerticalResourcePolicy defines how to scale a kind of compute resource of the instances.
+type VerticalResourcePolicy struct {
+	// The name of the resource.
+	//
+	// +kubebuilder:validation:Enum={cpu,memory}
+	Name corev1.ResourceName `json:"name"`
+
+	// The desired utilization of the resource, represented as a percentage of the requested resource.
+	// The request is set to the peak usage of the instances divided by the utilization.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TargetAverageUtilization int32 `json:"targetAverageUtilization"`
+
+	// A PromQL query that evaluates the usage of the resource of each instance, in cores for cpu and bytes for memory.
+	// The query should yield a vector whose samples are the usages of the instances, or a scalar of the peak usage.
+	// The usage reported by the Kubernetes metrics API is used if not set.
+	//
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// The lower bound of the requested resource.
+	//
+	// +optional
+	MinAllowed *resource.Quantity `json:"minAllowed,omitempty"`
+
+	// The upper bound of the requested resource.
+	//
+	// +optional
+	MaxAllowed *resource.Quantity `json:"maxAllowed,omitempty"`
+}
+
+// StorageAutoscaling defines how to expand the volumes of the instances of a Component.
+type StorageAutoscaling struct {
+	// Specifies the policies of the volume claim templates to expand.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	VolumeClaimTemplates []VolumeAutoscalingPolicy `json:"volumeClaimTemplates"`
+
+	// The source of the volume usages.
+	//
+	// +kubebuilder:default=Prometheus
+	// +optional
+	Source VolumeStatsSourceType `json:"source,omitempty"`
+
+	// The number of seconds to wait after the last expansion before expanding the volumes again.
+	// Some storage providers limit how often a volume can be modified.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=300
+	// +optional
+	CooldownSeconds *int32 `json:"cooldownSeconds,omitempty"`
+
+	// Specifies whether to switch the instances whose volume usages exceed the threshold to the read-only state
+	// by the `readonly` lifecycle action, if the volumes can not be expanded any more,
+	// either reaching the max size or the StorageClass not allowing volume expansion.
+	// The instances are switched back by the `readwrite` lifecycle action once the usages fall below the threshold.
+	//
+	// +optional
+	ReadOnlyOnExhausted bool `json:"readOnlyOnExhausted,omitempty"`
+}
+
+// VolumeAutoscalingPolicy defines how to expand the volumes of a volume claim template.
+type VolumeAutoscalingPolicy struct {
+	// The name of the volume claim template of the Component.
+	Name string `json:"name"`
+
+	// The usage of the volume, represented as a percentage of its capacity, above which the volume is expanded.
+	// The peak usage of the instances is used.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	UsageThresholdPercent int32 `json:"usageThresholdPercent"`
+
+	// The amount to increase the volume by each time, either a quantity (e.g. "10Gi")
+	// or a percentage of the current size (e.g. "20%").
+	// The new size is rounded up to a whole number of gibibytes.
+	//
+	// +kubebuilder:validation:XIntOrString
+	Increment intstr.IntOrString `json:"increment"`
+
+	// The max size of the volume.
+	MaxSize resource.Quantity `json:"maxSize"`
+}
+
+// VolumeStatsSourceType defines where the volume usages are read from.
+//
+// +enum
+// +kubebuilder:validation:Enum={Prometheus}
+type VolumeStatsSourceType string
+
+const (
+	// PrometheusVolumeStatsSource reads the volume usages from the kubelet_volume_stats_* metrics of the kubelets
+	// scraped by the Prometheus endpoint.
+	PrometheusVolumeStatsSource VolumeStatsSourceType = "Prometheus"
+)
+
+// MetricSourceType defines the type of the metric source.
+//
+// +enum
+// +kubebuilder:validation:Enum={Resource,Prometheus}
+type MetricSourceType string
+
+const (
+	// ResourceMetricSourceType reads the resource usage of the instances from the Kubernetes metrics API.
+	ResourceMetricSourceType MetricSourceType = "Resource"
+
+	// PrometheusMetricSourceType evaluates a PromQL query against the Prometheus endpoint.
+	PrometheusMetricSourceType MetricSourceType = "Prometheus"
+)
+
+// MetricSource specifies a metric and its target value.
+// Exactly one of the sources matching the Type must be set.
+type MetricSource struct {
+	// The type of the metric source.
+	Type MetricSourceType `json:"type"`
+
+	// Specifies a resource metric read from the Kubernetes metrics API.
+	//
+	// +optional
+	Resource *ResourceMetricSource `json:"resource,omitempty"`
+
+	// Specifies a metric evaluated by a PromQL query.
+	//
+	// +optional
+	Prometheus *PrometheusMetricSource `json:"prometheus,omitempty"`
+}
+
+// ResourceMetricSource defines a resource metric of the instances.
+type ResourceMetricSource struct {
+	// The name of the resource.
+	//
+	// +kubebuilder:validation:Enum={cpu,memory}
+	Name corev1.ResourceName `json:"name"`
+
+	// The target average utilization of the resource over all the ready instances,
+	// represented as a percentage of the requested resource.
+	//
+	// +kubebuilder:validation:Minimum=1
+	TargetAverageUtilization int32 `json:"targetAverageUtilization"`
+}
+
+// PrometheusMetricSource defines a metric evaluated by a PromQL query.
+type PrometheusMetricSource struct {
+	// The PromQL query that evaluates the metric.
+	// The query should yield a scalar, or a vector whose samples are averaged, as the value per instance.
+	Query string `json:"query"`
+
+	// The target value per instance.
+	TargetAverageValue resource.Quantity `json:"targetAverageValue"`
+}
+
+// ScalingRules defines the rules to scale in one direction.
+type ScalingRules struct {
+	// The number of seconds of the past recommendations to consider while scaling.
+	// The most conservative recommendation in the window is used, to avoid flapping.
+	// Defaults to 0 for scaling up and 300 for scaling down.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
+
+	// The number of seconds to wait after the last scaling before scaling in this direction again.
+	// Defaults to 60 for scaling up and 300 for scaling down.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CooldownSeconds *int32 `json:"cooldownSeconds,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window.
+type MaintenanceWindow struct {
+	// The days of the week on which the window starts. Every day if not set.
+	//
+	// +optional
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// The start time of the window in the format "HH:MM".
+	//
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// The length of the window in minutes.
+	//
+	// +kubebuilder:validation:Minimum=1
+	DurationMinutes int32 `json:"durationMinutes"`
+
+	// The IANA time zone of the StartTime, e.g. "Asia/Shanghai". Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// DayOfWeek defines a day of the week.
+//
+// +enum
+// +kubebuilder:validation:Enum={Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday}
+type DayOfWeek string
+
+// ComponentAutoscalerStatus defines the observed state of ComponentAutoscaler
+type ComponentAutoscalerStatus struct {
+	// The current number of instances of the Component.
+	//
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// The number of instances recommended by the latest stabilized metrics.
+	//
+	// +optional
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+
+	// The resources requests recommended by the latest stabilized metrics.
+	//
+	// +optional
+	DesiredResources corev1.ResourceList `json:"desiredResources,omitempty"`
+
+	// The latest observations of the volumes of the volume claim templates in the storage autoscaling.
+	//
+	// +optional
+	Volumes []VolumeAutoscalingStatus `json:"volumes,omitempty"`
+
+	// The names of the instances switched to the read-only state by the autoscaler.
+	//
+	// +optional
+	ReadOnlyInstances []string `json:"readOnlyInstances,omitempty"`
+
+	// LastExpansionTime is the last time the autoscaler created a VolumeExpansion OpsRequest.
+	//
+	// +optional
+	LastExpansionTime *metav1.Time `json:"lastExpansionTime,omitempty"`
+
+	// The latest values of the metrics.
+	//
+	// +optional
+	CurrentMetrics []MetricValue `json:"currentMetrics,omitempty"`
+
+	// The recent recommendations, kept for the stabilization windows.
+	//
+	// +optional
+	Recommendations []Recommendation `json:"recommendations,omitempty"`
+
+	// The name of the last OpsRequest created by the autoscaler.
+	//
+	// +optional
+	LastOpsRequestName string `json:"lastOpsRequestName,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler created a scaling OpsRequest.
+	//
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Represents the latest available observations of a componentautoscaler's current state.
+	// Known .status.conditions.type are: "ScalingActive", "AbleToScale", "AbleToExpandVolumes".
+	// ScalingActive - The metrics can be read and the recommendations can be calculated.
+	// AbleToScale - The autoscaler is able to create scaling OpsRequests.
+	// AbleToExpandVolumes - The autoscaler is able to create VolumeExpansion OpsRequests.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// VolumeAutoscalingStatus records the observation of the volumes of a volume claim template.
+type VolumeAutoscalingStatus struct {
+	// The name of the volume claim template.
+	Name string `json:"name"`
+
+	// The current size of the volume claim template.
+	//
+	// +optional
+	CurrentSize *resource.Quantity `json:"currentSize,omitempty"`
+
+	// The peak usage of the volumes, represented as a percentage of their capacities.
+	UsagePercent int32 `json:"usagePercent"`
+
+	// The size the volumes are being expanded to.
+	//
+	// +optional
+	DesiredSize *resource.Quantity `json:"desiredSize,omitempty"`
+
+	// Whether the volumes can not be expanded any more.
+	//
+	// +optional
+	Exhausted bool `json:"exhausted,omitempty"`
+}
+
+// MetricValue records the value of a metric.
+type MetricValue struct {
+	// The type of the metric source.
+	Type MetricSourceType `json:"type"`
+
+	// The resource name or the query of the metric.
+	Name string `json:"name"`
+
+	// The current value of the metric, which is the average utilization percentage for resource metrics
+	// and the average value per instance for Prometheus metrics.
+	Value resource.Quantity `json:"value"`
+}
+
+// Recommendation records a recommendation calculated from the metrics.
+type Recommendation struct {
+	// The time of the recommendation.
+	Time metav1.Time `json:"time"`
+
+	// The recommended number of instances.
+	//
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// The recommended resources requests.
+	//
+	// +optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+}
+
+const (
+	// ScalingActive is added to a componentautoscaler when the metrics are read.
+	ScalingActive ConditionType = "ScalingActive"
+
+	// AbleToScale is added to a componentautoscaler when it checks whether a scaling OpsRequest can be created.
+	AbleToScale ConditionType = "AbleToScale"
+
+	// AbleToExpandVolumes is added to a componentautoscaler when it checks whether the volumes can be expanded.
+	AbleToExpandVolumes ConditionType = "AbleToExpandVolumes"
+)
+
+const (
+	// ReasonValidMetricFound is a reason for condition ScalingActive.
+	ReasonValidMetricFound = "ValidMetricFound"
+
+	// ReasonFailedGetMetrics is a reason for condition ScalingActive.
+	ReasonFailedGetMetrics = "FailedGetMetrics"
+
+	// ReasonReadyForNewScale is a reason for condition AbleToScale and AbleToExpandVolumes.
+	ReasonReadyForNewScale = "ReadyForNewScale"
+
+	// ReasonOpsRequestInProgress is a reason for condition AbleToScale and AbleToExpandVolumes.
+	ReasonOpsRequestInProgress = "OpsRequestInProgress"
+
+	// ReasonCoolingDown is a reason for condition AbleToScale and AbleToExpandVolumes.
+	ReasonCoolingDown = "CoolingDown"
+
+	// ReasonOutOfMaintenanceWindow is a reason for condition AbleToScale.
+	ReasonOutOfMaintenanceWindow = "OutOfMaintenanceWindow"
+
+	// ReasonScaling is a reason for condition AbleToScale and AbleToExpandVolumes.
+	ReasonScaling = "Scaling"
+
+	// ReasonFailedGetVolumeStats is a reason for condition AbleToExpandVolumes.
+	ReasonFailedGetVolumeStats = "FailedGetVolumeStats"
+
+	// ReasonVolumesExhausted is a reason for condition AbleToExpandVolumes.
+	ReasonVolumesExhausted = "VolumesExhausted"
+
+	// ReasonSwitchedReadOnly is a reason of the events when instances are switched to the read-only state.
+	ReasonSwitchedReadOnly = "SwitchedReadOnly"
+
+	// ReasonSwitchedReadWrite is a reason of the events when instances are switched back to the read-write state.
+	ReasonSwitchedReadWrite = "SwitchedReadWrite"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=cas
+// +kubebuilder:printcolumn:name="TARGET-CLUSTER-NAME",type="string",JSONPath=".spec.targetClusterName",description="target cluster name."
+// +kubebuilder:printcolumn:name="TARGET-COMPONENT-NAME",type="string",JSONPath=".spec.targetComponentName",description="target component name."
+// +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.currentReplicas",description="current replicas."
+// +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".status.desiredReplicas",description="desired replicas."
+// +kubebuilder:printcolumn:name="ABLE-TO-SCALE",type="string",JSONPath=".status.conditions[?(@.type==\"AbleToScale\")].reason",description="able to scale."
+// +kubebuilder:printcolumn:name="LAST-SCALE-TIME",type="date",JSONPath=".status.lastScaleTime"
+
+// ComponentAutoscaler is the Schema for the componentautoscalers API
+type ComponentAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentAutoscalerSpec   `json:"spec,omitempty"`
+	Status ComponentAutoscalerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentAutoscalerList contains a list of ComponentAutoscaler
+type ComponentAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentAutoscaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentAutoscaler{}, &ComponentAutoscalerList{})
+}
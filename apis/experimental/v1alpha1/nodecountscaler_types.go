@@ -36,6 +36,24 @@ type NodeCountScalerSpec struct {
 	//
 	// +optional
 	TargetComponentNames []string `json:"targetComponentNames,omitempty"`
+
+	// Selects the nodes to count by labels, in addition to the scheduling constraints of each Component.
+	// All nodes that the instances of a Component can be scheduled to are counted if not set.
+	//
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// The lower bound of the desired number of instances of each Component.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The upper bound of the desired number of instances of each Component.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 }
 
 // NodeCountScalerStatus defines the observed state of NodeCountScaler
@@ -76,8 +94,14 @@ type ComponentStatus struct {
 	AvailableReplicas int32 `json:"availableReplicas"`
 
 	// The desired number of instances of this component.
-	// Usually, it should be the number of nodes.
+	// Usually, it should be the number of matched nodes, bounded by the MinReplicas and MaxReplicas.
 	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// The number of nodes that the instances of this component can be scheduled to, which are ready, schedulable,
+	// selected by the NodeSelector, and satisfy the node selectors, required node affinity and tolerations of the component.
+	//
+	// +optional
+	MatchedNodes int32 `json:"matchedNodes,omitempty"`
 }
 
 type ConditionType string
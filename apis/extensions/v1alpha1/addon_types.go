@@ -31,13 +31,14 @@ import (
 
 // AddonSpec defines the desired state of an add-on.
 // +kubebuilder:validation:XValidation:rule="has(self.type) && self.type == 'Helm' ?  has(self.helm) : !has(self.helm)",message="spec.helm is required when spec.type is Helm, and forbidden otherwise"
+// +kubebuilder:validation:XValidation:rule="has(self.type) && self.type == 'Manifest' ?  has(self.manifest) : !has(self.manifest)",message="spec.manifest is required when spec.type is Manifest, and forbidden otherwise"
 type AddonSpec struct {
 	// Specifies the description of the add-on.
 	//
 	// +optional
 	Description string `json:"description,omitempty"`
 
-	// Defines the type of the add-on. The only valid value is 'helm'.
+	// Defines the type of the add-on. Valid values are 'Helm' and 'Manifest'.
 	//
 	// +unionDiscriminator
 	// +kubebuilder:validation:Required
@@ -53,12 +54,29 @@ type AddonSpec struct {
 	// +optional
 	Provider string `json:"provider,omitempty"`
 
+	// Specifies the add-ons that this add-on depends on. The add-on is installed only after all
+	// its dependencies are enabled, and an add-on can't be disabled while any enabled add-on
+	// depends on it.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Dependencies []AddonDependency `json:"dependencies,omitempty"`
+
 	// Represents the Helm installation specifications. This is only processed
 	// when the type is set to 'helm'.
 	//
 	// +optional
 	Helm *HelmTypeInstallSpec `json:"helm,omitempty"`
 
+	// Represents the manifest installation specifications. This is only processed
+	// when the type is set to 'Manifest'.
+	//
+	// +optional
+	Manifest *ManifestTypeInstallSpec `json:"manifest,omitempty"`
+
 	// Specifies the default installation parameters.
 	//
 	// +kubebuilder:validation:Required
@@ -100,6 +118,60 @@ type AddonStatus struct {
 	//
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Lists the objects applied by a 'Manifest' type add-on. Objects that are no longer
+	// in the manifests are pruned on upgrade, and all of them are deleted on disable.
+	//
+	// +optional
+	AppliedResources []AppliedResource `json:"appliedResources,omitempty"`
+
+	// Records the hash of the manifests applied by a 'Manifest' type add-on. The add-on is upgraded
+	// once the manifests in the referenced ConfigMaps change, even if the add-on itself is not changed.
+	//
+	// +optional
+	ManifestsHash string `json:"manifestsHash,omitempty"`
+}
+
+// AddonDependency defines an add-on that another add-on depends on.
+type AddonDependency struct {
+	// Specifies the name of the add-on.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the semver constraint of the add-on version, i.e., '>=1.0.0'.
+	// Any version is accepted if it is empty.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// AppliedResource refers to an object applied by an add-on.
+type AppliedResource struct {
+	// Specifies the API group of the object.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Specifies the API version of the object.
+	//
+	// +kubebuilder:validation:Required
+	Version string `json:"version"`
+
+	// Specifies the kind of the object.
+	//
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Specifies the namespace of the object, it is empty for cluster-scoped objects.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Specifies the name of the object.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
 }
 
 type InstallableSpec struct {
@@ -182,6 +254,51 @@ type HelmTypeInstallSpec struct {
 
 type HelmInstallOptions map[string]string
 
+// ManifestTypeInstallSpec defines the installation spec of an add-on made of plain manifests,
+// i.e., ComponentDefinitions, ConfigConstraints and ActionSets. The manifests are applied by the
+// add-on controller with server-side apply, no Helm job is involved.
+// Only the definition kinds of the KubeBlocks API groups are allowed in the manifests, that is,
+// ClusterDefinition, ComponentDefinition, ComponentVersion, ShardingDefinition, ConfigConstraint,
+// ActionSet, BackupPolicyTemplate and OpsDefinition.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapRefs) != has(self.oci)",message="exactly one of configMapRefs and oci is required"
+type ManifestTypeInstallSpec struct {
+	// Selects the keys of ConfigMaps in the KubeBlocks namespace that hold the manifests.
+	// The value of a key can contain multiple YAML documents.
+	//
+	// +optional
+	ConfigMapRefs []DataObjectKeySelector `json:"configMapRefs,omitempty"`
+
+	// Specifies the OCI artifact that holds the manifests.
+	//
+	// +optional
+	OCI *OCIArtifactSource `json:"oci,omitempty"`
+}
+
+// OCIArtifactSource defines an OCI artifact that holds manifests, every layer of the artifact
+// is a file of YAML documents, i.e., as pushed by `oras push <reference> manifests.yaml`.
+type OCIArtifactSource struct {
+	// Specifies the reference of the artifact, i.e., registry.example.com/addons/mysql:1.0.0.
+	//
+	// +kubebuilder:validation:Required
+	Reference string `json:"reference"`
+
+	// Names a Secret in the KubeBlocks namespace that holds the `username` and `password`
+	// to access the registry.
+	//
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// Indicates whether to skip the TLS verification of the registry.
+	//
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Indicates whether to access the registry by plain HTTP.
+	//
+	// +optional
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+}
+
 type HelmInstallValues struct {
 	// Specifies the URL location of the values file.
 	//
@@ -27,11 +27,12 @@ import (
 
 // AddonType defines the addon types.
 // +enum
-// +kubebuilder:validation:Enum={Helm}
+// +kubebuilder:validation:Enum={Helm,Manifest}
 type AddonType string
 
 const (
-	HelmType AddonType = "Helm"
+	HelmType     AddonType = "Helm"
+	ManifestType AddonType = "Manifest"
 )
 
 // LineSelectorOperator defines line selector operators.
@@ -46,6 +46,7 @@ const (
 	ConditionTypeExpose             = "Exposing"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
+	ConditionTypePasswordRotating   = "PasswordRotating"
 	ConditionTypeCustomOperation    = "CustomOperation"
 
 	// condition and event reasons
@@ -196,6 +197,17 @@ func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewPasswordRotatingCondition creates a condition that the operation starts to rotate the passwords of the system accounts.
+func NewPasswordRotatingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePasswordRotating,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PasswordRotationStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to rotate the passwords of the system accounts in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
 // NewSwitchoveringCondition creates a condition that the operation starts to switchover components
 func NewSwitchoveringCondition(generation int64, message string) *metav1.Condition {
 	return &metav1.Condition{
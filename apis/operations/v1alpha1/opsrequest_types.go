@@ -205,6 +205,16 @@ type SpecificOpsRequest struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists PasswordRotation objects, each specifying a Component and the system accounts whose passwords will be rotated.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.passwordRotation"
+	PasswordRotationList []PasswordRotation `json:"passwordRotation,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Specifies a custom operation defined by OpsDefinition.
 	//
 	// +optional
@@ -272,6 +282,17 @@ type Instance struct {
 	TargetNodeName string `json:"targetNodeName,omitempty"`
 }
 
+type PasswordRotation struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the names of the system accounts to rotate the passwords.
+	// If not set, all the system accounts whose passwords are generated by KubeBlocks will be rotated.
+	//
+	// +optional
+	Accounts []string `json:"accounts,omitempty"`
+}
+
 type Switchover struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -538,15 +559,25 @@ type ConfigurationItem struct {
 	Policy *appsv1alpha1.UpgradePolicy `json:"policy,omitempty"`
 
 	// Sets the configuration files and their associated parameters that need to be updated.
-	// It should contain at least one item.
+	// It should contain at least one item, unless `rollbackToRevision` is specified.
 	//
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:MinItems=1
 	// +patchMergeKey=key
 	// +patchStrategy=merge,retainKeys
 	// +listType=map
 	// +listMapKey=key
-	Keys []ParameterConfig `json:"keys" patchStrategy:"merge,retainKeys" patchMergeKey:"key"`
+	// +optional
+	Keys []ParameterConfig `json:"keys,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"key"`
+
+	// Specifies the revision of the configuration to roll back to.
+	//
+	// The parameters recorded in the revision are restored, and the configuration template is re-rendered with them.
+	// The changes are applied to the instances through the same reconfigure policies as a normal reconfiguring.
+	// The available revisions can be found in `configuration.status.configurationStatus[*].revisionHistory`.
+	//
+	// Either the `keys` field or the `rollbackToRevision` field must be set, but not both.
+	//
+	// +optional
+	RollbackToRevision string `json:"rollbackToRevision,omitempty"`
 }
 
 type CustomOps struct {
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
@@ -147,6 +148,8 @@ func (r *OpsRequest) ValidateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case PasswordRotationType:
+		return r.validatePasswordRotation(cluster)
 	}
 	return nil
 }
@@ -272,6 +275,17 @@ func (r *OpsRequest) validateReconfigureParams(ctx context.Context,
 		if err != nil {
 			return err
 		}
+		if len(configuration.Keys) == 0 && configuration.RollbackToRevision == "" {
+			return errors.New("configuration.keys and configuration.rollbackToRevision cannot be empty at the same time")
+		}
+		if len(configuration.Keys) != 0 && configuration.RollbackToRevision != "" {
+			return errors.New("configuration.keys and configuration.rollbackToRevision cannot be set at the same time")
+		}
+		if configuration.RollbackToRevision != "" {
+			if _, ok := cmObj.Annotations[core.GenerateRevisionParametersKey(configuration.RollbackToRevision)]; !ok {
+				return errors.Errorf("revision %s not found in configmap %s", configuration.RollbackToRevision, configuration.Name)
+			}
+		}
 		for _, key := range configuration.Keys {
 			// check add file
 			if _, ok := cmObj.Data[key.Key]; !ok && key.FileContent == "" {
@@ -479,6 +493,19 @@ func (r *OpsRequest) validateVolumeExpansion(ctx context.Context, cli client.Cli
 	return r.checkVolumesAllowExpansion(ctx, cli, cluster)
 }
 
+// validatePasswordRotation validates password rotation api when spec.type is PasswordRotation.
+func (r *OpsRequest) validatePasswordRotation(cluster *appsv1.Cluster) error {
+	passwordRotationList := r.Spec.PasswordRotationList
+	if len(passwordRotationList) == 0 {
+		return notEmptyError("spec.passwordRotation")
+	}
+	compOpsList := make([]ComponentOps, len(passwordRotationList))
+	for i, v := range passwordRotationList {
+		compOpsList[i] = v.ComponentOps
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
 // validateSwitchover validates switchover api when spec.type is Switchover.
 func (r *OpsRequest) validateSwitchover(ctx context.Context, cli client.Client, cluster *appsv1.Cluster) error {
 	switchoverList := r.Spec.SwitchoverList
@@ -72,7 +72,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,PasswordRotation,Custom}
 type OpsType string
 
 const (
@@ -89,7 +89,8 @@ const (
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
-	CustomType            OpsType = "Custom"          // use opsDefinition
+	PasswordRotationType  OpsType = "PasswordRotation"
+	CustomType            OpsType = "Custom" // use opsDefinition
 )
 
 // ProgressStatus defines the status of the opsRequest progress.
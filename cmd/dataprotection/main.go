@@ -81,6 +81,7 @@ const (
 	multiClusterKubeConfigFlagKey       flagName = "multi-cluster-kubeconfig"
 	multiClusterContextsFlagKey         flagName = "multi-cluster-contexts"
 	multiClusterContextsDisabledFlagKey flagName = "multi-cluster-contexts-disabled"
+	multiClusterMemberClustersFlagKey   flagName = "multi-cluster-member-clusters"
 
 	userAgentFlagKey flagName = "user-agent"
 )
@@ -131,6 +132,7 @@ func main() {
 		multiClusterKubeConfig       string
 		multiClusterContexts         string
 		multiClusterContextsDisabled string
+		multiClusterMemberClusters   bool
 		userAgent                    string
 	)
 
@@ -147,6 +149,7 @@ func main() {
 	flag.String(multiClusterKubeConfigFlagKey.String(), "", "Paths to the kubeconfig for multi-cluster accessing.")
 	flag.String(multiClusterContextsFlagKey.String(), "", "Kube contexts the manager will talk to.")
 	flag.String(multiClusterContextsDisabledFlagKey.String(), "", "Kube contexts that mark as disabled.")
+	flag.Bool(multiClusterMemberClustersFlagKey.String(), false, "Enable to register member clusters dynamically by the MemberCluster API.")
 
 	flag.String(userAgentFlagKey.String(), "", "User agent of the operator.")
 
@@ -195,6 +198,7 @@ func main() {
 	multiClusterKubeConfig = viper.GetString(multiClusterKubeConfigFlagKey.viperName())
 	multiClusterContexts = viper.GetString(multiClusterContextsFlagKey.viperName())
 	multiClusterContextsDisabled = viper.GetString(multiClusterContextsDisabledFlagKey.viperName())
+	multiClusterMemberClusters = viper.GetBool(multiClusterMemberClustersFlagKey.viperName())
 	userAgent = viper.GetString(userAgentFlagKey.viperName())
 
 	setupLog.Info(fmt.Sprintf("config settings: %v", viper.AllSettings()))
@@ -264,7 +268,7 @@ func main() {
 
 	// multi-cluster manager for all data-plane k8s
 	multiClusterMgr, err := multicluster.Setup(mgr.GetScheme(), mgr.GetConfig(), mgr.GetClient(),
-		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled)
+		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled, multiClusterMemberClusters)
 	if err != nil {
 		setupLog.Error(err, "unable to setup multi-cluster manager")
 		os.Exit(1)
@@ -275,6 +279,18 @@ func main() {
 		client = multiClusterMgr.GetClient()
 	}
 
+	if multiClusterMgr != nil && multiClusterMemberClusters {
+		if err = (&dpcontrollers.MemberClusterReconciler{
+			Client:          mgr.GetClient(),
+			Scheme:          mgr.GetScheme(),
+			Recorder:        mgr.GetEventRecorderFor("member-cluster-controller"),
+			MultiClusterMgr: multiClusterMgr,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "MemberCluster")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&dpcontrollers.ActionSetReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -375,6 +391,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = metrics.RegisterCollectors(
+		metrics.NewBackupCollector(mgr.GetClient()),
+		metrics.NewBackupRepoCollector(mgr.GetClient()),
+	); err != nil {
+		setupLog.Error(err, "unable to register metrics collectors")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
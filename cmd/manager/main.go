@@ -20,9 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -65,10 +67,12 @@ import (
 	opscontrollers "github.com/apecloud/kubeblocks/controllers/operations"
 	workloadscontrollers "github.com/apecloud/kubeblocks/controllers/workloads"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -93,8 +97,11 @@ const (
 	multiClusterKubeConfigFlagKey       flagName = "multi-cluster-kubeconfig"
 	multiClusterContextsFlagKey         flagName = "multi-cluster-contexts"
 	multiClusterContextsDisabledFlagKey flagName = "multi-cluster-contexts-disabled"
+	multiClusterMemberClustersFlagKey   flagName = "multi-cluster-member-clusters"
 
 	userAgentFlagKey flagName = "user-agent"
+
+	debugExplainFlagKey flagName = "debug-explain"
 )
 
 var (
@@ -130,6 +137,10 @@ func init() {
 	viper.SetDefault(constant.EnableRBACManager, true)
 	viper.SetDefault("VOLUMESNAPSHOT_API_BETA", false)
 	viper.SetDefault(constant.KBToolsImage, "apecloud/kubeblocks-tools:latest")
+	viper.SetDefault(constant.CfgKeyLogCollectorImage, "timberio/vector:0.39.0-alpine")
+	viper.SetDefault(constant.CfgKeyAccountResyncInterval, "10m")
+	viper.SetDefault(constant.CfgKeyTLSCertValidityDays, 36500)
+	viper.SetDefault(constant.CfgKeyTLSCertRenewBefore, "720h")
 	viper.SetDefault("KUBEBLOCKS_SERVICEACCOUNT_NAME", "kubeblocks")
 	viper.SetDefault(constant.ConfigManagerGPRCPortEnv, 9901)
 	viper.SetDefault("CONFIG_MANAGER_LOG_LEVEL", "info")
@@ -142,6 +153,10 @@ func init() {
 	viper.SetDefault(instanceset.FeatureGateIgnorePodVerticalScaling, false)
 	viper.SetDefault(intctrlutil.FeatureGateEnableRuntimeMetrics, false)
 	viper.SetDefault(constant.CfgKBReconcileWorkers, 8)
+	viper.SetDefault(constant.CfgKBPlanExecutionWorkers, 1)
+	viper.SetDefault(constant.CfgKBServerSideApplyKinds, "")
+	viper.SetDefault(constant.CfgKBAutoscalerPrometheusEndpoint, "")
+	viper.SetDefault(constant.CfgKeyTracingSampleRatio, 1.0)
 	viper.SetDefault(constant.FeatureGateIgnoreConfigTemplateDefaultMode, false)
 	viper.SetDefault(constant.FeatureGateInPlacePodVerticalScaling, false)
 }
@@ -181,12 +196,16 @@ func setupFlags() {
 	flag.String(multiClusterKubeConfigFlagKey.String(), "", "Paths to the kubeconfig for multi-cluster accessing.")
 	flag.String(multiClusterContextsFlagKey.String(), "", "Kube contexts the manager will talk to.")
 	flag.String(multiClusterContextsDisabledFlagKey.String(), "", "Kube contexts that mark as disabled.")
+	flag.Bool(multiClusterMemberClustersFlagKey.String(), false, "Enable to register member clusters dynamically by the MemberCluster API.")
 
 	flag.String(constant.ManagedNamespacesFlag, "",
 		"The namespaces that the operator will manage, multiple namespaces are separated by commas.")
 
 	flag.String(userAgentFlagKey.String(), "", "User agent of the operator.")
 
+	flag.Bool(debugExplainFlagKey.String(), false,
+		fmt.Sprintf("Enable to serve the explanations of the last reconciliations at %s of the metrics endpoint.", tracing.ExplainPath))
+
 	opts := zap.Options{
 		Development: false,
 	}
@@ -274,6 +293,8 @@ func main() {
 		multiClusterKubeConfig       string
 		multiClusterContexts         string
 		multiClusterContextsDisabled string
+		multiClusterMemberClusters   bool
+		debugExplain                 bool
 		userAgent                    string
 		err                          error
 	)
@@ -308,15 +329,38 @@ func main() {
 	multiClusterKubeConfig = viper.GetString(multiClusterKubeConfigFlagKey.viperName())
 	multiClusterContexts = viper.GetString(multiClusterContextsFlagKey.viperName())
 	multiClusterContextsDisabled = viper.GetString(multiClusterContextsDisabledFlagKey.viperName())
+	multiClusterMemberClusters = viper.GetBool(multiClusterMemberClustersFlagKey.viperName())
+	debugExplain = viper.GetBool(debugExplainFlagKey.viperName())
 
 	userAgent = viper.GetString(userAgentFlagKey.viperName())
 
 	setupLog.Info("golang runtime metrics.", "featureGate", intctrlutil.EnabledRuntimeMetrics())
+	metricsHandlers := metrics.RuntimeMetric()
+	graph.RegisterTransformerObserver(metrics.TransformerErrorObserver{})
+	if debugExplain {
+		if metricsHandlers == nil {
+			metricsHandlers = map[string]http.Handler{}
+		}
+		metricsHandlers[tracing.ExplainPath] = tracing.ExplainHandler()
+		tracing.EnableExplain()
+	}
+
+	if endpoint := viper.GetString(constant.CfgKeyTracingEndpoint); len(endpoint) > 0 {
+		shutdown, err := tracing.Setup(context.Background(), appName, endpoint, viper.GetFloat64(constant.CfgKeyTracingSampleRatio))
+		if err != nil {
+			setupLog.Error(err, "unable to setup tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			_ = shutdown(context.Background())
+		}()
+	}
+
 	mgr, err := ctrl.NewManager(intctrlutil.GeKubeRestConfig(userAgent), ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
 			BindAddress:   metricsAddr,
-			ExtraHandlers: metrics.RuntimeMetric(),
+			ExtraHandlers: metricsHandlers,
 		},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -356,7 +400,7 @@ func main() {
 
 	// multi-cluster manager for all data-plane k8s
 	multiClusterMgr, err := multicluster.Setup(mgr.GetScheme(), mgr.GetConfig(), mgr.GetClient(),
-		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled)
+		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled, multiClusterMemberClusters)
 	if err != nil {
 		setupLog.Error(err, "unable to setup multi-cluster manager")
 		os.Exit(1)
@@ -437,6 +481,27 @@ func main() {
 			os.Exit(1)
 		}
 
+		if multiClusterMgr != nil && multiClusterMemberClusters {
+			if err = (&appscontrollers.MemberClusterReconciler{
+				Client:          mgr.GetClient(),
+				Scheme:          mgr.GetScheme(),
+				Recorder:        mgr.GetEventRecorderFor("member-cluster-controller"),
+				MultiClusterMgr: multiClusterMgr,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "MemberCluster")
+				os.Exit(1)
+			}
+		}
+
+		if err = (&appscontrollers.AccountReconciler{
+			Client:   client,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("account-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Account")
+			os.Exit(1)
+		}
+
 		if err = (&k8scorecontrollers.EventReconciler{
 			Client:   client,
 			Scheme:   mgr.GetScheme(),
@@ -472,6 +537,11 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "ReconfigureRequest")
 			os.Exit(1)
 		}
+
+		if err = metrics.RegisterCollectors(metrics.NewClusterCollector(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to register metrics collectors", "collector", "Cluster")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(workloadsFlagKey.viperName()) {
@@ -483,6 +553,11 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "InstanceSet")
 			os.Exit(1)
 		}
+
+		if err = metrics.RegisterCollectors(metrics.NewInstanceSetCollector(client)); err != nil {
+			setupLog.Error(err, "unable to register metrics collectors", "collector", "InstanceSet")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(operationsFlagKey.viperName()) {
@@ -503,6 +578,11 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "OpsRequest")
 			os.Exit(1)
 		}
+
+		if err = metrics.RegisterCollectors(metrics.NewOpsRequestCollector(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to register metrics collectors", "collector", "OpsRequest")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(extensionsFlagKey.viperName()) {
@@ -526,6 +606,14 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "NodeCountScaler")
 			os.Exit(1)
 		}
+		if err = (&experimentalcontrollers.ComponentAutoscalerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("component-autoscaler-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ComponentAutoscaler")
+			os.Exit(1)
+		}
 	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
@@ -49,7 +49,9 @@ const (
 
 var allNotifyType = map[NotifyEventType]appsv1beta1.DynamicReloadType{
 	UnixSignal: appsv1beta1.UnixSignalType,
+	WebHook:    appsv1beta1.HTTPType,
 	ShellTool:  appsv1beta1.ShellType,
+	SQL:        appsv1beta1.SQLType,
 	TPLScript:  appsv1beta1.TPLScriptType,
 }
 
@@ -0,0 +1,418 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// AccountReconciler reconciles an Account object
+type AccountReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=accounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=accounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=accounts/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
+func (r *AccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("account", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	account := &appsv1.Account{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, account); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, account, constant.AccountFinalizerName, func() (*ctrl.Result, error) {
+		return r.deletionHandler(reqCtx, account)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	secret, err := r.reconcileSecret(reqCtx, account)
+	if err != nil {
+		return r.failed(reqCtx, account, err)
+	}
+
+	synthesizedComp, err := r.loadRunningComponent(reqCtx, account)
+	if err != nil {
+		return r.failed(reqCtx, account, err)
+	}
+	if synthesizedComp == nil {
+		return r.updateStatus(reqCtx, account, appsv1.AccountPendingPhase, "the component is not running")
+	}
+	if err = r.checkUserName(account, synthesizedComp); err != nil {
+		return r.failed(reqCtx, account, err)
+	}
+
+	hash, err := r.provisionedHash(account, secret)
+	if err != nil {
+		return r.failed(reqCtx, account, err)
+	}
+	resync := viper.GetDuration(constant.CfgKeyAccountResyncInterval)
+	if account.Status.Phase == appsv1.AccountProvisionedPhase && account.Status.ProvisionedHash == hash &&
+		account.Status.LastProvisionedTime != nil && time.Since(account.Status.LastProvisionedTime.Time) < resync {
+		return intctrlutil.RequeueAfter(resync-time.Since(account.Status.LastProvisionedTime.Time), reqCtx.Log, "")
+	}
+
+	// provision the user if the statements or the password are changed, or re-provision it periodically to correct the drift.
+	if err = r.provision(reqCtx, account, synthesizedComp, secret); err != nil {
+		return r.failed(reqCtx, account, err)
+	}
+	patch := client.MergeFrom(account.DeepCopy())
+	account.Status.ObservedGeneration = account.Generation
+	account.Status.Phase = appsv1.AccountProvisionedPhase
+	account.Status.Message = ""
+	account.Status.ProvisionedHash = hash
+	account.Status.LastProvisionedTime = &metav1.Time{Time: time.Now()}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, account, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(resync, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1.Account{}).
+		Owns(&corev1.Secret{}).
+		Watches(&appsv1.Component{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentAccounts)).
+		Complete(r)
+}
+
+// filterComponentAccounts enqueues the accounts of the component, to provision them once the component is running.
+func (r *AccountReconciler) filterComponentAccounts(ctx context.Context, obj client.Object) []reconcile.Request {
+	comp, ok := obj.(*appsv1.Component)
+	if !ok || comp.Status.Phase != appsv1.RunningClusterCompPhase {
+		return nil
+	}
+	clusterName, err := component.GetClusterName(comp)
+	if err != nil {
+		return nil
+	}
+	compName, err := component.ShortName(clusterName, comp.Name)
+	if err != nil {
+		return nil
+	}
+	accounts := &appsv1.AccountList{}
+	if err = r.Client.List(ctx, accounts, client.InNamespace(comp.Namespace)); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, account := range accounts.Items {
+		if account.Spec.ClusterName == clusterName && account.Spec.ComponentName == compName {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&account)})
+		}
+	}
+	return requests
+}
+
+func (r *AccountReconciler) deletionHandler(reqCtx intctrlutil.RequestCtx, account *appsv1.Account) (*ctrl.Result, error) {
+	if account.Spec.Statements.Drop == "" || account.Status.ProvisionedHash == "" {
+		return nil, nil
+	}
+	if account.Status.Phase != appsv1.AccountDeletingPhase {
+		if res, err := r.updateStatus(reqCtx, account, appsv1.AccountDeletingPhase, ""); err != nil {
+			return &res, err
+		}
+	}
+	synthesizedComp, err := r.loadRunningComponent(reqCtx, account)
+	if err != nil {
+		return nil, err
+	}
+	if synthesizedComp == nil {
+		// the user is dropped along with the component
+		if r.isComponentDeleted(reqCtx, account) {
+			return nil, nil
+		}
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second*5, reqCtx.Log, "wait for the component to be running to drop the user"))
+	}
+	secret := &corev1.Secret{}
+	if err = r.Client.Get(reqCtx.Ctx, types.NamespacedName{Namespace: account.Namespace, Name: account.Name}, secret); err != nil {
+		return nil, err
+	}
+	if err = r.callAction(reqCtx, synthesizedComp, account, secret, account.Spec.Statements.Drop); err != nil {
+		r.Recorder.Eventf(account, corev1.EventTypeWarning, "DropFailed", "failed to drop the user: %s", err.Error())
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *AccountReconciler) isComponentDeleted(reqCtx intctrlutil.RequestCtx, account *appsv1.Account) bool {
+	comp := &appsv1.Component{}
+	compKey := types.NamespacedName{
+		Namespace: account.Namespace,
+		Name:      constant.GenerateClusterComponentName(account.Spec.ClusterName, account.Spec.ComponentName),
+	}
+	if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		return apierrors.IsNotFound(err)
+	}
+	return !comp.DeletionTimestamp.IsZero()
+}
+
+// reconcileSecret creates or updates the secret which holds the credential of the user,
+// an existing secret which is not controlled by the account is never touched.
+func (r *AccountReconciler) reconcileSecret(reqCtx intctrlutil.RequestCtx, account *appsv1.Account) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: account.Namespace, Name: account.Name}
+	if err := r.Client.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		secret = nil
+	}
+	if secret != nil && !metav1.IsControlledBy(secret, account) {
+		return nil, fmt.Errorf("the secret %s already exists and is not controlled by the account", secret.Name)
+	}
+
+	var password []byte
+	switch {
+	case account.Spec.SecretRef != nil:
+		refSecret := &corev1.Secret{}
+		// the referenced secret is always in the namespace of the account
+		refKey := types.NamespacedName{Namespace: account.Namespace, Name: account.Spec.SecretRef.Name}
+		if err := r.Client.Get(reqCtx.Ctx, refKey, refSecret); err != nil {
+			return nil, err
+		}
+		if len(refSecret.Data[constant.AccountPasswdForSecret]) == 0 {
+			return nil, fmt.Errorf("referenced account secret has no required credential field")
+		}
+		password = refSecret.Data[constant.AccountPasswdForSecret]
+	case secret != nil && len(secret.Data[constant.AccountPasswdForSecret]) > 0:
+		password = secret.Data[constant.AccountPasswdForSecret]
+	default:
+		var err error
+		if password, err = r.loadOrGeneratePassword(reqCtx, account); err != nil {
+			return nil, err
+		}
+	}
+
+	userName := []byte(r.userName(account))
+	if secret == nil {
+		secret = builder.NewSecretBuilder(account.Namespace, account.Name).
+			AddLabelsInMap(constant.GetCompLabels(account.Spec.ClusterName, account.Spec.ComponentName)).
+			PutData(constant.AccountNameForSecret, userName).
+			PutData(constant.AccountPasswdForSecret, password).
+			GetObject()
+		if err := controllerutil.SetControllerReference(account, secret, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Client.Create(reqCtx.Ctx, secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+	if !reflect.DeepEqual(secret.Data[constant.AccountNameForSecret], userName) ||
+		!reflect.DeepEqual(secret.Data[constant.AccountPasswdForSecret], password) {
+		patch := client.MergeFrom(secret.DeepCopy())
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[constant.AccountNameForSecret] = userName
+		secret.Data[constant.AccountPasswdForSecret] = password
+		if err := r.Client.Patch(reqCtx.Ctx, secret, patch); err != nil {
+			return nil, err
+		}
+	}
+	return secret, nil
+}
+
+// loadOrGeneratePassword loads the password from the external secret store if configured, or generates a new one.
+func (r *AccountReconciler) loadOrGeneratePassword(reqCtx intctrlutil.RequestCtx, account *appsv1.Account) ([]byte, error) {
+	if !secretstore.IsEnabled() {
+		return r.generatePassword(account), nil
+	}
+	store, err := secretstore.New()
+	if err != nil {
+		return nil, err
+	}
+	data, err := secretstore.GetOrCreate(reqCtx.Ctx, store, secretstore.UserAccountPath(account.Namespace, account.Name),
+		func() (map[string][]byte, error) {
+			return map[string][]byte{
+				constant.AccountNameForSecret:   []byte(r.userName(account)),
+				constant.AccountPasswdForSecret: r.generatePassword(account),
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(data[constant.AccountPasswdForSecret]) == 0 {
+		return nil, fmt.Errorf("the secret of account %s in the %s secret store has no required credential field", account.Name, store.Name())
+	}
+	return data[constant.AccountPasswdForSecret], nil
+}
+
+func (r *AccountReconciler) generatePassword(account *appsv1.Account) []byte {
+	config := appsv1.PasswordConfig{
+		Length:     16,
+		NumDigits:  4,
+		LetterCase: appsv1.MixedCases,
+	}
+	if account.Spec.PasswordConfig != nil {
+		config = *account.Spec.PasswordConfig
+	}
+	return (&componentAccountTransformer{}).generatePassword(appsv1.SystemAccount{PasswordGenerationPolicy: config})
+}
+
+// loadRunningComponent returns the synthesized component if it is running, otherwise nil.
+func (r *AccountReconciler) loadRunningComponent(reqCtx intctrlutil.RequestCtx, account *appsv1.Account) (*component.SynthesizedComponent, error) {
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, types.NamespacedName{Namespace: account.Namespace, Name: account.Spec.ClusterName}, cluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	comp, compDef, err := component.GetCompNCompDefByName(reqCtx.Ctx, r.Client, account.Namespace,
+		constant.GenerateClusterComponentName(account.Spec.ClusterName, account.Spec.ComponentName))
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !comp.DeletionTimestamp.IsZero() || comp.Status.Phase != appsv1.RunningClusterCompPhase {
+		return nil, nil
+	}
+	if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.AccountProvision == nil {
+		return nil, fmt.Errorf("the accountProvision action is not defined by the component definition %s", compDef.Name)
+	}
+	return component.BuildSynthesizedComponent(reqCtx.Ctx, r.Client, compDef, comp, cluster)
+}
+
+func (r *AccountReconciler) checkUserName(account *appsv1.Account, synthesizedComp *component.SynthesizedComponent) error {
+	userName := r.userName(account)
+	for _, sysAccount := range synthesizedComp.SystemAccounts {
+		if sysAccount.Name == userName {
+			return fmt.Errorf("the user name %s conflicts with the system account of the component", userName)
+		}
+	}
+	return nil
+}
+
+func (r *AccountReconciler) userName(account *appsv1.Account) string {
+	if account.Spec.UserName != "" {
+		return account.Spec.UserName
+	}
+	return account.Name
+}
+
+// provisionedHash returns the hash of the statements and the password to provision.
+func (r *AccountReconciler) provisionedHash(account *appsv1.Account, secret *corev1.Secret) (string, error) {
+	hash := fnv.New32a()
+	for _, data := range [][]byte{
+		[]byte(account.Spec.Statements.Create),
+		[]byte(strings.Join(account.Spec.Statements.Grants, "\n")),
+		secret.Data[constant.AccountNameForSecret],
+		secret.Data[constant.AccountPasswdForSecret],
+	} {
+		if _, err := hash.Write(data); err != nil {
+			return "", err
+		}
+		// separator
+		if _, err := hash.Write([]byte{0}); err != nil {
+			return "", err
+		}
+	}
+	return rand.SafeEncodeString(fmt.Sprintf("%d", hash.Sum32())), nil
+}
+
+func (r *AccountReconciler) provision(reqCtx intctrlutil.RequestCtx, account *appsv1.Account,
+	synthesizedComp *component.SynthesizedComponent, secret *corev1.Secret) error {
+	statements := append([]string{account.Spec.Statements.Create}, account.Spec.Statements.Grants...)
+	for _, statement := range statements {
+		if err := r.callAction(reqCtx, synthesizedComp, account, secret, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AccountReconciler) callAction(reqCtx intctrlutil.RequestCtx, synthesizedComp *component.SynthesizedComponent,
+	account *appsv1.Account, secret *corev1.Secret, statement string) error {
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, r.Client,
+		synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return err
+	}
+	lfa, err := lifecycle.New(synthesizedComp, nil, pods...)
+	if err != nil {
+		return err
+	}
+	// the statement is passed as is, the credential is passed separately and the placeholders are replaced by the action.
+	userName, password := secret.Data[constant.AccountNameForSecret], secret.Data[constant.AccountPasswdForSecret]
+	return lfa.AccountProvision(reqCtx.Ctx, r.Client, nil, statement, string(userName), string(password))
+}
+
+func (r *AccountReconciler) failed(reqCtx intctrlutil.RequestCtx, account *appsv1.Account, err error) (ctrl.Result, error) {
+	if _, err1 := r.updateStatus(reqCtx, account, appsv1.AccountFailedPhase, err.Error()); err1 != nil {
+		return intctrlutil.CheckedRequeueWithError(err1, reqCtx.Log, "")
+	}
+	return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+}
+
+func (r *AccountReconciler) updateStatus(reqCtx intctrlutil.RequestCtx, account *appsv1.Account,
+	phase appsv1.AccountPhase, message string) (ctrl.Result, error) {
+	patch := client.MergeFrom(account.DeepCopy())
+	account.Status.ObservedGeneration = account.Generation
+	account.Status.Phase = phase
+	account.Status.Message = message
+	account.Status.UserName = r.userName(account)
+	account.Status.SecretName = account.Name
+	if err := r.Client.Status().Patch(reqCtx.Ctx, account, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Account controller", func() {
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.AccountSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	newAccount := func(name string) *appsv1.Account {
+		return &appsv1.Account{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      name,
+				Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+			},
+			Spec: appsv1.AccountSpec{
+				ClusterName:   "test-cluster",
+				ComponentName: "mysql",
+				Statements: appsv1.AccountStatements{
+					Create: "CREATE USER IF NOT EXISTS $(USERNAME) IDENTIFIED BY '$(PASSWORD)'",
+					Grants: []string{"GRANT SELECT ON *.* TO $(USERNAME)"},
+					Drop:   "DROP USER IF EXISTS $(USERNAME)",
+				},
+			},
+		}
+	}
+
+	It("creates the credential secret and waits for the component", func() {
+		account := newAccount("app-" + testCtx.GetRandomStr())
+		Expect(testCtx.CreateObj(testCtx.Ctx, account)).Should(Succeed())
+
+		By("check the credential secret")
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(account), func(g Gomega, secret *corev1.Secret) {
+			g.Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountNameForSecret, []byte(account.Name)))
+			g.Expect(secret.Data[constant.AccountPasswdForSecret]).Should(HaveLen(16))
+			g.Expect(metav1.IsControlledBy(secret, account)).Should(BeTrue())
+		})).Should(Succeed())
+
+		By("check the account is pending since the component doesn't exist")
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(account), func(g Gomega, account *appsv1.Account) {
+			g.Expect(account.Status.Phase).Should(Equal(appsv1.AccountPendingPhase))
+			g.Expect(account.Status.SecretName).Should(Equal(account.Name))
+			g.Expect(account.Finalizers).Should(ContainElement(constant.AccountFinalizerName))
+		})).Should(Succeed())
+	})
+
+	It("copies the password from the referenced secret and restores the credential", func() {
+		refSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      "app-ref-" + testCtx.GetRandomStr(),
+				Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+			},
+			Data: map[string][]byte{constant.AccountPasswdForSecret: []byte("ref-password")},
+		}
+		Expect(testCtx.CreateObj(testCtx.Ctx, refSecret)).Should(Succeed())
+		account := newAccount("app-" + testCtx.GetRandomStr())
+		account.Spec.SecretRef = &corev1.LocalObjectReference{Name: refSecret.Name}
+		Expect(testCtx.CreateObj(testCtx.Ctx, account)).Should(Succeed())
+
+		checkCredential := func(g Gomega, secret *corev1.Secret) {
+			g.Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountNameForSecret, []byte(account.Name)))
+			g.Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte("ref-password")))
+		}
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(account), checkCredential)).Should(Succeed())
+
+		By("tamper the user name of the credential secret")
+		Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(account), func(secret *corev1.Secret) {
+			secret.Data[constant.AccountNameForSecret] = []byte("tampered")
+		})()).Should(Succeed())
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(account), checkCredential)).Should(Succeed())
+	})
+
+	It("refuses the existing secret which is not controlled by the account", func() {
+		name := "app-" + testCtx.GetRandomStr()
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      name,
+				Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+			},
+			Data: map[string][]byte{constant.AccountPasswdForSecret: []byte("others")},
+		}
+		Expect(testCtx.CreateObj(testCtx.Ctx, secret)).Should(Succeed())
+		account := newAccount(name)
+		Expect(testCtx.CreateObj(testCtx.Ctx, account)).Should(Succeed())
+
+		By("check the account is failed")
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(account), func(g Gomega, account *appsv1.Account) {
+			g.Expect(account.Status.Phase).Should(Equal(appsv1.AccountFailedPhase))
+			g.Expect(account.Status.Message).Should(ContainSubstring("not controlled by the account"))
+		})).Should(Succeed())
+
+		By("check the secret is untouched")
+		Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(secret), func(g Gomega, obj *corev1.Secret) {
+			g.Expect(obj.Data).Should(Equal(secret.Data))
+			g.Expect(obj.OwnerReferences).Should(BeEmpty())
+		})).Should(Succeed())
+	})
+
+	It("renders the statements and hashes them", func() {
+		r := &AccountReconciler{}
+		account := newAccount("app")
+		secret := &corev1.Secret{
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte("app"),
+				constant.AccountPasswdForSecret: []byte("secret"),
+			},
+		}
+		hash, err := r.provisionedHash(account, secret)
+		Expect(err).Should(BeNil())
+
+		By("the hash is changed when the grants are changed")
+		account.Spec.Statements.Grants = append(account.Spec.Statements.Grants, "GRANT INSERT ON *.* TO $(USERNAME)")
+		hash1, err := r.provisionedHash(account, secret)
+		Expect(err).Should(BeNil())
+		Expect(hash1).ShouldNot(Equal(hash))
+
+		By("the hash is changed when the password is changed")
+		secret.Data[constant.AccountPasswdForSecret] = []byte("new-secret")
+		hash2, err := r.provisionedHash(account, secret)
+		Expect(err).Should(BeNil())
+		Expect(hash2).ShouldNot(Equal(hash1))
+
+		By("the drop statement doesn't affect the hash")
+		account.Spec.Statements.Drop = ""
+		hash3, err := r.provisionedHash(account, secret)
+		Expect(err).Should(BeNil())
+		Expect(hash3).Should(Equal(hash2))
+	})
+})
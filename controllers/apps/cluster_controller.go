@@ -22,6 +22,8 @@ package apps
 import (
 	"context"
 	"math"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,19 +32,23 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=memberclusters,verbs=get;list;watch
 
 // owned K8s core API resources controller-gen RBAC marker
 // full access on core API resources
@@ -70,6 +76,9 @@ import (
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;delete;deletecollection
 
+// serviceDescriptorRefsField indexes the clusters by the `namespace/name` of the service descriptors they reference.
+const serviceDescriptorRefsField = "spec.serviceRefs.serviceDescriptor"
+
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
 	client.Client
@@ -83,7 +92,10 @@ type ClusterReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
-func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	ctx, end := tracing.StartReconcile(ctx, appsv1.ClusterKind, req.NamespacedName)
+	defer func() { end(res, err) }()
+
 	reqCtx := intctrlutil.RequestCtx{
 		Ctx:      ctx,
 		Req:      req,
@@ -93,6 +105,10 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	reqCtx.Log.V(1).Info("reconcile", "cluster", req.NamespacedName)
 
+	if res, err := waitForMemberClusters(reqCtx, r.MultiClusterMgr); res != nil {
+		return *res, err
+	}
+
 	// the cluster reconciliation loop is a 3-stage model: plan Init, plan Build and plan Execute
 	// Init stage
 	planBuilder := newClusterPlanBuilder(reqCtx, r.Client)
@@ -102,9 +118,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	requeueError := func(err error) (ctrl.Result, error) {
 		if re, ok := err.(intctrlutil.RequeueError); ok {
+			tracing.RecordRequeue(reqCtx.Ctx, re.Reason())
 			return intctrlutil.RequeueAfter(re.RequeueAfter(), reqCtx.Log, re.Reason())
 		}
 		if apierrors.IsConflict(err) {
+			tracing.RecordRequeue(reqCtx.Ctx, err.Error())
 			return intctrlutil.Requeue(reqCtx.Log, err.Error())
 		}
 		c := planBuilder.(*clusterPlanBuilder)
@@ -171,7 +189,16 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &appsv1.Cluster{}, serviceDescriptorRefsField, func(rawObj client.Object) []string {
+		var refs []string
+		for _, ref := range clusterServiceDescriptorRefs(rawObj.(*appsv1.Cluster)) {
+			refs = append(refs, ref.String())
+		}
+		return refs
+	}); err != nil {
+		return err
+	}
+	b := intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&appsv1.Cluster{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: int(math.Ceil(viper.GetFloat64(constant.CfgKBReconcileWorkers) / 4)),
@@ -181,5 +208,41 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Secret{}).  // sharding account secret
 		Owns(&dpv1alpha1.BackupPolicy{}).
 		Owns(&dpv1alpha1.BackupSchedule{}).
-		Complete(r)
+		Watches(&appsv1.ServiceDescriptor{}, handler.EnqueueRequestsFromMapFunc(r.filterServiceDescriptorClusters))
+	if r.MultiClusterMgr != nil {
+		b.Watches(&appsv1.MemberCluster{}, handler.EnqueueRequestsFromMapFunc(r.filterMemberClusterClusters))
+	}
+	return b.Complete(r)
+}
+
+// filterMemberClusterClusters enqueues the clusters placed on the member cluster, to re-place them once it is drained.
+func (r *ClusterReconciler) filterMemberClusterClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusters := &appsv1.ClusterList{}
+	if err := r.Client.List(ctx, clusters); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range clusters.Items {
+		if slices.Contains(strings.Split(placement(&clusters.Items[i]), ","), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusters.Items[i])})
+		}
+	}
+	return requests
+}
+
+// filterServiceDescriptorClusters enqueues the clusters referencing the service descriptor, to propagate its health check results.
+func (r *ClusterReconciler) filterServiceDescriptorClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	sd, ok := obj.(*appsv1.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	clusters := &appsv1.ClusterList{}
+	if err := r.Client.List(ctx, clusters, client.MatchingFields{serviceDescriptorRefsField: client.ObjectKeyFromObject(sd).String()}); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range clusters.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusters.Items[i])})
+	}
+	return requests
 }
@@ -46,6 +46,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // clusterTransformContext a graph.TransformContext implementation for Cluster reconciliation
@@ -68,7 +70,7 @@ type clusterTransformContext struct {
 
 	shardingComps map[string][]*appsv1.ClusterComponentSpec // comp specs for each sharding
 
-	// TODO: remove this, annotations to be added to components for sharding, mapping with @allComps.
+	// TODO: remove this, annotations to be added to components, mapping with @allComps.
 	annotations map[string]map[string]string
 }
 
@@ -137,6 +139,17 @@ func (c *clusterTransformContext) traverse(f func(spec *appsv1.ClusterComponentS
 	}
 }
 
+// annotate adds an annotation to be added to the component.
+func (c *clusterTransformContext) annotate(compName, key, value string) {
+	if c.annotations == nil {
+		c.annotations = make(map[string]map[string]string)
+	}
+	if c.annotations[compName] == nil {
+		c.annotations[compName] = make(map[string]string)
+	}
+	c.annotations[compName][key] = value
+}
+
 func init() {
 	model.AddScheme(appsv1alpha1.AddToScheme)
 	model.AddScheme(appsv1beta1.AddToScheme)
@@ -196,6 +209,7 @@ func (c *clusterPlanBuilder) Build() (graph.Plan, error) {
 	dag := graph.NewDAG()
 	err = c.transformers.ApplyTo(c.transCtx, dag)
 	c.transCtx.Logger.V(1).Info(fmt.Sprintf("DAG: %s", dag))
+	tracing.RecordPlan(c.transCtx.Context, dag)
 
 	// construct execution plan
 	plan := &clusterPlan{
@@ -210,7 +224,7 @@ func (c *clusterPlanBuilder) Build() (graph.Plan, error) {
 // Plan implementation
 
 func (p *clusterPlan) Execute() error {
-	err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
+	err := p.dag.WalkReverseTopoOrderConcurrently(model.TracedWalkFunc(p.transCtx.Context, p.walkFunc), nil, viper.GetInt(constant.CfgKBPlanExecutionWorkers))
 	if err != nil {
 		if hErr := p.handlePlanExecutionError(err); hErr != nil {
 			return hErr
@@ -298,6 +312,9 @@ func (c *clusterPlanBuilder) reconcileCluster(node *model.ObjectVertex) error {
 
 func (c *clusterPlanBuilder) reconcileObject(node *model.ObjectVertex) error {
 	ctx := c.transCtx.Context
+	if model.IsServerSideApply(node) {
+		return c.reconcileApplyObject(ctx, node)
+	}
 	switch *node.Action {
 	case model.CREATE:
 		return c.reconcileCreateObject(ctx, node)
@@ -340,6 +357,15 @@ func (c *clusterPlanBuilder) reconcilePatchObject(ctx context.Context, node *mod
 	return nil
 }
 
+func (c *clusterPlanBuilder) reconcileApplyObject(ctx context.Context, node *model.ObjectVertex) error {
+	onConflict := model.ApplyConflictEventFunc(c.transCtx.EventRecorder, c.transCtx.Cluster)
+	err := model.ServerSideApply(ctx, c.cli, node.OriObj, node.Obj, node.ForceApply, onConflict, clientOption(node))
+	if err != nil && *node.Action != model.CREATE && apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (c *clusterPlanBuilder) reconcileDeleteObject(ctx context.Context, node *model.ObjectVertex) error {
 	if controllerutil.RemoveFinalizer(node.Obj, constant.DBClusterFinalizerName) {
 		err := c.cli.Update(ctx, node.Obj, clientOption(node))
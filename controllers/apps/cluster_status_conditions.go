@@ -38,6 +38,8 @@ const (
 	ReasonApplyResourcesSucceed = "ApplyResourcesSucceed" // ReasonApplyResourcesSucceed applies resources succeeded to create or change the cluster
 	ReasonClusterReady          = "ClusterReady"          // ReasonClusterReady the components of cluster are ready, the component phase is running
 	ReasonComponentsNotReady    = "ComponentsNotReady"    // ReasonComponentsNotReady the components of cluster are not ready
+	ReasonServiceRefsReady      = "ServiceRefsReady"      // ReasonServiceRefsReady the external services referenced by the cluster are healthy
+	ReasonServiceRefsNotReady   = "ServiceRefsNotReady"   // ReasonServiceRefsNotReady some external services referenced by the cluster are unhealthy
 )
 
 func setProvisioningStartedCondition(conditions *[]metav1.Condition, clusterName string, clusterGeneration int64, err error) {
@@ -134,3 +136,20 @@ func newClusterNotReadyCondition(clusterName string, kindNames map[string][]stri
 		Reason:  ReasonComponentsNotReady,
 	}
 }
+
+func newServiceRefsReadyCondition(messages []string) metav1.Condition {
+	if len(messages) == 0 {
+		return metav1.Condition{
+			Type:    appsv1.ConditionTypeServiceRefsReady,
+			Status:  metav1.ConditionTrue,
+			Message: "all referenced external services are healthy",
+			Reason:  ReasonServiceRefsReady,
+		}
+	}
+	return metav1.Condition{
+		Type:    appsv1.ConditionTypeServiceRefsReady,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("unhealthy external services: %s", strings.Join(messages, "; ")),
+		Reason:  ReasonServiceRefsNotReady,
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -51,6 +52,8 @@ type ComponentReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	multiClusterMgr multicluster.Manager
 }
 
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch;create;update;patch;delete
@@ -98,12 +101,19 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings/status,verbs=get
 
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
-func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	ctx, end := tracing.StartReconcile(ctx, appsv1.ComponentKind, req.NamespacedName)
+	defer func() { end(res, err) }()
+
 	reqCtx := intctrlutil.RequestCtx{
 		Ctx:      ctx,
 		Req:      req,
@@ -113,6 +123,10 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	reqCtx.Log.V(1).Info("reconcile", "component", req.NamespacedName)
 
+	if res, err := waitForMemberClusters(reqCtx, r.multiClusterMgr); res != nil {
+		return *res, err
+	}
+
 	planBuilder := newComponentPlanBuilder(reqCtx, r.Client)
 	if err := planBuilder.Init(); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
@@ -120,9 +134,11 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	requeueError := func(err error) (ctrl.Result, error) {
 		if re, ok := err.(intctrlutil.RequeueError); ok {
+			tracing.RecordRequeue(reqCtx.Ctx, re.Reason())
 			return intctrlutil.RequeueAfter(re.RequeueAfter(), reqCtx.Log, re.Reason())
 		}
 		if apierrors.IsConflict(err) {
+			tracing.RecordRequeue(reqCtx.Ctx, err.Error())
 			return intctrlutil.Requeue(reqCtx.Log, err.Error())
 		}
 		c := planBuilder.(*componentPlanBuilder)
@@ -144,6 +160,8 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentValidationTransformer{},
 			// handle sidecar container
 			&componentMonitorContainerTransformer{},
+			// handle log collector sidecar container
+			&componentLogCollectorTransformer{},
 			// allocate ports for host-network component
 			&componentHostNetworkTransformer{},
 			// handle component services
@@ -166,10 +184,14 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentWorkloadTransformer{Client: r.Client},
 			// handle RBAC for component workloads
 			&componentRBACTransformer{},
+			// handle prometheus operator monitors for the component exporter
+			&componentPrometheusMonitorTransformer{},
 			// handle component postProvision lifecycle action
 			&componentPostProvisionTransformer{},
 			// update component status
 			&componentStatusTransformer{Client: r.Client},
+			// rotate the passwords of the system accounts, it may requeue, so put it at the end
+			&componentAccountRotationTransformer{},
 		).Build()
 
 	// Execute stage
@@ -193,6 +215,7 @@ func (r *ComponentReconciler) SetupWithManager(mgr ctrl.Manager, multiClusterMgr
 	if multiClusterMgr == nil {
 		return r.setupWithManager(mgr)
 	}
+	r.multiClusterMgr = multiClusterMgr
 	return r.setupWithMultiClusterManager(mgr, multiClusterMgr)
 }
 
@@ -209,7 +232,9 @@ func (r *ComponentReconciler) setupWithManager(mgr ctrl.Manager) error {
 		Owns(&dpv1alpha1.Backup{}).
 		Watches(&dpv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentRestoreResources)).
 		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)).
-		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler))
+		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler)).
+		Watches(&appsv1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.filterServiceRefComponents)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.filterTLSSecretComponents))
 
 	if viper.GetBool(constant.EnableRBACManager) {
 		b.Owns(&rbacv1.RoleBinding{}).
@@ -231,10 +256,13 @@ func (r *ComponentReconciler) setupWithMultiClusterManager(mgr ctrl.Manager, mul
 		Owns(&workloads.InstanceSet{}).
 		Owns(&dpv1alpha1.Backup{}).
 		Watches(&dpv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentRestoreResources)).
-		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler))
+		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler)).
+		Watches(&appsv1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.filterServiceRefComponents)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.filterTLSSecretComponents))
 
 	eventHandler := handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)
 	multiClusterMgr.Watch(b, &corev1.Service{}, eventHandler).
+		Watch(b, &corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.filterServiceRefComponents)).
 		Watch(b, &corev1.Secret{}, eventHandler).
 		Watch(b, &corev1.ConfigMap{}, eventHandler).
 		Watch(b, &corev1.PersistentVolumeClaim{}, eventHandler).
@@ -302,3 +330,70 @@ func (r *ComponentReconciler) configurationEventHandler(_ context.Context, obj c
 		},
 	}
 }
+
+// filterServiceRefComponents enqueues the components referencing the cluster via service-refs,
+// to re-resolve the service-refs when the referenced cluster or its exposed services change.
+func (r *ComponentReconciler) filterServiceRefComponents(ctx context.Context, obj client.Object) []reconcile.Request {
+	var clusterName string
+	switch o := obj.(type) {
+	case *appsv1.Cluster:
+		clusterName = o.Name
+	case *corev1.Service:
+		if o.Spec.Type != corev1.ServiceTypeLoadBalancer && o.Spec.Type != corev1.ServiceTypeNodePort {
+			return nil
+		}
+		labels := o.GetLabels()
+		if v, ok := labels[constant.AppManagedByLabelKey]; !ok || v != constant.AppName {
+			return nil
+		}
+		clusterName = labels[constant.AppInstanceLabelKey]
+	}
+	if len(clusterName) == 0 {
+		return nil
+	}
+
+	compList := &appsv1.ComponentList{}
+	if err := r.Client.List(ctx, compList); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0)
+	for _, comp := range compList.Items {
+		for _, serviceRef := range comp.Spec.ServiceRefs {
+			if serviceRef.ClusterServiceSelector == nil || serviceRef.ClusterServiceSelector.Cluster != clusterName {
+				continue
+			}
+			namespace := serviceRef.Namespace
+			if len(namespace) == 0 {
+				namespace = comp.Namespace
+			}
+			if namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&comp)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// filterTLSSecretComponents enqueues the components referencing the secret as the user-provided TLS certificates,
+// to reload the certificates when the secret changes.
+func (r *ComponentReconciler) filterTLSSecretComponents(ctx context.Context, obj client.Object) []reconcile.Request {
+	if v, ok := obj.GetLabels()[constant.AppManagedByLabelKey]; ok && v == constant.AppName {
+		return nil
+	}
+	compList := &appsv1.ComponentList{}
+	if err := r.Client.List(ctx, compList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0)
+	for _, comp := range compList.Items {
+		tls := comp.Spec.TLSConfig
+		if tls == nil || !tls.Enable || tls.Issuer == nil || tls.Issuer.Name != appsv1.IssuerUserProvided {
+			continue
+		}
+		if tls.Issuer.SecretRef != nil && tls.Issuer.SecretRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&comp)})
+		}
+	}
+	return requests
+}
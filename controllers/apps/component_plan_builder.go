@@ -36,6 +36,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // componentTransformContext a graph.TransformContext implementation for Component reconciliation
@@ -118,6 +120,7 @@ func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 		c.transCtx.Logger.Info(fmt.Sprintf("build error: %s", err.Error()))
 	}
 	c.transCtx.Logger.V(1).Info(fmt.Sprintf("DAG: %s", dag))
+	tracing.RecordPlan(c.transCtx.Context, dag)
 
 	plan := &componentPlan{
 		dag:      dag,
@@ -128,7 +131,7 @@ func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 }
 
 func (p *componentPlan) Execute() error {
-	err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
+	err := p.dag.WalkReverseTopoOrderConcurrently(model.TracedWalkFunc(p.transCtx.Context, p.walkFunc), nil, viper.GetInt(constant.CfgKBPlanExecutionWorkers))
 	if err != nil {
 		p.transCtx.Logger.Info(fmt.Sprintf("execute error: %s", err.Error()))
 	}
@@ -178,6 +181,9 @@ func (c *componentPlanBuilder) defaultWalkFunc(v graph.Vertex) error {
 		return fmt.Errorf("vertex action can't be nil")
 	}
 	ctx := c.transCtx.Context
+	if model.IsServerSideApply(vertex) {
+		return c.reconcileApplyObject(ctx, vertex)
+	}
 	switch *vertex.Action {
 	case model.CREATE:
 		return c.reconcileCreateObject(ctx, vertex)
@@ -218,6 +224,15 @@ func (c *componentPlanBuilder) reconcilePatchObject(ctx context.Context, vertex
 	return nil
 }
 
+func (c *componentPlanBuilder) reconcileApplyObject(ctx context.Context, vertex *model.ObjectVertex) error {
+	onConflict := model.ApplyConflictEventFunc(c.transCtx.EventRecorder, c.transCtx.Component)
+	err := model.ServerSideApply(ctx, c.cli, vertex.OriObj, vertex.Obj, vertex.ForceApply, onConflict, clientOption(vertex))
+	if err != nil && *vertex.Action != model.CREATE && apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (c *componentPlanBuilder) reconcileDeleteObject(ctx context.Context, vertex *model.ObjectVertex) error {
 	// The additional removal of DBClusterFinalizerName in the component controller is to backward compatibility.
 	// In versions prior to 0.9.0, the component object's finalizers includes DBClusterFinalizerName.
@@ -23,11 +23,18 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -91,4 +98,111 @@ var _ = Describe("component plan builder test", func() {
 			Expect(planBuilder.Init()).Should(Succeed())
 		})
 	})
+
+	Context("server-side apply", func() {
+		It("leaves the fields of other field managers and forces the conflicts only if opted in", func() {
+			const otherManager = "other"
+			svc := builder.NewServiceBuilder(testCtx.DefaultNamespace, "ssa-svc").
+				AddLabels(testCtx.TestObjLabelKey, "true").
+				AddSelector("app", "foo").
+				AddPorts(corev1.ServicePort{Name: "client", Port: 2379, Protocol: corev1.ProtocolTCP}).
+				GetObject()
+			svcKey := client.ObjectKeyFromObject(svc)
+			defer func() {
+				Expect(client.IgnoreNotFound(testCtx.Cli.Delete(testCtx.Ctx, svc))).Should(Succeed())
+			}()
+
+			By("create the service")
+			Expect(model.ServerSideApply(testCtx.Ctx, testCtx.Cli, nil, svc, false, nil)).Should(Succeed())
+
+			By("the second field manager sets a label and the session affinity")
+			otherApply := func(mutate func(obj *unstructured.Unstructured), force bool) error {
+				obj := &unstructured.Unstructured{}
+				obj.SetAPIVersion("v1")
+				obj.SetKind("Service")
+				obj.SetNamespace(svc.Namespace)
+				obj.SetName(svc.Name)
+				mutate(obj)
+				opts := []client.PatchOption{client.FieldOwner(otherManager)}
+				if force {
+					opts = append(opts, client.ForceOwnership)
+				}
+				return testCtx.Cli.Patch(testCtx.Ctx, obj, client.Apply, opts...)
+			}
+			Expect(otherApply(func(obj *unstructured.Unstructured) {
+				obj.SetLabels(map[string]string{"other": "value"})
+				Expect(unstructured.SetNestedField(obj.Object, string(corev1.ServiceAffinityClientIP), "spec", "sessionAffinity")).Should(Succeed())
+			}, false)).Should(Succeed())
+
+			By("apply the changed fields, the ones of the second field manager are kept")
+			live := &corev1.Service{}
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			desired := live.DeepCopy()
+			desired.Spec.Selector["app"] = "bar"
+			Expect(model.ServerSideApply(testCtx.Ctx, testCtx.Cli, live, desired, false, nil)).Should(Succeed())
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			Expect(live.Spec.Selector).Should(HaveKeyWithValue("app", "bar"))
+			Expect(live.Labels).Should(HaveKeyWithValue("other", "value"))
+			Expect(live.Spec.SessionAffinity).Should(Equal(corev1.ServiceAffinityClientIP))
+			managers := make([]string, 0)
+			for _, entry := range live.ManagedFields {
+				managers = append(managers, entry.Manager)
+			}
+			Expect(managers).Should(ContainElements(model.FieldManager, otherManager))
+
+			By("the conflicts are reported rather than forced by default")
+			Expect(otherApply(func(obj *unstructured.Unstructured) {
+				Expect(unstructured.SetNestedStringMap(obj.Object, map[string]string{"app": "baz"}, "spec", "selector")).Should(Succeed())
+			}, true)).Should(Succeed())
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			desired = live.DeepCopy()
+			desired.Spec.Selector["app"] = "qux"
+			recorder := record.NewFakeRecorder(2)
+			onConflict := model.ApplyConflictEventFunc(recorder, live)
+			err := model.ServerSideApply(testCtx.Ctx, testCtx.Cli, live, desired, false, onConflict)
+			Expect(apierrors.IsConflict(err)).Should(BeTrue())
+			Expect(recorder.Events).Should(Receive(ContainSubstring(constant.ReasonApplyConflict)))
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			Expect(live.Spec.Selector).Should(HaveKeyWithValue("app", "baz"))
+
+			By("force the conflicts if opted in")
+			Expect(model.ServerSideApply(testCtx.Ctx, testCtx.Cli, live, desired, true, onConflict)).Should(Succeed())
+			Expect(recorder.Events).Should(Receive(ContainSubstring(constant.ReasonApplyConflict)))
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			Expect(live.Spec.Selector).Should(HaveKeyWithValue("app", "qux"))
+			Expect(live.Labels).Should(HaveKeyWithValue("other", "value"))
+		})
+
+		It("migrates the fields written by update before to the apply field manager", func() {
+			const legacyManager = "KubeBlocks 0.9.0 (linux"
+			svc := builder.NewServiceBuilder(testCtx.DefaultNamespace, "ssa-legacy-svc").
+				AddLabels(testCtx.TestObjLabelKey, "true").
+				AddSelector("app", "foo").
+				AddPorts(corev1.ServicePort{Name: "client", Port: 2379, Protocol: corev1.ProtocolTCP}).
+				GetObject()
+			svcKey := client.ObjectKeyFromObject(svc)
+			defer func() {
+				Expect(client.IgnoreNotFound(testCtx.Cli.Delete(testCtx.Ctx, svc))).Should(Succeed())
+			}()
+
+			By("create the service by the legacy field manager")
+			Expect(testCtx.Cli.Create(testCtx.Ctx, svc.DeepCopy(), client.FieldOwner(legacyManager))).Should(Succeed())
+
+			By("apply the changed fields, no conflict with the legacy field manager")
+			live := &corev1.Service{}
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			desired := live.DeepCopy()
+			desired.Spec.Selector["app"] = "bar"
+			recorder := record.NewFakeRecorder(1)
+			Expect(model.ServerSideApply(testCtx.Ctx, testCtx.Cli, live, desired, false,
+				model.ApplyConflictEventFunc(recorder, live))).Should(Succeed())
+			Expect(recorder.Events).ShouldNot(Receive())
+			Expect(testCtx.Cli.Get(testCtx.Ctx, svcKey, live)).Should(Succeed())
+			Expect(live.Spec.Selector).Should(HaveKeyWithValue("app", "bar"))
+			Expect(live.Spec.Ports).Should(HaveLen(1))
+			for _, entry := range live.ManagedFields {
+				Expect(entry.Manager).ShouldNot(Equal(legacyManager))
+			}
+		})
+	})
 })
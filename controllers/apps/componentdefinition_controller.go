@@ -400,17 +400,7 @@ func (r *ComponentDefinitionReconciler) skipImmutableCheck(cmpd *appsv1.Componen
 }
 
 func (r *ComponentDefinitionReconciler) cmpdHash(cmpd *appsv1.ComponentDefinition) (string, error) {
-	objCopy := cmpd.DeepCopy()
-
-	// reset all mutable fields
-	objCopy.Spec.Provider = ""
-	objCopy.Spec.Description = ""
-	objCopy.Spec.Exporter = nil
-	objCopy.Spec.PodManagementPolicy = nil
-
-	// TODO: bpt
-
-	data, err := json.Marshal(objCopy.Spec)
+	data, err := json.Marshal(component.ImmutableCompDefSpec(cmpd))
 	if err != nil {
 		return "", err
 	}
@@ -117,12 +117,23 @@ func (r *ConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	if fetcherTask.ClusterComObj == nil || fetcherTask.ComponentObj == nil {
 		return r.failWithInvalidComponent(config, reqCtx)
 	}
-	if err := r.runTasks(TaskContext{config, ctx, fetcherTask}, tasks); err != nil {
+	synthesizedComp, err := r.buildSynthesizedComponent(TaskContext{config, ctx, fetcherTask})
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to build synthesized component.")
+	}
+	if err := r.runTasks(TaskContext{config, ctx, fetcherTask}, tasks, synthesizedComp); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to run configuration reconcile task.")
 	}
 	if !isAllReady(config) {
 		return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, "")
 	}
+	requeue, err := r.detectParametersDrift(reqCtx, config, fetcherTask, synthesizedComp)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to detect parameters drift.")
+	}
+	if requeue {
+		return intctrlutil.RequeueAfter(parametersDriftCheckInterval, reqCtx.Log, "")
+	}
 	return intctrlutil.Reconciled()
 }
 
@@ -147,22 +158,24 @@ func isAllReady(configuration *appsv1alpha1.Configuration) bool {
 	return true
 }
 
-func (r *ConfigurationReconciler) runTasks(taskCtx TaskContext, tasks []Task) (err error) {
-	var (
-		errs            []error
-		synthesizedComp *component.SynthesizedComponent
-		configuration   = taskCtx.configuration
-	)
-
+func (r *ConfigurationReconciler) buildSynthesizedComponent(taskCtx TaskContext) (*component.SynthesizedComponent, error) {
 	// build synthesized component for the component
-	synthesizedComp, err = component.BuildSynthesizedComponent(taskCtx.ctx, r.Client,
+	synthesizedComp, err := component.BuildSynthesizedComponent(taskCtx.ctx, r.Client,
 		taskCtx.fetcher.ComponentDefObj, taskCtx.fetcher.ComponentObj, taskCtx.fetcher.ClusterObj)
 	if err == nil {
 		err = buildTemplateVars(taskCtx.ctx, r.Client, taskCtx.fetcher.ComponentDefObj, synthesizedComp)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return synthesizedComp, nil
+}
+
+func (r *ConfigurationReconciler) runTasks(taskCtx TaskContext, tasks []Task, synthesizedComp *component.SynthesizedComponent) (err error) {
+	var (
+		errs          []error
+		configuration = taskCtx.configuration
+	)
 
 	// TODO manager multiple version
 	patch := client.MergeFrom(configuration.DeepCopy())
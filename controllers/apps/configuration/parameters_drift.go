@@ -0,0 +1,196 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const parametersDriftCheckInterval = time.Minute
+
+type driftItem struct {
+	item             appsv1alpha1.ConfigurationItemDetail
+	configMap        *corev1.ConfigMap
+	configConstraint *appsv1beta1.ConfigConstraint
+}
+
+// detectParametersDrift dumps the effective parameters of the running engine, and compares them with the rendered
+// configuration for the items whose drift policy is enabled. It returns true if the detection should be requeued.
+func (r *ConfigurationReconciler) detectParametersDrift(reqCtx intctrlutil.RequestCtx,
+	config *appsv1alpha1.Configuration,
+	fetcher *Task,
+	synthesizedComp *component.SynthesizedComponent) (bool, error) {
+	items, err := r.driftDetectionItems(reqCtx, config, fetcher)
+	if err != nil || len(items) == 0 {
+		return false, err
+	}
+
+	patch := client.MergeFrom(config.DeepCopy())
+	condition := r.checkParametersDrift(reqCtx, items, synthesizedComp)
+	condition.ObservedGeneration = config.Generation
+	meta.SetStatusCondition(&config.Status.Conditions, condition)
+	if err := r.Client.Status().Patch(reqCtx.Ctx, config, patch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ConfigurationReconciler) driftDetectionItems(reqCtx intctrlutil.RequestCtx,
+	config *appsv1alpha1.Configuration, fetcher *Task) ([]driftItem, error) {
+	var items []driftItem
+	for _, item := range config.Spec.ConfigItemDetails {
+		if item.DriftPolicy == "" || item.DriftPolicy == appsv1alpha1.NoneDriftPolicy {
+			continue
+		}
+		if item.ConfigSpec == nil || item.ConfigSpec.ConfigConstraintRef == "" {
+			reqCtx.Log.V(1).Info(fmt.Sprintf("config constraint is not defined, skip drift detection: %s", item.Name))
+			continue
+		}
+		resources := configctrl.NewResourceFetcher(fetcher.ResourceCtx).
+			ConfigMap(item.Name).
+			ConfigConstraints(item.ConfigSpec.ConfigConstraintRef)
+		if err := resources.Complete(); err != nil {
+			return nil, err
+		}
+		items = append(items, driftItem{
+			item:             item,
+			configMap:        resources.ConfigMapObj,
+			configConstraint: resources.ConfigConstraintObj,
+		})
+	}
+	return items, nil
+}
+
+func (r *ConfigurationReconciler) checkParametersDrift(reqCtx intctrlutil.RequestCtx,
+	items []driftItem, synthesizedComp *component.SynthesizedComponent) metav1.Condition {
+	dumpErrorCondition := func(err error) metav1.Condition {
+		return metav1.Condition{
+			Type:    appsv1alpha1.ConditionTypeParametersDrifted,
+			Status:  metav1.ConditionUnknown,
+			Reason:  appsv1alpha1.ReasonParametersDumpError,
+			Message: err.Error(),
+		}
+	}
+
+	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.ParametersDump == nil {
+		return dumpErrorCondition(fmt.Errorf("the parametersDump action is not defined in the component definition"))
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, r.Client, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return dumpErrorCondition(err)
+	}
+
+	var (
+		drifts    []string
+		reapplied = true
+	)
+	for _, pod := range pods {
+		if !intctrlutil.PodIsReady(pod) {
+			continue
+		}
+		lfa, err := lifecycle.New(synthesizedComp, pod)
+		if err != nil {
+			return dumpErrorCondition(err)
+		}
+		output, err := lfa.ParametersDump(reqCtx.Ctx, r.Client, nil)
+		if err != nil {
+			return dumpErrorCondition(err)
+		}
+		effective, err := core.ParseParametersDump(output)
+		if err != nil {
+			return dumpErrorCondition(err)
+		}
+		for _, item := range items {
+			cc := &item.configConstraint.Spec
+			drifted, err := core.DetectParametersDrift(item.configMap.Data, effective, cc.FileFormatConfig, item.item.ConfigSpec.Keys)
+			if err != nil {
+				return dumpErrorCondition(err)
+			}
+			if len(drifted) == 0 {
+				continue
+			}
+			for _, p := range drifted {
+				drifts = append(drifts, fmt.Sprintf("%s/%s/%s: expected %s, effective %s", pod.Name, p.File, p.Name, p.Expected, p.Effective))
+			}
+			if item.item.DriftPolicy != appsv1alpha1.ReapplyDriftPolicy {
+				reapplied = false
+				continue
+			}
+			if err := reapplyDriftedParameters(reqCtx, pod, item.item.Name, drifted, cc); err != nil {
+				reqCtx.Log.Error(err, fmt.Sprintf("failed to re-apply the drifted parameters to pod %s", pod.Name))
+				reapplied = false
+			}
+		}
+	}
+
+	switch {
+	case len(drifts) == 0:
+		return metav1.Condition{
+			Type:    appsv1alpha1.ConditionTypeParametersDrifted,
+			Status:  metav1.ConditionFalse,
+			Reason:  appsv1alpha1.ReasonParametersNoDrift,
+			Message: "the effective parameters are consistent with the rendered configuration",
+		}
+	case reapplied:
+		return metav1.Condition{
+			Type:    appsv1alpha1.ConditionTypeParametersDrifted,
+			Status:  metav1.ConditionFalse,
+			Reason:  appsv1alpha1.ReasonParametersReapplied,
+			Message: fmt.Sprintf("the drifted parameters have been re-applied: %s", strings.Join(drifts, "; ")),
+		}
+	default:
+		return metav1.Condition{
+			Type:    appsv1alpha1.ConditionTypeParametersDrifted,
+			Status:  metav1.ConditionTrue,
+			Reason:  appsv1alpha1.ReasonParametersDrifted,
+			Message: strings.Join(drifts, "; "),
+		}
+	}
+}
+
+func reapplyDriftedParameters(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod, configSpec string,
+	drifted []core.DriftedParameter, cc *appsv1beta1.ConfigConstraintSpec) error {
+	if cc.ReloadAction == nil {
+		return core.MakeError("the dynamic reload action is not defined in the config constraint")
+	}
+	params := make(map[string]string, len(drifted))
+	for _, p := range drifted {
+		if !cc.ReloadStaticParameters() && !core.IsDynamicParameter(p.Name, cc) {
+			return core.MakeError("the drifted parameter %s is not dynamic, and cannot be re-applied without restart", p.Name)
+		}
+		params[p.Name] = p.Expected
+	}
+	return commonOnlineUpdateWithPod(pod, reqCtx.Ctx, GetClientFactory(), configSpec, params)
+}
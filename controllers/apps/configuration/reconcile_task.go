@@ -127,6 +127,7 @@ func syncImpl(fetcher *Task,
 
 func syncStatus(configMap *corev1.ConfigMap, status *appsv1alpha1.ConfigurationItemDetailStatus) (err error) {
 	annotations := configMap.GetAnnotations()
+	status.RevisionDiff = RetrieveRevisionDiff(configMap)
 	// status.CurrentRevision = GetCurrentRevision(annotations)
 	revisions := RetrieveRevision(annotations)
 	if len(revisions) == 0 {
@@ -137,6 +138,7 @@ func syncStatus(configMap *corev1.ConfigMap, status *appsv1alpha1.ConfigurationI
 		updateRevision(revisions[i], status)
 		updateLastDoneRevision(revisions[i], status)
 	}
+	status.RevisionHistory = RetrieveRevisionHistory(configMap)
 
 	return
 }
@@ -266,6 +266,14 @@ func enableSyncTrigger(reloadAction *appsv1beta1.ReloadAction) bool {
 	if reloadAction.ShellTrigger != nil {
 		return !core.IsWatchModuleForShellTrigger(reloadAction.ShellTrigger)
 	}
+
+	if reloadAction.HTTPTrigger != nil {
+		return !core.IsWatchModuleForHTTPTrigger(reloadAction.HTTPTrigger)
+	}
+
+	if reloadAction.SQLTrigger != nil {
+		return !core.IsWatchModuleForSQLTrigger(reloadAction.SQLTrigger)
+	}
 	return false
 }
 
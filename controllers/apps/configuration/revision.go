@@ -21,6 +21,7 @@ package configuration
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -47,6 +49,7 @@ func GcConfigRevision(configObj *corev1.ConfigMap) {
 	if len(revisions) > 0 {
 		for _, v := range revisions {
 			delete(configObj.ObjectMeta.Annotations, core.GenerateRevisionPhaseKey(v.StrRevision))
+			delete(configObj.ObjectMeta.Annotations, core.GenerateRevisionParametersKey(v.StrRevision))
 		}
 	}
 }
@@ -120,6 +123,54 @@ func parseResult(data string, revision string) intctrlutil.Result {
 	return result
 }
 
+// RetrieveRevisionHistory returns the retained revisions, and the parameters changed in each revision compared to
+// its previous retained revision.
+func RetrieveRevisionHistory(configObj *corev1.ConfigMap) []appsv1alpha1.ConfigurationRevisionHistory {
+	var (
+		history []appsv1alpha1.ConfigurationRevisionHistory
+		last    map[string]appsv1alpha1.ConfigParams
+	)
+	for _, revision := range RetrieveRevision(configObj.GetAnnotations()) {
+		params, err := configctrl.GetRevisionParameters(configObj, revision.StrRevision)
+		if err != nil {
+			// the revision was created before the parameters are recorded, and cannot be rolled back to
+			continue
+		}
+		history = append(history, appsv1alpha1.ConfigurationRevisionHistory{
+			Revision: revision.StrRevision,
+			Phase:    revision.Phase,
+			Changes:  configctrl.DiffRevisionParameters(last, params),
+		})
+		last = params
+	}
+	return history
+}
+
+// RetrieveRevisionDiff returns the parameter-level diff between the two revisions requested by the annotation
+// ConfigurationRevisionDiff, or nil if it is not requested.
+func RetrieveRevisionDiff(configObj *corev1.ConfigMap) *appsv1alpha1.ConfigurationRevisionDiff {
+	request, ok := configObj.GetAnnotations()[constant.ConfigurationRevisionDiff]
+	if !ok {
+		return nil
+	}
+	from, to, found := strings.Cut(request, ",")
+	diff := &appsv1alpha1.ConfigurationRevisionDiff{
+		From: strings.TrimSpace(from),
+		To:   strings.TrimSpace(to),
+	}
+	if !found || diff.From == "" || diff.To == "" {
+		diff.Message = fmt.Sprintf("invalid revision diff request %q, the format is \"<from>,<to>\"", request)
+		return diff
+	}
+	changes, err := configctrl.DiffRevisions(configObj, diff.From, diff.To)
+	if err != nil {
+		diff.Message = err.Error()
+		return diff
+	}
+	diff.Changes = changes
+	return diff
+}
+
 func GetCurrentRevision(annotations map[string]string) string {
 	if len(annotations) == 0 {
 		return ""
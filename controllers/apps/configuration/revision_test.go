@@ -27,6 +27,7 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -205,3 +206,73 @@ func TestGetLastRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveRevisionHistory(t *testing.T) {
+	cm := builder.NewConfigMapBuilder("default", "test").
+		AddAnnotations(core.GenerateRevisionPhaseKey("1"), "Finished").
+		AddAnnotations(core.GenerateRevisionPhaseKey("2"), "Finished").
+		AddAnnotations(core.GenerateRevisionPhaseKey("3"), "Running").
+		AddAnnotations(core.GenerateRevisionParametersKey("2"), `{"my.cnf":{"content":null,"parameters":{"max_connections":"100"}}}`).
+		AddAnnotations(core.GenerateRevisionParametersKey("3"), `{"my.cnf":{"content":null,"parameters":{"max_connections":"200","innodb_buffer_pool_size":"1G"}}}`).
+		GetObject()
+
+	history := RetrieveRevisionHistory(cm)
+	// revision 1 has no parameters recorded
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, "2", history[0].Revision)
+	assert.Equal(t, appsv1alpha1.CFinishedPhase, history[0].Phase)
+	assert.Equal(t, 1, len(history[0].Changes))
+	assert.Equal(t, appsv1alpha1.ParameterAdded, history[0].Changes[0].Type)
+
+	assert.Equal(t, "3", history[1].Revision)
+	assert.Equal(t, []appsv1alpha1.ParameterChange{{
+		File:     "my.cnf",
+		Name:     "innodb_buffer_pool_size",
+		Type:     appsv1alpha1.ParameterAdded,
+		NewValue: cfgutil.ToPointer("1G"),
+	}, {
+		File:     "my.cnf",
+		Name:     "max_connections",
+		Type:     appsv1alpha1.ParameterUpdated,
+		OldValue: cfgutil.ToPointer("100"),
+		NewValue: cfgutil.ToPointer("200"),
+	}}, history[1].Changes)
+
+	GcConfigRevision(cm)
+	assert.Contains(t, cm.GetAnnotations(), core.GenerateRevisionParametersKey("3"))
+}
+
+func TestRetrieveRevisionDiff(t *testing.T) {
+	cm := builder.NewConfigMapBuilder("default", "test").
+		AddAnnotations(core.GenerateRevisionParametersKey("1"), `{"my.cnf":{"content":null,"parameters":{"max_connections":"100"}}}`).
+		AddAnnotations(core.GenerateRevisionParametersKey("2"), `{"my.cnf":{"content":null,"parameters":{"max_connections":"150"}}}`).
+		AddAnnotations(core.GenerateRevisionParametersKey("3"), `{"my.cnf":{"content":null,"parameters":{"max_connections":"200"}}}`).
+		GetObject()
+
+	// not requested
+	assert.Nil(t, RetrieveRevisionDiff(cm))
+
+	cm.Annotations[constant.ConfigurationRevisionDiff] = "1, 3"
+	diff := RetrieveRevisionDiff(cm)
+	assert.Equal(t, "1", diff.From)
+	assert.Equal(t, "3", diff.To)
+	assert.Empty(t, diff.Message)
+	assert.Equal(t, []appsv1alpha1.ParameterChange{{
+		File:     "my.cnf",
+		Name:     "max_connections",
+		Type:     appsv1alpha1.ParameterUpdated,
+		OldValue: cfgutil.ToPointer("100"),
+		NewValue: cfgutil.ToPointer("200"),
+	}}, diff.Changes)
+
+	// the revision has been garbage collected
+	cm.Annotations[constant.ConfigurationRevisionDiff] = "0,3"
+	diff = RetrieveRevisionDiff(cm)
+	assert.NotEmpty(t, diff.Message)
+	assert.Empty(t, diff.Changes)
+
+	// invalid request
+	cm.Annotations[constant.ConfigurationRevisionDiff] = "3"
+	diff = RetrieveRevisionDiff(cm)
+	assert.Contains(t, diff.Message, "invalid revision diff request")
+}
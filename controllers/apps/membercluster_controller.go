@@ -0,0 +1,298 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonMemberClusterReady       = "MemberClusterReady"
+	reasonMemberClusterUnreachable = "MemberClusterUnreachable"
+	reasonMemberClusterUnavailable = "MemberClusterUnavailable"
+	reasonMemberClusterDisabled    = "MemberClusterDisabled"
+	reasonMemberClusterInvalid     = "MemberClusterInvalid"
+
+	defaultMemberClusterPeriodSeconds    = 30
+	defaultMemberClusterTimeoutSeconds   = 5
+	defaultMemberClusterFailureThreshold = 3
+)
+
+// probeMemberCluster probes the member cluster and returns the version and the allocatable resources of it.
+var probeMemberCluster = func(ctx context.Context, config *rest.Config, timeout time.Duration) (string, corev1.ResourceList, error) {
+	config = rest.CopyConfig(config)
+	config.Timeout = timeout
+	cli, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", nil, err
+	}
+	version, err := cli.Discovery().ServerVersion()
+	if err != nil {
+		return "", nil, err
+	}
+	nodes, err := cli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", nil, err
+	}
+	return version.GitVersion, allocatableResources(nodes.Items), nil
+}
+
+// allocatableResources sums up the allocatable CPU and memory of the ready and schedulable nodes.
+func allocatableResources(nodes []corev1.Node) corev1.ResourceList {
+	cpu, memory := resource.Quantity{}, resource.Quantity{}
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		ready := false
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+		if !ready {
+			continue
+		}
+		cpu.Add(node.Status.Allocatable[corev1.ResourceCPU])
+		memory.Add(node.Status.Allocatable[corev1.ResourceMemory])
+	}
+	return corev1.ResourceList{corev1.ResourceCPU: cpu, corev1.ResourceMemory: memory}
+}
+
+// MemberClusterReconciler reconciles a MemberCluster object
+type MemberClusterReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	MultiClusterMgr multicluster.Manager
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=memberclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=memberclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=memberclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile registers the member cluster to the multi-cluster manager, and probes it periodically
+// to mark it as unavailable once it fails the probes consecutively.
+func (r *MemberClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("memberCluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	memberCluster := &appsv1.MemberCluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, memberCluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, memberCluster, constant.MemberClusterFinalizerName, func() (*ctrl.Result, error) {
+		return nil, r.MultiClusterMgr.Unregister(memberCluster.Name)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	oldPhase := memberCluster.Status.Phase
+	patch := client.MergeFrom(memberCluster.DeepCopy())
+	config, err := r.register(reqCtx, memberCluster)
+	if err != nil {
+		r.setPhase(memberCluster, appsv1.FailedMemberClusterPhase, metav1.ConditionFalse, reasonMemberClusterInvalid, err.Error())
+	}
+
+	var nextProbe time.Duration
+	switch {
+	case err != nil:
+	case memberCluster.Spec.Disabled:
+		memberCluster.Status.ConsecutiveFailures = 0
+		r.setPhase(memberCluster, appsv1.DisabledMemberClusterPhase, metav1.ConditionFalse, reasonMemberClusterDisabled,
+			"the member cluster is disabled")
+	default:
+		nextProbe = r.reconcileHealthCheck(reqCtx, memberCluster, config)
+	}
+
+	memberCluster.Status.ObservedGeneration = memberCluster.Generation
+	if perr := r.Client.Status().Patch(reqCtx.Ctx, memberCluster, patch); perr != nil {
+		return intctrlutil.CheckedRequeueWithError(perr, reqCtx.Log, "")
+	}
+
+	if oldPhase != memberCluster.Status.Phase {
+		eventType := corev1.EventTypeNormal
+		if memberCluster.Status.Phase == appsv1.FailedMemberClusterPhase ||
+			memberCluster.Status.Phase == appsv1.UnavailableMemberClusterPhase {
+			eventType = corev1.EventTypeWarning
+		}
+		r.Recorder.Event(memberCluster, eventType, string(memberCluster.Status.Phase), memberCluster.Status.Message)
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if nextProbe > 0 {
+		return intctrlutil.RequeueAfter(nextProbe, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MemberClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.MemberCluster{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.filterKubeConfigSecret)).
+		Complete(r)
+}
+
+// filterKubeConfigSecret enqueues the member clusters referencing the secret, to re-register them once the kubeconfig is changed.
+func (r *MemberClusterReconciler) filterKubeConfigSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	memberClusters := &appsv1.MemberClusterList{}
+	if err := r.Client.List(ctx, memberClusters); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, memberCluster := range memberClusters.Items {
+		ref := memberCluster.Spec.KubeConfigSecretRef
+		if ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&memberCluster)})
+		}
+	}
+	return requests
+}
+
+// register registers the member cluster to the multi-cluster manager with the kubeconfig referenced.
+func (r *MemberClusterReconciler) register(reqCtx intctrlutil.RequestCtx, memberCluster *appsv1.MemberCluster) (*rest.Config, error) {
+	config, err := multicluster.MemberClusterConfig(reqCtx.Ctx, r.Client, memberCluster)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.MultiClusterMgr.Register(memberCluster.Name, config, memberCluster.Spec.Disabled); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// reconcileHealthCheck probes the member cluster if the probe is due, and returns the duration after which the next probe is due.
+func (r *MemberClusterReconciler) reconcileHealthCheck(reqCtx intctrlutil.RequestCtx, memberCluster *appsv1.MemberCluster, config *rest.Config) time.Duration {
+	period, timeout, threshold := memberClusterHealthCheck(memberCluster.Spec.HealthCheck)
+
+	lastProbeTime := memberCluster.Status.LastProbeTime
+	phase := memberCluster.Status.Phase
+	if memberCluster.Status.ObservedGeneration == memberCluster.Generation && lastProbeTime != nil &&
+		time.Since(lastProbeTime.Time) < period &&
+		phase != appsv1.FailedMemberClusterPhase && phase != appsv1.DisabledMemberClusterPhase {
+		return period - time.Since(lastProbeTime.Time)
+	}
+
+	now := metav1.Now()
+	memberCluster.Status.LastProbeTime = &now
+	version, allocatable, err := probeMemberCluster(reqCtx.Ctx, config, timeout)
+	if err == nil {
+		memberCluster.Status.ConsecutiveFailures = 0
+		memberCluster.Status.ServerVersion = version
+		memberCluster.Status.Allocatable = allocatable
+		r.MultiClusterMgr.SetAvailable(memberCluster.Name, true)
+		r.setPhase(memberCluster, appsv1.ReadyMemberClusterPhase, metav1.ConditionTrue, reasonMemberClusterReady,
+			fmt.Sprintf("the member cluster is reachable, version: %s", version))
+		return period
+	}
+
+	memberCluster.Status.ConsecutiveFailures++
+	message := fmt.Sprintf("failed to probe the member cluster %d time(s): %s", memberCluster.Status.ConsecutiveFailures, err.Error())
+	switch {
+	case memberCluster.Status.ConsecutiveFailures >= threshold:
+		r.MultiClusterMgr.SetAvailable(memberCluster.Name, false)
+		r.setPhase(memberCluster, appsv1.UnavailableMemberClusterPhase, metav1.ConditionFalse, reasonMemberClusterUnavailable, message)
+	case phase == appsv1.ReadyMemberClusterPhase:
+		// keep it available until the failures reach the threshold
+		memberCluster.Status.Message = message
+	default:
+		r.setPhase(memberCluster, appsv1.PendingMemberClusterPhase, metav1.ConditionFalse, reasonMemberClusterUnreachable, message)
+	}
+	return period
+}
+
+// waitForMemberClusters requeues the reconciliation until the member clusters have been registered,
+// otherwise the objects in the member clusters not registered yet would be taken as absent.
+func waitForMemberClusters(reqCtx intctrlutil.RequestCtx, multiClusterMgr multicluster.Manager) (*ctrl.Result, error) {
+	if multiClusterMgr == nil {
+		return nil, nil
+	}
+	synced, err := multiClusterMgr.Synced(reqCtx.Ctx)
+	if err != nil {
+		res, err := intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		return &res, err
+	}
+	if !synced {
+		res, err := intctrlutil.RequeueAfter(time.Second, reqCtx.Log, "waiting for the member clusters to be registered")
+		return &res, err
+	}
+	return nil, nil
+}
+
+func (r *MemberClusterReconciler) setPhase(memberCluster *appsv1.MemberCluster, phase appsv1.MemberClusterPhase,
+	status metav1.ConditionStatus, reason, message string) {
+	memberCluster.Status.Phase = phase
+	memberCluster.Status.Message = message
+	meta.SetStatusCondition(&memberCluster.Status.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionTypeReady,
+		Status:             status,
+		ObservedGeneration: memberCluster.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func memberClusterHealthCheck(healthCheck *appsv1.MemberClusterHealthCheck) (time.Duration, time.Duration, int32) {
+	period := time.Duration(defaultMemberClusterPeriodSeconds) * time.Second
+	timeout := time.Duration(defaultMemberClusterTimeoutSeconds) * time.Second
+	threshold := int32(defaultMemberClusterFailureThreshold)
+	if healthCheck != nil {
+		if healthCheck.PeriodSeconds > 0 {
+			period = time.Duration(healthCheck.PeriodSeconds) * time.Second
+		}
+		if healthCheck.TimeoutSeconds > 0 {
+			timeout = time.Duration(healthCheck.TimeoutSeconds) * time.Second
+		}
+		if healthCheck.FailureThreshold > 0 {
+			threshold = healthCheck.FailureThreshold
+		}
+	}
+	return period, timeout, threshold
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+)
+
+const memberClusterKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: member
+  cluster:
+    server: https://member.example.com:6443
+users:
+- name: admin
+  user:
+    token: secret-token
+contexts:
+- name: member
+  context:
+    cluster: member
+    user: admin
+current-context: member
+`
+
+type mockMultiClusterMgr struct {
+	cli        client.Client
+	contexts   []string
+	registered map[string]*rest.Config
+	available  map[string]bool
+}
+
+var _ multicluster.Manager = &mockMultiClusterMgr{}
+
+func (m *mockMultiClusterMgr) GetClient() client.Client { return m.cli }
+
+func (m *mockMultiClusterMgr) GetContexts() []string { return m.contexts }
+
+func (m *mockMultiClusterMgr) Bind(ctrl.Manager) error { return nil }
+
+func (m *mockMultiClusterMgr) Own(*builder.Builder, client.Object, client.Object) multicluster.Manager {
+	return m
+}
+
+func (m *mockMultiClusterMgr) Watch(*builder.Builder, client.Object, handler.EventHandler) multicluster.Manager {
+	return m
+}
+
+func (m *mockMultiClusterMgr) Register(context string, config *rest.Config, _ bool) error {
+	m.registered[context] = config
+	m.available[context] = true
+	return nil
+}
+
+func (m *mockMultiClusterMgr) Unregister(context string) error {
+	delete(m.registered, context)
+	delete(m.available, context)
+	return nil
+}
+
+func (m *mockMultiClusterMgr) SetAvailable(context string, available bool) {
+	m.available[context] = available
+}
+
+func (m *mockMultiClusterMgr) Synced(context.Context) (bool, error) { return true, nil }
+
+var _ = Describe("MemberCluster controller", func() {
+	var (
+		mgr      *mockMultiClusterMgr
+		probeErr error
+	)
+
+	BeforeEach(func() {
+		mgr = &mockMultiClusterMgr{registered: map[string]*rest.Config{}, available: map[string]bool{}}
+		probeErr = nil
+		probe := probeMemberCluster
+		probeMemberCluster = func(context.Context, *rest.Config, time.Duration) (string, corev1.ResourceList, error) {
+			if probeErr != nil {
+				return "", nil, probeErr
+			}
+			return "v1.30.0", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}, nil
+		}
+		DeferCleanup(func() { probeMemberCluster = probe })
+	})
+
+	newReconciler := func(objs ...client.Object) (*MemberClusterReconciler, client.Client) {
+		s := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(s)).Should(Succeed())
+		Expect(appsv1.AddToScheme(s)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).
+			WithStatusSubresource(&appsv1.MemberCluster{}).Build()
+		return &MemberClusterReconciler{
+			Client:          cli,
+			Scheme:          s,
+			Recorder:        record.NewFakeRecorder(16),
+			MultiClusterMgr: mgr,
+		}, cli
+	}
+
+	newMemberCluster := func() *appsv1.MemberCluster {
+		return &appsv1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "member", Generation: 1},
+			Spec: appsv1.MemberClusterSpec{
+				KubeConfigSecretRef: appsv1.MemberClusterKubeConfigRef{Namespace: "kb-system", Name: "member-kubeconfig"},
+				HealthCheck:         &appsv1.MemberClusterHealthCheck{PeriodSeconds: 30, FailureThreshold: 2},
+			},
+		}
+	}
+
+	kubeConfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kb-system", Name: "member-kubeconfig"},
+		Data:       map[string][]byte{"kubeconfig": []byte(memberClusterKubeConfig)},
+	}
+
+	reconcileWithError := func(r *MemberClusterReconciler, cli client.Client) (*appsv1.MemberCluster, error) {
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKey{Name: "member"}})
+		memberCluster := &appsv1.MemberCluster{}
+		Expect(client.IgnoreNotFound(cli.Get(context.Background(), client.ObjectKey{Name: "member"}, memberCluster))).Should(Succeed())
+		return memberCluster, err
+	}
+
+	reconcile := func(r *MemberClusterReconciler, cli client.Client) *appsv1.MemberCluster {
+		memberCluster, err := reconcileWithError(r, cli)
+		Expect(err).Should(Succeed())
+		return memberCluster
+	}
+
+	It("registers the member cluster and marks it ready", func() {
+		r, cli := newReconciler(newMemberCluster(), kubeConfigSecret.DeepCopy())
+		memberCluster := reconcile(r, cli)
+
+		Expect(memberCluster.Finalizers).Should(ContainElement(constant.MemberClusterFinalizerName))
+		Expect(mgr.registered).Should(HaveKey("member"))
+		Expect(mgr.registered["member"].Host).Should(Equal("https://member.example.com:6443"))
+		Expect(mgr.available["member"]).Should(BeTrue())
+		Expect(memberCluster.Status.Phase).Should(Equal(appsv1.ReadyMemberClusterPhase))
+		Expect(memberCluster.Status.ServerVersion).Should(Equal("v1.30.0"))
+		Expect(meta.IsStatusConditionTrue(memberCluster.Status.Conditions, appsv1.ConditionTypeReady)).Should(BeTrue())
+	})
+
+	It("marks the member cluster unavailable once the failures reach the threshold", func() {
+		r, cli := newReconciler(newMemberCluster(), kubeConfigSecret.DeepCopy())
+		Expect(reconcile(r, cli).Status.Phase).Should(Equal(appsv1.ReadyMemberClusterPhase))
+
+		probeErr = fmt.Errorf("connection refused")
+		expire := func() {
+			memberCluster := &appsv1.MemberCluster{}
+			Expect(cli.Get(context.Background(), client.ObjectKey{Name: "member"}, memberCluster)).Should(Succeed())
+			memberCluster.Status.LastProbeTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			Expect(cli.Status().Update(context.Background(), memberCluster)).Should(Succeed())
+		}
+
+		expire()
+		memberCluster := reconcile(r, cli)
+		Expect(memberCluster.Status.Phase).Should(Equal(appsv1.ReadyMemberClusterPhase))
+		Expect(memberCluster.Status.ConsecutiveFailures).Should(BeEquivalentTo(1))
+		Expect(mgr.available["member"]).Should(BeTrue())
+
+		expire()
+		memberCluster = reconcile(r, cli)
+		Expect(memberCluster.Status.Phase).Should(Equal(appsv1.UnavailableMemberClusterPhase))
+		Expect(memberCluster.Status.ConsecutiveFailures).Should(BeEquivalentTo(2))
+		Expect(mgr.available["member"]).Should(BeFalse())
+
+		probeErr = nil
+		expire()
+		memberCluster = reconcile(r, cli)
+		Expect(memberCluster.Status.Phase).Should(Equal(appsv1.ReadyMemberClusterPhase))
+		Expect(mgr.available["member"]).Should(BeTrue())
+	})
+
+	It("fails if the kubeconfig secret is not found", func() {
+		r, cli := newReconciler(newMemberCluster())
+		memberCluster, err := reconcileWithError(r, cli)
+		Expect(err).Should(HaveOccurred())
+		Expect(memberCluster.Status.Phase).Should(Equal(appsv1.FailedMemberClusterPhase))
+		Expect(mgr.registered).ShouldNot(HaveKey("member"))
+	})
+
+	It("unregisters the member cluster on deletion", func() {
+		memberCluster := newMemberCluster()
+		memberCluster.Finalizers = []string{constant.MemberClusterFinalizerName}
+		r, cli := newReconciler(memberCluster, kubeConfigSecret.DeepCopy())
+		reconcile(r, cli)
+		Expect(mgr.registered).Should(HaveKey("member"))
+
+		Expect(cli.Delete(context.Background(), memberCluster)).Should(Succeed())
+		reconcile(r, cli)
+		Expect(mgr.registered).ShouldNot(HaveKey("member"))
+	})
+})
@@ -22,8 +22,12 @@ package apps
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -40,11 +44,16 @@ type ServiceDescriptorReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// lastProbes records the last probe of each service descriptor, the probe time isn't persisted in the status
+	// unless the result changes.
+	lastProbes sync.Map
 }
 
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=servicedescriptors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=servicedescriptors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=servicedescriptors/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -77,30 +86,56 @@ func (r *ServiceDescriptorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			constant.ServiceDescriptorNameLabelKey, recordEvent, &appsv1.ClusterList{}); res != nil || err != nil {
 			return res, err
 		}
+		r.lastProbes.Delete(reqCtx.Req.NamespacedName)
 		return nil, nil
 	})
 	if res != nil {
 		return *res, err
 	}
 
+	healthCheck := serviceDescriptor.Spec.HealthCheck
 	if serviceDescriptor.Status.ObservedGeneration == serviceDescriptor.Generation &&
-		serviceDescriptor.Status.Phase == appsv1.AvailablePhase {
+		serviceDescriptor.Status.Phase == appsv1.AvailablePhase &&
+		healthCheck == nil && len(serviceDescriptor.Status.Conditions) == 0 {
 		return intctrlutil.Reconciled()
 	}
 
+	oldPhase := serviceDescriptor.Status.Phase
+	origin := serviceDescriptor.DeepCopy()
+	patch := client.MergeFrom(origin)
 	if err := r.checkServiceDescriptor(reqCtx, serviceDescriptor); err != nil {
-		if err := r.updateServiceDescriptorStatus(r.Client, reqCtx, serviceDescriptor, appsv1.UnavailablePhase); err != nil {
+		if err := r.updateServiceDescriptorStatus(r.Client, reqCtx, serviceDescriptor, patch, appsv1.UnavailablePhase); err != nil {
 			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "InvalidServiceDescriptor update unavailable status failed")
 		}
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "InvalidServiceDescriptor")
 	}
 
-	err = r.updateServiceDescriptorStatus(r.Client, reqCtx, serviceDescriptor, appsv1.AvailablePhase)
-	if err != nil {
+	var nextProbe time.Duration
+	if healthCheck == nil {
+		serviceDescriptor.Status.Conditions = nil
+		serviceDescriptor.Status.LastProbeTime = nil
+		if err = r.cleanupAuthJobs(reqCtx, serviceDescriptor, ""); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	} else if nextProbe, err = r.reconcileHealthCheck(reqCtx, serviceDescriptor); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
-	intctrlutil.RecordCreatedEvent(r.Recorder, serviceDescriptor)
+	serviceDescriptor.Status.Phase = appsv1.AvailablePhase
+	serviceDescriptor.Status.ObservedGeneration = serviceDescriptor.Generation
+	if !equality.Semantic.DeepEqual(origin.Status, serviceDescriptor.Status) {
+		err = r.updateServiceDescriptorStatus(r.Client, reqCtx, serviceDescriptor, patch, appsv1.AvailablePhase)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if oldPhase != appsv1.AvailablePhase {
+		intctrlutil.RecordCreatedEvent(r.Recorder, serviceDescriptor)
+	}
+	if healthCheck != nil {
+		return intctrlutil.RequeueAfter(nextProbe, reqCtx.Log, "")
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -108,6 +143,7 @@ func (r *ServiceDescriptorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 func (r *ServiceDescriptorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&appsv1.ServiceDescriptor{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
 
@@ -162,8 +198,7 @@ func (r *ServiceDescriptorReconciler) checkServiceDescriptor(reqCtx intctrlutil.
 }
 
 // updateServiceDescriptorStatus updates the status of the service descriptor.
-func (r *ServiceDescriptorReconciler) updateServiceDescriptorStatus(cli client.Client, ctx intctrlutil.RequestCtx, serviceDescriptor *appsv1.ServiceDescriptor, phase appsv1.Phase) error {
-	patch := client.MergeFrom(serviceDescriptor.DeepCopy())
+func (r *ServiceDescriptorReconciler) updateServiceDescriptorStatus(cli client.Client, ctx intctrlutil.RequestCtx, serviceDescriptor *appsv1.ServiceDescriptor, patch client.Patch, phase appsv1.Phase) error {
 	serviceDescriptor.Status.Phase = phase
 	serviceDescriptor.Status.ObservedGeneration = serviceDescriptor.Generation
 	return cli.Status().Patch(ctx.Ctx, serviceDescriptor, patch)
@@ -0,0 +1,466 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonServiceReachable     = "ServiceReachable"
+	reasonServiceUnreachable   = "ServiceUnreachable"
+	reasonAddressUnresolved    = "AddressUnresolved"
+	reasonAuthActionSucceeded  = "AuthActionSucceeded"
+	reasonAuthActionFailed     = "AuthActionFailed"
+	reasonAuthActionInProgress = "AuthActionInProgress"
+
+	defaultHealthCheckPeriodSeconds  = 60
+	defaultHealthCheckTimeoutSeconds = 5
+	defaultAuthActionTimeoutSeconds  = 60
+)
+
+func healthCheckPeriod(healthCheck *appsv1.ServiceDescriptorHealthCheck) time.Duration {
+	if healthCheck.PeriodSeconds > 0 {
+		return time.Duration(healthCheck.PeriodSeconds) * time.Second
+	}
+	return defaultHealthCheckPeriodSeconds * time.Second
+}
+
+func healthCheckTimeout(healthCheck *appsv1.ServiceDescriptorHealthCheck) time.Duration {
+	if healthCheck.TimeoutSeconds > 0 {
+		return time.Duration(healthCheck.TimeoutSeconds) * time.Second
+	}
+	return defaultHealthCheckTimeoutSeconds * time.Second
+}
+
+// reconcileHealthCheck probes the external service and records the results in the status conditions,
+// it returns the duration after which the next probe is due.
+func (r *ServiceDescriptorReconciler) reconcileHealthCheck(reqCtx intctrlutil.RequestCtx, serviceDescriptor *appsv1.ServiceDescriptor) (time.Duration, error) {
+	healthCheck := serviceDescriptor.Spec.HealthCheck
+	period := healthCheckPeriod(healthCheck)
+
+	key := client.ObjectKeyFromObject(serviceDescriptor)
+	last, ok := r.lastProbes.Load(key)
+	if !ok || last.(probeRecord).generation != serviceDescriptor.Generation || time.Since(last.(probeRecord).time) >= period {
+		r.probe(reqCtx, serviceDescriptor)
+		r.lastProbes.Store(key, probeRecord{generation: serviceDescriptor.Generation, time: time.Now()})
+	} else {
+		period -= time.Since(last.(probeRecord).time)
+	}
+
+	if healthCheck.AuthAction == nil {
+		meta.RemoveStatusCondition(&serviceDescriptor.Status.Conditions, appsv1.ConditionTypeAuthenticated)
+		return period, r.cleanupAuthJobs(reqCtx, serviceDescriptor, "")
+	}
+	return period, r.reconcileAuthAction(reqCtx, serviceDescriptor)
+}
+
+// probeRecord records when the service descriptor of the generation was probed last time.
+type probeRecord struct {
+	generation int64
+	time       time.Time
+}
+
+// probe checks the external service, the probe time is only recorded in the status when the result changes,
+// so that an unchanged result doesn't patch the status on every probe.
+func (r *ServiceDescriptorReconciler) probe(reqCtx intctrlutil.RequestCtx, serviceDescriptor *appsv1.ServiceDescriptor) {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionTypeReachable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceDescriptor.Generation,
+		Reason:             reasonServiceReachable,
+	}
+	address, err := resolveServiceDescriptorAddress(reqCtx.Ctx, r.Client, serviceDescriptor)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonAddressUnresolved
+		condition.Message = err.Error()
+	} else if err = probeServiceDescriptor(reqCtx.Ctx, r.Client, serviceDescriptor, address); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonServiceUnreachable
+		condition.Message = err.Error()
+	} else {
+		condition.Message = fmt.Sprintf("%s check against %s succeeded", healthCheckType(serviceDescriptor.Spec.HealthCheck), address)
+	}
+
+	prev := meta.FindStatusCondition(serviceDescriptor.Status.Conditions, appsv1.ConditionTypeReachable)
+	if prev != nil && prev.Status == condition.Status && prev.Reason == condition.Reason &&
+		prev.Message == condition.Message && prev.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
+	now := metav1.Now()
+	serviceDescriptor.Status.LastProbeTime = &now
+	meta.SetStatusCondition(&serviceDescriptor.Status.Conditions, condition)
+	if condition.Status == metav1.ConditionFalse && (prev == nil || prev.Status != metav1.ConditionFalse) {
+		r.Recorder.Event(serviceDescriptor, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+}
+
+func healthCheckType(healthCheck *appsv1.ServiceDescriptorHealthCheck) appsv1.ServiceDescriptorHealthCheckType {
+	if healthCheck.Type == "" {
+		return appsv1.ServiceDescriptorTCPHealthCheck
+	}
+	return healthCheck.Type
+}
+
+// resolveServiceDescriptorAddress returns the address of the external service in the format of `host:port`,
+// the host and port take precedence over the endpoint.
+func resolveServiceDescriptorAddress(ctx context.Context, cli client.Reader, serviceDescriptor *appsv1.ServiceDescriptor) (string, error) {
+	namespace := serviceDescriptor.Namespace
+	host, err := resolveCredentialVarValue(ctx, cli, namespace, serviceDescriptor.Spec.Host)
+	if err != nil {
+		return "", err
+	}
+	port, err := resolveCredentialVarValue(ctx, cli, namespace, serviceDescriptor.Spec.Port)
+	if err != nil {
+		return "", err
+	}
+	if host != "" && port != "" {
+		return net.JoinHostPort(host, port), nil
+	}
+
+	endpoint, err := resolveCredentialVarValue(ctx, cli, namespace, serviceDescriptor.Spec.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	if endpoint == "" {
+		return "", fmt.Errorf("neither endpoint nor host and port of the external service are specified")
+	}
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid endpoint %s: %s", endpoint, err.Error())
+		}
+		endpoint = u.Host
+	}
+	if _, _, err = net.SplitHostPort(endpoint); err != nil {
+		if port == "" {
+			return "", fmt.Errorf("invalid endpoint %s: %s", endpoint, err.Error())
+		}
+		endpoint = net.JoinHostPort(endpoint, port)
+	}
+	return endpoint, nil
+}
+
+// resolveCredentialVarValue returns the value of the credential var, reading it from the referenced Secret or ConfigMap if needed.
+func resolveCredentialVarValue(ctx context.Context, cli client.Reader, namespace string, credentialVar *appsv1.CredentialVar) (string, error) {
+	switch {
+	case credentialVar == nil:
+		return "", nil
+	case credentialVar.Value != "" || credentialVar.ValueFrom == nil:
+		return credentialVar.Value, nil
+	case credentialVar.ValueFrom.SecretKeyRef != nil:
+		ref := credentialVar.ValueFrom.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return "", err
+		}
+		return string(secret.Data[ref.Key]), nil
+	case credentialVar.ValueFrom.ConfigMapKeyRef != nil:
+		ref := credentialVar.ValueFrom.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, cm); err != nil {
+			return "", err
+		}
+		return cm.Data[ref.Key], nil
+	}
+	return "", nil
+}
+
+// probeServiceDescriptor opens a connection to the external service, and completes a TLS handshake if required.
+func probeServiceDescriptor(ctx context.Context, cli client.Reader, serviceDescriptor *appsv1.ServiceDescriptor, address string) error {
+	healthCheck := serviceDescriptor.Spec.HealthCheck
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout(healthCheck))
+	defer cancel()
+
+	if healthCheckType(healthCheck) != appsv1.ServiceDescriptorTLSHealthCheck {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	config, err := buildHealthCheckTLSConfig(ctx, cli, serviceDescriptor, address)
+	if err != nil {
+		return err
+	}
+	conn, err := (&tls.Dialer{Config: config}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func buildHealthCheckTLSConfig(ctx context.Context, cli client.Reader, serviceDescriptor *appsv1.ServiceDescriptor, address string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	config.ServerName, _, _ = net.SplitHostPort(address)
+
+	tlsCheck := serviceDescriptor.Spec.HealthCheck.TLS
+	if tlsCheck == nil {
+		return config, nil
+	}
+	if tlsCheck.ServerName != "" {
+		config.ServerName = tlsCheck.ServerName
+	}
+	config.InsecureSkipVerify = tlsCheck.InsecureSkipVerify // #nosec G402
+	if tlsCheck.CA != nil {
+		secret := &corev1.Secret{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: serviceDescriptor.Namespace, Name: tlsCheck.CA.Name}, secret); err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(secret.Data[tlsCheck.CA.Key]) {
+			return nil, fmt.Errorf("no valid CA certificate found in secret %s/%s", tlsCheck.CA.Name, tlsCheck.CA.Key)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// reconcileAuthAction runs the auth action as a Job once per generation and version of the referenced credentials,
+// and reflects its result in the `Authenticated` condition.
+func (r *ServiceDescriptorReconciler) reconcileAuthAction(reqCtx intctrlutil.RequestCtx, serviceDescriptor *appsv1.ServiceDescriptor) error {
+	credentialVersion, err := authCredentialVersion(reqCtx.Ctx, r.Client, serviceDescriptor)
+	if err != nil {
+		return err
+	}
+	jobName := authJobName(serviceDescriptor, credentialVersion)
+	if err := r.cleanupAuthJobs(reqCtx, serviceDescriptor, jobName); err != nil {
+		return err
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionTypeAuthenticated,
+		ObservedGeneration: serviceDescriptor.Generation,
+	}
+	job := &batchv1.Job{}
+	err = r.Client.Get(reqCtx.Ctx, types.NamespacedName{Namespace: serviceDescriptor.Namespace, Name: jobName}, job)
+	switch {
+	case err != nil && !apierrors.IsNotFound(err):
+		return err
+	case err != nil:
+		// the credentials can only be verified after the service becomes reachable
+		if !meta.IsStatusConditionTrue(serviceDescriptor.Status.Conditions, appsv1.ConditionTypeReachable) {
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = reasonServiceUnreachable
+			condition.Message = "waiting for the external service to become reachable"
+			break
+		}
+		address, err := resolveServiceDescriptorAddress(reqCtx.Ctx, r.Client, serviceDescriptor)
+		if err != nil {
+			return err
+		}
+		job = buildAuthJob(serviceDescriptor, jobName, address)
+		if err = controllerutil.SetControllerReference(serviceDescriptor, job, r.Scheme); err != nil {
+			return err
+		}
+		if err = r.Client.Create(reqCtx.Ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonAuthActionInProgress
+		condition.Message = fmt.Sprintf("auth action job %s is running", jobName)
+	case job.Status.Succeeded > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonAuthActionSucceeded
+		condition.Message = fmt.Sprintf("auth action job %s succeeded", jobName)
+	case isJobFailed(job):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonAuthActionFailed
+		condition.Message = fmt.Sprintf("auth action job %s failed", jobName)
+		for _, c := range job.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Message != "" {
+				condition.Message = fmt.Sprintf("%s: %s", condition.Message, c.Message)
+			}
+		}
+	default:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonAuthActionInProgress
+		condition.Message = fmt.Sprintf("auth action job %s is running", jobName)
+	}
+
+	prev := meta.FindStatusCondition(serviceDescriptor.Status.Conditions, appsv1.ConditionTypeAuthenticated)
+	meta.SetStatusCondition(&serviceDescriptor.Status.Conditions, condition)
+	if condition.Status == metav1.ConditionFalse && (prev == nil || prev.Status != metav1.ConditionFalse) {
+		r.Recorder.Event(serviceDescriptor, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	return nil
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// authCredentialVersion returns the resource versions of the Secrets and ConfigMaps referenced by the credentials,
+// so that the auth action is re-run once the referenced credentials are changed.
+func authCredentialVersion(ctx context.Context, cli client.Reader, serviceDescriptor *appsv1.ServiceDescriptor) (string, error) {
+	auth := serviceDescriptor.Spec.Auth
+	if auth == nil {
+		return "", nil
+	}
+	var versions []string
+	for _, credentialVar := range []*appsv1.CredentialVar{auth.Username, auth.Password} {
+		if credentialVar == nil || credentialVar.Value != "" || credentialVar.ValueFrom == nil {
+			continue
+		}
+		var obj client.Object
+		var name string
+		switch {
+		case credentialVar.ValueFrom.SecretKeyRef != nil:
+			obj, name = &corev1.Secret{}, credentialVar.ValueFrom.SecretKeyRef.Name
+		case credentialVar.ValueFrom.ConfigMapKeyRef != nil:
+			obj, name = &corev1.ConfigMap{}, credentialVar.ValueFrom.ConfigMapKeyRef.Name
+		default:
+			continue
+		}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: serviceDescriptor.Namespace, Name: name}, obj); err != nil {
+			return "", err
+		}
+		versions = append(versions, obj.GetResourceVersion())
+	}
+	return strings.Join(versions, ","), nil
+}
+
+func authJobName(serviceDescriptor *appsv1.ServiceDescriptor, credentialVersion string) string {
+	suffix := "-auth-" + strconv.FormatInt(serviceDescriptor.Generation, 10)
+	if credentialVersion != "" {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(credentialVersion))
+		suffix += "-" + rand.SafeEncodeString(fmt.Sprint(hash.Sum32()))
+	}
+	// the job name is used as a label value of its pods, which is limited to 63 characters
+	name := serviceDescriptor.Name
+	if len(name)+len(suffix) > 63 {
+		name = strings.TrimRight(name[:63-len(suffix)], "-.")
+	}
+	return name + suffix
+}
+
+func buildAuthJob(serviceDescriptor *appsv1.ServiceDescriptor, jobName, address string) *batchv1.Job {
+	action := serviceDescriptor.Spec.HealthCheck.AuthAction
+	timeout := int64(defaultAuthActionTimeoutSeconds)
+	if action.TimeoutSeconds > 0 {
+		timeout = int64(action.TimeoutSeconds)
+	}
+
+	host, port, _ := net.SplitHostPort(address)
+	env := []corev1.EnvVar{
+		{Name: "SERVICE_ENDPOINT", Value: address},
+		{Name: "SERVICE_HOST", Value: host},
+		{Name: "SERVICE_PORT", Value: port},
+	}
+	if auth := serviceDescriptor.Spec.Auth; auth != nil {
+		env = append(env, credentialVarToEnv("SERVICE_USER", auth.Username)...)
+		env = append(env, credentialVarToEnv("SERVICE_PASSWORD", auth.Password)...)
+	}
+
+	labels := map[string]string{
+		constant.AppManagedByLabelKey:          constant.AppName,
+		constant.ServiceDescriptorNameLabelKey: serviceDescriptor.Name,
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceDescriptor.Namespace,
+			Name:      jobName,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32(0),
+			ActiveDeadlineSeconds: &timeout,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:            "auth",
+							Image:           action.Image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         action.Command,
+							Args:            action.Args,
+							Env:             env,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func credentialVarToEnv(name string, credentialVar *appsv1.CredentialVar) []corev1.EnvVar {
+	if credentialVar == nil {
+		return nil
+	}
+	if credentialVar.Value == "" && credentialVar.ValueFrom != nil {
+		return []corev1.EnvVar{{Name: name, ValueFrom: credentialVar.ValueFrom}}
+	}
+	return []corev1.EnvVar{{Name: name, Value: credentialVar.Value}}
+}
+
+// cleanupAuthJobs deletes the auth action jobs of the service descriptor except the one specified.
+func (r *ServiceDescriptorReconciler) cleanupAuthJobs(reqCtx intctrlutil.RequestCtx, serviceDescriptor *appsv1.ServiceDescriptor, keep string) error {
+	jobs := &batchv1.JobList{}
+	if err := r.Client.List(reqCtx.Ctx, jobs, client.InNamespace(serviceDescriptor.Namespace),
+		client.MatchingLabels{constant.ServiceDescriptorNameLabelKey: serviceDescriptor.Name}); err != nil {
+		return err
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Name == keep || !metav1.IsControlledBy(job, serviceDescriptor) {
+			continue
+		}
+		if err := r.Client.Delete(reqCtx.Ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+var _ = Describe("ServiceDescriptor health check", func() {
+	newServiceDescriptor := func() *appsv1.ServiceDescriptor {
+		return &appsv1.ServiceDescriptor{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "external-mysql",
+				Generation: 2,
+			},
+			Spec: appsv1.ServiceDescriptorSpec{
+				ServiceKind:    "mysql",
+				ServiceVersion: "8.0.30",
+				HealthCheck:    &appsv1.ServiceDescriptorHealthCheck{Type: appsv1.ServiceDescriptorTCPHealthCheck, TimeoutSeconds: 1},
+			},
+		}
+	}
+
+	Context("resolve address", func() {
+		It("prefers host and port over endpoint", func() {
+			sd := newServiceDescriptor()
+			sd.Spec.Endpoint = &appsv1.CredentialVar{Value: "endpoint:1234"}
+			sd.Spec.Host = &appsv1.CredentialVar{Value: "mysql.example.com"}
+			sd.Spec.Port = &appsv1.CredentialVar{Value: "3306"}
+			address, err := resolveServiceDescriptorAddress(context.Background(), fake.NewClientBuilder().Build(), sd)
+			Expect(err).Should(Succeed())
+			Expect(address).Should(Equal("mysql.example.com:3306"))
+		})
+
+		It("parses the endpoint and resolves values from secrets", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "conn"},
+				Data:       map[string][]byte{"endpoint": []byte("redis://redis.example.com:6379")},
+			}
+			cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()
+			sd := newServiceDescriptor()
+			sd.Spec.Endpoint = &appsv1.CredentialVar{
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "conn"},
+						Key:                  "endpoint",
+					},
+				},
+			}
+			address, err := resolveServiceDescriptorAddress(context.Background(), cli, sd)
+			Expect(err).Should(Succeed())
+			Expect(address).Should(Equal("redis.example.com:6379"))
+		})
+
+		It("fails without an address", func() {
+			_, err := resolveServiceDescriptorAddress(context.Background(), fake.NewClientBuilder().Build(), newServiceDescriptor())
+			Expect(err).ShouldNot(Succeed())
+		})
+	})
+
+	Context("probe", func() {
+		It("checks the TCP connectivity", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(Succeed())
+			address := listener.Addr().String()
+
+			sd := newServiceDescriptor()
+			Expect(probeServiceDescriptor(context.Background(), fake.NewClientBuilder().Build(), sd, address)).Should(Succeed())
+
+			Expect(listener.Close()).Should(Succeed())
+			Expect(probeServiceDescriptor(context.Background(), fake.NewClientBuilder().Build(), sd, address)).ShouldNot(Succeed())
+		})
+	})
+
+	Context("auth action", func() {
+		It("builds the job with the address and credentials", func() {
+			sd := newServiceDescriptor()
+			sd.Spec.Auth = &appsv1.ConnectionCredentialAuth{
+				Username: &appsv1.CredentialVar{Value: "root"},
+				Password: &appsv1.CredentialVar{
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "conn"},
+							Key:                  "password",
+						},
+					},
+				},
+			}
+			sd.Spec.HealthCheck.AuthAction = &appsv1.ServiceDescriptorAuthAction{
+				Image:   "mysql:8.0.30",
+				Command: []string{"sh", "-c", "mysql -h$SERVICE_HOST -P$SERVICE_PORT -u$SERVICE_USER -p$SERVICE_PASSWORD -e 'select 1'"},
+			}
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: sd.Namespace, Name: "conn", ResourceVersion: "1"}}
+			cli := fake.NewClientBuilder().WithObjects(secret).Build()
+			credentialVersion, err := authCredentialVersion(context.Background(), cli, sd)
+			Expect(err).Should(Succeed())
+			jobName := authJobName(sd, credentialVersion)
+			Expect(jobName).Should(HavePrefix("external-mysql-auth-2-"))
+
+			By("re-run the auth action once the credential secret is changed")
+			secret.Data = map[string][]byte{"password": []byte("changed")}
+			Expect(cli.Update(context.Background(), secret)).Should(Succeed())
+			credentialVersion, err = authCredentialVersion(context.Background(), cli, sd)
+			Expect(err).Should(Succeed())
+			Expect(authJobName(sd, credentialVersion)).ShouldNot(Equal(jobName))
+
+			job := buildAuthJob(sd, jobName, "mysql.example.com:3306")
+			Expect(*job.Spec.ActiveDeadlineSeconds).Should(BeEquivalentTo(defaultAuthActionTimeoutSeconds))
+			env := job.Spec.Template.Spec.Containers[0].Env
+			Expect(env).Should(ContainElements(
+				corev1.EnvVar{Name: "SERVICE_HOST", Value: "mysql.example.com"},
+				corev1.EnvVar{Name: "SERVICE_PORT", Value: "3306"},
+				corev1.EnvVar{Name: "SERVICE_USER", Value: "root"},
+				corev1.EnvVar{Name: "SERVICE_PASSWORD", ValueFrom: sd.Spec.Auth.Password.ValueFrom},
+			))
+		})
+
+		It("keeps the job name within the label value limit", func() {
+			sd := newServiceDescriptor()
+			sd.Name = "a-very-long-service-descriptor-name-which-exceeds-the-label-limit-of-kubernetes"
+			Expect(len(authJobName(sd, "1,2"))).Should(BeNumerically("<=", 63))
+		})
+	})
+
+	Context("cluster references", func() {
+		It("collects the referenced service descriptors", func() {
+			cluster := &appsv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+				Spec: appsv1.ClusterSpec{
+					ComponentSpecs: []appsv1.ClusterComponentSpec{
+						{
+							Name: "app",
+							ServiceRefs: []appsv1.ServiceRef{
+								{Name: "db", ServiceDescriptor: "external-mysql"},
+								{Name: "cache", Namespace: "infra", ServiceDescriptor: "external-redis"},
+								{Name: "zk", Cluster: "zookeeper"},
+							},
+						},
+					},
+					Shardings: []appsv1.ClusterSharding{
+						{
+							Name: "shard",
+							Template: appsv1.ClusterComponentSpec{
+								ServiceRefs: []appsv1.ServiceRef{{Name: "db", ServiceDescriptor: "external-mysql"}},
+							},
+						},
+					},
+				},
+			}
+			Expect(clusterServiceDescriptorRefs(cluster)).Should(ConsistOf(
+				types.NamespacedName{Namespace: "default", Name: "external-mysql"},
+				types.NamespacedName{Namespace: "infra", Name: "external-redis"},
+			))
+		})
+	})
+})
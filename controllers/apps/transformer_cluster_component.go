@@ -204,6 +204,7 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1.Component) *appsv1.Com
 	compObjCopy.Spec.SchedulingPolicy = compProto.Spec.SchedulingPolicy
 	compObjCopy.Spec.TLSConfig = compProto.Spec.TLSConfig
 	compObjCopy.Spec.Instances = compProto.Spec.Instances
+	compObjCopy.Spec.ZoneDistribution = compProto.Spec.ZoneDistribution
 	compObjCopy.Spec.OfflineInstances = compProto.Spec.OfflineInstances
 	compObjCopy.Spec.RuntimeClassName = compProto.Spec.RuntimeClassName
 	compObjCopy.Spec.DisableExporter = compProto.Spec.DisableExporter
@@ -683,7 +684,11 @@ func (h *clusterComponentHandler) runningComp(transCtx *clusterTransformContext,
 func (h *clusterComponentHandler) protoComp(transCtx *clusterTransformContext, name string) (*appsv1.Component, error) {
 	for _, comp := range transCtx.components {
 		if comp.Name == name {
-			return component.BuildComponent(transCtx.Cluster, comp, nil, nil)
+			var annotations map[string]string
+			if transCtx.annotations != nil {
+				annotations = transCtx.annotations[comp.Name]
+			}
+			return component.BuildComponent(transCtx.Cluster, comp, nil, annotations)
 		}
 	}
 	return nil, fmt.Errorf("cluster component %s not found", name)
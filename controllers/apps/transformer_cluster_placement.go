@@ -20,15 +20,34 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
-	"math/rand"
+	"fmt"
+	"hash/fnv"
 	"slices"
+	"sort"
 	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonPlacementReplaced = "PlacementReplaced"
+
+	maxPlacementReplacements = 10
+	placementRetryInterval   = 30 * time.Second
 )
 
 // clusterPlacementTransformer handles replicas placement.
@@ -38,6 +57,15 @@ type clusterPlacementTransformer struct {
 
 var _ graph.Transformer = &clusterPlacementTransformer{}
 
+// placementContext is a member cluster that the Cluster can be placed on.
+type placementContext struct {
+	name        string
+	labels      labels.Set
+	allocatable corev1.ResourceList
+	drained     bool // drained, disabled or unavailable, no new Cluster can be placed on it
+	unavailable bool // unreachable, the objects on it can't be deleted
+}
+
 func (t *clusterPlacementTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx, _ := ctx.(*clusterTransformContext)
 	if model.IsObjectDeleting(transCtx.OrigCluster) {
@@ -48,21 +76,34 @@ func (t *clusterPlacementTransformer) Transform(ctx graph.TransformContext, dag
 		return nil // do nothing
 	}
 
-	if t.assigned(transCtx) {
-		transCtx.Context = intoContext(transCtx.Context, placement(transCtx.OrigCluster))
-		return nil
+	contexts, err := t.contexts(transCtx)
+	if err != nil {
+		return err
 	}
 
-	p := t.assign(transCtx)
-
 	cluster := transCtx.Cluster
-	if cluster.Annotations == nil {
-		cluster.Annotations = make(map[string]string)
+	if cluster.Status.Placement == nil {
+		cluster.Status.Placement = &appsv1.ClusterPlacementStatus{}
 	}
-	cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
+	var replaceErr error
+	if t.assigned(transCtx) {
+		replaceErr = t.replace(transCtx, contexts)
+	} else {
+		p, err := t.assign(transCtx, contexts)
+		if err != nil {
+			cluster.Status.Placement.Message = err.Error()
+			return intctrlutil.NewDelayedRequeueError(placementRetryInterval, err.Error())
+		}
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
+		cluster.Status.Placement.Message = ""
+	}
+	t.assignComponents(transCtx)
 	transCtx.Context = intoContext(transCtx.Context, placement(cluster))
 
-	return nil
+	return replaceErr
 }
 
 func (t *clusterPlacementTransformer) assigned(transCtx *clusterTransformContext) bool {
@@ -75,20 +116,325 @@ func (t *clusterPlacementTransformer) assigned(transCtx *clusterTransformContext
 	return ok && len(strings.TrimSpace(p)) > 0
 }
 
-func (t *clusterPlacementTransformer) assign(transCtx *clusterTransformContext) []string {
-	replicas := t.maxReplicas(transCtx)
-	contexts := t.multiClusterMgr.GetContexts()
-	if replicas >= len(contexts) {
-		return contexts
+// contexts returns all the member clusters, with the labels and capacities from the MemberCluster objects.
+func (t *clusterPlacementTransformer) contexts(transCtx *clusterTransformContext) (map[string]*placementContext, error) {
+	contexts := make(map[string]*placementContext)
+	for _, name := range t.multiClusterMgr.GetContexts() {
+		contexts[name] = &placementContext{name: name, labels: labels.Set{}}
 	}
 
-	slices.Sort(contexts)
-	for k := 0; k < len(contexts); k++ {
-		rand.Shuffle(len(contexts), func(i, j int) {
-			contexts[i], contexts[j] = contexts[j], contexts[i]
+	memberClusters := &appsv1.MemberClusterList{}
+	if err := transCtx.Client.List(transCtx.Context, memberClusters); err != nil {
+		if meta.IsNoMatchError(err) {
+			return contexts, nil
+		}
+		return nil, err
+	}
+	for _, mc := range memberClusters.Items {
+		c, ok := contexts[mc.Name]
+		if !ok {
+			continue // not registered
+		}
+		c.labels = labels.Merge(c.labels, mc.Labels)
+		if len(mc.Spec.Region) > 0 {
+			c.labels[corev1.LabelTopologyRegion] = mc.Spec.Region
+		}
+		c.allocatable = mc.Status.Allocatable
+		switch {
+		case mc.Spec.Drain, mc.Spec.Disabled:
+			c.drained = true
+		case mc.Status.Phase == appsv1.UnavailableMemberClusterPhase, mc.Status.Phase == appsv1.FailedMemberClusterPhase:
+			c.drained = true
+			c.unavailable = true
+		}
+	}
+	return contexts, nil
+}
+
+// assign chooses the member clusters to place the Cluster on.
+func (t *clusterPlacementTransformer) assign(transCtx *clusterTransformContext, contexts map[string]*placementContext) ([]string, error) {
+	policy := placementPolicy(transCtx.Cluster)
+	candidates, err := t.candidates(transCtx, contexts, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	num := len(candidates)
+	if policy.MaxContexts != nil {
+		num = min(num, int(*policy.MaxContexts))
+	}
+	num = min(num, spreadContexts(t.maxReplicas(transCtx), policy))
+	// shrink the number of member clusters until all chosen ones have enough capacity
+	for ; num > 0; num-- {
+		fits, err := t.candidates(transCtx, contexts, nil, num)
+		if err != nil {
+			return nil, err
+		}
+		if len(fits) >= num {
+			p := make([]string, 0, num)
+			for _, c := range fits[:num] {
+				p = append(p, c.name)
+			}
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no member cluster has enough capacity to place the cluster")
+}
+
+// candidates returns the member clusters that the Cluster can be placed on, sorted by preference.
+// If num is positive, only the member clusters with enough capacity to hold the replicas spread across
+// num member clusters are returned.
+func (t *clusterPlacementTransformer) candidates(transCtx *clusterTransformContext,
+	contexts map[string]*placementContext, excluded []string, num int) ([]*placementContext, error) {
+	cluster := transCtx.Cluster
+	policy := placementPolicy(cluster)
+
+	required := labels.Everything()
+	if policy.RequiredContexts != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.RequiredContexts)
+		if err != nil {
+			return nil, err
+		}
+		required = selector
+	}
+
+	scores := make(map[string]int32)
+	candidates := make([]*placementContext, 0)
+	for _, c := range contexts {
+		if c.drained || slices.Contains(excluded, c.name) || !required.Matches(c.labels) {
+			continue
+		}
+		for _, preferred := range policy.PreferredContexts {
+			selector, err := metav1.LabelSelectorAsSelector(&preferred.Selector)
+			if err != nil {
+				return nil, err
+			}
+			if selector.Matches(c.labels) {
+				scores[c.name] += preferred.Weight
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no member cluster matches the placement of the cluster")
+	}
+	if num > 0 {
+		candidates = slices.DeleteFunc(candidates, func(c *placementContext) bool {
+			return !t.fits(transCtx, c, num)
 		})
 	}
-	return contexts[:replicas]
+
+	// the clusters are spread across the member clusters with the same preference
+	hash := func(name string) uint32 {
+		h := fnv.New32a()
+		h.Write([]byte(cluster.Namespace + "/" + cluster.Name + "/" + name))
+		return h.Sum32()
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if scores[ci.name] != scores[cj.name] {
+			return scores[ci.name] > scores[cj.name]
+		}
+		return hash(ci.name) < hash(cj.name)
+	})
+	return candidates, nil
+}
+
+// fits checks whether the member cluster has enough capacity to hold the replicas placed on it,
+// the member clusters whose capacities are unknown are considered as fit.
+func (t *clusterPlacementTransformer) fits(transCtx *clusterTransformContext, c *placementContext, num int) bool {
+	if len(c.allocatable) == 0 {
+		return true
+	}
+	policy := placementPolicy(transCtx.Cluster)
+	cpu, memory := resource.Quantity{}, resource.Quantity{}
+	transCtx.traverse(func(spec *appsv1.ClusterComponentSpec) {
+		n := min(num, spreadContexts(int(spec.Replicas), policy))
+		replicas := (int64(spec.Replicas) + int64(n) - 1) / int64(n)
+		requests := spec.Resources.Requests
+		if requests == nil {
+			requests = spec.Resources.Limits
+		}
+		for i := int64(0); i < replicas; i++ {
+			cpu.Add(requests[corev1.ResourceCPU])
+			memory.Add(requests[corev1.ResourceMemory])
+		}
+	})
+	if allocatable, ok := c.allocatable[corev1.ResourceCPU]; ok && allocatable.Cmp(cpu) < 0 {
+		return false
+	}
+	if allocatable, ok := c.allocatable[corev1.ResourceMemory]; ok && allocatable.Cmp(memory) < 0 {
+		return false
+	}
+	return true
+}
+
+// replace reports the drained member clusters that the Cluster is placed on, and replaces them if required.
+//
+// The objects of the Cluster on the drained member cluster are deleted before it is replaced, otherwise they
+// are left behind since nothing manages that member cluster for the Cluster anymore.
+func (t *clusterPlacementTransformer) replace(transCtx *clusterTransformContext, contexts map[string]*placementContext) error {
+	cluster := transCtx.Cluster
+	status := cluster.Status.Placement
+
+	p := strings.Split(placement(cluster), ",")
+	drained := make([]string, 0)
+	for _, name := range p {
+		if c, ok := contexts[name]; !ok || c.drained {
+			drained = append(drained, name)
+		}
+	}
+	status.DrainedContexts = drained
+	if len(drained) == 0 {
+		status.Message = ""
+		return nil
+	}
+
+	policy := placementPolicy(cluster)
+	if policy.ReplacementPolicy != appsv1.ReplacePlacementReplacementPolicy {
+		status.Message = fmt.Sprintf("member clusters %s are drained", strings.Join(drained, ","))
+		return nil
+	}
+	// replace one member cluster at a time, and only when the cluster is running,
+	// to make sure the replicas on other member clusters are available during the replacement.
+	if transCtx.OrigCluster.Status.Phase != appsv1.RunningClusterPhase {
+		status.Message = fmt.Sprintf("member clusters %s are drained, wait for the cluster to be running to replace them", strings.Join(drained, ","))
+		return nil
+	}
+	candidates, err := t.candidates(transCtx, contexts, p, len(p))
+	if err != nil || len(candidates) == 0 {
+		status.Message = fmt.Sprintf("member clusters %s are drained, but no member cluster is available to replace them", strings.Join(drained, ","))
+		return nil
+	}
+
+	from, to := drained[0], candidates[0].name
+	// the unreachable member cluster can't be drained, its objects are left behind
+	if c, ok := contexts[from]; ok && !c.unavailable {
+		empty, err := t.drain(transCtx, from)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			status.Message = fmt.Sprintf("draining member cluster %s before replacing it by %s", from, to)
+			return intctrlutil.NewDelayedRequeueError(time.Second, status.Message)
+		}
+	}
+	p[slices.Index(p, from)] = to
+	cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
+	for i, comp := range status.Components {
+		if idx := slices.Index(comp.Contexts, from); idx >= 0 {
+			status.Components[i].Contexts[idx] = to
+		}
+	}
+	status.Replacements = append(status.Replacements, appsv1.PlacementReplacement{From: from, To: to, Time: metav1.Now()})
+	if len(status.Replacements) > maxPlacementReplacements {
+		status.Replacements = status.Replacements[len(status.Replacements)-maxPlacementReplacements:]
+	}
+	status.DrainedContexts = drained[1:]
+	status.Message = fmt.Sprintf("member cluster %s is replaced by %s", from, to)
+	transCtx.EventRecorder.Event(cluster, corev1.EventTypeNormal, reasonPlacementReplaced, status.Message)
+	return nil
+}
+
+// drain deletes the objects of the Cluster on the member cluster, and returns true if there is nothing left.
+//
+// The workloads still place the replicas on the member cluster until it is replaced, so the objects are
+// deleted repeatedly until none of them is found.
+func (t *clusterPlacementTransformer) drain(transCtx *clusterTransformContext, context string) (bool, error) {
+	cli := t.multiClusterMgr.GetClient()
+	if cli == nil {
+		return true, nil
+	}
+	ctx := intoContext(transCtx.Context, context)
+	cluster := transCtx.Cluster
+	ml := client.MatchingLabels{
+		constant.AppInstanceLabelKey:  cluster.Name,
+		constant.AppManagedByLabelKey: constant.AppName,
+	}
+	lists := []client.ObjectList{
+		&corev1.PodList{},
+		&corev1.PersistentVolumeClaimList{},
+		&corev1.ServiceList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+	}
+	empty := true
+	for _, list := range lists {
+		if err := cli.List(ctx, list, client.InNamespace(cluster.Namespace), ml, inDataContext4C()); err != nil {
+			return false, err
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return false, err
+		}
+		for _, o := range objs {
+			obj, ok := o.(client.Object)
+			if !ok {
+				continue
+			}
+			empty = false
+			if model.IsObjectDeleting(obj) {
+				continue
+			}
+			if err := cli.Delete(ctx, obj, inDataContext4C()); err != nil && !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+	}
+	return empty, nil
+}
+
+// assignComponents spreads the replicas of each component across the member clusters that the Cluster is placed on.
+//
+// A component is placed on a subset of the member clusters if it has not enough replicas for all of them,
+// the subset is decided when the component is created and kept afterward.
+func (t *clusterPlacementTransformer) assignComponents(transCtx *clusterTransformContext) {
+	cluster := transCtx.Cluster
+	status := cluster.Status.Placement
+	policy := placementPolicy(cluster)
+
+	p := strings.Split(placement(cluster), ",")
+	status.Contexts = p
+
+	assigned := make(map[string][]string)
+	for _, comp := range status.Components {
+		assigned[comp.Name] = comp.Contexts
+	}
+
+	components := make([]appsv1.ComponentPlacementStatus, 0)
+	assign := func(spec *appsv1.ClusterComponentSpec, created bool) {
+		contexts, ok := assigned[spec.Name]
+		if !ok && !created {
+			n := spreadContexts(int(spec.Replicas), policy)
+			if n < len(p) {
+				h := fnv.New32a()
+				h.Write([]byte(spec.Name))
+				offset := int(h.Sum32() % uint32(len(p)))
+				for i := 0; i < n; i++ {
+					contexts = append(contexts, p[(offset+i)%len(p)])
+				}
+			}
+		}
+		if len(contexts) > 0 {
+			components = append(components, appsv1.ComponentPlacementStatus{Name: spec.Name, Contexts: contexts})
+			transCtx.annotate(spec.Name, constant.KBAppMultiClusterPlacementKey, strings.Join(contexts, ","))
+		}
+	}
+	// the components created before are kept on all the member clusters
+	for _, spec := range transCtx.components {
+		_, created := cluster.Status.Components[spec.Name]
+		assign(spec, created)
+	}
+	for shardingName, specs := range transCtx.shardingComps {
+		_, created := cluster.Status.Shardings[shardingName]
+		for _, spec := range specs {
+			assign(spec, created)
+		}
+	}
+	slices.SortFunc(components, func(a, b appsv1.ComponentPlacementStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	status.Components = components
 }
 
 func (t *clusterPlacementTransformer) maxReplicas(transCtx *clusterTransformContext) int {
@@ -98,3 +444,17 @@ func (t *clusterPlacementTransformer) maxReplicas(transCtx *clusterTransformCont
 	})
 	return replicas
 }
+
+func placementPolicy(cluster *appsv1.Cluster) *appsv1.ClusterPlacement {
+	if cluster.Spec.Placement == nil {
+		return &appsv1.ClusterPlacement{}
+	}
+	return cluster.Spec.Placement
+}
+
+// spreadContexts returns the number of member clusters to spread the replicas across,
+// while each of them holds at least the min replicas.
+func spreadContexts(replicas int, policy *appsv1.ClusterPlacement) int {
+	minReplicas := max(1, int(policy.MinReplicasPerContext))
+	return max(1, replicas/minReplicas)
+}
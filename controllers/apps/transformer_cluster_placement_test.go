@@ -0,0 +1,207 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("cluster placement transformer test", func() {
+	const (
+		clusterName    = "test-cluster"
+		clusterDefName = "test-clusterdef"
+	)
+
+	var (
+		reader   *mockReader
+		dag      *graph.DAG
+		transCtx *clusterTransformContext
+		mgr      *mockMultiClusterMgr
+	)
+
+	newMemberCluster := func(name, region string, cpu string, drain bool) *appsv1.MemberCluster {
+		mc := &appsv1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       appsv1.MemberClusterSpec{Region: region, Drain: drain},
+			Status:     appsv1.MemberClusterStatus{Phase: appsv1.ReadyMemberClusterPhase},
+		}
+		if len(cpu) > 0 {
+			mc.Status.Allocatable = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}
+		}
+		return mc
+	}
+
+	BeforeEach(func() {
+		reader = &mockReader{
+			objs: []client.Object{
+				newMemberCluster("a", "r1", "4", false),
+				newMemberCluster("b", "r1", "16", false),
+				newMemberCluster("c", "r2", "", false),
+				newMemberCluster("d", "r2", "", true),
+			},
+		}
+		mgr = &mockMultiClusterMgr{contexts: []string{"a", "b", "c", "d"}}
+
+		graphCli := model.NewGraphClient(reader)
+		cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName).
+			AddComponent("mysql", "mysql").SetReplicas(4).
+			AddComponent("proxy", "proxy").SetReplicas(1).
+			GetObject()
+
+		dag = graph.NewDAG()
+		graphCli.Root(dag, cluster, cluster, model.ActionStatusPtr())
+		transCtx = &clusterTransformContext{
+			Context:       ctx,
+			Client:        graphCli,
+			EventRecorder: record.NewFakeRecorder(16),
+			Logger:        logger,
+			Cluster:       cluster,
+			OrigCluster:   cluster.DeepCopy(),
+		}
+		for i := range cluster.Spec.ComponentSpecs {
+			transCtx.components = append(transCtx.components, &cluster.Spec.ComponentSpecs[i])
+		}
+	})
+
+	transformWithError := func() ([]string, error) {
+		transformer := &clusterPlacementTransformer{multiClusterMgr: mgr}
+		err := transformer.Transform(transCtx, dag)
+		return strings.Split(transCtx.Cluster.Annotations[constant.KBAppMultiClusterPlacementKey], ","), err
+	}
+
+	transform := func() []string {
+		p, err := transformWithError()
+		Expect(err).Should(Succeed())
+		return p
+	}
+
+	It("places the cluster on the available member clusters", func() {
+		p := transform()
+		Expect(p).Should(ConsistOf("a", "b", "c"))
+		Expect(transCtx.Cluster.Status.Placement.Contexts).Should(Equal(p))
+
+		// the proxy has only one replica, and it is placed on one of the member clusters
+		Expect(transCtx.Cluster.Status.Placement.Components).Should(HaveLen(1))
+		Expect(transCtx.Cluster.Status.Placement.Components[0].Name).Should(Equal("proxy"))
+		Expect(transCtx.Cluster.Status.Placement.Components[0].Contexts).Should(HaveLen(1))
+		Expect(transCtx.annotations["proxy"][constant.KBAppMultiClusterPlacementKey]).
+			Should(Equal(transCtx.Cluster.Status.Placement.Components[0].Contexts[0]))
+	})
+
+	It("places the cluster by the required and preferred member clusters", func() {
+		transCtx.Cluster.Spec.Placement = &appsv1.ClusterPlacement{
+			RequiredContexts: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      corev1.LabelTopologyRegion,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"r1", "r2"},
+				}},
+			},
+			PreferredContexts: []appsv1.WeightedContextSelector{{
+				Weight:   10,
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelTopologyRegion: "r2"}},
+			}},
+			MinReplicasPerContext: 2,
+		}
+		// the mysql is spread across two member clusters with two replicas on each
+		Expect(transform()).Should(Equal([]string{"c", "b"}))
+	})
+
+	It("places the cluster on the member clusters with enough capacity", func() {
+		transCtx.Cluster.Spec.Placement = &appsv1.ClusterPlacement{
+			RequiredContexts: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelTopologyRegion: "r1"}},
+		}
+		transCtx.components[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}
+		// two member clusters need 6 cores for each, only one is able to hold all the replicas
+		Expect(transform()).Should(Equal([]string{"b"}))
+	})
+
+	It("replaces the drained member cluster", func() {
+		transCtx.Cluster.Spec.Placement = &appsv1.ClusterPlacement{
+			ReplacementPolicy: appsv1.ReplacePlacementReplacementPolicy,
+		}
+		transCtx.Cluster.Annotations = map[string]string{constant.KBAppMultiClusterPlacementKey: "a,d,c"}
+		transCtx.OrigCluster = transCtx.Cluster.DeepCopy()
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: transCtx.Cluster.Namespace,
+				Name:      clusterName + "-mysql-1",
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:  clusterName,
+					constant.AppManagedByLabelKey: constant.AppName,
+				},
+			},
+		}
+		workers := map[string]client.Client{}
+		for _, name := range mgr.contexts {
+			workers[name] = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		}
+		Expect(workers["d"].Create(ctx, pod)).Should(Succeed())
+		mgr.cli = multicluster.NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), workers)
+
+		Expect(transform()).Should(Equal([]string{"a", "d", "c"}))
+		Expect(transCtx.Cluster.Status.Placement.DrainedContexts).Should(Equal([]string{"d"}))
+		Expect(transCtx.Cluster.Status.Placement.Replacements).Should(BeEmpty())
+
+		transCtx.OrigCluster.Status.Phase = appsv1.RunningClusterPhase
+		// the objects on the drained member cluster are deleted before replacing it
+		p, err := transformWithError()
+		Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		Expect(p).Should(Equal([]string{"a", "d", "c"}))
+		Expect(transCtx.Cluster.Status.Placement.Replacements).Should(BeEmpty())
+		Expect(workers["d"].Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{})).ShouldNot(Succeed())
+
+		Expect(transform()).Should(Equal([]string{"a", "b", "c"}))
+		Expect(transCtx.Cluster.Status.Placement.DrainedContexts).Should(BeEmpty())
+		Expect(transCtx.Cluster.Status.Placement.Replacements).Should(HaveLen(1))
+		Expect(transCtx.Cluster.Status.Placement.Replacements[0].From).Should(Equal("d"))
+		Expect(transCtx.Cluster.Status.Placement.Replacements[0].To).Should(Equal("b"))
+	})
+
+	It("keeps the placement if the replacement policy is none", func() {
+		transCtx.Cluster.Annotations = map[string]string{constant.KBAppMultiClusterPlacementKey: "a,d"}
+		transCtx.OrigCluster = transCtx.Cluster.DeepCopy()
+		transCtx.OrigCluster.Status.Phase = appsv1.RunningClusterPhase
+
+		Expect(transform()).Should(Equal([]string{"a", "d"}))
+		Expect(transCtx.Cluster.Status.Placement.DrainedContexts).Should(Equal([]string{"d"}))
+		Expect(transCtx.Cluster.Status.Placement.Message).ShouldNot(BeEmpty())
+	})
+})
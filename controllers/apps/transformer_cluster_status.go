@@ -21,9 +21,13 @@ package apps
 
 import (
 	"fmt"
+	"slices"
 
 	"golang.org/x/exp/maps"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
@@ -72,6 +76,10 @@ func (t *clusterStatusTransformer) markClusterDagStatusAction(graphCli model.Gra
 }
 
 func (t *clusterStatusTransformer) reconcileClusterStatus(transCtx *clusterTransformContext, cluster *appsv1.Cluster) error {
+	if err := t.syncServiceRefsCondition(transCtx, cluster); err != nil {
+		return err
+	}
+
 	if len(cluster.Status.Components) == 0 && len(cluster.Status.Shardings) == 0 {
 		return nil
 	}
@@ -150,6 +158,64 @@ func (t *clusterStatusTransformer) syncClusterConditions(cluster *appsv1.Cluster
 	}
 }
 
+// syncServiceRefsCondition reflects the health check results of the ServiceDescriptors referenced by the cluster.
+func (t *clusterStatusTransformer) syncServiceRefsCondition(transCtx *clusterTransformContext, cluster *appsv1.Cluster) error {
+	refs := clusterServiceDescriptorRefs(cluster)
+	if len(refs) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, appsv1.ConditionTypeServiceRefsReady)
+		return nil
+	}
+
+	messages := make([]string, 0)
+	for _, ref := range refs {
+		sd := &appsv1.ServiceDescriptor{}
+		if err := transCtx.Client.Get(transCtx.Context, ref, sd); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			messages = append(messages, fmt.Sprintf("%s: not found", ref.String()))
+			continue
+		}
+		if sd.Status.Phase == appsv1.UnavailablePhase {
+			messages = append(messages, fmt.Sprintf("%s: unavailable", ref.String()))
+			continue
+		}
+		for _, condType := range []string{appsv1.ConditionTypeReachable, appsv1.ConditionTypeAuthenticated} {
+			if cond := meta.FindStatusCondition(sd.Status.Conditions, condType); cond != nil && cond.Status == metav1.ConditionFalse {
+				messages = append(messages, fmt.Sprintf("%s: %s", ref.String(), cond.Message))
+			}
+		}
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, newServiceRefsReadyCondition(messages))
+	return nil
+}
+
+// clusterServiceDescriptorRefs returns the ServiceDescriptors referenced by the components and shardings of the cluster.
+func clusterServiceDescriptorRefs(cluster *appsv1.Cluster) []types.NamespacedName {
+	refs := make([]types.NamespacedName, 0)
+	visit := func(serviceRefs []appsv1.ServiceRef) {
+		for _, serviceRef := range serviceRefs {
+			if serviceRef.ServiceDescriptor == "" {
+				continue
+			}
+			ref := types.NamespacedName{Namespace: serviceRef.Namespace, Name: serviceRef.ServiceDescriptor}
+			if ref.Namespace == "" {
+				ref.Namespace = cluster.Namespace
+			}
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	for _, spec := range cluster.Spec.ComponentSpecs {
+		visit(spec.ServiceRefs)
+	}
+	for _, sharding := range cluster.Spec.Shardings {
+		visit(sharding.Template.ServiceRefs)
+	}
+	return refs
+}
+
 func composeClusterPhase(statusList []appsv1.ClusterComponentStatus) appsv1.ClusterPhase {
 	var (
 		isAllComponentCreating       = true
@@ -20,12 +20,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -38,6 +41,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // componentAccountTransformer handles component system accounts.
@@ -69,6 +73,9 @@ func (t *componentAccountTransformer) Transform(ctx graph.TransformContext, dag
 		}
 
 		if existSecret == nil {
+			if err = t.syncSecretStore(transCtx, synthesizeComp, account, secret); err != nil {
+				return err
+			}
 			graphCli.Create(dag, secret, inUniversalContext4G())
 			continue
 		}
@@ -77,6 +84,13 @@ func (t *componentAccountTransformer) Transform(ctx graph.TransformContext, dag
 		existSecretCopy := existSecret.DeepCopy()
 		ctrlutil.MergeMetadataMapInplace(secret.Labels, &existSecretCopy.Labels)
 		ctrlutil.MergeMetadataMapInplace(secret.Annotations, &existSecretCopy.Annotations)
+		if err = t.resyncSecretStore(transCtx, synthesizeComp, account, existSecretCopy); err != nil {
+			return err
+		}
+		if existSecret.Immutable != nil && *existSecret.Immutable && !reflect.DeepEqual(existSecret.Data, existSecretCopy.Data) {
+			t.recreateImmutableSecret(graphCli, dag, existSecret, existSecretCopy)
+			continue
+		}
 		if !reflect.DeepEqual(existSecret, existSecretCopy) {
 			graphCli.Update(dag, existSecret, existSecretCopy, inUniversalContext4G())
 		}
@@ -134,17 +148,120 @@ func (t *componentAccountTransformer) getPasswordFromSecret(ctx graph.TransformC
 }
 
 func (t *componentAccountTransformer) buildPassword(ctx *componentTransformContext, account appsv1.SystemAccount) []byte {
-	// get restore password if exists during recovery.
+	password := t.getRestorePassword(ctx, account)
+	if password == "" {
+		return t.generatePassword(account)
+	}
+	return []byte(password)
+}
+
+// getRestorePassword gets the restore password if exists during recovery.
+func (t *componentAccountTransformer) getRestorePassword(ctx *componentTransformContext, account appsv1.SystemAccount) string {
 	password := factory.GetRestoreSystemAccountPassword(ctx.SynthesizeComponent, account)
 	if account.InitAccount && password == "" {
 		// initAccount can also restore from factory.GetRestoreSystemAccountPassword(ctx.SynthesizeComponent, account).
 		// This is compatibility processing.
 		password = factory.GetRestorePassword(ctx.SynthesizeComponent)
 	}
-	if password == "" {
-		return t.generatePassword(account)
+	return password
+}
+
+// syncSecretStore loads the password of the account from the external secret store if it has been kept there,
+// otherwise saves the password built into the store.
+func (t *componentAccountTransformer) syncSecretStore(ctx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent, account appsv1.SystemAccount, secret *corev1.Secret) error {
+	if account.SecretRef != nil || !secretstore.IsEnabled() {
+		return nil
 	}
-	return []byte(password)
+	store, err := secretstore.New()
+	if err != nil {
+		return err
+	}
+	storePath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+	if t.getRestorePassword(ctx, account) != "" {
+		// the password restored from the backup takes precedence over the one in the store.
+		return store.Put(ctx.Context, storePath, secret.Data)
+	}
+	data, err := secretstore.GetOrCreate(ctx.Context, store, storePath, func() (map[string][]byte, error) {
+		return secret.Data, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(data[constant.AccountPasswdForSecret]) == 0 {
+		return fmt.Errorf("the secret of account %s in the %s secret store has no required credential field", account.Name, store.Name())
+	}
+	secret.Data[constant.AccountPasswdForSecret] = data[constant.AccountPasswdForSecret]
+	return nil
+}
+
+// resyncSecretStore syncs the password of the account kept in the external secret store into the existing secret,
+// to pick up the password changed in the store directly, and saves the password into the store if it is not there.
+//
+// The password changed in the store is applied as the next password by the accountRotate action if it is defined,
+// otherwise it is expected to have been changed in the database along with the store.
+func (t *componentAccountTransformer) resyncSecretStore(ctx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent, account appsv1.SystemAccount, secret *corev1.Secret) error {
+	if account.SecretRef != nil || !secretstore.IsEnabled() {
+		return nil
+	}
+	if len(secret.Data[constant.AccountNextPasswdForSecret]) > 0 || len(secret.Data[constant.AccountPrevPasswdForSecret]) > 0 {
+		// the password is being rotated, and it will be saved into the store once rotated.
+		return nil
+	}
+	store, err := secretstore.New()
+	if err != nil {
+		return err
+	}
+	storePath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+	data, err := secretstore.GetOrCreate(ctx.Context, store, storePath, func() (map[string][]byte, error) {
+		return map[string][]byte{
+			constant.AccountNameForSecret:   secret.Data[constant.AccountNameForSecret],
+			constant.AccountPasswdForSecret: secret.Data[constant.AccountPasswdForSecret],
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+	password := data[constant.AccountPasswdForSecret]
+	if len(password) == 0 {
+		return fmt.Errorf("the secret of account %s in the %s secret store has no required credential field", account.Name, store.Name())
+	}
+	if bytes.Equal(password, secret.Data[constant.AccountPasswdForSecret]) {
+		return nil
+	}
+	if synthesizeComp.LifecycleActions != nil && synthesizeComp.LifecycleActions.AccountRotate != nil {
+		secret.Data[constant.AccountNextPasswdForSecret] = password
+		return nil
+	}
+	secret.Data[constant.AccountPasswdForSecret] = password
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	// roll the pods referencing the password
+	secret.Annotations[constant.AccountPasswordRotatedAtAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	return nil
+}
+
+// recreateImmutableSecret recreates the immutable account secret created by the previous versions as mutable,
+// since the data synced from the secret store can't be updated into it.
+func (t *componentAccountTransformer) recreateImmutableSecret(graphCli model.GraphClient, dag *graph.DAG, secret, synced *corev1.Secret) {
+	recreated := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       synced.Namespace,
+			Name:            synced.Name,
+			Labels:          synced.Labels,
+			Annotations:     synced.Annotations,
+			OwnerReferences: synced.OwnerReferences,
+			Finalizers:      synced.Finalizers,
+		},
+		Type: synced.Type,
+		Data: synced.Data,
+	}
+	deleteVertex := graphCli.Do(dag, nil, secret.DeepCopy(), model.ActionDeletePtr(), nil, inUniversalContext4G())
+	createVertex := graphCli.Do(dag, nil, recreated, model.ActionCreatePtr(), nil, inUniversalContext4G())
+	// create the secret after the immutable one is deleted
+	dag.Connect(createVertex, deleteVertex)
 }
 
 func (t *componentAccountTransformer) generatePassword(account appsv1.SystemAccount) []byte {
@@ -170,7 +287,6 @@ func (t *componentAccountTransformer) buildAccountSecretWithPassword(ctx *compon
 		AddAnnotationsInMap(synthesizeComp.StaticAnnotations).
 		PutData(constant.AccountNameForSecret, []byte(account.Name)).
 		PutData(constant.AccountPasswdForSecret, password).
-		SetImmutable(true).
 		GetObject()
 	if err := setCompOwnershipNFinalizer(ctx.Component, secret); err != nil {
 		return nil, err
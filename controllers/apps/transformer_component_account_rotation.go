@@ -0,0 +1,374 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+)
+
+// componentAccountRotationTransformer rotates the passwords of the component system accounts,
+// which is requested on demand by annotating the account secret (e.g. by the OpsRequest), or scheduled by the rotation policy.
+//
+// The rotation is done in stages and each of them is persisted into the account secret before moving to the next one:
+//  1. a new password is generated and saved as the next password;
+//  2. the next password is applied by the accountRotate action, and then the secret is updated atomically:
+//     the next password becomes the password, and the password becomes the previous password;
+//  3. after the grace period, the previous password is discarded by the accountRotate action.
+type componentAccountRotationTransformer struct{}
+
+var _ graph.Transformer = &componentAccountRotationTransformer{}
+
+func (t *componentAccountRotationTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	if common.IsCompactMode(transCtx.ComponentOrig.Annotations) {
+		transCtx.V(1).Info("Component is in compact mode, no need to rotate the account passwords",
+			"component", client.ObjectKeyFromObject(transCtx.ComponentOrig))
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	if len(synthesizeComp.SystemAccounts) == 0 {
+		return nil
+	}
+	if synthesizeComp.LifecycleActions == nil || synthesizeComp.LifecycleActions.AccountRotate == nil {
+		return nil
+	}
+	if transCtx.Component.Status.Phase != appsv1.RunningClusterCompPhase {
+		return nil
+	}
+
+	var (
+		lfa          lifecycle.Lifecycle
+		requeueAfter time.Duration
+		now          = time.Now()
+	)
+	for _, account := range synthesizeComp.SystemAccounts {
+		if account.SecretRef != nil {
+			// the password is managed by the referenced secret
+			continue
+		}
+		secret, err := t.getAccountSecret(transCtx, synthesizeComp, account)
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			continue
+		}
+		after, err := t.rotate(transCtx, dag, &lfa, account, secret, now)
+		if err != nil {
+			return err
+		}
+		if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+	if requeueAfter > 0 {
+		return newRequeueError(requeueAfter, "requeue to rotate the passwords of the system accounts")
+	}
+	return nil
+}
+
+func (t *componentAccountRotationTransformer) getAccountSecret(transCtx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent, account appsv1.SystemAccount) (*corev1.Secret, error) {
+	secretKey := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name),
+	}
+	secret := &corev1.Secret{}
+	if err := transCtx.Client.Get(transCtx.Context, secretKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (t *componentAccountRotationTransformer) lifecycleAction(transCtx *componentTransformContext) (lifecycle.Lifecycle, error) {
+	synthesizedComp := transCtx.SynthesizeComponent
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client,
+		synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	return lifecycle.New(synthesizedComp, nil, pods...)
+}
+
+// rotate moves the rotation of the account to the next stage, and returns the duration to check it again.
+func (t *componentAccountRotationTransformer) rotate(transCtx *componentTransformContext, dag *graph.DAG,
+	lfa *lifecycle.Lifecycle, account appsv1.SystemAccount, secret *corev1.Secret, now time.Time) (time.Duration, error) {
+	var (
+		policy       = account.PasswordGenerationPolicy.RotationPolicy
+		request      = secret.Annotations[constant.AccountPasswordRotationRequestAnnotationKey]
+		username     = string(secret.Data[constant.AccountNameForSecret])
+		password     = secret.Data[constant.AccountPasswdForSecret]
+		nextPassword = secret.Data[constant.AccountNextPasswdForSecret]
+		prevPassword = secret.Data[constant.AccountPrevPasswdForSecret]
+		rotatedAt    = t.rotatedAt(secret)
+	)
+
+	switch {
+	case len(nextPassword) > 0:
+		// the new password may have been applied in the last round, the action should be idempotent.
+		if err := t.accountRotate(transCtx, lfa, lifecycle.AccountRotationStageRotate, username, nextPassword, password); err != nil {
+			return 0, err
+		}
+		if err := t.saveToSecretStore(transCtx, account, username, nextPassword); err != nil {
+			return 0, err
+		}
+		t.updateSecret(transCtx, dag, secret, func(secret *corev1.Secret) {
+			t.rotated(secret, password, nextPassword, request, now)
+		})
+		transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeNormal, "PasswordRotated",
+			"the password of the system account %s is rotated", account.Name)
+		return t.gracePeriod(policy) + time.Second, nil
+
+	case request != "" || t.isRotationDue(policy, rotatedAt, now):
+		immutable := secret.Immutable != nil && *secret.Immutable
+		if immutable {
+			graphCli, _ := transCtx.Client.(model.GraphClient)
+			if graphCli.FindMatchedVertex(dag, secret) != nil {
+				// the secret is updated by other transformers in this round, rotate it in the next round.
+				return time.Second, nil
+			}
+		}
+		if len(prevPassword) > 0 {
+			// a new rotation starts before the grace period of the last one ends, discard the previous password first.
+			if err := t.accountRotate(transCtx, lfa, lifecycle.AccountRotationStageDiscard, username, password, prevPassword); err != nil {
+				return 0, err
+			}
+		}
+		nextPassword = t.generatePassword(account)
+		if immutable {
+			return t.rotateImmutableSecret(transCtx, dag, lfa, account, secret, nextPassword, now)
+		}
+		t.updateSecret(transCtx, dag, secret, func(secret *corev1.Secret) {
+			delete(secret.Data, constant.AccountPrevPasswdForSecret)
+			secret.Data[constant.AccountNextPasswdForSecret] = nextPassword
+		})
+		return time.Second, nil
+
+	case len(prevPassword) > 0:
+		if remaining := rotatedAt.Add(t.gracePeriod(policy)).Sub(now); remaining > 0 {
+			return remaining, nil
+		}
+		if err := t.accountRotate(transCtx, lfa, lifecycle.AccountRotationStageDiscard, username, password, prevPassword); err != nil {
+			return 0, err
+		}
+		t.updateSecret(transCtx, dag, secret, func(secret *corev1.Secret) {
+			delete(secret.Data, constant.AccountPrevPasswdForSecret)
+		})
+	}
+
+	if policy != nil && policy.Period != nil && policy.Period.Duration > 0 {
+		return rotatedAt.Add(policy.Period.Duration).Sub(now), nil
+	}
+	return 0, nil
+}
+
+// rotated updates the secret to the state after the next password is applied:
+// the next password becomes the password, and the password becomes the previous password.
+func (t *componentAccountRotationTransformer) rotated(secret *corev1.Secret, password, nextPassword []byte, request string, now time.Time) {
+	secret.Data[constant.AccountPasswdForSecret] = nextPassword
+	secret.Data[constant.AccountPrevPasswdForSecret] = password
+	delete(secret.Data, constant.AccountNextPasswdForSecret)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.AccountPasswordRotatedAtAnnotationKey] = now.UTC().Format(time.RFC3339)
+	if request != "" {
+		secret.Annotations[constant.AccountPasswordRotatedByAnnotationKey] = request
+	} else {
+		delete(secret.Annotations, constant.AccountPasswordRotatedByAnnotationKey)
+	}
+	delete(secret.Annotations, constant.AccountPasswordRotationRequestAnnotationKey)
+}
+
+// updateSecret plans the update of the account secret. If the secret has been planned by other transformers
+// in this round (e.g. the metadata update by the account transformer), the changes are merged into that one,
+// since the graph client keeps the object of the existing vertex and the changes would be dropped otherwise.
+func (t *componentAccountRotationTransformer) updateSecret(transCtx *componentTransformContext,
+	dag *graph.DAG, secret *corev1.Secret, mutate func(*corev1.Secret)) {
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if vertex, ok := graphCli.FindMatchedVertex(dag, secret).(*model.ObjectVertex); ok {
+		if planned, ok := vertex.Obj.(*corev1.Secret); ok {
+			mutate(planned)
+			graphCli.Update(dag, vertex.OriObj, planned, &model.ReplaceIfExistingOption{})
+			return
+		}
+	}
+	secretCopy := secret.DeepCopy()
+	mutate(secretCopy)
+	graphCli.Update(dag, secret, secretCopy, inUniversalContext4G())
+}
+
+// rotateImmutableSecret rotates the password of the immutable account secret created by the previous versions.
+// The next password can't be persisted into the immutable secret before it is applied, so the secret is deleted
+// and recreated as mutable with the rotated password in the same round, only after the password is rotated.
+func (t *componentAccountRotationTransformer) rotateImmutableSecret(transCtx *componentTransformContext, dag *graph.DAG,
+	lfa *lifecycle.Lifecycle, account appsv1.SystemAccount, secret *corev1.Secret, nextPassword []byte, now time.Time) (time.Duration, error) {
+	var (
+		request  = secret.Annotations[constant.AccountPasswordRotationRequestAnnotationKey]
+		username = string(secret.Data[constant.AccountNameForSecret])
+		password = secret.Data[constant.AccountPasswdForSecret]
+	)
+	if err := t.accountRotate(transCtx, lfa, lifecycle.AccountRotationStageRotate, username, nextPassword, password); err != nil {
+		return 0, err
+	}
+	if err := t.saveToSecretStore(transCtx, account, username, nextPassword); err != nil {
+		return 0, err
+	}
+	recreated := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       secret.Namespace,
+			Name:            secret.Name,
+			Labels:          secret.Labels,
+			Annotations:     maps.Clone(secret.Annotations),
+			OwnerReferences: secret.OwnerReferences,
+			Finalizers:      secret.Finalizers,
+		},
+		Type: secret.Type,
+		Data: maps.Clone(secret.Data),
+	}
+	t.rotated(recreated, password, nextPassword, request, now)
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	deleteVertex := graphCli.Do(dag, nil, secret.DeepCopy(), model.ActionDeletePtr(), nil, inUniversalContext4G())
+	createVertex := graphCli.Do(dag, nil, recreated, model.ActionCreatePtr(), nil, inUniversalContext4G())
+	// create the secret after the immutable one is deleted
+	dag.Connect(createVertex, deleteVertex)
+	transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeNormal, "PasswordRotated",
+		"the password of the system account %s is rotated", account.Name)
+	return t.gracePeriod(account.PasswordGenerationPolicy.RotationPolicy) + time.Second, nil
+}
+
+// accountRotate calls the accountRotate action, the lifecycle is created on demand and shared by the accounts.
+func (t *componentAccountRotationTransformer) accountRotate(transCtx *componentTransformContext,
+	lfa *lifecycle.Lifecycle, stage, username string, password, prevPassword []byte) error {
+	if *lfa == nil {
+		var err error
+		if *lfa, err = t.lifecycleAction(transCtx); err != nil {
+			return err
+		}
+	}
+	return (*lfa).AccountRotate(transCtx.Context, transCtx.Client, nil, stage, username, string(password), string(prevPassword))
+}
+
+// saveToSecretStore saves the rotated password into the external secret store if configured.
+func (t *componentAccountRotationTransformer) saveToSecretStore(transCtx *componentTransformContext,
+	account appsv1.SystemAccount, username string, password []byte) error {
+	if !secretstore.IsEnabled() {
+		return nil
+	}
+	store, err := secretstore.New()
+	if err != nil {
+		return err
+	}
+	synthesizeComp := transCtx.SynthesizeComponent
+	storePath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+	return store.Put(transCtx.Context, storePath, map[string][]byte{
+		constant.AccountNameForSecret:   []byte(username),
+		constant.AccountPasswdForSecret: password,
+	})
+}
+
+// rotatedAt returns the time of the last rotation, or the creation time of the secret if it has never been rotated.
+func (t *componentAccountRotationTransformer) rotatedAt(secret *corev1.Secret) time.Time {
+	if value, ok := secret.Annotations[constant.AccountPasswordRotatedAtAnnotationKey]; ok {
+		if rotatedAt, err := time.Parse(time.RFC3339, value); err == nil {
+			return rotatedAt
+		}
+	}
+	return secret.CreationTimestamp.Time
+}
+
+func (t *componentAccountRotationTransformer) isRotationDue(policy *appsv1.PasswordRotationPolicy, rotatedAt, now time.Time) bool {
+	if policy == nil || policy.Period == nil || policy.Period.Duration <= 0 {
+		return false
+	}
+	return !now.Before(rotatedAt.Add(policy.Period.Duration))
+}
+
+func (t *componentAccountRotationTransformer) gracePeriod(policy *appsv1.PasswordRotationPolicy) time.Duration {
+	if policy == nil || policy.GracePeriod == nil || policy.GracePeriod.Duration < 0 {
+		return 0
+	}
+	return policy.GracePeriod.Duration
+}
+
+func (t *componentAccountRotationTransformer) generatePassword(account appsv1.SystemAccount) []byte {
+	// the seed is ignored, otherwise the same password will be generated again.
+	account.PasswordGenerationPolicy.Seed = ""
+	return (&componentAccountTransformer{}).generatePassword(account)
+}
+
+// rollPods4RotatedPasswords annotates the pod template with the time of the last rotation of the passwords
+// referenced by the env of containers, so that the pods are rolled to pick up the rotated passwords.
+// The pods which don't reference any rotated password are kept untouched.
+func rollPods4RotatedPasswords(transCtx *componentTransformContext) error {
+	synthesizeComp := transCtx.SynthesizeComponent
+	secretNames := sets.New[string]()
+	for _, env := range synthesizeComp.EnvVars {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Key == constant.AccountPasswdForSecret {
+			secretNames.Insert(env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	var rotatedAt string
+	for _, name := range sets.List(secretNames) {
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{Namespace: synthesizeComp.Namespace, Name: name}
+		if err := transCtx.Client.Get(transCtx.Context, secretKey, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if secret.Annotations[constant.AccountPasswordRotatedAtAnnotationKey] > rotatedAt {
+			rotatedAt = secret.Annotations[constant.AccountPasswordRotatedAtAnnotationKey]
+		}
+	}
+	if rotatedAt != "" {
+		if synthesizeComp.PodAnnotations == nil {
+			synthesizeComp.PodAnnotations = map[string]string{}
+		}
+		synthesizeComp.PodAnnotations[constant.AccountPasswordRotatedAtAnnotationKey] = rotatedAt
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var _ = Describe("account rotation transformer test", func() {
+	var (
+		t      = &componentAccountRotationTransformer{}
+		now    = time.Now()
+		policy = &appsv1.PasswordRotationPolicy{
+			Period:      &metav1.Duration{Duration: 24 * time.Hour},
+			GracePeriod: &metav1.Duration{Duration: time.Hour},
+		}
+	)
+
+	It("resolves the time of the last rotation", func() {
+		created := metav1.NewTime(now.Add(-48 * time.Hour))
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}}
+		Expect(t.rotatedAt(secret).Equal(created.Time)).Should(BeTrue())
+
+		rotatedAt := now.Add(-time.Hour).UTC().Truncate(time.Second)
+		secret.Annotations = map[string]string{
+			constant.AccountPasswordRotatedAtAnnotationKey: rotatedAt.Format(time.RFC3339),
+		}
+		Expect(t.rotatedAt(secret).Equal(rotatedAt)).Should(BeTrue())
+	})
+
+	It("checks whether the rotation is due", func() {
+		Expect(t.isRotationDue(nil, now.Add(-48*time.Hour), now)).Should(BeFalse())
+		Expect(t.isRotationDue(policy, now.Add(-time.Hour), now)).Should(BeFalse())
+		Expect(t.isRotationDue(policy, now.Add(-24*time.Hour), now)).Should(BeTrue())
+		Expect(t.gracePeriod(nil)).Should(BeZero())
+		Expect(t.gracePeriod(policy)).Should(Equal(time.Hour))
+	})
+
+	Context("rotate", func() {
+		var (
+			comp     *appsv1.Component
+			secret   *corev1.Secret
+			lfa      *mockAccountRotator
+			dag      *graph.DAG
+			graphCli model.GraphClient
+			transCtx *componentTransformContext
+		)
+
+		BeforeEach(func() {
+			comp = &appsv1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      constant.GenerateClusterComponentName("test-cluster", "comp"),
+				},
+			}
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       testCtx.DefaultNamespace,
+					Name:            constant.GenerateAccountSecretName("test-cluster", "comp", "root"),
+					ResourceVersion: "1",
+					Finalizers:      []string{constant.DBComponentFinalizerName},
+					Annotations: map[string]string{
+						constant.AccountPasswordRotationRequestAnnotationKey: "rotate-ops",
+					},
+				},
+				Data: map[string][]byte{
+					constant.AccountNameForSecret:   []byte("root"),
+					constant.AccountPasswdForSecret: []byte("password"),
+				},
+			}
+			lfa = &mockAccountRotator{}
+			graphCli = model.NewGraphClient(&mockReader{objs: []client.Object{secret}})
+			dag = graph.NewDAG()
+			graphCli.Root(dag, comp, comp, model.ActionStatusPtr())
+			transCtx = &componentTransformContext{
+				Context:       ctx,
+				Client:        graphCli,
+				EventRecorder: record.NewFakeRecorder(10),
+				Logger:        logger,
+				Component:     comp,
+				ComponentOrig: comp.DeepCopy(),
+			}
+		})
+
+		plannedSecrets := func() ([]model.Action, []*corev1.Secret) {
+			// the vertices are executed in the reverse topological order
+			var (
+				actions []model.Action
+				secrets []*corev1.Secret
+			)
+			Expect(dag.WalkReverseTopoOrder(func(v graph.Vertex) error {
+				vertex := v.(*model.ObjectVertex)
+				if obj, ok := vertex.Obj.(*corev1.Secret); ok {
+					actions = append(actions, *vertex.Action)
+					secrets = append(secrets, obj)
+				}
+				return nil
+			}, nil)).Should(Succeed())
+			return actions, secrets
+		}
+
+		It("persists the next password before applying it", func() {
+			var rotator lifecycle.Lifecycle = lfa
+			account := appsv1.SystemAccount{Name: "root"}
+			after, err := t.rotate(transCtx, dag, &rotator, account, secret, now)
+			Expect(err).Should(Succeed())
+			Expect(after).Should(Equal(time.Second))
+			Expect(lfa.stages).Should(BeEmpty())
+			actions, secrets := plannedSecrets()
+			Expect(actions).Should(Equal([]model.Action{model.UPDATE}))
+			Expect(secrets[0].Data).Should(HaveKey(constant.AccountNextPasswdForSecret))
+		})
+
+		It("merges the rotated password into the secret planned by other transformers", func() {
+			// the account transformer updates the metadata of the secret in the same round
+			secretCopy := secret.DeepCopy()
+			secretCopy.Labels = map[string]string{"foo": "bar"}
+			graphCli.Update(dag, secret, secretCopy)
+
+			secret.Data[constant.AccountNextPasswdForSecret] = []byte("next")
+			var rotator lifecycle.Lifecycle = lfa
+			account := appsv1.SystemAccount{Name: "root"}
+			_, err := t.rotate(transCtx, dag, &rotator, account, secret, now)
+			Expect(err).Should(Succeed())
+			Expect(lfa.stages).Should(Equal([]string{lifecycle.AccountRotationStageRotate}))
+			actions, secrets := plannedSecrets()
+			Expect(actions).Should(Equal([]model.Action{model.UPDATE}))
+			Expect(secrets[0].Labels).Should(HaveKeyWithValue("foo", "bar"))
+			Expect(secrets[0].Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte("next")))
+			Expect(secrets[0].Data).Should(HaveKeyWithValue(constant.AccountPrevPasswdForSecret, []byte("password")))
+			Expect(secrets[0].Data).ShouldNot(HaveKey(constant.AccountNextPasswdForSecret))
+		})
+
+		It("recreates the immutable secret only after the password is rotated", func() {
+			secret.Immutable = func() *bool { b := true; return &b }()
+			account := appsv1.SystemAccount{Name: "root"}
+
+			By("fail to rotate the password")
+			lfa.err = fmt.Errorf("mock error")
+			var rotator lifecycle.Lifecycle = lfa
+			_, err := t.rotate(transCtx, dag, &rotator, account, secret, now)
+			Expect(err).ShouldNot(Succeed())
+			actions, _ := plannedSecrets()
+			Expect(actions).Should(BeEmpty())
+
+			By("rotate the password")
+			lfa.err = nil
+			_, err = t.rotate(transCtx, dag, &rotator, account, secret, now)
+			Expect(err).Should(Succeed())
+			actions, secrets := plannedSecrets()
+			Expect(actions).Should(Equal([]model.Action{model.DELETE, model.CREATE}))
+			created := secrets[1]
+			Expect(created.Immutable).Should(BeNil())
+			Expect(created.ResourceVersion).Should(BeEmpty())
+			Expect(created.Data).Should(HaveKeyWithValue(constant.AccountPrevPasswdForSecret, []byte("password")))
+			Expect(created.Data[constant.AccountPasswdForSecret]).Should(Equal([]byte(lfa.passwords[1])))
+			Expect(created.Annotations).Should(HaveKeyWithValue(constant.AccountPasswordRotatedByAnnotationKey, "rotate-ops"))
+			Expect(created.Annotations).ShouldNot(HaveKey(constant.AccountPasswordRotationRequestAnnotationKey))
+			Expect(created.Finalizers).Should(ContainElement(constant.DBComponentFinalizerName))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte("password")))
+		})
+	})
+
+	It("rolls the pods only if the rotated password is referenced", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateAccountSecretName("test-cluster", "comp", "root"),
+				Annotations: map[string]string{
+					constant.AccountPasswordRotatedAtAnnotationKey: now.UTC().Format(time.RFC3339),
+				},
+			},
+		}
+		transCtx := &componentTransformContext{
+			Context: ctx,
+			Client:  model.NewGraphClient(&mockReader{objs: []client.Object{secret}}),
+			SynthesizeComponent: &component.SynthesizedComponent{
+				Namespace: testCtx.DefaultNamespace,
+				EnvVars:   []corev1.EnvVar{{Name: "USER", Value: "root"}},
+			},
+		}
+		Expect(rollPods4RotatedPasswords(transCtx)).Should(Succeed())
+		Expect(transCtx.SynthesizeComponent.PodAnnotations).ShouldNot(HaveKey(constant.AccountPasswordRotatedAtAnnotationKey))
+
+		transCtx.SynthesizeComponent.EnvVars = append(transCtx.SynthesizeComponent.EnvVars, corev1.EnvVar{
+			Name: "PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  constant.AccountPasswdForSecret,
+				},
+			},
+		})
+		Expect(rollPods4RotatedPasswords(transCtx)).Should(Succeed())
+		Expect(transCtx.SynthesizeComponent.PodAnnotations).Should(HaveKeyWithValue(constant.AccountPasswordRotatedAtAnnotationKey,
+			secret.Annotations[constant.AccountPasswordRotatedAtAnnotationKey]))
+	})
+
+	It("generates a different password even if the seed is set", func() {
+		account := appsv1.SystemAccount{
+			Name: "root",
+			PasswordGenerationPolicy: appsv1.PasswordConfig{
+				Length: 16,
+				Seed:   "seed",
+			},
+		}
+		password := (&componentAccountTransformer{}).generatePassword(account)
+		Expect(t.generatePassword(account)).ShouldNot(Equal(password))
+		Expect(account.PasswordGenerationPolicy.Seed).Should(Equal("seed"))
+	})
+})
+
+type mockAccountRotator struct {
+	lifecycle.Lifecycle
+	err       error
+	stages    []string
+	passwords []string
+}
+
+func (m *mockAccountRotator) AccountRotate(_ context.Context, _ client.Reader, _ *lifecycle.Options, stage, _, password, _ string) error {
+	m.stages = append(m.stages, stage)
+	m.passwords = append(m.passwords, password)
+	return m.err
+}
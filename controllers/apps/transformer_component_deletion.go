@@ -120,6 +120,30 @@ func (t *componentDeletionTransformer) deleteCompResources(transCtx *componentTr
 	if err1 != nil {
 		return newRequeueError(requeueDuration, err1.Error())
 	}
+	// the monitors are optional, their CRDs may not be installed
+	monitors, _, err2 := listCompPrometheusMonitors(transCtx, comp, matchLabels)
+	if err2 != nil {
+		return newRequeueError(requeueDuration, err2.Error())
+	}
+	for _, monitor := range monitors {
+		name, err := model.GetGVKName(monitor)
+		if err != nil {
+			return err
+		}
+		snapshot[*name] = monitor
+	}
+	// the cert-manager Certificates are optional as well
+	certificates, err3 := listCompCertificates(transCtx, comp, matchLabels)
+	if err3 != nil {
+		return newRequeueError(requeueDuration, err3.Error())
+	}
+	for _, certificate := range certificates {
+		name, err := model.GetGVKName(certificate)
+		if err != nil {
+			return err
+		}
+		snapshot[*name] = certificate
+	}
 	if len(snapshot) > 0 {
 		// delete the sub-resources owned by the component before deleting the component
 		for _, object := range snapshot {
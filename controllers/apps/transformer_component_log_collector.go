@@ -0,0 +1,227 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	logCollectorContainerName  = "log-collector"
+	logCollectorConfigVolume   = "log-collector-config"
+	logCollectorDataVolume     = "log-collector-data"
+	logCollectorConfigDir      = "/etc/vector"
+	logCollectorDataDir        = "/var/lib/vector"
+	logCollectorConfigFileName = "vector.yaml"
+	logCollectorSinkName       = "kb_sink"
+)
+
+// componentLogCollectorTransformer injects the log collector sidecar into the pod template of the component,
+// which collects the logs declared by the LogConfigs of the component definition and enabled by the cluster annotations.
+type componentLogCollectorTransformer struct{}
+
+var _ graph.Transformer = &componentLogCollectorTransformer{}
+
+func (t *componentLogCollectorTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	if common.IsCompactMode(transCtx.ComponentOrig.Annotations) {
+		transCtx.V(1).Info("Component is in compact mode, no need to inject the log collector",
+			"component", client.ObjectKeyFromObject(transCtx.ComponentOrig))
+		return nil
+	}
+
+	comp := transCtx.Component
+	synthesizeComp := transCtx.SynthesizeComponent
+	logConfigs, err := component.EnabledLogConfigs(synthesizeComp, comp.Annotations[constant.EnabledLogsAnnotationKey])
+	if err != nil {
+		return err
+	}
+
+	var configMap *corev1.ConfigMap
+	if len(logConfigs) > 0 {
+		if configMap, err = buildLogCollectorConfigMap(comp, synthesizeComp, logConfigs); err != nil {
+			return err
+		}
+		if err = setCompOwnershipNFinalizer(comp, configMap); err != nil {
+			return err
+		}
+		injectLogCollectorContainer(synthesizeComp, logConfigs, configMap.Name)
+	}
+	return t.reconcileConfigMap(transCtx, dag, configMap)
+}
+
+func (t *componentLogCollectorTransformer) reconcileConfigMap(transCtx *componentTransformContext,
+	dag *graph.DAG, configMap *corev1.ConfigMap) error {
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	running := &corev1.ConfigMap{}
+	key := types.NamespacedName{
+		Namespace: transCtx.SynthesizeComponent.Namespace,
+		Name:      logCollectorConfigMapName(transCtx.SynthesizeComponent),
+	}
+	if err := transCtx.Client.Get(transCtx.Context, key, running, inDataContext4C()); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		running = nil
+	}
+
+	switch {
+	case running == nil && configMap != nil:
+		graphCli.Create(dag, configMap, inDataContext4G())
+	case running != nil && configMap == nil:
+		if model.IsOwnerOf(transCtx.Component, running) {
+			graphCli.Delete(dag, running, inDataContext4G())
+		}
+	case running != nil && configMap != nil:
+		if !reflect.DeepEqual(running.Data, configMap.Data) {
+			updated := running.DeepCopy()
+			updated.Data = configMap.Data
+			graphCli.Update(dag, running, updated, inDataContext4G())
+		}
+	}
+	return nil
+}
+
+func logCollectorConfigMapName(synthesizeComp *component.SynthesizedComponent) string {
+	return fmt.Sprintf("%s-log-collector", synthesizeComp.FullCompName)
+}
+
+func buildLogCollectorConfigMap(comp *appsv1.Component, synthesizeComp *component.SynthesizedComponent,
+	logConfigs []appsv1.LogConfig) (*corev1.ConfigMap, error) {
+	config, err := buildLogCollectorConfig(comp, synthesizeComp, logConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return builder.NewConfigMapBuilder(synthesizeComp.Namespace, logCollectorConfigMapName(synthesizeComp)).
+		AddLabelsInMap(constant.GetCompLabels(synthesizeComp.ClusterName, synthesizeComp.Name)).
+		PutData(logCollectorConfigFileName, config).
+		GetObject(), nil
+}
+
+// buildLogCollectorConfig renders the vector config: a file source and a remap transform for each log,
+// all of them are sent to the sink specified by the cluster, or to the console by default.
+func buildLogCollectorConfig(comp *appsv1.Component, synthesizeComp *component.SynthesizedComponent,
+	logConfigs []appsv1.LogConfig) (string, error) {
+	sink := map[string]interface{}{
+		"type":     "console",
+		"encoding": map[string]interface{}{"codec": "json"},
+	}
+	if value := comp.Annotations[constant.LogSinkAnnotationKey]; value != "" {
+		sink = map[string]interface{}{}
+		if err := json.Unmarshal([]byte(value), &sink); err != nil {
+			return "", fmt.Errorf("invalid log sink %s: %s", value, err.Error())
+		}
+		if _, ok := sink["type"]; !ok {
+			return "", fmt.Errorf("the type of the log sink is required: %s", value)
+		}
+	}
+
+	sources := map[string]interface{}{}
+	transforms := map[string]interface{}{}
+	inputs := make([]interface{}, 0, len(logConfigs))
+	for _, logConfig := range logConfigs {
+		sourceName := "log_" + logConfig.Name
+		sources[sourceName] = map[string]interface{}{
+			"type":    "file",
+			"include": []interface{}{logConfig.FilePathPattern},
+		}
+		transformName := "kb_" + logConfig.Name
+		transforms[transformName] = map[string]interface{}{
+			"type":   "remap",
+			"inputs": []interface{}{sourceName},
+			"source": strings.Join([]string{
+				fmt.Sprintf(".namespace = %s", strconv.Quote(synthesizeComp.Namespace)),
+				fmt.Sprintf(".cluster = %s", strconv.Quote(synthesizeComp.ClusterName)),
+				fmt.Sprintf(".component = %s", strconv.Quote(synthesizeComp.Name)),
+				fmt.Sprintf(".log = %s", strconv.Quote(logConfig.Name)),
+				`.pod = get_env_var("POD_NAME") ?? ""`,
+			}, "\n"),
+		}
+		inputs = append(inputs, transformName)
+	}
+	sink["inputs"] = inputs
+
+	out, err := yaml.Marshal(map[string]interface{}{
+		"data_dir":   logCollectorDataDir,
+		"sources":    sources,
+		"transforms": transforms,
+		"sinks":      map[string]interface{}{logCollectorSinkName: sink},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func injectLogCollectorContainer(synthesizeComp *component.SynthesizedComponent, logConfigs []appsv1.LogConfig, configMapName string) {
+	container := builder.NewContainerBuilder(logCollectorContainerName).
+		SetImage(viper.GetString(constant.CfgKeyLogCollectorImage)).
+		SetImagePullPolicy(corev1.PullIfNotPresent).
+		AddArgs("--config", logCollectorConfigDir+"/"+logCollectorConfigFileName, "--watch-config").
+		AddEnv(corev1.EnvVar{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		}).
+		AddVolumeMounts(
+			corev1.VolumeMount{Name: logCollectorConfigVolume, MountPath: logCollectorConfigDir, ReadOnly: true},
+			corev1.VolumeMount{Name: logCollectorDataVolume, MountPath: logCollectorDataDir},
+		).
+		AddVolumeMounts(component.LogVolumeMounts(synthesizeComp, logConfigs)...).
+		GetObject()
+
+	synthesizeComp.PodSpec.Containers = append(synthesizeComp.PodSpec.Containers, *container)
+	synthesizeComp.PodSpec.Volumes = append(synthesizeComp.PodSpec.Volumes,
+		corev1.Volume{
+			Name: logCollectorConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+				},
+			},
+		},
+		corev1.Volume{
+			Name:         logCollectorDataVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+}
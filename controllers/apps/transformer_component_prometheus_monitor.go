@@ -0,0 +1,261 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	podMonitorKind     = "PodMonitor"
+	serviceMonitorKind = "ServiceMonitor"
+
+	// prometheusMonitorConditionType records the kind of the monitor created for the component,
+	// the monitors are only listed if the component opts in or the condition exists.
+	prometheusMonitorConditionType = "PrometheusMonitor"
+)
+
+var monitorGroupVersion = schema.GroupVersion{Group: "monitoring.coreos.com", Version: "v1"}
+
+// componentPrometheusMonitorTransformer generates the PodMonitor or the ServiceMonitor of the Prometheus Operator
+// from the exporter of the component definition, if it is enabled by the cluster annotations.
+type componentPrometheusMonitorTransformer struct{}
+
+var _ graph.Transformer = &componentPrometheusMonitorTransformer{}
+
+func (t *componentPrometheusMonitorTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	if common.IsCompactMode(transCtx.ComponentOrig.Annotations) {
+		transCtx.V(1).Info("Component is in compact mode, no need to create monitor related objects",
+			"component", client.ObjectKeyFromObject(transCtx.ComponentOrig))
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	monitor, err := buildPrometheusMonitor(transCtx.Component, synthesizeComp, transCtx.CompDef)
+	if err != nil {
+		return err
+	}
+
+	if monitor == nil && meta.FindStatusCondition(transCtx.Component.Status.Conditions, prometheusMonitorConditionType) == nil {
+		return nil
+	}
+
+	runningMonitors, installedKinds, err := listCompPrometheusMonitors(transCtx, transCtx.Component,
+		constant.GetCompLabels(synthesizeComp.ClusterName, synthesizeComp.Name))
+	if err != nil {
+		return err
+	}
+	if monitor == nil && len(runningMonitors) == 0 {
+		meta.RemoveStatusCondition(&transCtx.Component.Status.Conditions, prometheusMonitorConditionType)
+		return nil
+	}
+
+	desiredKind := ""
+	if monitor != nil {
+		desiredKind = monitor.GetKind()
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	for _, running := range runningMonitors {
+		if monitor != nil && running.GetKind() == monitor.GetKind() && running.GetName() == monitor.GetName() {
+			if !reflect.DeepEqual(running.Object["spec"], monitor.Object["spec"]) ||
+				!reflect.DeepEqual(running.GetLabels(), monitor.GetLabels()) {
+				updated := running.DeepCopy()
+				updated.SetLabels(monitor.GetLabels())
+				updated.Object["spec"] = monitor.Object["spec"]
+				graphCli.Update(dag, running, updated, inDataContext4G())
+			}
+			monitor = nil
+			continue
+		}
+		graphCli.Delete(dag, running, inDataContext4G())
+	}
+	if monitor != nil {
+		if !installedKinds.Has(monitor.GetKind()) {
+			transCtx.V(1).Info("the CRD of the monitor is not installed, skip to create it", "kind", monitor.GetKind())
+			return nil
+		}
+		graphCli.Create(dag, monitor, inDataContext4G())
+	}
+	if desiredKind != "" {
+		meta.SetStatusCondition(&transCtx.Component.Status.Conditions, metav1.Condition{
+			Type:               prometheusMonitorConditionType,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: transCtx.Component.Generation,
+			Reason:             desiredKind,
+		})
+	}
+	return nil
+}
+
+// buildPrometheusMonitor builds the monitor, returns nil if the monitor is not enabled.
+func buildPrometheusMonitor(comp *appsv1.Component, synthesizeComp *component.SynthesizedComponent,
+	compDef *appsv1.ComponentDefinition) (*unstructured.Unstructured, error) {
+	kind := comp.Annotations[constant.MonitorKindAnnotationKey]
+	if kind == "" || synthesizeComp.DisableExporter == nil || *synthesizeComp.DisableExporter || compDef == nil {
+		return nil, nil
+	}
+	if kind != podMonitorKind && kind != serviceMonitorKind {
+		return nil, fmt.Errorf("unsupported monitor kind %s, only %s and %s are supported", kind, podMonitorKind, serviceMonitorKind)
+	}
+	exporter := component.GetExporter(compDef.Spec)
+	if exporter == nil {
+		return nil, nil
+	}
+	port := exporterContainerPort(exporter, synthesizeComp)
+	if port == nil {
+		return nil, fmt.Errorf("the scrape port of the exporter is not found, component: %s", synthesizeComp.Name)
+	}
+
+	monitorLabels := constant.GetCompLabels(synthesizeComp.ClusterName, synthesizeComp.Name)
+	if value, ok := comp.Annotations[constant.MonitorLabelsAnnotationKey]; ok && value != "" {
+		extraLabels, err := labels.ConvertSelectorToLabelsMap(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid monitor labels %s: %s", value, err.Error())
+		}
+		for k, v := range extraLabels {
+			monitorLabels[k] = v
+		}
+	}
+
+	endpoint := map[string]interface{}{
+		"path":   common.FromScrapePath(exporter.Exporter),
+		"scheme": common.FromScheme(exporter.Exporter),
+	}
+	if interval := comp.Annotations[constant.MonitorIntervalAnnotationKey]; interval != "" {
+		endpoint["interval"] = interval
+	}
+	spec := map[string]interface{}{
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{synthesizeComp.Namespace},
+		},
+	}
+	switch kind {
+	case podMonitorKind:
+		if port.Name != "" {
+			endpoint["port"] = port.Name
+		} else {
+			endpoint["targetPort"] = int64(port.ContainerPort)
+		}
+		spec["selector"] = labelSelector(constant.GetCompLabels(synthesizeComp.ClusterName, synthesizeComp.Name))
+		spec["podMetricsEndpoints"] = []interface{}{endpoint}
+	case serviceMonitorKind:
+		// scrape the headless service of the InstanceSet, which exposes all the container ports.
+		// the protocol of the ports in the InstanceSet is defaulted to TCP by the API server.
+		svcPort := *port
+		if svcPort.Protocol == "" {
+			svcPort.Protocol = corev1.ProtocolTCP
+		}
+		endpoint["port"] = instanceset.HeadlessServicePortName(svcPort)
+		spec["selector"] = labelSelector(map[string]string{
+			instanceset.WorkloadsManagedByLabelKey: workloads.Kind,
+			instanceset.WorkloadsInstanceLabelKey:  synthesizeComp.FullCompName,
+		})
+		spec["endpoints"] = []interface{}{endpoint}
+	}
+
+	monitor := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	monitor.SetGroupVersionKind(monitorGroupVersion.WithKind(kind))
+	monitor.SetNamespace(synthesizeComp.Namespace)
+	monitor.SetName(synthesizeComp.FullCompName)
+	monitor.SetLabels(monitorLabels)
+	if err := setCompOwnershipNFinalizer(comp, monitor); err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
+
+// exporterContainerPort returns the container port to scrape.
+func exporterContainerPort(exporter *common.Exporter, synthesizeComp *component.SynthesizedComponent) *corev1.ContainerPort {
+	var container *corev1.Container
+	for i, c := range synthesizeComp.PodSpec.Containers {
+		if c.Name == exporter.ContainerName {
+			container = &synthesizeComp.PodSpec.Containers[i]
+			break
+		}
+	}
+	portNumber, err := strconv.Atoi(common.FromContainerPort(*exporter, container))
+	if err != nil {
+		return nil
+	}
+	if container != nil {
+		for i, port := range container.Ports {
+			if int(port.ContainerPort) == portNumber {
+				return &container.Ports[i]
+			}
+		}
+	}
+	return &corev1.ContainerPort{ContainerPort: int32(portNumber), Protocol: corev1.ProtocolTCP}
+}
+
+func labelSelector(matchLabels map[string]string) map[string]interface{} {
+	m := make(map[string]interface{}, len(matchLabels))
+	for k, v := range matchLabels {
+		m[k] = v
+	}
+	return map[string]interface{}{"matchLabels": m}
+}
+
+// listCompPrometheusMonitors lists the monitors owned by the component and the monitor kinds whose CRD is installed.
+func listCompPrometheusMonitors(transCtx graph.TransformContext, comp *appsv1.Component,
+	matchLabels client.MatchingLabels) ([]*unstructured.Unstructured, sets.Set[string], error) {
+	var monitors []*unstructured.Unstructured
+	installedKinds := sets.New[string]()
+	for _, kind := range []string{podMonitorKind, serviceMonitorKind} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(monitorGroupVersion.WithKind(kind + "List"))
+		if err := transCtx.GetClient().List(transCtx.GetContext(), list, client.InNamespace(comp.Namespace), matchLabels); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		installedKinds.Insert(kind)
+		for i := range list.Items {
+			if model.IsOwnerOf(comp, &list.Items[i]) {
+				monitors = append(monitors, &list.Items[i])
+			}
+		}
+	}
+	return monitors, installedKinds, nil
+}
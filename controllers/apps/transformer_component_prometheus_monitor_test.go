@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+var _ = Describe("prometheus monitor transformer test", func() {
+	const (
+		clusterName = "test-cluster"
+		compName    = "mysql"
+	)
+
+	var (
+		comp            *appsv1.Component
+		compDef         *appsv1.ComponentDefinition
+		synthesizedComp *component.SynthesizedComponent
+	)
+
+	BeforeEach(func() {
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+				UID:       "test-uid",
+				Annotations: map[string]string{
+					constant.MonitorKindAnnotationKey:     podMonitorKind,
+					constant.MonitorLabelsAnnotationKey:   "release=prometheus",
+					constant.MonitorIntervalAnnotationKey: "30s",
+				},
+			},
+		}
+		compDef = &appsv1.ComponentDefinition{
+			Spec: appsv1.ComponentDefinitionSpec{
+				Exporter: &appsv1.Exporter{
+					ContainerName: "exporter",
+					ScrapePath:    "/metrics",
+					ScrapePort:    "http-metrics",
+					ScrapeScheme:  appsv1.HTTPProtocol,
+				},
+			},
+		}
+		synthesizedComp = &component.SynthesizedComponent{
+			Namespace:       "default",
+			ClusterName:     clusterName,
+			Name:            compName,
+			FullCompName:    comp.Name,
+			DisableExporter: pointer.Bool(false),
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "mysql", Ports: []corev1.ContainerPort{{Name: "mysql", ContainerPort: 3306}}},
+					{Name: "exporter", Ports: []corev1.ContainerPort{{Name: "http-metrics", ContainerPort: 9104}}},
+				},
+			},
+		}
+	})
+
+	endpoints := func(monitor *unstructured.Unstructured, field string) map[string]interface{} {
+		items, found, err := unstructured.NestedSlice(monitor.Object, "spec", field)
+		Expect(err).Should(BeNil())
+		Expect(found).Should(BeTrue())
+		Expect(items).Should(HaveLen(1))
+		return items[0].(map[string]interface{})
+	}
+
+	It("builds the pod monitor", func() {
+		monitor, err := buildPrometheusMonitor(comp, synthesizedComp, compDef)
+		Expect(err).Should(BeNil())
+		Expect(monitor).ShouldNot(BeNil())
+		Expect(monitor.GetKind()).Should(Equal(podMonitorKind))
+		Expect(monitor.GetName()).Should(Equal(comp.Name))
+		Expect(monitor.GetLabels()).Should(HaveKeyWithValue("release", "prometheus"))
+		Expect(monitor.GetLabels()).Should(HaveKeyWithValue(constant.KBAppComponentLabelKey, compName))
+		Expect(monitor.GetOwnerReferences()).Should(HaveLen(1))
+
+		endpoint := endpoints(monitor, "podMetricsEndpoints")
+		Expect(endpoint).Should(HaveKeyWithValue("port", "http-metrics"))
+		Expect(endpoint).Should(HaveKeyWithValue("path", "/metrics"))
+		Expect(endpoint).Should(HaveKeyWithValue("interval", "30s"))
+	})
+
+	It("builds the service monitor on the headless service", func() {
+		comp.Annotations[constant.MonitorKindAnnotationKey] = serviceMonitorKind
+		// the exporter port is resolved by the container instead
+		compDef.Spec.Exporter.ScrapePort = ""
+		synthesizedComp.PodSpec.Containers[1].Ports = []corev1.ContainerPort{{ContainerPort: 9104}}
+
+		monitor, err := buildPrometheusMonitor(comp, synthesizedComp, compDef)
+		Expect(err).Should(BeNil())
+		Expect(monitor.GetKind()).Should(Equal(serviceMonitorKind))
+		Expect(endpoints(monitor, "endpoints")).Should(HaveKeyWithValue("port", "tcp-9104"))
+	})
+
+	It("skips the monitor if the exporter is disabled", func() {
+		synthesizedComp.DisableExporter = pointer.Bool(true)
+		monitor, err := buildPrometheusMonitor(comp, synthesizedComp, compDef)
+		Expect(err).Should(BeNil())
+		Expect(monitor).Should(BeNil())
+	})
+
+	It("rejects the unknown monitor kind", func() {
+		comp.Annotations[constant.MonitorKindAnnotationKey] = "Probe"
+		_, err := buildPrometheusMonitor(comp, synthesizedComp, compDef)
+		Expect(err).ShouldNot(BeNil())
+	})
+})
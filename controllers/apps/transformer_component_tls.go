@@ -20,18 +20,23 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
@@ -39,8 +44,23 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+const (
+	tlsCertificateValid    = "TLSCertificateValid"
+	tlsCertificateExpiring = "TLSCertificateExpiring"
+	tlsCertificateExpired  = "TLSCertificateExpired"
+	tlsCertificateInvalid  = "TLSCertificateInvalid"
+	tlsCertificateRenewed  = "TLSCertificateRenewed"
+
+	tlsCertCheckInterval = 24 * time.Hour
+)
+
+// tlsSecretStoreKeys are the keys of the TLS certificates kept in the external secret store.
+var tlsSecretStoreKeys = []string{constant.CAName, constant.CAKeyName, constant.CertName, constant.KeyName}
+
 // componentTLSTransformer handles component configuration render
 type componentTLSTransformer struct {
 	client.Client
@@ -57,8 +77,22 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 		return err
 	}
 
+	// delete the cert-manager Certificates not used anymore
+	if err := cleanupCertManagerCerts(transCtx, dag); err != nil {
+		return err
+	}
+
 	// build tls cert
-	if err := buildTLSCert(transCtx.Context, transCtx.Client, *synthesizedComp, dag); err != nil {
+	var (
+		cert *tlsCertificate
+		err  error
+	)
+	if isCertManagerIssued(synthesizedComp) {
+		cert, err = buildCertManagerCert(transCtx, dag)
+	} else {
+		cert, err = buildTLSCert(transCtx.Context, transCtx.Client, *synthesizedComp, dag)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -66,6 +100,113 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 		return err
 	}
 
+	// roll the pods to load the changed certificate
+	if err := t.rollOnCertificateChange(transCtx, cert); err != nil {
+		return err
+	}
+
+	// track the expiry of the certificate
+	return t.reconcileCertificateStatus(transCtx, cert)
+}
+
+// rollOnCertificateChange sets the hash of the certificate to the pod template once the certificate is changed,
+// and keeps it afterward, so the pods are not restarted for the components that have never changed their certificates.
+func (t *componentTLSTransformer) rollOnCertificateChange(transCtx *componentTransformContext, cert *tlsCertificate) error {
+	if cert == nil {
+		return nil
+	}
+	synthesizedComp := transCtx.SynthesizeComponent
+	its := &workloads.InstanceSet{}
+	itsKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	if err := transCtx.Client.Get(transCtx.Context, itsKey, its); err != nil {
+		// the workload is not created yet, nothing to roll
+		return client.IgnoreNotFound(err)
+	}
+	hash := plan.TLSCertificateHash(cert.ca, cert.cert, cert.key)
+	prevHash := ""
+	if transCtx.ComponentOrig.Status.TLS != nil {
+		prevHash = transCtx.ComponentOrig.Status.TLS.CertificateHash
+	}
+	_, annotated := its.Spec.Template.Annotations[constant.TLSCertificateHashAnnotationKey]
+	if annotated || (prevHash != "" && prevHash != hash) {
+		if synthesizedComp.PodAnnotations == nil {
+			synthesizedComp.PodAnnotations = map[string]string{}
+		}
+		synthesizedComp.PodAnnotations[constant.TLSCertificateHashAnnotationKey] = hash
+	}
+	return nil
+}
+
+// reconcileCertificateStatus records the certificate in the component status, and raises the condition and warnings
+// if the certificate is invalid, expired or about to expire.
+func (t *componentTLSTransformer) reconcileCertificateStatus(transCtx *componentTransformContext, cert *tlsCertificate) error {
+	comp := transCtx.Component
+	if cert == nil {
+		comp.Status.TLS = nil
+		meta.RemoveStatusCondition(&comp.Status.Conditions, appsv1.ConditionTypeTLSCertificateReady)
+		return nil
+	}
+	if cert.renewed && transCtx.EventRecorder != nil {
+		transCtx.EventRecorder.Eventf(comp, corev1.EventTypeNormal, tlsCertificateRenewed,
+			"the TLS certificate in secret %s is renewed", cert.secretName)
+	}
+
+	var (
+		status = &appsv1.ComponentTLSStatus{
+			Issuer:          transCtx.SynthesizeComponent.TLSConfig.Issuer.Name,
+			SecretName:      cert.secretName,
+			CertificateHash: plan.TLSCertificateHash(cert.ca, cert.cert, cert.key),
+		}
+		condition = metav1.Condition{
+			Type:               appsv1.ConditionTypeTLSCertificateReady,
+			ObservedGeneration: comp.Generation,
+		}
+		now          = time.Now()
+		requeueAfter time.Duration
+	)
+	x509Cert, err := plan.ParseTLSCertificate(cert.cert)
+	if err == nil {
+		status.NotBefore = &metav1.Time{Time: x509Cert.NotBefore}
+		status.NotAfter = &metav1.Time{Time: x509Cert.NotAfter}
+	}
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = tlsCertificateInvalid
+		condition.Message = fmt.Sprintf("failed to parse the TLS certificate in secret %s: %s", cert.secretName, err.Error())
+	case !now.Before(x509Cert.NotAfter):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = tlsCertificateExpired
+		condition.Message = fmt.Sprintf("the TLS certificate in secret %s has expired at %s", cert.secretName, x509Cert.NotAfter.UTC().Format(time.RFC3339))
+	case !now.Before(x509Cert.NotAfter.Add(-tlsCertRenewBefore())):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = tlsCertificateExpiring
+		condition.Message = fmt.Sprintf("the TLS certificate in secret %s will expire at %s", cert.secretName, x509Cert.NotAfter.UTC().Format(time.RFC3339))
+		requeueAfter = x509Cert.NotAfter.Sub(now)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = tlsCertificateValid
+		condition.Message = fmt.Sprintf("the TLS certificate in secret %s is valid until %s", cert.secretName, x509Cert.NotAfter.UTC().Format(time.RFC3339))
+		requeueAfter = x509Cert.NotAfter.Add(-tlsCertRenewBefore()).Sub(now)
+	}
+	comp.Status.TLS = status
+	prevCondition := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeTLSCertificateReady)
+	if condition.Status == metav1.ConditionFalse && transCtx.EventRecorder != nil &&
+		(prevCondition == nil || prevCondition.Reason != condition.Reason) {
+		transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	meta.SetStatusCondition(&comp.Status.Conditions, condition)
+
+	// check the certificate again when it is about to expire, at most once a day.
+	if requeueAfter > 0 {
+		if requeueAfter > tlsCertCheckInterval {
+			requeueAfter = tlsCertCheckInterval
+		}
+		return intctrlutil.NewDelayedRequeueError(requeueAfter+time.Second, "requeue to check the expiry of the TLS certificate")
+	}
 	return nil
 }
 
@@ -136,20 +277,33 @@ func checkAndTriggerReRender(ctx context.Context, synthesizedComp component.Synt
 	return nil
 }
 
-func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) error {
+// tlsCertificate is the TLS certificate in use by the component.
+type tlsCertificate struct {
+	secretName string
+	ca         []byte
+	cert       []byte
+	key        []byte
+	renewed    bool
+}
+
+func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) (*tlsCertificate, error) {
 	tls := synthesizedComp.TLSConfig
 	if tls == nil || !tls.Enable {
-		return nil
+		return nil, nil
 	}
 	if tls.Issuer == nil {
-		return fmt.Errorf("issuer shouldn't be nil when tls enabled")
+		return nil, fmt.Errorf("issuer shouldn't be nil when tls enabled")
 	}
 
 	switch tls.Issuer.Name {
 	case appsv1.IssuerUserProvided:
 		if err := plan.CheckTLSSecretRef(ctx, cli, synthesizedComp.Namespace, tls.Issuer.SecretRef); err != nil {
-			return err
+			return nil, err
 		}
+		return getUserProvidedTLSCert(ctx, cli, synthesizedComp.Namespace, tls.Issuer.SecretRef)
+	case appsv1.IssuerCertManager:
+		// the certificates issued by cert-manager are handled by buildCertManagerCert
+		return nil, nil
 	case appsv1.IssuerKubeBlocks:
 		graphCli, _ := cli.(model.GraphClient)
 		secretName := plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
@@ -157,19 +311,191 @@ func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp compon
 		err := cli.Get(ctx, types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: secretName}, existSecret)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				secret, err := plan.ComposeTLSSecret(synthesizedComp)
+				secret, err := composeTLSSecret(ctx, synthesizedComp, nil)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				graphCli.Create(dag, secret)
-				return nil
+				return newTLSCertificate(secretName, secret.StringData), nil
 			}
-			return err
-		} else {
-			updateTLSSecretMeta(existSecret, graphCli, dag, synthesizedComp)
+			return nil, err
 		}
+		cert := &tlsCertificate{
+			secretName: secretName,
+			ca:         existSecret.Data[constant.CAName],
+			cert:       existSecret.Data[constant.CertName],
+			key:        existSecret.Data[constant.KeyName],
+		}
+		var secret *corev1.Secret
+		if !needRenewTLSCert(cert.cert, time.Now()) {
+			if secret, err = syncTLSSecretStore(ctx, synthesizedComp, existSecret); err != nil {
+				return nil, err
+			}
+			if secret == nil {
+				updateTLSSecretMeta(existSecret, graphCli, dag, synthesizedComp)
+				return cert, nil
+			}
+		} else if secret, err = composeTLSSecret(ctx, synthesizedComp, existSecret); err != nil {
+			return nil, err
+		}
+		existSecretCopy := existSecret.DeepCopy()
+		existSecretCopy.Labels = secret.Labels
+		existSecretCopy.Annotations = secret.Annotations
+		if existSecretCopy.Annotations == nil {
+			existSecretCopy.Annotations = map[string]string{}
+		}
+		existSecretCopy.Annotations[constant.TLSCertificateRenewedAtAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+		existSecretCopy.Data = map[string][]byte{}
+		for k, v := range secret.StringData {
+			existSecretCopy.Data[k] = []byte(v)
+		}
+		graphCli.Update(dag, existSecret, existSecretCopy)
+		cert = newTLSCertificate(secretName, secret.StringData)
+		cert.renewed = true
+		return cert, nil
 	}
-	return nil
+	return nil, nil
+}
+
+func newTLSCertificate(secretName string, data map[string]string) *tlsCertificate {
+	return &tlsCertificate{
+		secretName: secretName,
+		ca:         []byte(data[constant.CAName]),
+		cert:       []byte(data[constant.CertName]),
+		key:        []byte(data[constant.KeyName]),
+	}
+}
+
+func getUserProvidedTLSCert(ctx context.Context, cli client.Reader, namespace string, secretRef *appsv1.TLSSecretRef) (*tlsCertificate, error) {
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretRef.Name}, secret); err != nil {
+		return nil, err
+	}
+	value := func(key string) []byte {
+		if data, ok := secret.Data[key]; ok {
+			return data
+		}
+		return []byte(secret.StringData[key])
+	}
+	return &tlsCertificate{
+		secretName: secretRef.Name,
+		ca:         value(secretRef.CA),
+		cert:       value(secretRef.Cert),
+		key:        value(secretRef.Key),
+	}, nil
+}
+
+// needRenewTLSCert checks whether the certificate issued by KubeBlocks should be renewed,
+// a certificate which can't be parsed is renewed as well.
+func needRenewTLSCert(cert []byte, now time.Time) bool {
+	x509Cert, err := plan.ParseTLSCertificate(cert)
+	if err != nil {
+		return true
+	}
+	return !now.Before(x509Cert.NotAfter.Add(-tlsCertRenewBefore()))
+}
+
+func tlsCertRenewBefore() time.Duration {
+	renewBefore := viper.GetDuration(constant.CfgKeyTLSCertRenewBefore)
+	if renewBefore < 0 {
+		return 0
+	}
+	return renewBefore
+}
+
+// composeTLSSecret composes the TLS secret with the certificates kept in the external secret store if configured,
+// the certificates are generated and saved into the store at the first time or on renewal.
+// The certificate is renewed if the secret to renew is given, and it is re-issued by the CA of the secret.
+func composeTLSSecret(ctx context.Context, synthesizedComp component.SynthesizedComponent, renewSecret *corev1.Secret) (*corev1.Secret, error) {
+	var renewData map[string][]byte
+	if renewSecret != nil {
+		renewData = renewSecret.Data
+	}
+	if !secretstore.IsEnabled() {
+		return issueTLSSecret(synthesizedComp, renewData)
+	}
+	store, err := secretstore.New()
+	if err != nil {
+		return nil, err
+	}
+	storePath := secretstore.TLSPath(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	generate := func() (map[string][]byte, error) {
+		secret, err := issueTLSSecret(synthesizedComp, renewData)
+		if err != nil {
+			return nil, err
+		}
+		data := map[string][]byte{}
+		for _, key := range tlsSecretStoreKeys {
+			data[key] = []byte(secret.StringData[key])
+		}
+		return data, nil
+	}
+	var data map[string][]byte
+	if renewSecret != nil {
+		if data, err = generate(); err == nil {
+			err = store.Put(ctx, storePath, data)
+		}
+	} else {
+		data, err = secretstore.GetOrCreate(ctx, store, storePath, generate)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buildTLSSecretWithStoreData(synthesizedComp, store, data)
+}
+
+// syncTLSSecretStore loads the certificates kept in the external secret store if configured, and composes the TLS
+// secret with them if they are different from the ones of the existing secret, e.g., changed in the store directly.
+// The certificates of the existing secret are saved into the store if they are not there.
+func syncTLSSecretStore(ctx context.Context, synthesizedComp component.SynthesizedComponent, existSecret *corev1.Secret) (*corev1.Secret, error) {
+	if !secretstore.IsEnabled() {
+		return nil, nil
+	}
+	store, err := secretstore.New()
+	if err != nil {
+		return nil, err
+	}
+	storePath := secretstore.TLSPath(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	data, err := secretstore.GetOrCreate(ctx, store, storePath, func() (map[string][]byte, error) {
+		data := map[string][]byte{}
+		for _, key := range tlsSecretStoreKeys {
+			if len(existSecret.Data[key]) > 0 {
+				data[key] = existSecret.Data[key]
+			}
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for _, key := range tlsSecretStoreKeys {
+		if key == constant.CAKeyName && len(data[key]) == 0 {
+			continue
+		}
+		if !bytes.Equal(data[key], existSecret.Data[key]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return buildTLSSecretWithStoreData(synthesizedComp, store, data)
+}
+
+func buildTLSSecretWithStoreData(synthesizedComp component.SynthesizedComponent, store secretstore.Provider, data map[string][]byte) (*corev1.Secret, error) {
+	secret := plan.BuildTLSSecret(synthesizedComp)
+	for _, key := range tlsSecretStoreKeys {
+		if key == constant.CAKeyName && len(data[key]) == 0 {
+			// the certificates saved by the previous versions have no CA key
+			continue
+		}
+		if len(data[key]) == 0 {
+			return nil, fmt.Errorf("the TLS certificates in the %s secret store have no %s field", store.Name(), key)
+		}
+		secret.StringData[key] = string(data[key])
+	}
+	return secret, nil
 }
 
 func updateTLSSecretMeta(existSecret *corev1.Secret, graphCli model.GraphClient, dag *graph.DAG, synthesizedComp component.SynthesizedComponent) {
@@ -177,6 +503,12 @@ func updateTLSSecretMeta(existSecret *corev1.Secret, graphCli model.GraphClient,
 	existSecretCopy := existSecret.DeepCopy()
 	existSecretCopy.Labels = secretProto.Labels
 	existSecretCopy.Annotations = secretProto.Annotations
+	if renewedAt, ok := existSecret.Annotations[constant.TLSCertificateRenewedAtAnnotationKey]; ok {
+		if existSecretCopy.Annotations == nil {
+			existSecretCopy.Annotations = map[string]string{}
+		}
+		existSecretCopy.Annotations[constant.TLSCertificateRenewedAtAnnotationKey] = renewedAt
+	}
 	if !reflect.DeepEqual(existSecret, existSecretCopy) {
 		graphCli.Update(dag, existSecret, existSecretCopy)
 	}
@@ -222,7 +554,8 @@ func composeTLSVolume(clusterName string, synthesizeComp component.SynthesizedCo
 
 	var secretName, ca, cert, key string
 	switch tls.Issuer.Name {
-	case appsv1.IssuerKubeBlocks:
+	case appsv1.IssuerKubeBlocks, appsv1.IssuerCertManager:
+		// the secret issued by cert-manager has the same name and keys as the one issued by KubeBlocks
 		secretName = plan.GenerateTLSSecretName(clusterName, synthesizeComp.Name)
 		ca = constant.CAName
 		cert = constant.CertName
@@ -260,3 +593,13 @@ func composeTLSVolumeMount() corev1.VolumeMount {
 		ReadOnly:  true,
 	}
 }
+
+// issueTLSSecret issues the certificate by the CA of the secret data to renew, a new CA is generated if there is no CA to renew,
+// or the CA key is absent, e.g. the secret is created by the previous versions, or the CA itself is about to expire.
+func issueTLSSecret(synthesizedComp component.SynthesizedComponent, renewData map[string][]byte) (*corev1.Secret, error) {
+	caCert, caKey := renewData[constant.CAName], renewData[constant.CAKeyName]
+	if len(caCert) > 0 && len(caKey) > 0 && !needRenewTLSCert(caCert, time.Now()) {
+		return plan.ComposeTLSSecretWithCA(synthesizedComp, caCert, caKey)
+	}
+	return plan.ComposeTLSSecret(synthesizedComp)
+}
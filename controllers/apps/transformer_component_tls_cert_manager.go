@@ -0,0 +1,298 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	certManagerCertificateKind  = "Certificate"
+	certManagerDefaultGroup     = "cert-manager.io"
+	certManagerDefaultIssuer    = "Issuer"
+	certManagerMaxCommonNameLen = 64
+)
+
+var certManagerGroupVersion = schema.GroupVersion{Group: "cert-manager.io", Version: "v1"}
+
+func isCertManagerIssued(synthesizedComp *component.SynthesizedComponent) bool {
+	tls := synthesizedComp.TLSConfig
+	return tls != nil && tls.Enable && tls.Issuer != nil && tls.Issuer.Name == appsv1.IssuerCertManager
+}
+
+// buildCertManagerCert creates the cert-manager Certificate of the component, and waits for it to be issued.
+// The certificate is renewed by cert-manager, and the issued secret has the same layout as the one issued by KubeBlocks.
+func buildCertManagerCert(transCtx *componentTransformContext, dag *graph.DAG) (*tlsCertificate, error) {
+	synthesizedComp := transCtx.SynthesizeComponent
+	expected, err := buildCertManagerCertificate(transCtx.Component, synthesizedComp)
+	if err != nil {
+		return nil, err
+	}
+
+	running := &unstructured.Unstructured{}
+	running.SetGroupVersionKind(expected.GroupVersionKind())
+	if err = transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(expected), running, inDataContext4C()); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("the TLS issuer is %s, but cert-manager is not installed", appsv1.IssuerCertManager)
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		running = nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if running == nil {
+		graphCli.Create(dag, expected, inDataContext4G())
+	} else if isCertificateSpecChanged(running, expected) || !reflect.DeepEqual(running.GetLabels(), expected.GetLabels()) {
+		updated := running.DeepCopy()
+		updated.SetLabels(expected.GetLabels())
+		spec, _, _ := unstructured.NestedMap(updated.Object, "spec")
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		for k, v := range expected.Object["spec"].(map[string]interface{}) {
+			spec[k] = v
+		}
+		updated.Object["spec"] = spec
+		graphCli.Update(dag, running, updated, inDataContext4G())
+	}
+
+	secretName := plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
+	secret := &corev1.Secret{}
+	if err = transCtx.Client.Get(transCtx.Context, types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: secretName}, secret, inDataContext4C()); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		secret = nil
+	}
+	if secret == nil || (!isCertificateReady(running) && len(secret.Data[constant.CertName]) == 0) {
+		// the Certificate is created or not issued yet, stop here and wait for it to be ready,
+		// otherwise the pods will be created with the secret volume that doesn't exist.
+		// the issuer is recorded in advance, so that the Certificate is cleaned up if the issuer is changed meanwhile.
+		transCtx.Component.Status.TLS = &appsv1.ComponentTLSStatus{Issuer: appsv1.IssuerCertManager, SecretName: secretName}
+		return nil, newRequeueError(time.Second*5, fmt.Sprintf("wait for the certificate %s to be issued by cert-manager", expected.GetName()))
+	}
+	return &tlsCertificate{
+		secretName: secretName,
+		ca:         secret.Data[constant.CAName],
+		cert:       secret.Data[constant.CertName],
+		key:        secret.Data[constant.KeyName],
+	}, nil
+}
+
+func buildCertManagerCertificate(comp *appsv1.Component, synthesizedComp *component.SynthesizedComponent) (*unstructured.Unstructured, error) {
+	issuerRef := synthesizedComp.TLSConfig.Issuer.IssuerRef
+	if issuerRef == nil || issuerRef.Name == "" {
+		return nil, fmt.Errorf("issuerRef shouldn't be empty when issuer is %s", appsv1.IssuerCertManager)
+	}
+	kind, group := issuerRef.Kind, issuerRef.Group
+	if kind == "" {
+		kind = certManagerDefaultIssuer
+	}
+	if group == "" {
+		group = certManagerDefaultGroup
+	}
+
+	compLabels := constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name)
+	dnsNames := certificateDNSNames(synthesizedComp)
+	spec := map[string]interface{}{
+		"secretName": plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+		"dnsNames":   toInterfaceSlice(dnsNames),
+		"ipAddresses": []interface{}{
+			"127.0.0.1",
+			"::1",
+		},
+		"usages": []interface{}{
+			"server auth",
+			"client auth",
+			"digital signature",
+			"key encipherment",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  kind,
+			"group": group,
+		},
+		"secretTemplate": map[string]interface{}{
+			"labels": labelsToInterfaceMap(compLabels),
+		},
+	}
+	// the common name is limited to 64 characters, and it is deprecated by the SANs.
+	for _, name := range dnsNames {
+		if name != "localhost" && len(name) <= certManagerMaxCommonNameLen {
+			spec["commonName"] = name
+			break
+		}
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	certificate.SetGroupVersionKind(certManagerGroupVersion.WithKind(certManagerCertificateKind))
+	certificate.SetNamespace(synthesizedComp.Namespace)
+	certificate.SetName(plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name))
+	certificate.SetLabels(compLabels)
+	if err := setCompOwnershipNFinalizer(comp, certificate); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// certificateDNSNames returns the SANs of the certificate, which cover the component services and the pods.
+//
+// The pods are covered by the wildcard name of the headless service rather than their FQDNs one by one,
+// and the per-pod services are not included, so the certificate will not be reissued when scaling the component.
+func certificateDNSNames(synthesizedComp *component.SynthesizedComponent) []string {
+	var (
+		names     []string
+		namespace = synthesizedComp.Namespace
+		domain    = viper.GetString(constant.KubernetesClusterDomainEnv)
+		seen      = map[string]bool{}
+	)
+	add := func(values ...string) {
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				names = append(names, value)
+			}
+		}
+	}
+	addService := func(svcName string) {
+		add(svcName, fmt.Sprintf("%s.%s", svcName, namespace), fmt.Sprintf("%s.%s.svc", svcName, namespace))
+		if domain != "" {
+			add(fmt.Sprintf("%s.%s.svc.%s", svcName, namespace, domain))
+		}
+	}
+
+	add("localhost")
+	for _, svc := range synthesizedComp.ComponentServices {
+		if svc.PodService != nil && *svc.PodService {
+			continue
+		}
+		addService(constant.GenerateComponentServiceName(synthesizedComp.ClusterName, synthesizedComp.Name, svc.ServiceName))
+	}
+	headlessSvcName := constant.GenerateDefaultComponentHeadlessServiceName(synthesizedComp.ClusterName, synthesizedComp.Name)
+	addService(headlessSvcName)
+	add(fmt.Sprintf("*.%s.%s.svc", headlessSvcName, namespace))
+	if domain != "" {
+		add(fmt.Sprintf("*.%s.%s.svc.%s", headlessSvcName, namespace, domain))
+	}
+	return names
+}
+
+// isCertificateSpecChanged checks whether the fields managed by KubeBlocks are changed,
+// the fields not set by KubeBlocks are kept as they are.
+func isCertificateSpecChanged(running, expected *unstructured.Unstructured) bool {
+	runningSpec, _, _ := unstructured.NestedMap(running.Object, "spec")
+	for k, v := range expected.Object["spec"].(map[string]interface{}) {
+		if !reflect.DeepEqual(runningSpec[k], v) {
+			return true
+		}
+	}
+	return false
+}
+
+func isCertificateReady(certificate *unstructured.Unstructured) bool {
+	if certificate == nil {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" && condition["status"] == string(corev1.ConditionTrue) {
+			return true
+		}
+	}
+	return false
+}
+
+// listCompCertificates lists the cert-manager Certificates owned by the component, nothing is returned if cert-manager is not installed.
+func listCompCertificates(transCtx graph.TransformContext, comp *appsv1.Component, matchLabels client.MatchingLabels) ([]*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(certManagerGroupVersion.WithKind(certManagerCertificateKind + "List"))
+	if err := transCtx.GetClient().List(transCtx.GetContext(), list, client.InNamespace(comp.Namespace), matchLabels, inDataContext4C()); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var certificates []*unstructured.Unstructured
+	for i := range list.Items {
+		if model.IsOwnerOf(comp, &list.Items[i]) {
+			certificates = append(certificates, &list.Items[i])
+		}
+	}
+	return certificates, nil
+}
+
+// cleanupCertManagerCerts deletes the Certificates which are not used by the component anymore,
+// e.g., the issuer is changed from CertManager to others.
+//
+// The Certificates are listed only if the component has used the CertManager issuer, which is recorded in the status.
+func cleanupCertManagerCerts(transCtx *componentTransformContext, dag *graph.DAG) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	tlsStatus := transCtx.ComponentOrig.Status.TLS
+	if tlsStatus == nil || tlsStatus.Issuer != appsv1.IssuerCertManager || isCertManagerIssued(synthesizedComp) {
+		return nil
+	}
+	certificates, err := listCompCertificates(transCtx, transCtx.Component,
+		constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name))
+	if err != nil {
+		return err
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	for _, certificate := range certificates {
+		graphCli.Delete(dag, certificate, inDataContext4G())
+	}
+	return nil
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
+
+func labelsToInterfaceMap(labels map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result
+}
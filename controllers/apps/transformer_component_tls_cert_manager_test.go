@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("cert-manager issuer", func() {
+	var (
+		comp            *appsv1.Component
+		synthesizedComp *component.SynthesizedComponent
+	)
+
+	BeforeEach(func() {
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-cluster-mysql",
+				UID:       "uid",
+			},
+		}
+		synthesizedComp = &component.SynthesizedComponent{
+			Namespace:   "default",
+			ClusterName: "test-cluster",
+			Name:        "mysql",
+			TLSConfig: &appsv1.TLSConfig{
+				Enable: true,
+				Issuer: &appsv1.Issuer{
+					Name: appsv1.IssuerCertManager,
+					IssuerRef: &appsv1.CertManagerIssuerRef{
+						Name: "corp-ca",
+						Kind: "ClusterIssuer",
+					},
+				},
+			},
+			ComponentServices: []appsv1.ComponentService{
+				{Service: appsv1.Service{Name: "default"}},
+				{Service: appsv1.Service{Name: "readonly", ServiceName: "ro"}},
+				{Service: appsv1.Service{Name: "pod", ServiceName: "pod"}, PodService: pointer.Bool(true)},
+			},
+		}
+	})
+
+	It("computes the SANs from the services and the pods", func() {
+		viper.Set(constant.KubernetesClusterDomainEnv, "cluster.local")
+		defer viper.Set(constant.KubernetesClusterDomainEnv, nil)
+
+		names := certificateDNSNames(synthesizedComp)
+		Expect(names).Should(ContainElements(
+			"localhost",
+			"test-cluster-mysql",
+			"test-cluster-mysql.default.svc.cluster.local",
+			"test-cluster-mysql-ro.default.svc",
+			"test-cluster-mysql-headless.default",
+			"*.test-cluster-mysql-headless.default.svc.cluster.local",
+		))
+		for _, name := range names {
+			Expect(name).ShouldNot(ContainSubstring("-pod"))
+		}
+	})
+
+	It("builds the certificate", func() {
+		certificate, err := buildCertManagerCertificate(comp, synthesizedComp)
+		Expect(err).Should(BeNil())
+		Expect(certificate.GetKind()).Should(Equal(certManagerCertificateKind))
+		Expect(certificate.GetName()).Should(Equal("test-cluster-mysql-tls-certs"))
+		Expect(certificate.GetOwnerReferences()).Should(HaveLen(1))
+
+		secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+		Expect(secretName).Should(Equal(certificate.GetName()))
+		issuerRef, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+		Expect(issuerRef).Should(Equal(map[string]string{"name": "corp-ca", "kind": "ClusterIssuer", "group": "cert-manager.io"}))
+		commonName, _, _ := unstructured.NestedString(certificate.Object, "spec", "commonName")
+		Expect(commonName).Should(Equal("test-cluster-mysql"))
+
+		By("the issuerRef is required")
+		synthesizedComp.TLSConfig.Issuer.IssuerRef = nil
+		_, err = buildCertManagerCertificate(comp, synthesizedComp)
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("checks the spec changes and the readiness", func() {
+		expected, err := buildCertManagerCertificate(comp, synthesizedComp)
+		Expect(err).Should(BeNil())
+
+		running := expected.DeepCopy()
+		Expect(unstructured.SetNestedField(running.Object, "2160h0m0s", "spec", "duration")).Should(Succeed())
+		Expect(isCertificateSpecChanged(running, expected)).Should(BeFalse())
+		Expect(isCertificateReady(running)).Should(BeFalse())
+
+		Expect(unstructured.SetNestedField(running.Object, "other-ca", "spec", "issuerRef", "name")).Should(Succeed())
+		Expect(isCertificateSpecChanged(running, expected)).Should(BeTrue())
+
+		Expect(unstructured.SetNestedSlice(running.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		}, "status", "conditions")).Should(Succeed())
+		Expect(isCertificateReady(running)).Should(BeTrue())
+	})
+
+	It("cleans up the certificates only if the component has used cert-manager", func() {
+		certificate, err := buildCertManagerCertificate(comp, synthesizedComp)
+		Expect(err).Should(BeNil())
+		graphCli := model.NewGraphClient(&mockReader{objs: []client.Object{certificate}})
+		dag := graph.NewDAG()
+		graphCli.Root(dag, comp, comp, model.ActionStatusPtr())
+		transCtx := &componentTransformContext{
+			Context:             ctx,
+			Client:              graphCli,
+			Component:           comp,
+			ComponentOrig:       comp.DeepCopy(),
+			SynthesizeComponent: synthesizedComp,
+		}
+		deletedCertificates := func() []client.Object {
+			return graphCli.FindAll(dag, &unstructured.Unstructured{})
+		}
+
+		By("the component is issued by cert-manager")
+		transCtx.ComponentOrig.Status.TLS = &appsv1.ComponentTLSStatus{Issuer: appsv1.IssuerCertManager}
+		Expect(cleanupCertManagerCerts(transCtx, dag)).Should(Succeed())
+		Expect(deletedCertificates()).Should(BeEmpty())
+
+		By("the component has never used cert-manager")
+		synthesizedComp.TLSConfig.Issuer.Name = appsv1.IssuerKubeBlocks
+		transCtx.ComponentOrig.Status.TLS = &appsv1.ComponentTLSStatus{Issuer: appsv1.IssuerKubeBlocks}
+		Expect(cleanupCertManagerCerts(transCtx, dag)).Should(Succeed())
+		Expect(deletedCertificates()).Should(BeEmpty())
+
+		By("the issuer is changed from cert-manager")
+		transCtx.ComponentOrig.Status.TLS = &appsv1.ComponentTLSStatus{Issuer: appsv1.IssuerCertManager}
+		Expect(cleanupCertManagerCerts(transCtx, dag)).Should(Succeed())
+		Expect(deletedCertificates()).Should(HaveLen(1))
+		Expect(graphCli.IsAction(dag, certificate, model.ActionDeletePtr())).Should(BeTrue())
+	})
+})
@@ -22,6 +22,7 @@ package apps
 import (
 	"context"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -41,6 +42,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("TLS self-signed cert function", func() {
@@ -258,13 +260,44 @@ var _ = Describe("TLS self-signed cert function", func() {
 			})
 
 			It("should skip if the existence of the secret is confirmed", func() {
-				err := buildTLSCert(ctx, k8sClient, synthesizedComp, dag)
+				_, err := buildTLSCert(ctx, k8sClient, synthesizedComp, dag)
 				Expect(err).Should(BeNil())
 				createdSecret := &corev1.Secret{}
 				err = k8sClient.Get(ctx, types.NamespacedName{Namespace: testCtx.DefaultNamespace, Name: kbTLSSecretObj.Name}, createdSecret)
 				Expect(err).Should(BeNil())
 				Expect(createdSecret.Data).To(Equal(kbTLSSecretObj.Data))
 			})
+
+			It("should renew the certificate before it expires", func() {
+				cert := []byte(kbTLSSecretObj.StringData[constant.CertName])
+				x509Cert, err := plan.ParseTLSCertificate(cert)
+				Expect(err).Should(BeNil())
+
+				viper.Set(constant.CfgKeyTLSCertRenewBefore, "720h")
+				defer viper.Set(constant.CfgKeyTLSCertRenewBefore, nil)
+				Expect(needRenewTLSCert(cert, time.Now())).Should(BeFalse())
+				Expect(needRenewTLSCert(cert, x509Cert.NotAfter.Add(-721*time.Hour))).Should(BeFalse())
+				Expect(needRenewTLSCert(cert, x509Cert.NotAfter.Add(-719*time.Hour))).Should(BeTrue())
+				Expect(needRenewTLSCert(cert, x509Cert.NotAfter.Add(time.Hour))).Should(BeTrue())
+				Expect(needRenewTLSCert([]byte("invalid"), time.Now())).Should(BeTrue())
+			})
+
+			It("should reuse the CA to renew the certificate", func() {
+				data := map[string][]byte{}
+				for k, v := range kbTLSSecretObj.StringData {
+					data[k] = []byte(v)
+				}
+				renewed, err := issueTLSSecret(synthesizedComp, data)
+				Expect(err).Should(BeNil())
+				Expect(renewed.StringData[constant.CAName]).Should(Equal(kbTLSSecretObj.StringData[constant.CAName]))
+				Expect(renewed.StringData[constant.CertName]).ShouldNot(Equal(kbTLSSecretObj.StringData[constant.CertName]))
+
+				By("a new CA is generated if the CA key is absent")
+				delete(data, constant.CAKeyName)
+				renewed, err = issueTLSSecret(synthesizedComp, data)
+				Expect(err).Should(BeNil())
+				Expect(renewed.StringData[constant.CAName]).ShouldNot(Equal(kbTLSSecretObj.StringData[constant.CAName]))
+			})
 		})
 	})
 })
@@ -21,8 +21,12 @@ package apps
 
 import (
 	"fmt"
+	"slices"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 )
 
@@ -50,6 +54,9 @@ func (t *componentValidationTransformer) Transform(ctx graph.TransformContext, d
 	if err = validateCompReplicas(comp, transCtx.CompDef); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
+	if err = validateZoneDistribution(transCtx, comp); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
 	// if err = validateSidecarContainers(comp, transCtx.CompDef); err != nil {
 	// 	return newRequeueError(requeueDuration, err.Error())
 	// }
@@ -70,6 +77,44 @@ func validateCompReplicas(comp *appsv1.Component, compDef *appsv1.ComponentDefin
 	return replicasOutOfLimitError(replicas, *replicasLimit)
 }
 
+func validateZoneDistribution(transCtx *componentTransformContext, comp *appsv1.Component) error {
+	if comp.Spec.ZoneDistribution != nil {
+		if _, err := component.BuildInstanceTemplates(comp); err != nil {
+			return err
+		}
+	}
+	return validateZoneDistributionSwitch(transCtx, comp)
+}
+
+// validateZoneDistributionSwitch refuses to enable or disable the zone distribution of a provisioned component,
+// since the pods are named after the instance templates generated for the zones, all of them would be renamed
+// and re-created with new volumes.
+func validateZoneDistributionSwitch(transCtx *componentTransformContext, comp *appsv1.Component) error {
+	clusterName, err := component.GetClusterName(comp)
+	if err != nil {
+		return err
+	}
+	compName, err := component.ShortName(clusterName, comp.Name)
+	if err != nil {
+		return err
+	}
+	objs, err := component.ListOwnedWorkloads(transCtx.Context, transCtx.Client, comp.Namespace, clusterName, compName)
+	if err != nil || len(objs) == 0 {
+		return err
+	}
+	zoned := slices.ContainsFunc(objs[0].Spec.Instances, func(tpl workloads.InstanceTemplate) bool {
+		_, ok := tpl.Labels[constant.KBAppZoneLabelKey]
+		return ok
+	})
+	switch {
+	case !zoned && comp.Spec.ZoneDistribution != nil:
+		return fmt.Errorf("the zone distribution can't be enabled on the provisioned component, the pods would be renamed")
+	case zoned && comp.Spec.ZoneDistribution == nil:
+		return fmt.Errorf("the zone distribution can't be disabled on the provisioned component, the pods would be renamed")
+	}
+	return nil
+}
+
 func replicasOutOfLimitError(replicas int32, replicasLimit appsv1.ReplicasLimit) error {
 	return fmt.Errorf("replicas %d out-of-limit [%d, %d]", replicas, replicasLimit.MinReplicas, replicasLimit.MaxReplicas)
 }
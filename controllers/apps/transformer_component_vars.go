@@ -23,7 +23,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 
+	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
@@ -59,6 +61,10 @@ func (t *componentVarsTransformer) Transform(ctx graph.TransformContext, dag *gr
 	reader := &varsReader{transCtx.Client, graphCli, dag}
 	synthesizedComp := transCtx.SynthesizeComponent
 
+	if err := createOrUpdateServiceRefSecrets(transCtx, dag); err != nil {
+		return err
+	}
+
 	templateVars, envVars, err := component.ResolveTemplateNEnvVars(transCtx.Context, reader,
 		synthesizedComp, transCtx.CompDef.Spec.Vars)
 	if err != nil {
@@ -68,6 +74,9 @@ func (t *componentVarsTransformer) Transform(ctx graph.TransformContext, dag *gr
 	// pass all direct value env vars through CM
 	envVars2, envData := buildEnvVarsNData(envVars)
 	setTemplateNEnvVars(synthesizedComp, templateVars, envVars2)
+	if err = rollPods4RotatedPasswords(transCtx); err != nil {
+		return err
+	}
 
 	if err := createOrUpdateEnvConfigMap(ctx, dag, envData); err != nil {
 		return err
@@ -147,6 +156,42 @@ func createOrUpdateEnvConfigMap(ctx graph.TransformContext, dag *graph.DAG, data
 	return nil
 }
 
+// createOrUpdateServiceRefSecrets materializes the credentials copied from the service-refs to clusters in other contexts,
+// which are referenced by the env vars of the component.
+func createOrUpdateServiceRefSecrets(transCtx *componentTransformContext, dag *graph.DAG) error {
+	var (
+		synthesizedComp = transCtx.SynthesizeComponent
+		graphCli, _     = transCtx.Client.(model.GraphClient)
+	)
+	names := maps.Keys(synthesizedComp.ServiceReferenceSecrets)
+	slices.Sort(names)
+	for _, name := range names {
+		secret := synthesizedComp.ServiceReferenceSecrets[name]
+		obj := &corev1.Secret{}
+		err := transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(secret), obj, inDataContext4C())
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err != nil { // not-found
+			obj = builder.NewSecretBuilder(secret.Namespace, secret.Name).
+				AddLabelsInMap(constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+				AddLabelsInMap(synthesizedComp.StaticLabels).
+				AddAnnotationsInMap(synthesizedComp.StaticAnnotations).
+				SetData(secret.Data).
+				GetObject()
+			if err = setCompOwnershipNFinalizer(transCtx.Component, obj); err != nil {
+				return err
+			}
+			graphCli.Create(dag, obj, inDataContext4G())
+		} else if !reflect.DeepEqual(obj.Data, secret.Data) {
+			objCopy := obj.DeepCopy()
+			objCopy.Data = secret.Data
+			graphCli.Update(dag, obj, objCopy, inDataContext4G())
+		}
+	}
+	return nil
+}
+
 type varsReader struct {
 	cli      client.Reader
 	graphCli model.GraphClient
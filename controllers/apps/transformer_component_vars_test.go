@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var _ = Describe("vars transformer test", func() {
+	It("materializes the credentials of the service-refs to clusters in other contexts", func() {
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName("test-cluster", "comp"),
+			},
+		}
+		secretName := constant.GenerateServiceRefCredentialSecretName("test-cluster", "comp", "etcd")
+		staleSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      secretName,
+			},
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte("root"),
+				constant.AccountPasswdForSecret: []byte("stale"),
+			},
+		}
+		synthesizedComp := &component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: "test-cluster",
+			Name:        "comp",
+			ServiceReferenceSecrets: map[string]*corev1.Secret{
+				"etcd": {
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testCtx.DefaultNamespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{
+						constant.AccountNameForSecret:   []byte("root"),
+						constant.AccountPasswdForSecret: []byte("password"),
+					},
+				},
+			},
+		}
+		newTransCtx := func(objs ...client.Object) (*componentTransformContext, *graph.DAG) {
+			graphCli := model.NewGraphClient(&mockReader{objs: objs})
+			dag := graph.NewDAG()
+			graphCli.Root(dag, comp, comp, model.ActionStatusPtr())
+			return &componentTransformContext{
+				Context:             ctx,
+				Client:              graphCli,
+				Logger:              logger,
+				Component:           comp,
+				ComponentOrig:       comp.DeepCopy(),
+				SynthesizeComponent: synthesizedComp,
+			}, dag
+		}
+		secretVertex := func(dag *graph.DAG) *model.ObjectVertex {
+			for _, v := range dag.Vertices() {
+				if vertex := v.(*model.ObjectVertex); vertex.Obj.GetName() == secretName {
+					return vertex
+				}
+			}
+			return nil
+		}
+
+		By("create the secret owned by the component")
+		transCtx, dag := newTransCtx()
+		Expect(createOrUpdateServiceRefSecrets(transCtx, dag)).Should(Succeed())
+		vertex := secretVertex(dag)
+		Expect(vertex).ShouldNot(BeNil())
+		Expect(*vertex.Action).Should(Equal(model.CREATE))
+		Expect(vertex.Obj.(*corev1.Secret).Data).Should(Equal(synthesizedComp.ServiceReferenceSecrets["etcd"].Data))
+		Expect(metav1.IsControlledBy(vertex.Obj, comp)).Should(BeTrue())
+
+		By("update the secret once the referenced credential is changed")
+		transCtx, dag = newTransCtx(staleSecret)
+		Expect(createOrUpdateServiceRefSecrets(transCtx, dag)).Should(Succeed())
+		vertex = secretVertex(dag)
+		Expect(vertex).ShouldNot(BeNil())
+		Expect(*vertex.Action).Should(Equal(model.UPDATE))
+		Expect(vertex.Obj.(*corev1.Secret).Data[constant.AccountPasswdForSecret]).Should(Equal([]byte("password")))
+	})
+})
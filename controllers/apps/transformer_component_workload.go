@@ -26,6 +26,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"golang.org/x/exp/maps"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubectl/pkg/util/podutils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -44,6 +46,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -205,6 +208,11 @@ func (t *componentWorkloadTransformer) handleWorkloadUpdate(reqCtx intctrlutil.R
 		return err
 	}
 
+	// keep the leader in the primary zone
+	if err := cwo.switchover4PrimaryZone(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -624,30 +632,13 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 	if err != nil {
 		return err
 	}
-	isLeader := func(pod *corev1.Pod) bool {
-		if pod == nil || len(pod.Labels) == 0 {
-			return false
-		}
-		roleName, ok := pod.Labels[constant.RoleLabelKey]
-		if !ok {
-			return false
-		}
-
-		for _, replicaRole := range r.runningITS.Spec.Roles {
-			if roleName == replicaRole.Name && replicaRole.IsLeader {
-				return true
-			}
-		}
-		return false
-	}
-
 	tryToSwitchover := func(lfa lifecycle.Lifecycle, pod *corev1.Pod) error {
 		// if pod is not leader/primary, no need to switchover
-		if !isLeader(pod) {
+		if !r.isLeader(pod) {
 			return nil
 		}
 		// if HA functionality is not enabled, no need to switchover
-		err := lfa.Switchover(r.reqCtx.Ctx, r.cli, nil, "")
+		err := lfa.Switchover(r.reqCtx.Ctx, r.cli, nil, r.switchoverCandidate(pods))
 		if err != nil && errors.Is(err, lifecycle.ErrActionNotDefined) {
 			return nil
 		}
@@ -667,7 +658,7 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 		podsToMemberLeave = append(podsToMemberLeave, pod)
 	}
 	for _, pod := range podsToMemberLeave {
-		if !(isLeader(pod) || // if the pod is leader, it needs to call switchover
+		if !(r.isLeader(pod) || // if the pod is leader, it needs to call switchover
 			(r.synthesizeComp.LifecycleActions != nil && r.synthesizeComp.LifecycleActions.MemberLeave != nil)) { // if the memberLeave action is defined, it needs to call it
 			continue
 		}
@@ -694,6 +685,89 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 	return err // TODO: use requeue-after
 }
 
+func (r *componentWorkloadOps) isLeader(pod *corev1.Pod) bool {
+	if pod == nil || len(pod.Labels) == 0 {
+		return false
+	}
+	roleName, ok := pod.Labels[constant.RoleLabelKey]
+	if !ok {
+		return false
+	}
+
+	for _, replicaRole := range r.runningITS.Spec.Roles {
+		if roleName == replicaRole.Name && replicaRole.IsLeader {
+			return true
+		}
+	}
+	return false
+}
+
+// switchover4PrimaryZone switches the leader over to a pod in the primary zone if it is placed in other zones,
+// e.g., after a failover. It is done only when the workload is steady: not scaling, and all the pods are ready.
+func (r *componentWorkloadOps) switchover4PrimaryZone() error {
+	zd := r.synthesizeComp.ZoneDistribution
+	if zd == nil || len(zd.PrimaryZone) == 0 {
+		return nil
+	}
+	if r.synthesizeComp.LifecycleActions == nil || r.synthesizeComp.LifecycleActions.Switchover == nil {
+		return nil
+	}
+	if !r.runningItsPodNameSet.Equal(r.desiredCompPodNameSet) || !instanceset.IsInstanceSetReady(r.runningITS) {
+		return nil
+	}
+
+	pods, err := component.ListOwnedPods(r.reqCtx.Ctx, r.cli, r.cluster.Namespace, r.cluster.Name, r.synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	var leader *corev1.Pod
+	for _, pod := range pods {
+		if r.isLeader(pod) {
+			leader = pod
+			break
+		}
+	}
+	if leader == nil || leader.Labels[constant.KBAppZoneLabelKey] == zd.PrimaryZone {
+		return nil
+	}
+	candidate := r.switchoverCandidate(pods)
+	if len(candidate) == 0 {
+		return nil
+	}
+
+	lfa, err := lifecycle.New(r.synthesizeComp, leader, pods...)
+	if err != nil {
+		return err
+	}
+	if err = lfa.Switchover(r.reqCtx.Ctx, r.cli, nil, candidate); err != nil {
+		if errors.Is(err, lifecycle.ErrActionNotDefined) {
+			return nil
+		}
+		return err
+	}
+	r.reqCtx.Recorder.Eventf(r.cluster, corev1.EventTypeNormal, "Switchover",
+		"switch the leader %s of component %s over to %s in the primary zone %s", leader.Name, r.synthesizeComp.Name, candidate, zd.PrimaryZone)
+	return intctrlutil.NewDelayedRequeueError(time.Second, "switchover to the primary zone succeed, wait role label to be updated")
+}
+
+// switchoverCandidate returns a remaining pod in the primary zone as the candidate of switchover if the primary zone
+// is specified, otherwise an empty candidate is returned and the decision is left to the switchover action.
+func (r *componentWorkloadOps) switchoverCandidate(pods []*corev1.Pod) string {
+	zd := r.synthesizeComp.ZoneDistribution
+	if zd == nil || len(zd.PrimaryZone) == 0 {
+		return ""
+	}
+	for _, pod := range pods {
+		if _, ok := r.desiredCompPodNameSet[pod.Name]; !ok {
+			continue
+		}
+		if pod.Labels[constant.KBAppZoneLabelKey] == zd.PrimaryZone && podutils.IsPodReady(pod) {
+			return pod.Name
+		}
+	}
+	return ""
+}
+
 func (r *componentWorkloadOps) deletePVCs4ScaleIn(itsObj *workloads.InstanceSet) error {
 	graphCli := model.NewGraphClient(r.cli)
 	for _, podName := range r.runningItsPodNames {
@@ -421,6 +421,9 @@ func (r *BackupReconciler) prepareBackupRequest(
 		if secretKeyRef == nil {
 			return nil, fmt.Errorf("encryptionConfig.passPhraseSecretKeyRef if empty")
 		}
+		if err := syncSecretKeyRefFromStore(reqCtx, r.Client, request.Namespace, secretKeyRef); err != nil {
+			return nil, fmt.Errorf("failed to sync encryption key from the secret store: %w", err)
+		}
 		err := checkSecretKeyRef(reqCtx, r.Client, request.Namespace, secretKeyRef)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check encryption key reference: %w", err)
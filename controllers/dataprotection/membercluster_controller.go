@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// MemberClusterReconciler registers the member clusters to the multi-cluster manager of the data protection.
+//
+// The member clusters are probed by the KubeBlocks operator, the reconciler only follows the phase of them
+// to mark the member clusters as available or unavailable.
+type MemberClusterReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	MultiClusterMgr multicluster.Manager
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=memberclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+func (r *MemberClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("memberCluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	memberCluster := &appsv1.MemberCluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, memberCluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		memberCluster = nil
+	}
+	if memberCluster == nil || !memberCluster.GetDeletionTimestamp().IsZero() {
+		if err := r.MultiClusterMgr.Unregister(req.Name); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	config, err := multicluster.MemberClusterConfig(reqCtx.Ctx, r.Client, memberCluster)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.MultiClusterMgr.Register(memberCluster.Name, config, memberCluster.Spec.Disabled); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	r.MultiClusterMgr.SetAvailable(memberCluster.Name, memberCluster.Status.Phase != appsv1.UnavailableMemberClusterPhase)
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MemberClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.MemberCluster{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.filterKubeConfigSecret)).
+		Complete(r)
+}
+
+func (r *MemberClusterReconciler) filterKubeConfigSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	memberClusters := &appsv1.MemberClusterList{}
+	if err := r.Client.List(ctx, memberClusters); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, memberCluster := range memberClusters.Items {
+		ref := memberCluster.Spec.KubeConfigSecretRef
+		if ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&memberCluster)})
+		}
+	}
+	return requests
+}
@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package dataprotection
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -43,12 +45,14 @@ import (
 	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -479,6 +483,48 @@ func checkSecretKeyRef(reqCtx intctrlutil.RequestCtx, cli client.Client,
 	return nil
 }
 
+// syncSecretKeyRefFromStore syncs the key referred by the secret key selector from the external secret store
+// into the Secret, so that it can be referenced by the workloads. The Secret is left untouched if the store is
+// not configured or the key is not kept in the store.
+func syncSecretKeyRefFromStore(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	namespace string, ref *corev1.SecretKeySelector) error {
+	if ref == nil || !secretstore.IsEnabled() {
+		return nil
+	}
+	store, err := secretstore.New()
+	if err != nil {
+		return err
+	}
+	data, err := store.Get(reqCtx.Ctx, secretstore.SecretPath(namespace, ref.Name))
+	if err != nil {
+		if errors.Is(err, secretstore.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	value, ok := data[ref.Key]
+	if !ok {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		secret = builder.NewSecretBuilder(namespace, ref.Name).PutData(ref.Key, value).GetObject()
+		return cli.Create(reqCtx.Ctx, secret)
+	}
+	if bytes.Equal(secret.Data[ref.Key], value) {
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[ref.Key] = value
+	return cli.Patch(reqCtx.Ctx, secret, patch)
+}
+
 // ============================================================================
 // refObjectMapper
 // ============================================================================
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const metricsQueryTimeout = 10 * time.Second
+
+// MetricsClient reads the metrics that the ComponentAutoscaler scales by.
+type MetricsClient interface {
+	// PodUsages returns the resource usages of the pods selected, keyed by the pod name.
+	// The usage of a pod is the sum of the usages of its containers.
+	PodUsages(ctx context.Context, namespace string, selector labels.Selector) (map[string]corev1.ResourceList, error)
+
+	// Query evaluates a PromQL query against the Prometheus endpoint and returns the values of the samples.
+	Query(ctx context.Context, endpoint, query string) ([]float64, error)
+
+	// VolumeUsages returns the usages of the volumes claimed in the namespace, keyed by the PVC name.
+	// They are read from the kubelet_volume_stats_* metrics of the kubelets scraped by the Prometheus endpoint.
+	VolumeUsages(ctx context.Context, endpoint, namespace string) (map[string]VolumeUsage, error)
+}
+
+// VolumeUsage is the usage of a volume.
+type VolumeUsage struct {
+	UsedBytes     int64
+	CapacityBytes int64
+}
+
+type metricsClientImpl struct {
+	cli metricsclient.Interface
+}
+
+// NewMetricsClient creates a MetricsClient which reads the resource usages from the Kubernetes metrics API,
+// and the others from the Prometheus endpoints.
+func NewMetricsClient(config *rest.Config) (MetricsClient, error) {
+	cli, err := metricsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsClientImpl{cli: cli}, nil
+}
+
+func (c *metricsClientImpl) PodUsages(ctx context.Context, namespace string, selector labels.Selector) (map[string]corev1.ResourceList, error) {
+	ctx, cancel := context.WithTimeout(ctx, metricsQueryTimeout)
+	defer cancel()
+	metricsList, err := c.cli.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	usages := make(map[string]corev1.ResourceList, len(metricsList.Items))
+	for _, m := range metricsList.Items {
+		usage := corev1.ResourceList{}
+		for _, container := range m.Containers {
+			for name, quantity := range container.Usage {
+				sum := usage[name]
+				sum.Add(quantity)
+				usage[name] = sum
+			}
+		}
+		usages[m.Name] = usage
+	}
+	return usages, nil
+}
+
+func (c *metricsClientImpl) Query(ctx context.Context, endpoint, query string) ([]float64, error) {
+	value, err := c.query(ctx, endpoint, query)
+	if err != nil {
+		return nil, err
+	}
+	return sampleValues(value)
+}
+
+func (c *metricsClientImpl) VolumeUsages(ctx context.Context, endpoint, namespace string) (map[string]VolumeUsage, error) {
+	used, err := c.volumeStats(ctx, endpoint, "kubelet_volume_stats_used_bytes", namespace)
+	if err != nil {
+		return nil, err
+	}
+	capacity, err := c.volumeStats(ctx, endpoint, "kubelet_volume_stats_capacity_bytes", namespace)
+	if err != nil {
+		return nil, err
+	}
+	usages := map[string]VolumeUsage{}
+	for pvc, usedBytes := range used {
+		if capacityBytes, ok := capacity[pvc]; ok {
+			usages[pvc] = VolumeUsage{UsedBytes: int64(usedBytes), CapacityBytes: int64(capacityBytes)}
+		}
+	}
+	return usages, nil
+}
+
+// volumeStats returns the values of the volume stats metric of the PVCs in the namespace, keyed by the PVC name.
+func (c *metricsClientImpl) volumeStats(ctx context.Context, endpoint, metric, namespace string) (map[string]float64, error) {
+	value, err := c.query(ctx, endpoint, fmt.Sprintf("max by (persistentvolumeclaim) (%s{namespace=%q})", metric, namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", metric, err)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unsupported result type of %s: %s", metric, value.Type())
+	}
+	stats := map[string]float64{}
+	for _, sample := range vector {
+		pvc := string(sample.Metric["persistentvolumeclaim"])
+		if f := float64(sample.Value); len(pvc) > 0 && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			stats[pvc] = f
+		}
+	}
+	return stats, nil
+}
+
+func (c *metricsClientImpl) query(ctx context.Context, endpoint, query string) (model.Value, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("no Prometheus endpoint is configured")
+	}
+	cli, err := promapi.NewClient(promapi.Config{Address: endpoint})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, metricsQueryTimeout)
+	defer cancel()
+	value, _, err := promv1.NewAPI(cli).Query(ctx, query, time.Now())
+	return value, err
+}
+
+// prometheusEndpoint returns the Prometheus endpoint of the autoscaler, or the one configured for the controller manager.
+func prometheusEndpoint(autoscaler *experimental.ComponentAutoscaler) string {
+	if len(autoscaler.Spec.PrometheusEndpoint) > 0 {
+		return autoscaler.Spec.PrometheusEndpoint
+	}
+	return viper.GetString(constant.CfgKBAutoscalerPrometheusEndpoint)
+}
+
+// sampleValues extracts the valid values from the result of a PromQL query.
+func sampleValues(value model.Value) ([]float64, error) {
+	var values []float64
+	appendValue := func(v model.SampleValue) {
+		f := float64(v)
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			values = append(values, f)
+		}
+	}
+	switch v := value.(type) {
+	case *model.Scalar:
+		appendValue(v.Value)
+	case model.Vector:
+		for _, sample := range v {
+			appendValue(sample.Value)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported result type of the query: %s", value.Type())
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("the query yields no valid sample")
+	}
+	return values, nil
+}
+
+var _ MetricsClient = &metricsClientImpl{}
@@ -0,0 +1,65 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("autoscaler metrics test", func() {
+	It("reads the volume usages from the Prometheus endpoint", func() {
+		var queries []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).Should(Succeed())
+			query := r.Form.Get("query")
+			queries = append(queries, query)
+			value := "30"
+			if strings.Contains(query, "capacity") {
+				value = "100"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[`+
+				`{"metric":{"persistentvolumeclaim":"data-mysql-0"},"value":[1700000000,"%s"]},`+
+				`{"metric":{"persistentvolumeclaim":"data-mysql-1"},"value":[1700000000,"NaN"]}]}}`, value)
+		}))
+		defer server.Close()
+
+		usages, err := (&metricsClientImpl{}).VolumeUsages(context.Background(), server.URL, "default")
+		Expect(err).Should(Succeed())
+		Expect(usages).Should(Equal(map[string]VolumeUsage{
+			"data-mysql-0": {UsedBytes: 30, CapacityBytes: 100},
+		}))
+		Expect(queries).Should(ConsistOf(
+			`max by (persistentvolumeclaim) (kubelet_volume_stats_used_bytes{namespace="default"})`,
+			`max by (persistentvolumeclaim) (kubelet_volume_stats_capacity_bytes{namespace="default"})`,
+		))
+
+		By("fail without the endpoint")
+		_, err = (&metricsClientImpl{}).VolumeUsages(context.Background(), "", "default")
+		Expect(err).ShouldNot(Succeed())
+	})
+})
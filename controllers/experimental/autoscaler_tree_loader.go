@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// autoscalerLabelKey labels the OpsRequests created by a ComponentAutoscaler with its name.
+const autoscalerLabelKey = "experimental.kubeblocks.io/component-autoscaler"
+
+type autoscalerTreeLoader struct{}
+
+func (t *autoscalerTreeLoader) Load(ctx context.Context, reader client.Reader, req ctrl.Request, recorder record.EventRecorder, logger logr.Logger) (*kubebuilderx.ObjectTree, error) {
+	tree, err := kubebuilderx.ReadObjectTree[*experimental.ComponentAutoscaler](ctx, reader, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	root := tree.GetRoot()
+	if root == nil {
+		return tree, nil
+	}
+	autoscaler, _ := root.(*experimental.ComponentAutoscaler)
+	key := types.NamespacedName{Namespace: autoscaler.Namespace, Name: autoscaler.Spec.TargetClusterName}
+	cluster := &appsv1.Cluster{}
+	if err = reader.Get(ctx, key, cluster); err != nil {
+		// the cluster may be deleted before the autoscaler, which has nothing to release then
+		if !apierrors.IsNotFound(err) || !model.IsObjectDeleting(autoscaler) {
+			return nil, err
+		}
+		tree.EventRecorder = recorder
+		tree.Logger = logger
+		return tree, nil
+	}
+	if err = tree.Add(cluster); err != nil {
+		return nil, err
+	}
+
+	// the Component and its definition tell the ReplicasLimit, they may not be created or resolved yet
+	key.Name = constant.GenerateClusterComponentName(autoscaler.Spec.TargetClusterName, autoscaler.Spec.TargetComponentName)
+	comp := &appsv1.Component{}
+	if err = reader.Get(ctx, key, comp); err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err = tree.Add(comp); err != nil {
+			return nil, err
+		}
+		if len(comp.Spec.CompDef) > 0 {
+			compDef := &appsv1.ComponentDefinition{}
+			if err = reader.Get(ctx, types.NamespacedName{Name: comp.Spec.CompDef}, compDef); err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			if err == nil {
+				if err = tree.Add(compDef); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	inNS := client.InNamespace(autoscaler.Namespace)
+	podList := &corev1.PodList{}
+	ml := client.MatchingLabels(constant.GetCompLabels(autoscaler.Spec.TargetClusterName, autoscaler.Spec.TargetComponentName))
+	if err = reader.List(ctx, podList, inNS, ml); err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if err = tree.Add(&podList.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if autoscaler.Spec.Storage != nil {
+		if err = loadVolumes(ctx, reader, tree, inNS, ml); err != nil {
+			return nil, err
+		}
+	}
+
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err = reader.List(ctx, opsList, inNS, client.MatchingLabels{autoscalerLabelKey: autoscaler.Name}); err != nil {
+		return nil, err
+	}
+	for i := range opsList.Items {
+		if err = tree.Add(&opsList.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	tree.EventRecorder = recorder
+	tree.Logger = logger
+
+	return tree, nil
+}
+
+// loadVolumes loads the PVCs of the Component and the StorageClasses they use.
+func loadVolumes(ctx context.Context, reader client.Reader, tree *kubebuilderx.ObjectTree, opts ...client.ListOption) error {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := reader.List(ctx, pvcList, opts...); err != nil {
+		return err
+	}
+	classNames := map[string]bool{}
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if err := tree.Add(pvc); err != nil {
+			return err
+		}
+		if pvc.Spec.StorageClassName != nil && len(*pvc.Spec.StorageClassName) > 0 {
+			classNames[*pvc.Spec.StorageClassName] = true
+		}
+	}
+	for className := range classNames {
+		storageClass := &storagev1.StorageClass{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: className}, storageClass); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := tree.Add(storageClass); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func autoscalerObjectTree() kubebuilderx.TreeLoader {
+	return &autoscalerTreeLoader{}
+}
+
+var _ kubebuilderx.TreeLoader = &autoscalerTreeLoader{}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+// componentScheduling returns a pod carrying the scheduling constraints of the component.
+// The pod template of the InstanceSet is used as it is synthesized from the Cluster and the ComponentDefinition,
+// and it falls back to the scheduling policy of the Cluster if the InstanceSet is not created yet.
+func componentScheduling(tree *kubebuilderx.ObjectTree, scaler *experimental.NodeCountScaler, compName string) *corev1.Pod {
+	pod := &corev1.Pod{}
+	itsName := constant.GenerateClusterComponentName(scaler.Spec.TargetClusterName, compName)
+	object, _ := tree.Get(builder.NewInstanceSetBuilder(scaler.Namespace, itsName).GetObject())
+	if its, ok := object.(*workloads.InstanceSet); ok && its != nil {
+		pod.Spec = *its.Spec.Template.Spec.DeepCopy()
+		return pod
+	}
+	object, _ = tree.Get(builder.NewClusterBuilder(scaler.Namespace, scaler.Spec.TargetClusterName).GetObject())
+	cluster, ok := object.(*appsv1.Cluster)
+	if !ok || cluster == nil {
+		return pod
+	}
+	for _, spec := range cluster.Spec.ComponentSpecs {
+		if spec.Name != compName || spec.SchedulingPolicy == nil {
+			continue
+		}
+		pod.Spec.NodeSelector = spec.SchedulingPolicy.NodeSelector
+		pod.Spec.Affinity = spec.SchedulingPolicy.Affinity
+		pod.Spec.Tolerations = spec.SchedulingPolicy.Tolerations
+	}
+	return pod
+}
+
+// matchedNodes returns the nodes that the instances of the component can be scheduled to.
+func matchedNodes(tree *kubebuilderx.ObjectTree, scaler *experimental.NodeCountScaler, compName string) ([]*corev1.Node, error) {
+	selector := labels.Everything()
+	if scaler.Spec.NodeSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(scaler.Spec.NodeSelector); err != nil {
+			return nil, fmt.Errorf("invalid node selector: %w", err)
+		}
+	}
+	pod := componentScheduling(tree, scaler, compName)
+	affinity := nodeaffinity.GetRequiredNodeAffinity(pod)
+	untolerated := func(taint *corev1.Taint) bool {
+		return taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute
+	}
+
+	var nodes []*corev1.Node
+	for _, object := range tree.List(&corev1.Node{}) {
+		node, _ := object.(*corev1.Node)
+		if node == nil || node.Spec.Unschedulable || !isNodeReady(node) {
+			continue
+		}
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		matched, err := affinity.Match(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node affinity of component %s: %w", compName, err)
+		}
+		if !matched {
+			continue
+		}
+		if _, found := corev1helpers.FindMatchingUntoleratedTaint(node.Spec.Taints, pod.Spec.Tolerations, untolerated); found {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	return slices.ContainsFunc(node.Status.Conditions, func(condition corev1.NodeCondition) bool {
+		return condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue
+	})
+}
+
+// componentDesiredReplicas returns the desired replicas of the component and the number of its matched nodes.
+func componentDesiredReplicas(tree *kubebuilderx.ObjectTree, scaler *experimental.NodeCountScaler, compName string) (int32, int32, error) {
+	nodes, err := matchedNodes(tree, scaler, compName)
+	if err != nil {
+		return 0, 0, err
+	}
+	matched := int32(len(nodes))
+	replicas := matched
+	if scaler.Spec.MaxReplicas != nil && replicas > *scaler.Spec.MaxReplicas {
+		replicas = *scaler.Spec.MaxReplicas
+	}
+	if scaler.Spec.MinReplicas != nil && replicas < *scaler.Spec.MinReplicas {
+		replicas = *scaler.Spec.MinReplicas
+	}
+	return replicas, matched, nil
+}
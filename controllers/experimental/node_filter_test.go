@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimentalv1alpha1 "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("node filter test", func() {
+	newNode := func(name string, labels map[string]string, ready bool, taints ...corev1.Taint) *corev1.Node {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		}
+	}
+	proxyTaint := corev1.Taint{Key: "dedicated", Value: "proxy", Effect: corev1.TaintEffectNoSchedule}
+
+	BeforeEach(func() {
+		tree = mockTestTree()
+		unschedulable := newNode("node-unschedulable", nil, true)
+		unschedulable.Spec.Unschedulable = true
+		Expect(tree.Add(
+			newNode("node-not-ready", nil, false),
+			unschedulable,
+			newNode("node-proxy-0", map[string]string{"role": "proxy"}, true, proxyTaint),
+			newNode("node-proxy-1", map[string]string{"role": "proxy"}, true, proxyTaint),
+			newNode("node-proxy-2", map[string]string{"role": "proxy"}, true, proxyTaint),
+		)).Should(Succeed())
+	})
+
+	getITS := func(compName string) *workloads.InstanceSet {
+		itsName := constant.GenerateClusterComponentName(clusterName, compName)
+		object, err := tree.Get(builder.NewInstanceSetBuilder(namespace, itsName).GetObject())
+		Expect(err).Should(BeNil())
+		return object.(*workloads.InstanceSet)
+	}
+
+	It("filters the not ready, unschedulable and tainted nodes", func() {
+		replicas, matched, err := componentDesiredReplicas(tree, ncs, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(2))
+		Expect(replicas).Should(BeEquivalentTo(2))
+	})
+
+	It("respects the tolerations and node selectors of the component", func() {
+		its := getITS(componentNames[0])
+		its.Spec.Template.Spec.Tolerations = []corev1.Toleration{{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "proxy",
+			Effect:   corev1.TaintEffectNoSchedule,
+		}}
+		_, matched, err := componentDesiredReplicas(tree, ncs, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(5))
+
+		its.Spec.Template.Spec.NodeSelector = map[string]string{"role": "proxy"}
+		_, matched, err = componentDesiredReplicas(tree, ncs, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(3))
+
+		its.Spec.Template.Spec.NodeSelector = nil
+		its.Spec.Template.Spec.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "role",
+							Operator: corev1.NodeSelectorOpDoesNotExist,
+						}},
+					}},
+				},
+			},
+		}
+		_, matched, err = componentDesiredReplicas(tree, ncs, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(2))
+	})
+
+	It("falls back to the scheduling policy of the cluster", func() {
+		Expect(tree.Delete(getITS(componentNames[1]))).Should(Succeed())
+		object, err := tree.Get(builder.NewClusterBuilder(namespace, clusterName).GetObject())
+		Expect(err).Should(BeNil())
+		cluster := object.(*appsv1.Cluster)
+		cluster.Spec.ComponentSpecs[1].SchedulingPolicy = &appsv1.SchedulingPolicy{
+			NodeSelector: map[string]string{"role": "proxy"},
+			Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+		}
+		_, matched, err := componentDesiredReplicas(tree, ncs, componentNames[1])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(3))
+	})
+
+	It("respects the node selector and replicas bounds of the scaler", func() {
+		getITS(componentNames[0]).Spec.Template.Spec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+		scaler := tree.GetRoot().(*experimentalv1alpha1.NodeCountScaler)
+		scaler.Spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"role": "proxy"}}
+		replicas, matched, err := componentDesiredReplicas(tree, scaler, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(3))
+		Expect(replicas).Should(BeEquivalentTo(3))
+
+		scaler.Spec.MaxReplicas = ptr.To[int32](2)
+		replicas, _, err = componentDesiredReplicas(tree, scaler, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(replicas).Should(BeEquivalentTo(2))
+
+		scaler.Spec.MaxReplicas = nil
+		scaler.Spec.MinReplicas = ptr.To[int32](5)
+		replicas, matched, err = componentDesiredReplicas(tree, scaler, componentNames[0])
+		Expect(err).Should(BeNil())
+		Expect(matched).Should(BeEquivalentTo(3))
+		Expect(replicas).Should(BeEquivalentTo(5))
+	})
+})
@@ -0,0 +1,448 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	defaultScaleUpStabilizationWindowSeconds   = 0
+	defaultScaleDownStabilizationWindowSeconds = 300
+	defaultScaleUpCooldownSeconds              = 60
+	defaultScaleDownCooldownSeconds            = 300
+
+	// maxRecommendations is the max number of the recommendations kept in the status.
+	maxRecommendations = 128
+
+	// autoscalingOpsTTLSeconds is the time to keep the completed OpsRequests created by the autoscalers.
+	autoscalingOpsTTLSeconds = 24 * 60 * 60
+)
+
+type autoscaleReconciler struct{}
+
+func (r *autoscaleReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ConditionUnsatisfied
+	}
+	return kubebuilderx.ConditionSatisfied
+}
+
+func (r *autoscaleReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
+	autoscaler, _ := tree.GetRoot().(*experimental.ComponentAutoscaler)
+	compSpec, err := targetComponentSpec(tree, autoscaler)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	history := autoscaler.Status.Recommendations
+	if len(history) == 0 {
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+	now := history[len(history)-1].Time.Time
+	autoscaler.Status.Recommendations = trimRecommendations(history, now.Add(-maxStabilizationWindow(autoscaler)))
+
+	// calculate the stabilized recommendations
+	var (
+		hScale *opsv1alpha1.HorizontalScaling
+		vScale *opsv1alpha1.VerticalScaling
+	)
+	scaleUp, scaleDown := false, false
+	if h := autoscaler.Spec.Horizontal; h != nil {
+		desired := stabilizeReplicas(history, now, compSpec.Replicas,
+			stabilizationWindow(h.ScaleUp, defaultScaleUpStabilizationWindowSeconds),
+			stabilizationWindow(h.ScaleDown, defaultScaleDownStabilizationWindowSeconds))
+		minReplicas, maxReplicas := replicasBounds(tree, h)
+		desired = max(min(desired, maxReplicas), minReplicas)
+		autoscaler.Status.DesiredReplicas = &desired
+		if desired != compSpec.Replicas {
+			hScale = buildHorizontalScaling(autoscaler.Spec.TargetComponentName, desired-compSpec.Replicas)
+			scaleUp, scaleDown = desired > compSpec.Replicas, desired < compSpec.Replicas
+		}
+	}
+	if v := autoscaler.Spec.Vertical; v != nil {
+		desired := corev1.ResourceList{}
+		resources := compSpec.Resources.DeepCopy()
+		changed := false
+		for _, policy := range v.Resources {
+			current := currentRequest(compSpec.Resources, policy.Name)
+			quantity, ok := stabilizeResource(history, policy.Name, now, current,
+				stabilizationWindow(v.ScaleUp, defaultScaleUpStabilizationWindowSeconds),
+				stabilizationWindow(v.ScaleDown, defaultScaleDownStabilizationWindowSeconds))
+			if !ok {
+				continue
+			}
+			desired[policy.Name] = quantity
+			if withinTolerance(current, quantity) {
+				continue
+			}
+			setRequest(resources, policy.Name, current, quantity)
+			changed = true
+			scaleUp = scaleUp || quantity.Cmp(current) > 0
+			scaleDown = scaleDown || quantity.Cmp(current) < 0
+		}
+		autoscaler.Status.DesiredResources = desired
+		if changed {
+			vScale = &opsv1alpha1.VerticalScaling{
+				ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: autoscaler.Spec.TargetComponentName},
+				ResourceRequirements: *resources,
+			}
+		}
+	}
+	if hScale == nil && vScale == nil {
+		setAbleToScale(autoscaler, metav1.ConditionTrue, experimental.ReasonReadyForNewScale, "the component is at the desired scale")
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+
+	// check whether a new scaling is allowed
+	for _, object := range tree.List(&opsv1alpha1.OpsRequest{}) {
+		ops, _ := object.(*opsv1alpha1.OpsRequest)
+		if !ops.IsComplete() {
+			setAbleToScale(autoscaler, metav1.ConditionFalse, experimental.ReasonOpsRequestInProgress,
+				fmt.Sprintf("waiting for OpsRequest %s to complete", ops.Name))
+			return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+		}
+	}
+	if autoscaler.Status.LastScaleTime != nil {
+		cooldown := time.Duration(0)
+		if scaleUp {
+			cooldown = max(cooldown, cooldownPeriod(autoscaler, true))
+		}
+		if scaleDown {
+			cooldown = max(cooldown, cooldownPeriod(autoscaler, false))
+		}
+		if remaining := autoscaler.Status.LastScaleTime.Add(cooldown).Sub(now); remaining > 0 {
+			setAbleToScale(autoscaler, metav1.ConditionFalse, experimental.ReasonCoolingDown,
+				fmt.Sprintf("cooling down for %s since the last scaling", remaining.Round(time.Second)))
+			return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+		}
+	}
+	inWindow, err := inMaintenanceWindows(autoscaler.Spec.MaintenanceWindows, now)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	if !inWindow {
+		setAbleToScale(autoscaler, metav1.ConditionFalse, experimental.ReasonOutOfMaintenanceWindow,
+			"waiting for the next maintenance window")
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+
+	// the horizontal scaling goes first, and the vertical scaling follows after it completes
+	var ops *opsv1alpha1.OpsRequest
+	if hScale != nil {
+		ops = buildScalingOpsRequest(autoscaler, now, opsv1alpha1.HorizontalScalingType)
+		ops.Spec.HorizontalScalingList = []opsv1alpha1.HorizontalScaling{*hScale}
+	} else {
+		ops = buildScalingOpsRequest(autoscaler, now, opsv1alpha1.VerticalScalingType)
+		ops.Spec.VerticalScalingList = []opsv1alpha1.VerticalScaling{*vScale}
+	}
+	if err = tree.Add(ops); err != nil {
+		return kubebuilderx.Continue, err
+	}
+	autoscaler.Status.LastScaleTime = &metav1.Time{Time: now}
+	autoscaler.Status.LastOpsRequestName = ops.Name
+	message := fmt.Sprintf("created %s OpsRequest %s", ops.Spec.Type, ops.Name)
+	setAbleToScale(autoscaler, metav1.ConditionTrue, experimental.ReasonScaling, message)
+	if tree.EventRecorder != nil {
+		tree.EventRecorder.Event(autoscaler, corev1.EventTypeNormal, experimental.ReasonScaling, message)
+	}
+	return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+}
+
+// stabilizeReplicas picks the lowest recommendation in the scale-up window and the highest one in the
+// scale-down window, and keeps the current replicas if they lie in between.
+func stabilizeReplicas(history []experimental.Recommendation, now time.Time, current int32, upWindow, downWindow time.Duration) int32 {
+	upRecommendation, downRecommendation := int32(math.MaxInt32), int32(math.MinInt32)
+	for _, rec := range history {
+		if rec.Replicas == nil {
+			continue
+		}
+		if !rec.Time.Time.Before(now.Add(-upWindow)) {
+			upRecommendation = min(upRecommendation, *rec.Replicas)
+		}
+		if !rec.Time.Time.Before(now.Add(-downWindow)) {
+			downRecommendation = max(downRecommendation, *rec.Replicas)
+		}
+	}
+	stabilized := current
+	if upRecommendation != math.MaxInt32 && stabilized < upRecommendation {
+		stabilized = upRecommendation
+	}
+	if downRecommendation != math.MinInt32 && stabilized > downRecommendation {
+		stabilized = downRecommendation
+	}
+	return stabilized
+}
+
+// stabilizeResource stabilizes the recommendations of a resource like stabilizeReplicas.
+func stabilizeResource(history []experimental.Recommendation, name corev1.ResourceName, now time.Time,
+	current resource.Quantity, upWindow, downWindow time.Duration) (resource.Quantity, bool) {
+	var upRecommendation, downRecommendation *resource.Quantity
+	for _, rec := range history {
+		quantity, ok := rec.Resources[name]
+		if !ok {
+			continue
+		}
+		if !rec.Time.Time.Before(now.Add(-upWindow)) && (upRecommendation == nil || quantity.Cmp(*upRecommendation) < 0) {
+			upRecommendation = &quantity
+		}
+		if !rec.Time.Time.Before(now.Add(-downWindow)) && (downRecommendation == nil || quantity.Cmp(*downRecommendation) > 0) {
+			downRecommendation = &quantity
+		}
+	}
+	if upRecommendation == nil && downRecommendation == nil {
+		return resource.Quantity{}, false
+	}
+	stabilized := current.DeepCopy()
+	if upRecommendation != nil && stabilized.Cmp(*upRecommendation) < 0 {
+		stabilized = *upRecommendation
+	}
+	if downRecommendation != nil && stabilized.Cmp(*downRecommendation) > 0 {
+		stabilized = *downRecommendation
+	}
+	return stabilized, true
+}
+
+// replicasBounds merges the bounds of the autoscaler and the ReplicasLimit of the ComponentDefinition.
+func replicasBounds(tree *kubebuilderx.ObjectTree, h *experimental.HorizontalAutoscaling) (int32, int32) {
+	minReplicas, maxReplicas := int32(1), h.MaxReplicas
+	if h.MinReplicas != nil {
+		minReplicas = *h.MinReplicas
+	}
+	for _, object := range tree.List(&appsv1.ComponentDefinition{}) {
+		compDef, _ := object.(*appsv1.ComponentDefinition)
+		if limit := compDef.Spec.ReplicasLimit; limit != nil {
+			minReplicas = max(minReplicas, limit.MinReplicas)
+			maxReplicas = min(maxReplicas, limit.MaxReplicas)
+		}
+	}
+	return minReplicas, max(minReplicas, maxReplicas)
+}
+
+func currentRequest(resources corev1.ResourceRequirements, name corev1.ResourceName) resource.Quantity {
+	if request, ok := resources.Requests[name]; ok {
+		return request
+	}
+	// the request defaults to the limit
+	return resources.Limits[name]
+}
+
+// setRequest sets the request of a resource, and scales the limit proportionally if both are set.
+func setRequest(resources *corev1.ResourceRequirements, name corev1.ResourceName, current, desired resource.Quantity) {
+	if resources.Requests == nil {
+		resources.Requests = corev1.ResourceList{}
+	}
+	resources.Requests[name] = desired
+	limit, ok := resources.Limits[name]
+	if !ok {
+		return
+	}
+	if !current.IsZero() {
+		ratio := float64(limit.MilliValue()) / float64(current.MilliValue())
+		limit = requestQuantity(name, desired.AsApproximateFloat64()*ratio)
+	}
+	if limit.Cmp(desired) < 0 {
+		limit = desired.DeepCopy()
+	}
+	resources.Limits[name] = limit
+}
+
+func withinTolerance(current, desired resource.Quantity) bool {
+	if current.IsZero() {
+		return desired.IsZero()
+	}
+	ratio := desired.AsApproximateFloat64() / current.AsApproximateFloat64()
+	return math.Abs(ratio-1) <= scalingTolerance
+}
+
+func buildHorizontalScaling(compName string, delta int32) *opsv1alpha1.HorizontalScaling {
+	hScale := &opsv1alpha1.HorizontalScaling{
+		ComponentOps: opsv1alpha1.ComponentOps{ComponentName: compName},
+	}
+	if delta > 0 {
+		hScale.ScaleOut = &opsv1alpha1.ScaleOut{ReplicaChanger: opsv1alpha1.ReplicaChanger{ReplicaChanges: &delta}}
+	} else {
+		delta = -delta
+		hScale.ScaleIn = &opsv1alpha1.ScaleIn{ReplicaChanger: opsv1alpha1.ReplicaChanger{ReplicaChanges: &delta}}
+	}
+	return hScale
+}
+
+func buildScalingOpsRequest(autoscaler *experimental.ComponentAutoscaler, now time.Time, opsType opsv1alpha1.OpsType) *opsv1alpha1.OpsRequest {
+	suffix := "hscale"
+	switch opsType {
+	case opsv1alpha1.VerticalScalingType:
+		suffix = "vscale"
+	case opsv1alpha1.VolumeExpansionType:
+		suffix = "volumeexpansion"
+	}
+	return &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: autoscaler.Namespace,
+			Name:      fmt.Sprintf("%s-%s-%d", autoscaler.Name, suffix, now.Unix()),
+			Labels: map[string]string{
+				autoscalerLabelKey:              autoscaler.Name,
+				constant.AppInstanceLabelKey:    autoscaler.Spec.TargetClusterName,
+				constant.KBAppComponentLabelKey: autoscaler.Spec.TargetComponentName,
+				constant.OpsRequestTypeLabelKey: string(opsType),
+				constant.AppManagedByLabelKey:   constant.AppName,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName:                           autoscaler.Spec.TargetClusterName,
+			Type:                                  opsType,
+			TTLSecondsAfterSucceed:                autoscalingOpsTTLSeconds,
+			TTLSecondsAfterUnsuccessfulCompletion: autoscalingOpsTTLSeconds,
+		},
+	}
+}
+
+// inMaintenanceWindows tells whether the time is in any of the maintenance windows,
+// it is always true if no window is specified.
+func inMaintenanceWindows(windows []experimental.MaintenanceWindow, now time.Time) (bool, error) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+	for _, window := range windows {
+		location := time.UTC
+		if len(window.TimeZone) > 0 {
+			var err error
+			if location, err = time.LoadLocation(window.TimeZone); err != nil {
+				return false, fmt.Errorf("invalid time zone of the maintenance window: %w", err)
+			}
+		}
+		var hour, minute int
+		if _, err := fmt.Sscanf(window.StartTime, "%d:%d", &hour, &minute); err != nil {
+			return false, fmt.Errorf("invalid start time of the maintenance window %q: %w", window.StartTime, err)
+		}
+		local := now.In(location)
+		duration := time.Duration(window.DurationMinutes) * time.Minute
+		// the window may start on the previous days if it lasts across the midnight
+		for days := 0; days <= int(duration/(24*time.Hour))+1; days++ {
+			day := local.AddDate(0, 0, -days)
+			start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, location)
+			if start.After(local) {
+				continue
+			}
+			if len(window.DaysOfWeek) > 0 && !slices.Contains(window.DaysOfWeek, experimental.DayOfWeek(start.Weekday().String())) {
+				continue
+			}
+			if local.Before(start.Add(duration)) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func stabilizationWindow(rules *experimental.ScalingRules, defaultSeconds int32) time.Duration {
+	seconds := defaultSeconds
+	if rules != nil && rules.StabilizationWindowSeconds != nil {
+		seconds = *rules.StabilizationWindowSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// cooldownPeriod returns the longest cooldown specified for the direction, or the default one if none is specified.
+func cooldownPeriod(autoscaler *experimental.ComponentAutoscaler, up bool) time.Duration {
+	rulesOf := func(scaleUp, scaleDown *experimental.ScalingRules) *experimental.ScalingRules {
+		if up {
+			return scaleUp
+		}
+		return scaleDown
+	}
+	var rules []*experimental.ScalingRules
+	if h := autoscaler.Spec.Horizontal; h != nil {
+		rules = append(rules, rulesOf(h.ScaleUp, h.ScaleDown))
+	}
+	if v := autoscaler.Spec.Vertical; v != nil {
+		rules = append(rules, rulesOf(v.ScaleUp, v.ScaleDown))
+	}
+	seconds := int32(-1)
+	for _, r := range rules {
+		if r != nil && r.CooldownSeconds != nil {
+			seconds = max(seconds, *r.CooldownSeconds)
+		}
+	}
+	if seconds < 0 {
+		seconds = defaultScaleDownCooldownSeconds
+		if up {
+			seconds = defaultScaleUpCooldownSeconds
+		}
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func maxStabilizationWindow(autoscaler *experimental.ComponentAutoscaler) time.Duration {
+	window := time.Duration(0)
+	if h := autoscaler.Spec.Horizontal; h != nil {
+		window = max(window, stabilizationWindow(h.ScaleUp, defaultScaleUpStabilizationWindowSeconds),
+			stabilizationWindow(h.ScaleDown, defaultScaleDownStabilizationWindowSeconds))
+	}
+	if v := autoscaler.Spec.Vertical; v != nil {
+		window = max(window, stabilizationWindow(v.ScaleUp, defaultScaleUpStabilizationWindowSeconds),
+			stabilizationWindow(v.ScaleDown, defaultScaleDownStabilizationWindowSeconds))
+	}
+	return window
+}
+
+func trimRecommendations(history []experimental.Recommendation, since time.Time) []experimental.Recommendation {
+	index := slices.IndexFunc(history, func(rec experimental.Recommendation) bool {
+		return !rec.Time.Time.Before(since)
+	})
+	if index < 0 {
+		return nil
+	}
+	history = history[index:]
+	if len(history) > maxRecommendations {
+		history = history[len(history)-maxRecommendations:]
+	}
+	return history
+}
+
+func setAbleToScale(autoscaler *experimental.ComponentAutoscaler, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&autoscaler.Status.Conditions, metav1.Condition{
+		Type:               string(experimental.AbleToScale),
+		Status:             status,
+		ObservedGeneration: autoscaler.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func autoscale() kubebuilderx.Reconciler {
+	return &autoscaleReconciler{}
+}
+
+var _ kubebuilderx.Reconciler = &autoscaleReconciler{}
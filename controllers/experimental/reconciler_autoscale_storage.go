@@ -0,0 +1,374 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	defaultExpansionCooldownSeconds = 300
+
+	// autoscalerFinalizer holds the ComponentAutoscaler until its read-only instances are switched back to read-write.
+	autoscalerFinalizer = "componentautoscaler.experimental.kubeblocks.io/finalizer"
+)
+
+type autoscaleStorageReconciler struct {
+	ctx     context.Context
+	cli     client.Reader
+	metrics MetricsClient
+
+	// switchAccessMode switches an instance to the read-only state, or back to the read-write state.
+	switchAccessMode func(tree *kubebuilderx.ObjectTree, pod *corev1.Pod, readonly bool) error
+}
+
+func (r *autoscaleStorageReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil {
+		return kubebuilderx.ConditionUnsatisfied
+	}
+	return kubebuilderx.ConditionSatisfied
+}
+
+func (r *autoscaleStorageReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
+	autoscaler, _ := tree.GetRoot().(*experimental.ComponentAutoscaler)
+	if model.IsObjectDeleting(autoscaler) {
+		return r.release(tree, autoscaler)
+	}
+	controllerutil.AddFinalizer(autoscaler, autoscalerFinalizer)
+
+	storage := autoscaler.Spec.Storage
+	if storage == nil {
+		autoscaler.Status.Volumes = nil
+		meta.RemoveStatusCondition(&autoscaler.Status.Conditions, string(experimental.AbleToExpandVolumes))
+		r.switchReadWrite(tree, autoscaler, nil)
+		return kubebuilderx.Continue, nil
+	}
+	compSpec, err := targetComponentSpec(tree, autoscaler)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+
+	usages, err := r.volumeUsages(autoscaler)
+	if err != nil {
+		setAbleToExpandVolumes(autoscaler, metav1.ConditionFalse, experimental.ReasonFailedGetVolumeStats, err.Error())
+		if tree.EventRecorder != nil {
+			tree.EventRecorder.Event(autoscaler, corev1.EventTypeWarning, experimental.ReasonFailedGetVolumeStats, err.Error())
+		}
+		return kubebuilderx.Continue, nil
+	}
+
+	var (
+		statuses   []experimental.VolumeAutoscalingStatus
+		expansions []opsv1alpha1.OpsRequestVolumeClaimTemplate
+		// the instances whose volume usages exceed the thresholds
+		overPods  = sets.New[string]()
+		exhausted = sets.New[string]()
+	)
+	for _, policy := range storage.VolumeClaimTemplates {
+		index := slices.IndexFunc(compSpec.VolumeClaimTemplates, func(vct appsv1.ClusterComponentVolumeClaimTemplate) bool {
+			return vct.Name == policy.Name
+		})
+		if index < 0 {
+			return kubebuilderx.Continue, fmt.Errorf("volume claim template %s not found in component %s", policy.Name, compSpec.Name)
+		}
+		current := compSpec.VolumeClaimTemplates[index].Spec.Resources.Requests[corev1.ResourceStorage]
+		status := experimental.VolumeAutoscalingStatus{Name: policy.Name, CurrentSize: &current}
+		pvcs := volumeClaims(tree, policy.Name)
+		var podsOverThreshold []string
+		for _, pvc := range pvcs {
+			usage, ok := usages[pvc.Name]
+			if !ok || usage.CapacityBytes <= 0 {
+				continue
+			}
+			percent := int32(usage.UsedBytes * 100 / usage.CapacityBytes)
+			status.UsagePercent = max(status.UsagePercent, percent)
+			if percent >= policy.UsageThresholdPercent {
+				podsOverThreshold = append(podsOverThreshold, strings.TrimPrefix(pvc.Name, policy.Name+"-"))
+			}
+		}
+		overPods.Insert(podsOverThreshold...)
+		if len(podsOverThreshold) > 0 {
+			desired, err := expandedSize(current, policy)
+			if err != nil {
+				return kubebuilderx.Continue, err
+			}
+			if desired.Cmp(current) <= 0 || !volumeExpansionAllowed(tree, pvcs) {
+				status.Exhausted = true
+				exhausted.Insert(podsOverThreshold...)
+			} else {
+				status.DesiredSize = &desired
+				expansions = append(expansions, opsv1alpha1.OpsRequestVolumeClaimTemplate{Name: policy.Name, Storage: desired})
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	autoscaler.Status.Volumes = statuses
+
+	if storage.ReadOnlyOnExhausted {
+		r.switchReadOnly(tree, autoscaler, exhausted)
+		r.switchReadWrite(tree, autoscaler, overPods)
+	} else {
+		r.switchReadWrite(tree, autoscaler, nil)
+	}
+
+	if len(expansions) == 0 {
+		if exhausted.Len() > 0 {
+			setAbleToExpandVolumes(autoscaler, metav1.ConditionFalse, experimental.ReasonVolumesExhausted,
+				fmt.Sprintf("the volumes of instances %s can not be expanded any more", strings.Join(sets.List(exhausted), ",")))
+		} else {
+			setAbleToExpandVolumes(autoscaler, metav1.ConditionTrue, experimental.ReasonReadyForNewScale, "the volume usages are below the thresholds")
+		}
+		return kubebuilderx.Continue, nil
+	}
+
+	// check whether a new expansion is allowed
+	for _, object := range tree.List(&opsv1alpha1.OpsRequest{}) {
+		ops, _ := object.(*opsv1alpha1.OpsRequest)
+		if !ops.IsComplete() {
+			setAbleToExpandVolumes(autoscaler, metav1.ConditionFalse, experimental.ReasonOpsRequestInProgress,
+				fmt.Sprintf("waiting for OpsRequest %s to complete", ops.Name))
+			return kubebuilderx.Continue, nil
+		}
+	}
+	now := time.Now()
+	if autoscaler.Status.LastExpansionTime != nil {
+		cooldown := time.Duration(defaultExpansionCooldownSeconds) * time.Second
+		if storage.CooldownSeconds != nil {
+			cooldown = time.Duration(*storage.CooldownSeconds) * time.Second
+		}
+		if remaining := autoscaler.Status.LastExpansionTime.Add(cooldown).Sub(now); remaining > 0 {
+			setAbleToExpandVolumes(autoscaler, metav1.ConditionFalse, experimental.ReasonCoolingDown,
+				fmt.Sprintf("cooling down for %s since the last expansion", remaining.Round(time.Second)))
+			return kubebuilderx.Continue, nil
+		}
+	}
+
+	ops := buildScalingOpsRequest(autoscaler, now, opsv1alpha1.VolumeExpansionType)
+	ops.Spec.VolumeExpansionList = []opsv1alpha1.VolumeExpansion{
+		{
+			ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: autoscaler.Spec.TargetComponentName},
+			VolumeClaimTemplates: expansions,
+		},
+	}
+	if err = tree.Add(ops); err != nil {
+		return kubebuilderx.Continue, err
+	}
+	autoscaler.Status.LastExpansionTime = &metav1.Time{Time: now}
+	autoscaler.Status.LastOpsRequestName = ops.Name
+	message := fmt.Sprintf("created %s OpsRequest %s", ops.Spec.Type, ops.Name)
+	setAbleToExpandVolumes(autoscaler, metav1.ConditionTrue, experimental.ReasonScaling, message)
+	if tree.EventRecorder != nil {
+		tree.EventRecorder.Event(autoscaler, corev1.EventTypeNormal, experimental.ReasonScaling, message)
+	}
+	return kubebuilderx.Continue, nil
+}
+
+// release switches all the read-only instances back to the read-write state before the ComponentAutoscaler is deleted,
+// the instances would be left read-only forever otherwise.
+func (r *autoscaleStorageReconciler) release(tree *kubebuilderx.ObjectTree, autoscaler *experimental.ComponentAutoscaler) (kubebuilderx.Result, error) {
+	r.switchReadWrite(tree, autoscaler, nil)
+	if len(autoscaler.Status.ReadOnlyInstances) > 0 {
+		return kubebuilderx.RetryAfter(time.Second * 5), nil
+	}
+	if controllerutil.ContainsFinalizer(autoscaler, autoscalerFinalizer) {
+		tree.DeleteRoot()
+	}
+	return kubebuilderx.Commit, nil
+}
+
+// volumeUsages reads the volume usages from the Prometheus endpoint.
+func (r *autoscaleStorageReconciler) volumeUsages(autoscaler *experimental.ComponentAutoscaler) (map[string]VolumeUsage, error) {
+	return r.metrics.VolumeUsages(r.ctx, prometheusEndpoint(autoscaler), autoscaler.Namespace)
+}
+
+// switchReadOnly switches the instances with exhausted volumes to the read-only state.
+func (r *autoscaleStorageReconciler) switchReadOnly(tree *kubebuilderx.ObjectTree, autoscaler *experimental.ComponentAutoscaler, exhausted sets.Set[string]) {
+	for _, podName := range sets.List(exhausted) {
+		if slices.Contains(autoscaler.Status.ReadOnlyInstances, podName) {
+			continue
+		}
+		if r.switchInstance(tree, autoscaler, podName, true) {
+			autoscaler.Status.ReadOnlyInstances = append(autoscaler.Status.ReadOnlyInstances, podName)
+		}
+	}
+}
+
+// switchReadWrite switches the read-only instances back to the read-write state,
+// except the ones whose volume usages still exceed the thresholds.
+func (r *autoscaleStorageReconciler) switchReadWrite(tree *kubebuilderx.ObjectTree, autoscaler *experimental.ComponentAutoscaler, overPods sets.Set[string]) {
+	var readOnlyInstances []string
+	for _, podName := range autoscaler.Status.ReadOnlyInstances {
+		if overPods.Has(podName) || !r.switchInstance(tree, autoscaler, podName, false) {
+			readOnlyInstances = append(readOnlyInstances, podName)
+		}
+	}
+	autoscaler.Status.ReadOnlyInstances = readOnlyInstances
+}
+
+func (r *autoscaleStorageReconciler) switchInstance(tree *kubebuilderx.ObjectTree, autoscaler *experimental.ComponentAutoscaler, podName string, readonly bool) bool {
+	reason := experimental.ReasonSwitchedReadWrite
+	if readonly {
+		reason = experimental.ReasonSwitchedReadOnly
+	}
+	object, err := tree.Get(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: autoscaler.Namespace, Name: podName}})
+	if err == nil && object == nil {
+		// the instance is gone, nothing to switch
+		return !readonly
+	}
+	if err == nil {
+		err = r.switchAccessMode(tree, object.(*corev1.Pod), readonly)
+	}
+	if tree.EventRecorder != nil {
+		if err != nil {
+			tree.EventRecorder.Eventf(autoscaler, corev1.EventTypeWarning, reason, "failed to switch instance %s: %s", podName, err.Error())
+		} else {
+			tree.EventRecorder.Eventf(autoscaler, corev1.EventTypeNormal, reason, "switched instance %s", podName)
+		}
+	}
+	return err == nil
+}
+
+// switchAccessMode calls the readonly or readwrite lifecycle action of the Component on the instance.
+func (r *autoscaleStorageReconciler) switchAccessModeByLifecycleAction(tree *kubebuilderx.ObjectTree, pod *corev1.Pod, readonly bool) error {
+	var (
+		cluster *appsv1.Cluster
+		comp    *appsv1.Component
+		compDef *appsv1.ComponentDefinition
+	)
+	for _, object := range tree.List(&appsv1.Cluster{}) {
+		cluster, _ = object.(*appsv1.Cluster)
+	}
+	for _, object := range tree.List(&appsv1.Component{}) {
+		comp, _ = object.(*appsv1.Component)
+	}
+	for _, object := range tree.List(&appsv1.ComponentDefinition{}) {
+		compDef, _ = object.(*appsv1.ComponentDefinition)
+	}
+	if cluster == nil || comp == nil || compDef == nil {
+		return fmt.Errorf("the cluster, component or its definition is not found")
+	}
+	synthesizedComp, err := component.BuildSynthesizedComponent(r.ctx, r.cli, compDef, comp, cluster)
+	if err != nil {
+		return err
+	}
+	lfa, err := lifecycle.New(synthesizedComp, pod)
+	if err != nil {
+		return err
+	}
+	if readonly {
+		return lfa.Readonly(r.ctx, r.cli, nil)
+	}
+	return lfa.Readwrite(r.ctx, r.cli, nil)
+}
+
+// expandedSize increases the size by the increment of the policy, rounded up to gibibytes and capped by the max size.
+func expandedSize(current resource.Quantity, policy experimental.VolumeAutoscalingPolicy) (resource.Quantity, error) {
+	var increment int64
+	if policy.Increment.Type == intstr.String && strings.HasSuffix(policy.Increment.StrVal, "%") {
+		percent, err := intstr.GetScaledValueFromIntOrPercent(&policy.Increment, 100, true)
+		if err != nil {
+			return resource.Quantity{}, err
+		}
+		increment = current.Value() * int64(percent) / 100
+	} else {
+		quantity, err := resource.ParseQuantity(policy.Increment.String())
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf("invalid increment of volume claim template %s: %w", policy.Name, err)
+		}
+		increment = quantity.Value()
+	}
+	const gibibyte = 1024 * 1024 * 1024
+	size := (current.Value() + increment + gibibyte - 1) / gibibyte * gibibyte
+	desired := *resource.NewQuantity(size, resource.BinarySI)
+	if desired.Cmp(policy.MaxSize) > 0 {
+		desired = policy.MaxSize.DeepCopy()
+	}
+	return desired, nil
+}
+
+// volumeExpansionAllowed tells whether the StorageClasses of the PVCs allow volume expansion.
+func volumeExpansionAllowed(tree *kubebuilderx.ObjectTree, pvcs []*corev1.PersistentVolumeClaim) bool {
+	for _, pvc := range pvcs {
+		if pvc.Spec.StorageClassName == nil {
+			return false
+		}
+		object, err := tree.Get(&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: *pvc.Spec.StorageClassName}})
+		if err != nil || object == nil {
+			return false
+		}
+		storageClass, _ := object.(*storagev1.StorageClass)
+		if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+			return false
+		}
+	}
+	return true
+}
+
+func volumeClaims(tree *kubebuilderx.ObjectTree, vctName string) []*corev1.PersistentVolumeClaim {
+	var pvcs []*corev1.PersistentVolumeClaim
+	for _, object := range tree.List(&corev1.PersistentVolumeClaim{}) {
+		pvc, _ := object.(*corev1.PersistentVolumeClaim)
+		if pvc.Labels[constant.VolumeClaimTemplateNameLabelKey] == vctName {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+	return pvcs
+}
+
+func setAbleToExpandVolumes(autoscaler *experimental.ComponentAutoscaler, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&autoscaler.Status.Conditions, metav1.Condition{
+		Type:               string(experimental.AbleToExpandVolumes),
+		Status:             status,
+		ObservedGeneration: autoscaler.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func autoscaleStorage(ctx context.Context, cli client.Reader, metrics MetricsClient) kubebuilderx.Reconciler {
+	r := &autoscaleStorageReconciler{ctx: ctx, cli: cli, metrics: metrics}
+	r.switchAccessMode = r.switchAccessModeByLifecycleAction
+	return r
+}
+
+var _ kubebuilderx.Reconciler = &autoscaleStorageReconciler{}
@@ -0,0 +1,457 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimentalv1alpha1 "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+type fakeMetricsClient struct {
+	usages  map[string]corev1.ResourceList
+	values  []float64
+	volumes map[string]VolumeUsage
+}
+
+func (c *fakeMetricsClient) PodUsages(_ context.Context, _ string, _ labels.Selector) (map[string]corev1.ResourceList, error) {
+	return c.usages, nil
+}
+
+func (c *fakeMetricsClient) Query(_ context.Context, _, _ string) ([]float64, error) {
+	return c.values, nil
+}
+
+func (c *fakeMetricsClient) VolumeUsages(_ context.Context, _, _ string) (map[string]VolumeUsage, error) {
+	return c.volumes, nil
+}
+
+var _ = Describe("autoscale reconciler test", func() {
+	const compName = "mysql"
+
+	var (
+		autoscaler *experimentalv1alpha1.ComponentAutoscaler
+		metrics    *fakeMetricsClient
+	)
+
+	newPod := func(name string) *corev1.Pod {
+		pod := builder.NewPodBuilder(namespace, name).
+			AddLabelsInMap(constant.GetCompLabels(clusterName, compName)).
+			AddContainer(corev1.Container{
+				Name: "mysql",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}).
+			GetObject()
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		return pod
+	}
+
+	mockAutoscalerTree := func() *kubebuilderx.ObjectTree {
+		specs := []appsv1.ClusterComponentSpec{
+			{
+				Name:     compName,
+				Replicas: 2,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+		cluster := builder.NewClusterBuilder(namespace, clusterName).SetComponentSpecs(specs).GetObject()
+		autoscalerTree := kubebuilderx.NewObjectTree()
+		autoscalerTree.SetRoot(autoscaler)
+		Expect(autoscalerTree.Add(cluster, newPod("pod-0"), newPod("pod-1"))).Should(Succeed())
+		return autoscalerTree
+	}
+
+	reconcile := func(autoscalerTree *kubebuilderx.ObjectTree) {
+		for _, reconciler := range []kubebuilderx.Reconciler{recommend(context.Background(), metrics), autoscale()} {
+			Expect(reconciler.PreCondition(autoscalerTree)).Should(Equal(kubebuilderx.ConditionSatisfied))
+			res, err := reconciler.Reconcile(autoscalerTree)
+			Expect(err).Should(BeNil())
+			if res.Next != kubebuilderx.Continue.Next {
+				Expect(res).Should(Equal(kubebuilderx.RetryAfter(autoscalerSyncPeriod)))
+				return
+			}
+		}
+	}
+
+	listOps := func(autoscalerTree *kubebuilderx.ObjectTree) []*opsv1alpha1.OpsRequest {
+		var opsList []*opsv1alpha1.OpsRequest
+		for _, object := range autoscalerTree.List(&opsv1alpha1.OpsRequest{}) {
+			opsList = append(opsList, object.(*opsv1alpha1.OpsRequest))
+		}
+		return opsList
+	}
+
+	BeforeEach(func() {
+		autoscaler = builder.NewComponentAutoscalerBuilder(namespace, name).
+			SetTarget(clusterName, compName).
+			SetHorizontal(&experimentalv1alpha1.HorizontalAutoscaling{
+				MaxReplicas: 5,
+				Metrics: []experimentalv1alpha1.MetricSource{
+					{
+						Type: experimentalv1alpha1.ResourceMetricSourceType,
+						Resource: &experimentalv1alpha1.ResourceMetricSource{
+							Name:                     corev1.ResourceCPU,
+							TargetAverageUtilization: 50,
+						},
+					},
+				},
+			}).
+			GetObject()
+		metrics = &fakeMetricsClient{
+			usages: map[string]corev1.ResourceList{
+				"pod-0": {corev1.ResourceCPU: resource.MustParse("900m"), corev1.ResourceMemory: resource.MustParse("900Mi")},
+				"pod-1": {corev1.ResourceCPU: resource.MustParse("700m"), corev1.ResourceMemory: resource.MustParse("1000Mi")},
+			},
+		}
+	})
+
+	Context("horizontal scaling", func() {
+		It("should scale out by a HorizontalScaling OpsRequest", func() {
+			autoscalerTree := mockAutoscalerTree()
+			reconcile(autoscalerTree)
+
+			Expect(autoscaler.Status.CurrentReplicas).Should(BeEquivalentTo(2))
+			Expect(autoscaler.Status.CurrentMetrics).Should(HaveLen(1))
+			Expect(autoscaler.Status.CurrentMetrics[0].Value.Value()).Should(BeEquivalentTo(80))
+			Expect(autoscaler.Status.DesiredReplicas).ShouldNot(BeNil())
+			Expect(*autoscaler.Status.DesiredReplicas).Should(BeEquivalentTo(4))
+			opsList := listOps(autoscalerTree)
+			Expect(opsList).Should(HaveLen(1))
+			ops := opsList[0]
+			Expect(ops.Labels[autoscalerLabelKey]).Should(Equal(name))
+			Expect(ops.Spec.Type).Should(Equal(opsv1alpha1.HorizontalScalingType))
+			Expect(ops.Spec.ClusterName).Should(Equal(clusterName))
+			Expect(ops.Spec.HorizontalScalingList).Should(HaveLen(1))
+			Expect(ops.Spec.HorizontalScalingList[0].ComponentName).Should(Equal(compName))
+			Expect(ops.Spec.HorizontalScalingList[0].ScaleOut).ShouldNot(BeNil())
+			Expect(*ops.Spec.HorizontalScalingList[0].ScaleOut.ReplicaChanges).Should(BeEquivalentTo(2))
+			Expect(autoscaler.Status.LastOpsRequestName).Should(Equal(ops.Name))
+			Expect(autoscaler.Status.LastScaleTime).ShouldNot(BeNil())
+		})
+
+		It("should respect the ReplicasLimit of the ComponentDefinition", func() {
+			autoscalerTree := mockAutoscalerTree()
+			compDef := builder.NewComponentDefinitionBuilder("mysql-8.0").
+				SetReplicasLimit(1, 3).
+				GetObject()
+			Expect(autoscalerTree.Add(compDef)).Should(Succeed())
+			reconcile(autoscalerTree)
+
+			Expect(*autoscaler.Status.DesiredReplicas).Should(BeEquivalentTo(3))
+			opsList := listOps(autoscalerTree)
+			Expect(opsList).Should(HaveLen(1))
+			Expect(*opsList[0].Spec.HorizontalScalingList[0].ScaleOut.ReplicaChanges).Should(BeEquivalentTo(1))
+		})
+
+		It("should wait for the OpsRequest in progress", func() {
+			autoscalerTree := mockAutoscalerTree()
+			ops := &opsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "running-ops",
+					Labels:    map[string]string{autoscalerLabelKey: name},
+				},
+				Status: opsv1alpha1.OpsRequestStatus{Phase: opsv1alpha1.OpsRunningPhase},
+			}
+			Expect(autoscalerTree.Add(ops)).Should(Succeed())
+			reconcile(autoscalerTree)
+
+			Expect(listOps(autoscalerTree)).Should(HaveLen(1))
+			Expect(autoscaler.Status.Conditions).Should(ContainElement(HaveField("Reason", experimentalv1alpha1.ReasonOpsRequestInProgress)))
+		})
+
+		It("should cool down after the last scaling", func() {
+			autoscaler.Status.LastScaleTime = &metav1.Time{Time: time.Now().Add(-10 * time.Second)}
+			autoscalerTree := mockAutoscalerTree()
+			reconcile(autoscalerTree)
+
+			Expect(listOps(autoscalerTree)).Should(BeEmpty())
+			Expect(autoscaler.Status.Conditions).Should(ContainElement(HaveField("Reason", experimentalv1alpha1.ReasonCoolingDown)))
+		})
+
+		It("should scale out by the Prometheus metrics", func() {
+			autoscaler.Spec.Horizontal.Metrics = []experimentalv1alpha1.MetricSource{
+				{
+					Type: experimentalv1alpha1.PrometheusMetricSourceType,
+					Prometheus: &experimentalv1alpha1.PrometheusMetricSource{
+						Query:              "mysql_global_status_threads_connected",
+						TargetAverageValue: resource.MustParse("100"),
+					},
+				},
+			}
+			metrics.values = []float64{250, 350}
+			autoscalerTree := mockAutoscalerTree()
+			reconcile(autoscalerTree)
+
+			Expect(*autoscaler.Status.DesiredReplicas).Should(BeEquivalentTo(5))
+			Expect(autoscaler.Status.CurrentMetrics[0].Value.AsApproximateFloat64()).Should(BeNumerically("==", 300))
+		})
+	})
+
+	Context("vertical scaling", func() {
+		It("should scale up by a VerticalScaling OpsRequest", func() {
+			autoscaler.Spec.Horizontal = nil
+			autoscaler.Spec.Vertical = &experimentalv1alpha1.VerticalAutoscaling{
+				Resources: []experimentalv1alpha1.VerticalResourcePolicy{
+					{
+						Name:                     corev1.ResourceCPU,
+						TargetAverageUtilization: 50,
+						MaxAllowed:               ptr.To(resource.MustParse("1500m")),
+					},
+					{
+						Name:                     corev1.ResourceMemory,
+						TargetAverageUtilization: 50,
+					},
+				},
+			}
+			autoscalerTree := mockAutoscalerTree()
+			reconcile(autoscalerTree)
+
+			Expect(autoscaler.Status.DesiredResources.Cpu().String()).Should(Equal("1500m"))
+			Expect(autoscaler.Status.DesiredResources.Memory().String()).Should(Equal("2000Mi"))
+			opsList := listOps(autoscalerTree)
+			Expect(opsList).Should(HaveLen(1))
+			ops := opsList[0]
+			Expect(ops.Spec.Type).Should(Equal(opsv1alpha1.VerticalScalingType))
+			Expect(ops.Spec.VerticalScalingList).Should(HaveLen(1))
+			vScale := ops.Spec.VerticalScalingList[0]
+			Expect(vScale.Requests.Cpu().String()).Should(Equal("1500m"))
+			Expect(vScale.Limits.Cpu().String()).Should(Equal("3"))
+			Expect(vScale.Requests.Memory().String()).Should(Equal("2000Mi"))
+			Expect(vScale.Limits.Memory().String()).Should(Equal("2000Mi"))
+		})
+	})
+
+	Context("stabilization and maintenance windows", func() {
+		It("should stabilize the recommendations", func() {
+			now := time.Now()
+			history := []experimentalv1alpha1.Recommendation{
+				{Time: metav1.NewTime(now.Add(-200 * time.Second)), Replicas: ptr.To[int32](5)},
+				{Time: metav1.NewTime(now.Add(-100 * time.Second)), Replicas: ptr.To[int32](3)},
+				{Time: metav1.NewTime(now), Replicas: ptr.To[int32](1)},
+			}
+			// scale down to the highest recommendation in the window
+			Expect(stabilizeReplicas(history, now, 6, 0, 300*time.Second)).Should(BeEquivalentTo(5))
+			Expect(stabilizeReplicas(history, now, 6, 0, 150*time.Second)).Should(BeEquivalentTo(3))
+			// scale up to the lowest recommendation in the window
+			Expect(stabilizeReplicas(history, now, 0, 300*time.Second, 0)).Should(BeEquivalentTo(1))
+			// keep the current replicas if they lie in between
+			Expect(stabilizeReplicas(history, now, 2, 300*time.Second, 300*time.Second)).Should(BeEquivalentTo(2))
+		})
+
+		It("should tell whether it is in the maintenance windows", func() {
+			windows := []experimentalv1alpha1.MaintenanceWindow{
+				{
+					DaysOfWeek:      []experimentalv1alpha1.DayOfWeek{"Saturday"},
+					StartTime:       "23:00",
+					DurationMinutes: 120,
+				},
+			}
+			// 2024-06-01 is a Saturday
+			for t, expected := range map[string]bool{
+				"2024-06-01T23:30:00Z": true,
+				"2024-06-02T00:30:00Z": true,
+				"2024-06-02T01:30:00Z": false,
+				"2024-06-01T22:30:00Z": false,
+				"2024-06-02T23:30:00Z": false,
+			} {
+				now, err := time.Parse(time.RFC3339, t)
+				Expect(err).Should(BeNil())
+				Expect(inMaintenanceWindows(windows, now)).Should(Equal(expected), t)
+			}
+		})
+	})
+
+	Context("storage autoscaling", func() {
+		const (
+			vctName          = "data"
+			storageClassName = "standard"
+		)
+
+		var switched map[string]bool
+
+		mockStorageTree := func(allowVolumeExpansion bool) *kubebuilderx.ObjectTree {
+			specs := []appsv1.ClusterComponentSpec{
+				{
+					Name:     compName,
+					Replicas: 2,
+					VolumeClaimTemplates: []appsv1.ClusterComponentVolumeClaimTemplate{
+						{
+							Name: vctName,
+							Spec: appsv1.PersistentVolumeClaimSpec{
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+								},
+							},
+						},
+					},
+				},
+			}
+			cluster := builder.NewClusterBuilder(namespace, clusterName).SetComponentSpecs(specs).GetObject()
+			storageClass := &storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: storageClassName},
+				AllowVolumeExpansion: ptr.To(allowVolumeExpansion),
+			}
+			autoscalerTree := kubebuilderx.NewObjectTree()
+			autoscalerTree.SetRoot(autoscaler)
+			Expect(autoscalerTree.Add(cluster, storageClass)).Should(Succeed())
+			for _, podName := range []string{"pod-0", "pod-1"} {
+				pod := newPod(podName)
+				pod.Spec.NodeName = "node-0"
+				pvc := builder.NewPVCBuilder(namespace, vctName+"-"+podName).
+					AddLabelsInMap(constant.GetCompLabels(clusterName, compName)).
+					AddLabels(constant.VolumeClaimTemplateNameLabelKey, vctName).
+					SetStorageClass(storageClassName).
+					GetObject()
+				Expect(autoscalerTree.Add(pod, pvc)).Should(Succeed())
+			}
+			return autoscalerTree
+		}
+
+		reconcileStorage := func(autoscalerTree *kubebuilderx.ObjectTree) {
+			reconciler := autoscaleStorage(context.Background(), nil, metrics).(*autoscaleStorageReconciler)
+			reconciler.switchAccessMode = func(_ *kubebuilderx.ObjectTree, pod *corev1.Pod, readonly bool) error {
+				switched[pod.Name] = readonly
+				return nil
+			}
+			Expect(reconciler.PreCondition(autoscalerTree)).Should(Equal(kubebuilderx.ConditionSatisfied))
+			res, err := reconciler.Reconcile(autoscalerTree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+		}
+
+		BeforeEach(func() {
+			autoscaler.Spec.Horizontal = nil
+			autoscaler.Spec.Storage = &experimentalv1alpha1.StorageAutoscaling{
+				VolumeClaimTemplates: []experimentalv1alpha1.VolumeAutoscalingPolicy{
+					{
+						Name:                  vctName,
+						UsageThresholdPercent: 80,
+						Increment:             intstr.FromString("50%"),
+						MaxSize:               resource.MustParse("100Gi"),
+					},
+				},
+				ReadOnlyOnExhausted: true,
+			}
+			metrics.volumes = map[string]VolumeUsage{
+				vctName + "-pod-0": {UsedBytes: 90, CapacityBytes: 100},
+				vctName + "-pod-1": {UsedBytes: 50, CapacityBytes: 100},
+			}
+			switched = map[string]bool{}
+		})
+
+		It("should expand the volumes by a VolumeExpansion OpsRequest", func() {
+			autoscalerTree := mockStorageTree(true)
+			reconcileStorage(autoscalerTree)
+
+			Expect(autoscaler.Status.Volumes).Should(HaveLen(1))
+			Expect(autoscaler.Status.Volumes[0].UsagePercent).Should(BeEquivalentTo(90))
+			Expect(autoscaler.Status.Volumes[0].DesiredSize.String()).Should(Equal("15Gi"))
+			opsList := listOps(autoscalerTree)
+			Expect(opsList).Should(HaveLen(1))
+			Expect(opsList[0].Spec.Type).Should(Equal(opsv1alpha1.VolumeExpansionType))
+			Expect(opsList[0].Spec.VolumeExpansionList).Should(HaveLen(1))
+			Expect(opsList[0].Spec.VolumeExpansionList[0].VolumeClaimTemplates[0].Storage.String()).Should(Equal("15Gi"))
+			Expect(autoscaler.Status.LastExpansionTime).ShouldNot(BeNil())
+			Expect(switched).Should(BeEmpty())
+
+			By("cool down after the expansion")
+			opsList[0].Status.Phase = opsv1alpha1.OpsSucceedPhase
+			reconcileStorage(autoscalerTree)
+			Expect(listOps(autoscalerTree)).Should(HaveLen(1))
+			cond := meta.FindStatusCondition(autoscaler.Status.Conditions, string(experimentalv1alpha1.AbleToExpandVolumes))
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(experimentalv1alpha1.ReasonCoolingDown))
+		})
+
+		It("should switch the instances to read-only if the volumes are exhausted", func() {
+			autoscalerTree := mockStorageTree(false)
+			reconcileStorage(autoscalerTree)
+
+			Expect(listOps(autoscalerTree)).Should(BeEmpty())
+			Expect(autoscaler.Status.Volumes[0].Exhausted).Should(BeTrue())
+			Expect(autoscaler.Status.ReadOnlyInstances).Should(Equal([]string{"pod-0"}))
+			Expect(switched).Should(Equal(map[string]bool{"pod-0": true}))
+			cond := meta.FindStatusCondition(autoscaler.Status.Conditions, string(experimentalv1alpha1.AbleToExpandVolumes))
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(experimentalv1alpha1.ReasonVolumesExhausted))
+
+			By("switch back to read-write once the usage drops")
+			metrics.volumes[vctName+"-pod-0"] = VolumeUsage{UsedBytes: 50, CapacityBytes: 100}
+			reconcileStorage(autoscalerTree)
+			Expect(autoscaler.Status.ReadOnlyInstances).Should(BeEmpty())
+			Expect(switched).Should(Equal(map[string]bool{"pod-0": false}))
+		})
+
+		It("should switch the instances back to read-write before the autoscaler is deleted", func() {
+			autoscalerTree := mockStorageTree(false)
+			reconcileStorage(autoscalerTree)
+			Expect(autoscaler.Finalizers).Should(ContainElement(autoscalerFinalizer))
+			Expect(autoscaler.Status.ReadOnlyInstances).Should(Equal([]string{"pod-0"}))
+
+			By("delete the autoscaler")
+			autoscaler.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			reconciler := autoscaleStorage(context.Background(), nil, metrics).(*autoscaleStorageReconciler)
+			reconciler.switchAccessMode = func(_ *kubebuilderx.ObjectTree, pod *corev1.Pod, readonly bool) error {
+				switched[pod.Name] = readonly
+				return nil
+			}
+			Expect(reconciler.PreCondition(autoscalerTree)).Should(Equal(kubebuilderx.ConditionSatisfied))
+			res, err := reconciler.Reconcile(autoscalerTree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Commit))
+			Expect(autoscaler.Status.ReadOnlyInstances).Should(BeEmpty())
+			Expect(switched).Should(Equal(map[string]bool{"pod-0": false}))
+			Expect(autoscalerTree.GetRoot()).Should(BeNil())
+		})
+	})
+})
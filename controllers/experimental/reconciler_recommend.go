@@ -0,0 +1,363 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/kubectl/pkg/util/podutils"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	// autoscalerSyncPeriod is the interval to read the metrics and recalculate the recommendations.
+	autoscalerSyncPeriod = 30 * time.Second
+
+	// scalingTolerance is the ratio of the change below which no scaling happens.
+	scalingTolerance = 0.1
+)
+
+type recommendReconciler struct {
+	ctx     context.Context
+	metrics MetricsClient
+}
+
+func (r *recommendReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ConditionUnsatisfied
+	}
+	return kubebuilderx.ConditionSatisfied
+}
+
+func (r *recommendReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
+	autoscaler, _ := tree.GetRoot().(*experimental.ComponentAutoscaler)
+	compSpec, err := targetComponentSpec(tree, autoscaler)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	autoscaler.Status.CurrentReplicas = compSpec.Replicas
+	if autoscaler.Spec.Horizontal == nil && autoscaler.Spec.Vertical == nil {
+		// only the storage is autoscaled, nothing to recommend
+		autoscaler.Status.CurrentMetrics = nil
+		autoscaler.Status.Recommendations = nil
+		meta.RemoveStatusCondition(&autoscaler.Status.Conditions, string(experimental.ScalingActive))
+		meta.RemoveStatusCondition(&autoscaler.Status.Conditions, string(experimental.AbleToScale))
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+
+	source := &metricsReader{
+		ctx:        r.ctx,
+		metrics:    r.metrics,
+		autoscaler: autoscaler,
+		pods:       readyPods(tree),
+	}
+	recommendation := experimental.Recommendation{Time: metav1.Now()}
+	if autoscaler.Spec.Horizontal != nil {
+		replicas, err := source.recommendReplicas(compSpec.Replicas)
+		if err != nil {
+			return r.failedGetMetrics(tree, autoscaler, err)
+		}
+		recommendation.Replicas = &replicas
+	}
+	if autoscaler.Spec.Vertical != nil {
+		resources, err := source.recommendResources()
+		if err != nil {
+			return r.failedGetMetrics(tree, autoscaler, err)
+		}
+		recommendation.Resources = resources
+	}
+	autoscaler.Status.CurrentMetrics = source.values
+	autoscaler.Status.Recommendations = append(autoscaler.Status.Recommendations, recommendation)
+	meta.SetStatusCondition(&autoscaler.Status.Conditions, metav1.Condition{
+		Type:               string(experimental.ScalingActive),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: autoscaler.Generation,
+		Reason:             experimental.ReasonValidMetricFound,
+		Message:            "the recommendations are calculated from the metrics",
+	})
+
+	return kubebuilderx.Continue, nil
+}
+
+// failedGetMetrics records the failure and stops scaling until the next sync.
+func (r *recommendReconciler) failedGetMetrics(tree *kubebuilderx.ObjectTree, autoscaler *experimental.ComponentAutoscaler, err error) (kubebuilderx.Result, error) {
+	meta.SetStatusCondition(&autoscaler.Status.Conditions, metav1.Condition{
+		Type:               string(experimental.ScalingActive),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: autoscaler.Generation,
+		Reason:             experimental.ReasonFailedGetMetrics,
+		Message:            err.Error(),
+	})
+	if tree.EventRecorder != nil {
+		tree.EventRecorder.Event(autoscaler, corev1.EventTypeWarning, experimental.ReasonFailedGetMetrics, err.Error())
+	}
+	return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+}
+
+// metricsReader reads the metrics of a ComponentAutoscaler and records their values.
+type metricsReader struct {
+	ctx        context.Context
+	metrics    MetricsClient
+	autoscaler *experimental.ComponentAutoscaler
+	pods       []*corev1.Pod
+
+	usages map[string]corev1.ResourceList
+	values []experimental.MetricValue
+}
+
+// recommendReplicas calculates the number of instances that brings the metrics to their targets.
+func (m *metricsReader) recommendReplicas(currentReplicas int32) (int32, error) {
+	var recommended int32
+	for _, metric := range m.autoscaler.Spec.Horizontal.Metrics {
+		var (
+			replicas int32
+			err      error
+		)
+		switch {
+		case metric.Type == experimental.ResourceMetricSourceType && metric.Resource != nil:
+			replicas, err = m.replicasByResource(metric.Resource)
+		case metric.Type == experimental.PrometheusMetricSourceType && metric.Prometheus != nil:
+			replicas, err = m.replicasByPrometheus(metric.Prometheus, currentReplicas)
+		default:
+			err = fmt.Errorf("the source of the %s metric is not set", metric.Type)
+		}
+		if err != nil {
+			return 0, err
+		}
+		recommended = max(recommended, replicas)
+	}
+	return recommended, nil
+}
+
+func (m *metricsReader) replicasByResource(source *experimental.ResourceMetricSource) (int32, error) {
+	usages, err := m.podUsages()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		usageSum, requestSum int64
+		count                int32
+	)
+	for _, pod := range m.pods {
+		usage, ok := usages[pod.Name][source.Name]
+		if !ok {
+			continue
+		}
+		request := podRequest(pod, source.Name)
+		if request.IsZero() {
+			return 0, fmt.Errorf("the %s request of pod %s is not set", source.Name, pod.Name)
+		}
+		usageSum += usage.MilliValue()
+		requestSum += request.MilliValue()
+		count++
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("no %s usage of the ready instances is reported", source.Name)
+	}
+	utilization := float64(usageSum) * 100 / float64(requestSum)
+	m.record(experimental.ResourceMetricSourceType, string(source.Name), *resource.NewQuantity(int64(math.Round(utilization)), resource.DecimalSI))
+	return replicasByRatio(count, utilization/float64(source.TargetAverageUtilization)), nil
+}
+
+func (m *metricsReader) replicasByPrometheus(source *experimental.PrometheusMetricSource, currentReplicas int32) (int32, error) {
+	values, err := m.query(source.Query)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	average := sum / float64(len(values))
+	m.record(experimental.PrometheusMetricSourceType, source.Query, *resource.NewMilliQuantity(int64(math.Round(average*1000)), resource.DecimalSI))
+	target := source.TargetAverageValue.AsApproximateFloat64()
+	if target <= 0 {
+		return 0, fmt.Errorf("the target value of query %q should be positive", source.Query)
+	}
+	return replicasByRatio(currentReplicas, average/target), nil
+}
+
+// recommendResources calculates the resources requests that bring the peak usages to the target utilizations.
+func (m *metricsReader) recommendResources() (corev1.ResourceList, error) {
+	resources := corev1.ResourceList{}
+	for _, policy := range m.autoscaler.Spec.Vertical.Resources {
+		var (
+			peak float64
+			err  error
+		)
+		if len(policy.Query) > 0 {
+			peak, err = m.peakByPrometheus(policy)
+		} else {
+			peak, err = m.peakByResource(policy)
+		}
+		if err != nil {
+			return nil, err
+		}
+		desired := requestQuantity(policy.Name, peak*100/float64(policy.TargetAverageUtilization))
+		if policy.MinAllowed != nil && desired.Cmp(*policy.MinAllowed) < 0 {
+			desired = policy.MinAllowed.DeepCopy()
+		}
+		if policy.MaxAllowed != nil && desired.Cmp(*policy.MaxAllowed) > 0 {
+			desired = policy.MaxAllowed.DeepCopy()
+		}
+		resources[policy.Name] = desired
+	}
+	return resources, nil
+}
+
+func (m *metricsReader) peakByResource(policy experimental.VerticalResourcePolicy) (float64, error) {
+	usages, err := m.podUsages()
+	if err != nil {
+		return 0, err
+	}
+	found := false
+	peak := resource.Quantity{}
+	for _, pod := range m.pods {
+		if usage, ok := usages[pod.Name][policy.Name]; ok {
+			found = true
+			if usage.Cmp(peak) > 0 {
+				peak = usage
+			}
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("no %s usage of the ready instances is reported", policy.Name)
+	}
+	m.record(experimental.ResourceMetricSourceType, string(policy.Name), peak)
+	return peak.AsApproximateFloat64(), nil
+}
+
+func (m *metricsReader) peakByPrometheus(policy experimental.VerticalResourcePolicy) (float64, error) {
+	values, err := m.query(policy.Query)
+	if err != nil {
+		return 0, err
+	}
+	peak := slices.Max(values)
+	m.record(experimental.PrometheusMetricSourceType, policy.Query, requestQuantity(policy.Name, peak))
+	return peak, nil
+}
+
+func (m *metricsReader) podUsages() (map[string]corev1.ResourceList, error) {
+	if m.usages != nil {
+		return m.usages, nil
+	}
+	selector := labels.SelectorFromSet(constant.GetCompLabels(m.autoscaler.Spec.TargetClusterName, m.autoscaler.Spec.TargetComponentName))
+	usages, err := m.metrics.PodUsages(m.ctx, m.autoscaler.Namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the resource metrics: %w", err)
+	}
+	m.usages = usages
+	return usages, nil
+}
+
+func (m *metricsReader) query(query string) ([]float64, error) {
+	values, err := m.metrics.Query(m.ctx, prometheusEndpoint(m.autoscaler), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate query %q: %w", query, err)
+	}
+	return values, nil
+}
+
+func (m *metricsReader) record(sourceType experimental.MetricSourceType, name string, value resource.Quantity) {
+	m.values = append(m.values, experimental.MetricValue{
+		Type:  sourceType,
+		Name:  name,
+		Value: value,
+	})
+}
+
+// replicasByRatio scales the replicas by the ratio of the current value to the target value of a metric,
+// the replicas are kept if the ratio is within the tolerance.
+func replicasByRatio(replicas int32, ratio float64) int32 {
+	if replicas == 0 || math.Abs(ratio-1) <= scalingTolerance {
+		return replicas
+	}
+	return int32(math.Ceil(ratio * float64(replicas)))
+}
+
+// requestQuantity rounds up the amount of a resource, to millicores for cpu and mebibytes for memory.
+func requestQuantity(name corev1.ResourceName, amount float64) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return *resource.NewMilliQuantity(int64(math.Ceil(amount*1000)), resource.DecimalSI)
+	}
+	const mebibyte = 1024 * 1024
+	return *resource.NewQuantity(int64(math.Ceil(amount/mebibyte))*mebibyte, resource.BinarySI)
+}
+
+func podRequest(pod *corev1.Pod, name corev1.ResourceName) resource.Quantity {
+	sum := resource.Quantity{}
+	for _, container := range pod.Spec.Containers {
+		if request, ok := container.Resources.Requests[name]; ok {
+			sum.Add(request)
+		}
+	}
+	return sum
+}
+
+func readyPods(tree *kubebuilderx.ObjectTree) []*corev1.Pod {
+	var pods []*corev1.Pod
+	for _, object := range tree.List(&corev1.Pod{}) {
+		pod, _ := object.(*corev1.Pod)
+		if model.IsObjectDeleting(pod) || !podutils.IsPodReady(pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+func targetComponentSpec(tree *kubebuilderx.ObjectTree, autoscaler *experimental.ComponentAutoscaler) (*appsv1.ClusterComponentSpec, error) {
+	clusterKey := builder.NewClusterBuilder(autoscaler.Namespace, autoscaler.Spec.TargetClusterName).GetObject()
+	object, err := tree.Get(clusterKey)
+	if err != nil {
+		return nil, err
+	}
+	cluster, _ := object.(*appsv1.Cluster)
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster %s not found", autoscaler.Spec.TargetClusterName)
+	}
+	for i := range cluster.Spec.ComponentSpecs {
+		if cluster.Spec.ComponentSpecs[i].Name == autoscaler.Spec.TargetComponentName {
+			return &cluster.Spec.ComponentSpecs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("component %s not found in cluster %s", autoscaler.Spec.TargetComponentName, autoscaler.Spec.TargetClusterName)
+}
+
+func recommend(ctx context.Context, metrics MetricsClient) kubebuilderx.Reconciler {
+	return &recommendReconciler{ctx: ctx, metrics: metrics}
+}
+
+var _ kubebuilderx.Reconciler = &recommendReconciler{}
@@ -23,7 +23,6 @@ import (
 	"slices"
 	"time"
 
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -50,9 +49,6 @@ func (r *scaleTargetClusterReconciler) Reconcile(tree *kubebuilderx.ObjectTree)
 		return kubebuilderx.Continue, err
 	}
 	cluster, _ := object.(*appsv1.Cluster)
-	nodes := tree.List(&corev1.Node{})
-	// TODO(free6om): filter nodes that satisfy pod template spec of each component (by nodeSelector, nodeAffinity&nodeAntiAffinity, tolerations)
-	desiredReplicas := int32(len(nodes))
 	scaled := false
 	for i := range cluster.Spec.ComponentSpecs {
 		spec := &cluster.Spec.ComponentSpecs[i]
@@ -61,6 +57,10 @@ func (r *scaleTargetClusterReconciler) Reconcile(tree *kubebuilderx.ObjectTree)
 		}) < 0 {
 			continue
 		}
+		desiredReplicas, _, err := componentDesiredReplicas(tree, scaler, spec.Name)
+		if err != nil {
+			return kubebuilderx.Continue, err
+		}
 		if spec.Replicas != desiredReplicas {
 			spec.Replicas = desiredReplicas
 			scaled = true
@@ -24,7 +24,6 @@ import (
 	"slices"
 	"strings"
 
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,9 +48,6 @@ func (r *updateStatusReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *ku
 func (r *updateStatusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
 	scaler, _ := tree.GetRoot().(*experimental.NodeCountScaler)
 	itsList := tree.List(&workloads.InstanceSet{})
-	nodes := tree.List(&corev1.Node{})
-	// TODO(free6om): filter nodes that satisfy pod template spec of each component (by nodeSelector, nodeAffinity&nodeAntiAffinity, tolerations)
-	desiredReplicas := int32(len(nodes))
 	var statusList []experimental.ComponentStatus
 	for _, name := range scaler.Spec.TargetComponentNames {
 		index := slices.IndexFunc(itsList, func(object client.Object) bool {
@@ -62,12 +58,17 @@ func (r *updateStatusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubeb
 			continue
 		}
 		its, _ := itsList[index].(*workloads.InstanceSet)
+		desiredReplicas, matchedNodes, err := componentDesiredReplicas(tree, scaler, name)
+		if err != nil {
+			return kubebuilderx.Continue, err
+		}
 		status := experimental.ComponentStatus{
 			Name:              name,
 			CurrentReplicas:   its.Status.CurrentReplicas,
 			ReadyReplicas:     its.Status.ReadyReplicas,
 			AvailableReplicas: its.Status.AvailableReplicas,
 			DesiredReplicas:   desiredReplicas,
+			MatchedNodes:      matchedNodes,
 		}
 		statusList = append(statusList, status)
 	}
@@ -72,6 +72,7 @@ var _ = Describe("update status reconciler test", func() {
 			Expect(newNCS.Status.ComponentStatuses[0].ReadyReplicas).Should(Equal(desiredReplicas))
 			Expect(newNCS.Status.ComponentStatuses[0].AvailableReplicas).Should(Equal(desiredReplicas))
 			Expect(newNCS.Status.ComponentStatuses[0].DesiredReplicas).Should(Equal(desiredReplicas))
+			Expect(newNCS.Status.ComponentStatuses[0].MatchedNodes).Should(Equal(desiredReplicas))
 			Expect(newNCS.Status.ComponentStatuses[1].CurrentReplicas).Should(Equal(desiredReplicas))
 			Expect(newNCS.Status.ComponentStatuses[1].ReadyReplicas).Should(Equal(desiredReplicas))
 			Expect(newNCS.Status.ComponentStatuses[1].AvailableReplicas).Should(Equal(desiredReplicas))
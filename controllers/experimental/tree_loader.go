@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -61,6 +62,10 @@ func (t *treeLoader) Load(ctx context.Context, reader client.Reader, req ctrl.Re
 		key = types.NamespacedName{Namespace: scaler.Namespace, Name: name}
 		its := &workloads.InstanceSet{}
 		if err = reader.Get(ctx, key, its); err != nil {
+			// the component may not be created yet, its scheduling policy in the cluster is used then
+			if apierrors.IsNotFound(err) {
+				continue
+			}
 			return nil, err
 		}
 		if err = tree.Add(its); err != nil {
@@ -22,10 +22,12 @@ package extensions
 import (
 	"context"
 	"runtime"
+	"slices"
 
 	ctrlerihandler "github.com/authzed/controller-idioms/handler"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -37,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -66,6 +69,8 @@ func init() {
 // +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list
 
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -119,6 +124,10 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrlerihandler.NewTypeHandler(&enabledWithDefaultValuesStage{stageCtx: buildStageCtx(next...)})
 	}
 
+	dependencyCheckStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
+		return ctrlerihandler.NewTypeHandler(&dependencyCheckStage{stageCtx: buildStageCtx(next...)})
+	}
+
 	progressingStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
 		return ctrlerihandler.NewTypeHandler(&progressingHandler{stageCtx: buildStageCtx(next...)})
 	}
@@ -134,6 +143,7 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		installableCheckStageBuilder,
 		autoInstallCheckStageBuilder,
 		enabledAutoValuesStageBuilder,
+		dependencyCheckStageBuilder,
 		progressingStageBuilder,
 		terminalStateStageBuilder,
 	).Handler("")
@@ -156,6 +166,9 @@ func (r *AddonReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&extensionsv1alpha1.Addon{}).
 		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.findAddonJobs)).
+		Watches(&extensionsv1alpha1.Addon{}, handler.EnqueueRequestsFromMapFunc(r.findRelatedAddons)).
+		Watches(&appsv1.Component{}, handler.EnqueueRequestsFromMapFunc(r.findUpgradeBlockedAddons)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findManifestAddons)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: viper.GetInt(maxConcurrentReconcilesKey),
 		}).
@@ -180,6 +193,81 @@ func (r *AddonReconciler) findAddonJobs(ctx context.Context, job client.Object)
 	}
 }
 
+// findRelatedAddons finds the addons that depend on the addon, and the dependencies of the addon.
+func (r *AddonReconciler) findRelatedAddons(ctx context.Context, obj client.Object) []reconcile.Request {
+	addon, ok := obj.(*extensionsv1alpha1.Addon)
+	if !ok {
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, dep := range addon.Spec.Dependencies {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: dep.Name}})
+	}
+	addons := &extensionsv1alpha1.AddonList{}
+	if err := r.List(ctx, addons); err != nil {
+		return requests
+	}
+	for _, item := range addons.Items {
+		for _, dep := range item.Spec.Dependencies {
+			if dep.Name == addon.Name {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// findManifestAddons finds the addons whose manifests are held by the ConfigMap,
+// so that they are upgraded once the manifests are changed.
+func (r *AddonReconciler) findManifestAddons(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != viper.GetString(constant.CfgKeyCtrlrMgrNS) {
+		return []reconcile.Request{}
+	}
+	addons := &extensionsv1alpha1.AddonList{}
+	if err := r.List(ctx, addons); err != nil {
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, addon := range addons.Items {
+		if addon.Spec.Type != extensionsv1alpha1.ManifestType || addon.Spec.Manifest == nil {
+			continue
+		}
+		if slices.ContainsFunc(addon.Spec.Manifest.ConfigMapRefs, func(ref extensionsv1alpha1.DataObjectKeySelector) bool {
+			return ref.Name == obj.GetName()
+		}) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: addon.Name}})
+		}
+	}
+	return requests
+}
+
+// findUpgradeBlockedAddons finds the addons whose upgrade is blocked by the component,
+// so that they are checked again once the component is changed or deleted.
+func (r *AddonReconciler) findUpgradeBlockedAddons(ctx context.Context, obj client.Object) []reconcile.Request {
+	comp, ok := obj.(*appsv1.Component)
+	if !ok {
+		return []reconcile.Request{}
+	}
+	addons := &extensionsv1alpha1.AddonList{}
+	if err := r.List(ctx, addons); err != nil {
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, addon := range addons.Items {
+		cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+		if cond == nil || cond.Reason != UpgradeBlocked {
+			continue
+		}
+		if slices.ContainsFunc(addon.Status.AppliedResources, func(res extensionsv1alpha1.AppliedResource) bool {
+			return res.Group == appsv1.GroupVersion.Group && res.Kind == kindComponentDefinition && res.Name == comp.Spec.CompDef
+		}) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: addon.Name}})
+		}
+	}
+	return requests
+}
+
 func (r *AddonReconciler) cleanupJobPods(reqCtx intctrlutil.RequestCtx) error {
 	if err := r.DeleteAllOf(reqCtx.Ctx, &corev1.Pod{},
 		client.InNamespace(viper.GetString(constant.CfgKeyCtrlrMgrNS)),
@@ -0,0 +1,201 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+)
+
+// unsatisfiedDependencies returns the descriptions of the dependencies of the addon that are not enabled,
+// or whose versions don't satisfy the constraints.
+func unsatisfiedDependencies(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon) ([]string, error) {
+	addons, err := listAddons(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	var unsatisfied []string
+	for _, dep := range addon.Spec.Dependencies {
+		if path := dependencyPath(addons, dep.Name, addon.Name); path != nil {
+			return nil, &dependencyCycleError{cycle: append([]string{addon.Name}, path...)}
+		}
+		depAddon, ok := addons[dep.Name]
+		if !ok {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("addon %s is not found", dep.Name))
+			continue
+		}
+		if depAddon.Status.Phase != extensionsv1alpha1.AddonEnabled {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("addon %s is not enabled", dep.Name))
+			continue
+		}
+		if len(dep.Version) == 0 {
+			continue
+		}
+		if ok, err := validateVersion(dep.Version, depAddon.Spec.Version); err != nil || !ok {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("the version of addon %s is %s, %s is required",
+				dep.Name, depAddon.Spec.Version, dep.Version))
+		}
+	}
+	return unsatisfied, nil
+}
+
+// enabledDependents returns the names of the addons that depend on the addon and are enabled,
+// or still have their resources installed.
+func enabledDependents(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon) ([]string, error) {
+	addons, err := listAddons(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(addons))
+	for name := range addons {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var dependents []string
+	for _, name := range names {
+		item := addons[name]
+		if item.Name == addon.Name || !slices.ContainsFunc(item.Spec.Dependencies, func(dep extensionsv1alpha1.AddonDependency) bool {
+			return dep.Name == addon.Name
+		}) {
+			continue
+		}
+		switch {
+		case item.Spec.InstallSpec.GetEnabled() && item.GetDeletionTimestamp().IsZero():
+		case slices.Contains([]extensionsv1alpha1.AddonPhase{extensionsv1alpha1.AddonEnabled,
+			extensionsv1alpha1.AddonEnabling, extensionsv1alpha1.AddonDisabling}, item.Status.Phase):
+		default:
+			continue
+		}
+		if path := dependencyPath(addons, addon.Name, item.Name); path != nil {
+			return nil, &dependencyCycleError{cycle: append(path, addon.Name)}
+		}
+		dependents = append(dependents, item.Name)
+	}
+	return dependents, nil
+}
+
+// dependencyCycleError is returned if the addon is in a circular dependency chain,
+// which can be neither enabled nor disabled until the chain is broken.
+type dependencyCycleError struct {
+	cycle []string
+}
+
+func (e *dependencyCycleError) Error() string {
+	return fmt.Sprintf("circular dependencies of addons: %s", strings.Join(e.cycle, " -> "))
+}
+
+func listAddons(ctx context.Context, cli client.Reader) (map[string]*extensionsv1alpha1.Addon, error) {
+	addons := &extensionsv1alpha1.AddonList{}
+	if err := cli.List(ctx, addons); err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*extensionsv1alpha1.Addon, len(addons.Items))
+	for i := range addons.Items {
+		byName[addons.Items[i].Name] = &addons.Items[i]
+	}
+	return byName, nil
+}
+
+// dependencyPath returns the names of the addons on the dependency path from the addon to the target,
+// or nil if the target is not depended on by the addon directly or transitively.
+func dependencyPath(addons map[string]*extensionsv1alpha1.Addon, from, to string) []string {
+	visited := map[string]bool{}
+	var walk func(name string) []string
+	walk = func(name string) []string {
+		if name == to {
+			return []string{name}
+		}
+		if visited[name] {
+			return nil
+		}
+		visited[name] = true
+		addon, ok := addons[name]
+		if !ok {
+			return nil
+		}
+		for _, dep := range addon.Spec.Dependencies {
+			if path := walk(dep.Name); path != nil {
+				return append([]string{name}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// setAddonCheckedCondition sets the checked condition to false with the reason, and records an event if it is changed.
+// Unlike setAddonErrorConditions, the observed generation is kept, so that the addon is processed again once the
+// condition is resolved.
+func setAddonCheckedCondition(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, reason, message string) error {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+	if cond != nil && cond.Status == metav1.ConditionFalse && cond.Reason == reason && cond.Message == message {
+		return nil
+	}
+	patch := client.MergeFrom(addon.DeepCopy())
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               extensionsv1alpha1.ConditionTypeChecked,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: addon.Generation,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := stageCtx.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+		return err
+	}
+	stageCtx.reconciler.Event(addon, corev1.EventTypeWarning, reason, message)
+	return nil
+}
+
+// clearAddonCheckedConditions removes the checked condition if it is set with any of the reasons.
+func clearAddonCheckedConditions(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, reasons ...string) error {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+	if cond == nil || !slices.Contains(reasons, cond.Reason) {
+		return nil
+	}
+	patch := client.MergeFrom(addon.DeepCopy())
+	meta.RemoveStatusCondition(&addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+	return stageCtx.reconciler.Status().Patch(ctx, addon, patch)
+}
+
+// handleDependencyCycle sets the checked condition if the addon is in a circular dependency chain,
+// and returns true if so. The addon is reconciled again once the related addons are changed.
+func (r *stageCtx) handleDependencyCycle(ctx context.Context, addon *extensionsv1alpha1.Addon, err error) bool {
+	var cycleErr *dependencyCycleError
+	if !errors.As(err, &cycleErr) {
+		return false
+	}
+	if err = setAddonCheckedCondition(ctx, r, addon, DependencyCycleDetected, cycleErr.Error()); err != nil {
+		r.setRequeueWithErr(err, "")
+		return true
+	}
+	r.setReconciled()
+	return true
+}
@@ -0,0 +1,399 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/containerd/containerd/remotes/docker"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	orascontent "oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// pullOCIManifests pulls the manifests from an OCI artifact, it is a variable to be replaced in tests.
+var pullOCIManifests = pullOCIArtifactLayers
+
+func (r *manifestTypeInstallStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		r.reqCtx.Log.V(1).Info("manifestTypeInstallStage", "phase", addon.Status.Phase)
+		mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+
+		var manifests [][]byte
+		if addon.Spec.Manifest.OCI != nil {
+			layers, err := pullOCIManifests(ctx, r.reconciler.Client, addon.Spec.Manifest.OCI)
+			if err != nil {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+					fmt.Sprintf("Pull manifests from OCI artifact %s failed: %s", addon.Spec.Manifest.OCI.Reference, err.Error()))
+				r.setReconciled()
+				return
+			}
+			manifests = append(manifests, layers...)
+		}
+		for _, cmRef := range addon.Spec.Manifest.ConfigMapRefs {
+			cm := &corev1.ConfigMap{}
+			key := client.ObjectKey{
+				Name:      cmRef.Name,
+				Namespace: mgrNS}
+			if err := r.reconciler.Get(ctx, key, cm); err != nil {
+				if !apierrors.IsNotFound(err) {
+					r.setRequeueWithErr(err, "")
+					return
+				}
+				r.setRequeueAfter(time.Second, fmt.Sprintf("ConfigMap %s not found", cmRef.Name))
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, false, true, AddonRefObjError,
+					fmt.Sprintf("ConfigMap object %v not found", key))
+				return
+			}
+			if !findDataKey(cm.Data, cmRef) {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, AddonRefObjError,
+					fmt.Sprintf("Read manifests from ConfigMap %v failed, key %s not found", key, cmRef.Key))
+				r.setReconciled()
+				return
+			}
+			manifests = append(manifests, []byte(cm.Data[cmRef.Key]))
+		}
+
+		objs, err := decodeManifests(manifests...)
+		if err != nil {
+			setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+				fmt.Sprintf("Decode manifests failed: %s", err.Error()))
+			r.setReconciled()
+			return
+		}
+		applied := make([]extensionsv1alpha1.AppliedResource, 0, len(objs))
+		for _, obj := range objs {
+			if !isManifestKindAllowed(obj.GroupVersionKind().GroupKind()) {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+					fmt.Sprintf("Kind %s of %s is not allowed in the manifests", obj.GroupVersionKind().GroupKind().String(), obj.GetName()))
+				r.setReconciled()
+				return
+			}
+			namespaced, err := r.reconciler.IsObjectNamespaced(obj)
+			if err != nil {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+					fmt.Sprintf("Resolve %s %s failed: %s", obj.GetKind(), obj.GetName(), err.Error()))
+				r.setReconciled()
+				return
+			}
+			if !namespaced {
+				obj.SetNamespace("")
+			} else if obj.GetNamespace() == "" {
+				obj.SetNamespace(mgrNS)
+			}
+			conflict, err := ownershipConflict(ctx, r.reconciler.Client, addon, obj)
+			if err != nil {
+				r.setRequeueWithErr(err, "")
+				return
+			}
+			if conflict != "" {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed, conflict)
+				r.setReconciled()
+				return
+			}
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[constant.AddonNameLabelKey] = addon.Name
+			labels[constant.AppManagedByLabelKey] = constant.AppName
+			obj.SetLabels(labels)
+			applied = append(applied, appliedResourceOf(obj))
+		}
+
+		// record the objects to be applied before applying them, so that they can be
+		// cleaned up even if the installation fails halfway
+		stale := staleAppliedResources(addon.Status.AppliedResources, applied)
+		if addon.Annotations[ForceUpgrade] != trueVal {
+			impacts, err := upgradeImpacts(ctx, r.reconciler.Client, objs, stale)
+			if err != nil {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+					fmt.Sprintf("Check upgrade impacts failed: %s", err.Error()))
+				r.setReconciled()
+				return
+			}
+			if len(impacts) > 0 {
+				blockUpgrade(ctx, &r.stageCtx, addon, impacts)
+				return
+			}
+		}
+		if err = patchAppliedResources(ctx, r.reconciler.Client, addon, append(slices.Clone(stale), applied...), addon.Status.ManifestsHash); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		for _, obj := range objs {
+			if err = model.ServerSideApply(ctx, r.reconciler.Client, nil, obj, true, model.ApplyConflictEventFunc(r.reconciler, addon)); err != nil {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+					fmt.Sprintf("Apply %s %s failed: %s", obj.GetKind(), obj.GetName(), err.Error()))
+				r.setReconciled()
+				return
+			}
+		}
+
+		// prune the objects which are no longer in the manifests
+		if err = deleteAppliedResources(ctx, r.reconciler.Client, stale); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if err = patchAppliedResources(ctx, r.reconciler.Client, addon, applied, manifestsHash(manifests)); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if err = finishUpgrade(ctx, &r.stageCtx, addon); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+	})
+	r.next.Handle(ctx)
+}
+
+func (r *manifestTypeUninstallStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		r.reqCtx.Log.V(1).Info("manifestTypeUninstallStage", "phase", addon.Status.Phase)
+		if len(addon.Status.AppliedResources) == 0 {
+			return
+		}
+		if err := deleteAppliedResources(ctx, r.reconciler.Client, addon.Status.AppliedResources); err != nil {
+			r.reconciler.Event(addon, corev1.EventTypeWarning, UninstallationFailed,
+				fmt.Sprintf("Uninstallation failed: %s", err.Error()))
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if err := patchAppliedResources(ctx, r.reconciler.Client, addon, nil, ""); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+	})
+	r.next.Handle(ctx)
+}
+
+// manifestKinds are the kinds allowed in the manifests of add-ons.
+var manifestKinds = []schema.GroupKind{
+	{Group: "apps.kubeblocks.io", Kind: "ClusterDefinition"},
+	{Group: "apps.kubeblocks.io", Kind: kindComponentDefinition},
+	{Group: "apps.kubeblocks.io", Kind: kindComponentVersion},
+	{Group: "apps.kubeblocks.io", Kind: "ShardingDefinition"},
+	{Group: "apps.kubeblocks.io", Kind: "ConfigConstraint"},
+	{Group: "dataprotection.kubeblocks.io", Kind: "ActionSet"},
+	{Group: "dataprotection.kubeblocks.io", Kind: "BackupPolicyTemplate"},
+	{Group: "operations.kubeblocks.io", Kind: "OpsDefinition"},
+}
+
+func isManifestKindAllowed(gk schema.GroupKind) bool {
+	return slices.Contains(manifestKinds, gk)
+}
+
+// ownershipConflict checks whether the object exists but has not been applied by the add-on, i.e., it is owned
+// by another add-on or not managed by any add-on. All the add-ons apply the objects with the same field manager,
+// so these objects are refused rather than being taken over silently.
+func ownershipConflict(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon, obj *unstructured.Unstructured) (string, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	switch owner := existing.GetLabels()[constant.AddonNameLabelKey]; owner {
+	case addon.Name:
+		return "", nil
+	case "":
+		return fmt.Sprintf("%s %s already exists and is not managed by any addon", obj.GetKind(), obj.GetName()), nil
+	default:
+		return fmt.Sprintf("%s %s is owned by addon %s", obj.GetKind(), obj.GetName(), owner), nil
+	}
+}
+
+// manifestsHash computes the hash of the manifests, which tells whether the manifests are changed since they are applied.
+func manifestsHash(manifests [][]byte) string {
+	hash := sha256.New()
+	for _, manifest := range manifests {
+		hash.Write(manifest)
+		// separate the manifests, so that moving the content between them changes the hash
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// manifestsChanged checks whether the manifests in the ConfigMaps referenced by the enabled add-on are changed
+// since they are applied. The manifests that can't be read are ignored, the applied objects are kept as they are.
+func manifestsChanged(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon) (bool, error) {
+	if addon.Spec.Type != extensionsv1alpha1.ManifestType || addon.Spec.Manifest == nil || len(addon.Spec.Manifest.ConfigMapRefs) == 0 {
+		return false, nil
+	}
+	var manifests [][]byte
+	for _, cmRef := range addon.Spec.Manifest.ConfigMapRefs {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS), Name: cmRef.Name}
+		if err := cli.Get(ctx, key, cm); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if !findDataKey(cm.Data, cmRef) {
+			return false, nil
+		}
+		manifests = append(manifests, []byte(cm.Data[cmRef.Key]))
+	}
+	return manifestsHash(manifests) != addon.Status.ManifestsHash, nil
+}
+
+// decodeManifests decodes the YAML or JSON documents into objects, empty documents are skipped.
+func decodeManifests(manifests ...[]byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, manifest := range manifests {
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, err
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+				return nil, fmt.Errorf("apiVersion, kind and name are required, but got %s %s/%s",
+					obj.GetAPIVersion(), obj.GetKind(), obj.GetName())
+			}
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// pullOCIArtifactLayers pulls the artifact and returns the contents of its layers.
+func pullOCIArtifactLayers(ctx context.Context, cli client.Client, source *extensionsv1alpha1.OCIArtifactSource) ([][]byte, error) {
+	httpClient := &http.Client{}
+	if source.Insecure {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	authorizerOpts := []docker.AuthorizerOpt{docker.WithAuthClient(httpClient)}
+	if source.CredentialsSecretRef != nil {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{
+			Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+			Name:      source.CredentialsSecretRef.Name,
+		}
+		if err := cli.Get(ctx, key, secret); err != nil {
+			return nil, err
+		}
+		username := string(secret.Data[corev1.BasicAuthUsernameKey])
+		password := string(secret.Data[corev1.BasicAuthPasswordKey])
+		authorizerOpts = append(authorizerOpts, docker.WithAuthCreds(func(string) (string, string, error) {
+			return username, password, nil
+		}))
+	}
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(
+			docker.WithClient(httpClient),
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(authorizerOpts...)),
+			docker.WithPlainHTTP(func(string) (bool, error) { return source.PlainHTTP, nil }),
+		),
+	})
+
+	var layers []ocispec.Descriptor
+	store := orascontent.NewMemory()
+	if _, err := oras.Copy(ctx, orascontent.Registry{Resolver: resolver}, source.Reference, store, "",
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithLayerDescriptors(func(descriptors []ocispec.Descriptor) { layers = descriptors })); err != nil {
+		return nil, err
+	}
+	contents := make([][]byte, 0, len(layers))
+	for _, layer := range layers {
+		_, content, ok := store.Get(layer)
+		if !ok {
+			return nil, fmt.Errorf("layer %s of artifact %s not found", layer.Digest, source.Reference)
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+func appliedResourceOf(obj *unstructured.Unstructured) extensionsv1alpha1.AppliedResource {
+	gvk := obj.GroupVersionKind()
+	return extensionsv1alpha1.AppliedResource{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// staleAppliedResources returns the resources of 'origin' which are not in 'applied', the version is ignored.
+func staleAppliedResources(origin, applied []extensionsv1alpha1.AppliedResource) []extensionsv1alpha1.AppliedResource {
+	var stale []extensionsv1alpha1.AppliedResource
+	for _, res := range origin {
+		if !slices.ContainsFunc(applied, func(r extensionsv1alpha1.AppliedResource) bool {
+			return r.Group == res.Group && r.Kind == res.Kind && r.Namespace == res.Namespace && r.Name == res.Name
+		}) {
+			stale = append(stale, res)
+		}
+	}
+	return stale
+}
+
+func deleteAppliedResources(ctx context.Context, cli client.Client, resources []extensionsv1alpha1.AppliedResource) error {
+	for _, res := range resources {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: res.Group, Version: res.Version, Kind: res.Kind})
+		obj.SetNamespace(res.Namespace)
+		obj.SetName(res.Name)
+		// the API of the object may have been removed, nothing to delete
+		if err := cli.Delete(ctx, obj); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func patchAppliedResources(ctx context.Context, cli client.Client, addon *extensionsv1alpha1.Addon,
+	resources []extensionsv1alpha1.AppliedResource, hash string) error {
+	patch := client.MergeFrom(addon.DeepCopy())
+	addon.Status.AppliedResources = resources
+	addon.Status.ManifestsHash = hash
+	return cli.Status().Patch(ctx, addon, patch)
+}
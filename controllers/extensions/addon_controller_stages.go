@@ -141,6 +141,10 @@ type enabledWithDefaultValuesStage struct {
 	stageCtx
 }
 
+type dependencyCheckStage struct {
+	stageCtx
+}
+
 type progressingHandler struct {
 	stageCtx
 	enablingStage  enablingStage
@@ -155,14 +159,24 @@ type helmTypeUninstallStage struct {
 	stageCtx
 }
 
+type manifestTypeInstallStage struct {
+	stageCtx
+}
+
+type manifestTypeUninstallStage struct {
+	stageCtx
+}
+
 type enablingStage struct {
 	stageCtx
-	helmTypeInstallStage helmTypeInstallStage
+	helmTypeInstallStage     helmTypeInstallStage
+	manifestTypeInstallStage manifestTypeInstallStage
 }
 
 type disablingStage struct {
 	stageCtx
-	helmTypeUninstallStage helmTypeUninstallStage
+	helmTypeUninstallStage     helmTypeUninstallStage
+	manifestTypeUninstallStage manifestTypeUninstallStage
 }
 
 type terminalStateStage struct {
@@ -190,6 +204,31 @@ func (r *fetchNDeletionCheckStage) Handle(ctx context.Context) {
 			r.updateResultNErr(res, err)
 			return
 		}
+
+		// the addon can't be disabled while any enabled addon depends on it
+		if addon.Status.Phase != "" && addon.Status.Phase != extensionsv1alpha1.AddonDisabled {
+			dependents, err := enabledDependents(ctx, r.reconciler.Client, addon)
+			if r.handleDependencyCycle(ctx, addon, err) {
+				return
+			}
+			if err != nil {
+				r.setRequeueWithErr(err, "")
+				return
+			}
+			if len(dependents) > 0 {
+				if err = setAddonCheckedCondition(ctx, &r.stageCtx, addon, DisableBlockedByDependents,
+					fmt.Sprintf("Addon is depended on by enabled addons: %s", strings.Join(dependents, ", "))); err != nil {
+					r.setRequeueWithErr(err, "")
+					return
+				}
+				r.setReconciled()
+				return
+			}
+			if err = clearAddonCheckedConditions(ctx, &r.stageCtx, addon, DisableBlockedByDependents, DependencyCycleDetected); err != nil {
+				r.setRequeueWithErr(err, "")
+				return
+			}
+		}
 	}
 	res, err := intctrlutil.HandleCRDeletion(*r.reqCtx, r.reconciler, addon, addonFinalizerName, func() (*ctrl.Result, error) {
 		r.deletionStage.Handle(ctx)
@@ -209,6 +248,18 @@ func (r *genIDProceedCheckStage) Handle(ctx context.Context) {
 		switch addon.Status.Phase {
 		case extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonDisabled:
 			if addon.Generation == addon.Status.ObservedGeneration {
+				if addon.Status.Phase == extensionsv1alpha1.AddonEnabled {
+					changed, err := manifestsChanged(ctx, r.reconciler.Client, addon)
+					if err != nil {
+						r.setRequeueWithErr(err, "")
+						return
+					}
+					if changed {
+						// upgrade the addon with the changed manifests
+						r.reqCtx.Log.V(1).Info("the manifests of the addon are changed")
+						return
+					}
+				}
 				res, err := r.reconciler.deleteExternalResources(*r.reqCtx, addon)
 				if res != nil || err != nil {
 					r.updateResultNErr(res, err)
@@ -393,6 +444,38 @@ func (r *enabledWithDefaultValuesStage) Handle(ctx context.Context) {
 	r.next.Handle(ctx)
 }
 
+func (r *dependencyCheckStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		r.reqCtx.Log.V(1).Info("dependencyCheckStage", "phase", addon.Status.Phase)
+		// the dependencies are checked before enabling or upgrading the addon
+		if !addon.Spec.InstallSpec.GetEnabled() || addon.Status.Phase == extensionsv1alpha1.AddonEnabling {
+			return
+		}
+		unsatisfied, err := unsatisfiedDependencies(ctx, r.reconciler.Client, addon)
+		if r.handleDependencyCycle(ctx, addon, err) {
+			return
+		}
+		if err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if len(unsatisfied) == 0 {
+			if err = clearAddonCheckedConditions(ctx, &r.stageCtx, addon, DependenciesNotSatisfied, DependencyCycleDetected); err != nil {
+				r.setRequeueWithErr(err, "")
+			}
+			return
+		}
+		if err = setAddonCheckedCondition(ctx, &r.stageCtx, addon, DependenciesNotSatisfied,
+			fmt.Sprintf("Waiting for the dependencies: %s", strings.Join(unsatisfied, "; "))); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		// it will be reconciled again once the dependencies are changed
+		r.setReconciled()
+	})
+	r.next.Handle(ctx)
+}
+
 func (r *progressingHandler) Handle(ctx context.Context) {
 	r.enablingStage.stageCtx = r.stageCtx
 	r.disablingStage.stageCtx = r.stageCtx
@@ -459,6 +542,10 @@ func getInstallJobName(addon *extensionsv1alpha1.Addon) string {
 	return fmt.Sprintf("install-%s-addon", addon.Name)
 }
 
+func getTemplateJobName(addon *extensionsv1alpha1.Addon) string {
+	return fmt.Sprintf("template-%s-addon", addon.Name)
+}
+
 func getUninstallJobName(addon *extensionsv1alpha1.Addon) string {
 	return fmt.Sprintf("uninstall-%s-addon", addon.Name)
 }
@@ -543,6 +630,9 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 			return
 		} else if err == nil {
 			if helmInstallJob.Status.Succeeded > 0 {
+				if err = finishUpgrade(ctx, &r.stageCtx, addon); err != nil {
+					r.setRequeueWithErr(err, "")
+				}
 				return
 			}
 
@@ -689,6 +779,24 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 		setSharedVolume(addon, helmJobPodSpec)
 		setInitContainer(addon, helmJobPodSpec)
 
+		if addon.Annotations[ForceUpgrade] != trueVal {
+			impacts, rendered, err := helmUpgradeImpacts(ctx, &r.stageCtx, addon, helmInstallJob)
+			if err != nil {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
+					fmt.Sprintf("Check upgrade impacts failed: %s", err.Error()))
+				r.setReconciled()
+				return
+			}
+			if !rendered {
+				r.setRequeueAfter(time.Second, fmt.Sprintf("rendering Helm chart of addon %s", addon.Name))
+				return
+			}
+			if len(impacts) > 0 {
+				blockUpgrade(ctx, &r.stageCtx, addon, impacts)
+				return
+			}
+		}
+
 		if err := r.reconciler.Create(ctx, helmInstallJob); err != nil {
 			r.setRequeueWithErr(err, "")
 			return
@@ -760,21 +868,11 @@ func (r *helmTypeUninstallStage) Handle(ctx context.Context) {
 		}
 
 		// inspect helm releases secrets
-		helmSecrets := &corev1.SecretList{}
-		if err := r.reconciler.List(ctx, helmSecrets, client.MatchingLabels{
-			"name":  getHelmReleaseName(addon),
-			"owner": "helm",
-		}); err != nil {
+		releaseExist, err := helmReleaseExists(ctx, r.reconciler.Client, addon)
+		if err != nil {
 			r.setRequeueWithErr(err, "")
 			return
 		}
-		releaseExist := false
-		for _, s := range helmSecrets.Items {
-			if string(s.Type) == "helm.sh/release.v1" {
-				releaseExist = true
-				break
-			}
-		}
 
 		// has no installed release simply return
 		if !releaseExist {
@@ -783,7 +881,6 @@ func (r *helmTypeUninstallStage) Handle(ctx context.Context) {
 		}
 
 		r.reqCtx.Log.V(1).Info("creating helm uninstall job", "job", key)
-		var err error
 		// create `helm delete <release>` job
 		helmUninstallJob, err = createHelmJobProto(addon)
 		if err != nil {
@@ -812,11 +909,14 @@ func (r *helmTypeUninstallStage) Handle(ctx context.Context) {
 
 func (r *enablingStage) Handle(ctx context.Context) {
 	r.helmTypeInstallStage.stageCtx = r.stageCtx
+	r.manifestTypeInstallStage.stageCtx = r.stageCtx
 	r.process(func(addon *extensionsv1alpha1.Addon) {
 		r.reqCtx.Log.V(1).Info("enablingStage", "phase", addon.Status.Phase)
 		switch addon.Spec.Type {
 		case extensionsv1alpha1.HelmType:
 			r.helmTypeInstallStage.Handle(ctx)
+		case extensionsv1alpha1.ManifestType:
+			r.manifestTypeInstallStage.Handle(ctx)
 		default:
 		}
 	})
@@ -825,11 +925,14 @@ func (r *enablingStage) Handle(ctx context.Context) {
 
 func (r *disablingStage) Handle(ctx context.Context) {
 	r.helmTypeUninstallStage.stageCtx = r.stageCtx
+	r.manifestTypeUninstallStage.stageCtx = r.stageCtx
 	r.process(func(addon *extensionsv1alpha1.Addon) {
 		r.reqCtx.Log.V(1).Info("disablingStage", "phase", addon.Status.Phase, "type", addon.Spec.Type)
 		switch addon.Spec.Type {
 		case extensionsv1alpha1.HelmType:
 			r.helmTypeUninstallStage.Handle(ctx)
+		case extensionsv1alpha1.ManifestType:
+			r.manifestTypeUninstallStage.Handle(ctx)
 		default:
 		}
 	})
@@ -1128,6 +1231,52 @@ podsloop:
 	return nil
 }
 
+// readJobPodLogs reads the logs of the succeeded pod of the job, it is a variable to be replaced in tests.
+var readJobPodLogs = readSucceededJobPodLogs
+
+func readSucceededJobPodLogs(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, jobName string) ([]byte, error) {
+	podList := &corev1.PodList{}
+	if err := stageCtx.reconciler.List(ctx, podList,
+		client.InNamespace(viper.GetString(constant.CfgKeyCtrlrMgrNS)),
+		client.MatchingLabels{
+			constant.AddonNameLabelKey:    addon.Name,
+			constant.AppManagedByLabelKey: constant.AppName,
+			"job-name":                    jobName,
+		}); err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		clientset, err := corev1client.NewForConfig(stageCtx.reconciler.RestConfig)
+		if err != nil {
+			return nil, err
+		}
+		return clientset.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: getJobMainContainerName(addon),
+		}).DoRaw(ctx)
+	}
+	return nil, fmt.Errorf("no succeeded pod of jobs.batch %s found", jobName)
+}
+
+// helmReleaseExists checks whether the Helm release of the add-on is installed by inspecting the release secrets.
+func helmReleaseExists(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon) (bool, error) {
+	helmSecrets := &corev1.SecretList{}
+	if err := cli.List(ctx, helmSecrets, client.MatchingLabels{
+		"name":  getHelmReleaseName(addon),
+		"owner": "helm",
+	}); err != nil {
+		return false, err
+	}
+	for _, s := range helmSecrets.Items {
+		if string(s.Type) == "helm.sh/release.v1" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func findDataKey[V string | []byte](data map[string]V, refObj extensionsv1alpha1.DataObjectKeySelector) bool {
 	for k := range data {
 		if k != refObj.Key {
@@ -1256,6 +1405,14 @@ func checkAddonSpec(addon *extensionsv1alpha1.Addon) error {
 			return fmt.Errorf("invalid Helm configuration: either 'Helm' is not specified")
 		}
 	}
+	if addon.Spec.Type == extensionsv1alpha1.ManifestType {
+		if addon.Spec.Manifest == nil {
+			return fmt.Errorf("invalid Manifest configuration: 'Manifest' is not specified")
+		}
+		if (len(addon.Spec.Manifest.ConfigMapRefs) > 0) == (addon.Spec.Manifest.OCI != nil) {
+			return fmt.Errorf("invalid Manifest configuration: exactly one of 'ConfigMapRefs' and 'OCI' is required")
+		}
+	}
 	return nil
 }
 
@@ -31,6 +31,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
@@ -41,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
 
 	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
@@ -63,6 +65,9 @@ var _ = Describe("Addon controller", func() {
 		// non-namespaced
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.AddonSignature, true, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentDefinitionSignature, true, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true,
+			client.InNamespace(testCtx.DefaultNamespace), ml)
 
 		inNS := client.InNamespace(viper.GetString(constant.CfgKeyCtrlrMgrNS))
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS,
@@ -746,6 +751,403 @@ var _ = Describe("Addon controller", func() {
 			addonStatusPhaseCheck(2, extensionsv1alpha1.AddonFailed, nil)
 		})
 
+		It("should successfully reconcile a custom resource for Addon with spec.type=Manifest", func() {
+			mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+			manifest := func(name string) string {
+				compDef := testapps.NewComponentDefinitionFactory(name).
+					SetDefaultSpec().
+					AddLabels(testCtx.TestObjLabelKey, "true").
+					GetObject()
+				compDef.APIVersion = kbappsv1.GroupVersion.String()
+				compDef.Kind = kindComponentDefinition
+				out, err := yaml.Marshal(compDef)
+				Expect(err).Should(Succeed())
+				return string(out)
+			}
+			checkApplied := func(g Gomega, name string, expected bool) {
+				compDef := &kbappsv1.ComponentDefinition{}
+				err := testCtx.Cli.Get(ctx, client.ObjectKey{Name: name}, compDef)
+				if !expected {
+					g.Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+					return
+				}
+				g.Expect(err).Should(Succeed())
+				g.Expect(compDef.Labels).Should(HaveKeyWithValue(constant.AddonNameLabelKey, addon.Name))
+			}
+
+			By("By create the manifests ConfigMap")
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addon-manifests",
+					Namespace: mgrNS,
+				},
+				Data: map[string]string{
+					"manifests.yaml": manifest("test-addon-manifest-a") + "---\n" + manifest("test-addon-manifest-b"),
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, manifests)).Should(Succeed())
+
+			By("By addon enabled via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Type = extensionsv1alpha1.ManifestType
+				newOjb.Spec.Helm = nil
+				newOjb.Spec.Manifest = &extensionsv1alpha1.ManifestTypeInstallSpec{
+					ConfigMapRefs: []extensionsv1alpha1.DataObjectKeySelector{
+						{Name: manifests.Name, Key: "manifests.yaml"},
+					},
+				}
+			})
+			addonStatusPhaseCheck(2, extensionsv1alpha1.AddonEnabled, nil)
+			Eventually(func(g Gomega) {
+				checkApplied(g, "test-addon-manifest-a", true)
+				checkApplied(g, "test-addon-manifest-b", true)
+			}).Should(Succeed())
+			Expect(addon.Status.AppliedResources).Should(HaveLen(2))
+
+			By("By upgrading addon with the manifest b removed")
+			Expect(testapps.ChangeObj(&testCtx, manifests, func(cm *corev1.ConfigMap) {
+				cm.Data["manifests.yaml"] = manifest("test-addon-manifest-a")
+			})).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, addon, func(obj *extensionsv1alpha1.Addon) {
+				obj.Spec.Version = "1.0.1"
+			})).Should(Succeed())
+			addonStatusPhaseCheck(3, extensionsv1alpha1.AddonEnabled, nil)
+			Eventually(func(g Gomega) {
+				checkApplied(g, "test-addon-manifest-a", true)
+				checkApplied(g, "test-addon-manifest-b", false)
+			}).Should(Succeed())
+			Expect(addon.Status.AppliedResources).Should(HaveLen(1))
+
+			By("By upgrading addon with the manifest b added back to the ConfigMap only")
+			Expect(testapps.ChangeObj(&testCtx, manifests, func(cm *corev1.ConfigMap) {
+				cm.Data["manifests.yaml"] = manifest("test-addon-manifest-a") + "---\n" + manifest("test-addon-manifest-b")
+			})).Should(Succeed())
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				checkApplied(g, "test-addon-manifest-b", true)
+			}).Should(Succeed())
+			addonStatusPhaseCheck(3, extensionsv1alpha1.AddonEnabled, nil)
+			Expect(addon.Status.AppliedResources).Should(HaveLen(2))
+
+			By("By disabling enabled addon")
+			disableAddon(4)
+			addonStatusPhaseCheck(4, extensionsv1alpha1.AddonDisabled, nil)
+			Eventually(func(g Gomega) {
+				checkApplied(g, "test-addon-manifest-a", false)
+			}).Should(Succeed())
+			Expect(addon.Status.AppliedResources).Should(BeEmpty())
+		})
+
+		It("should failed reconcile a custom resource for Addon with spec.type=Manifest of disallowed kinds", func() {
+			mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+			By("By create the manifests ConfigMap")
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addon-manifests",
+					Namespace: mgrNS,
+				},
+				Data: map[string]string{
+					"manifests.yaml": fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-addon-manifest
+  labels:
+    %s: "true"
+data:
+  key: value
+`, testCtx.TestObjLabelKey),
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, manifests)).Should(Succeed())
+
+			By("By addon enabled via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Type = extensionsv1alpha1.ManifestType
+				newOjb.Spec.Helm = nil
+				newOjb.Spec.Manifest = &extensionsv1alpha1.ManifestTypeInstallSpec{
+					ConfigMapRefs: []extensionsv1alpha1.DataObjectKeySelector{
+						{Name: manifests.Name, Key: "manifests.yaml"},
+					},
+				}
+			})
+			addonStatusPhaseCheck(2, extensionsv1alpha1.AddonFailed, nil)
+			err := testCtx.Cli.Get(ctx, client.ObjectKey{Namespace: mgrNS, Name: "test-addon-manifest"}, &corev1.ConfigMap{})
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		})
+
+		It("should failed reconcile a custom resource for Addon with spec.type=Manifest of objects not managed by it", func() {
+			mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+			By("By create the ComponentDefinition not managed by any addon")
+			compDef := testapps.NewComponentDefinitionFactory("test-addon-unmanaged-compdef").
+				SetDefaultSpec().
+				AddLabels(testCtx.TestObjLabelKey, "true").
+				Create(&testCtx).
+				GetObject()
+			compDefManifest := compDef.DeepCopy()
+			compDefManifest.ObjectMeta = metav1.ObjectMeta{Name: compDef.Name, Labels: compDef.Labels}
+			compDefManifest.APIVersion = kbappsv1.GroupVersion.String()
+			compDefManifest.Kind = kindComponentDefinition
+			out, err := yaml.Marshal(compDefManifest)
+			Expect(err).Should(Succeed())
+
+			By("By create the manifests ConfigMap")
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addon-manifests",
+					Namespace: mgrNS,
+				},
+				Data: map[string]string{
+					"manifests.yaml": string(out),
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, manifests)).Should(Succeed())
+
+			By("By addon enabled via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Type = extensionsv1alpha1.ManifestType
+				newOjb.Spec.Helm = nil
+				newOjb.Spec.Manifest = &extensionsv1alpha1.ManifestTypeInstallSpec{
+					ConfigMapRefs: []extensionsv1alpha1.DataObjectKeySelector{
+						{Name: manifests.Name, Key: "manifests.yaml"},
+					},
+				}
+			})
+			addonStatusPhaseCheck(2, extensionsv1alpha1.AddonFailed, nil)
+			Expect(addon.Status.AppliedResources).Should(BeEmpty())
+			Expect(testCtx.Cli.Get(ctx, client.ObjectKeyFromObject(compDef), compDef)).Should(Succeed())
+			Expect(compDef.Labels).ShouldNot(HaveKey(constant.AddonNameLabelKey))
+		})
+
+		It("should failed reconcile a custom resource for Addon with spec.type=Manifest of invalid OCI artifact", func() {
+			pullOCIManifests = func(context.Context, client.Client, *extensionsv1alpha1.OCIArtifactSource) ([][]byte, error) {
+				return [][]byte{[]byte("kind: ConfigMap")}, nil
+			}
+			defer func() {
+				pullOCIManifests = pullOCIArtifactLayers
+			}()
+
+			By("By addon enabled via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Type = extensionsv1alpha1.ManifestType
+				newOjb.Spec.Helm = nil
+				newOjb.Spec.Manifest = &extensionsv1alpha1.ManifestTypeInstallSpec{
+					OCI: &extensionsv1alpha1.OCIArtifactSource{Reference: "registry.example.com/addons/test:1.0.0"},
+				}
+			})
+			addonStatusPhaseCheck(2, extensionsv1alpha1.AddonFailed, nil)
+		})
+
+		It("should install Addon after its dependencies are enabled, and block disabling the dependencies", func() {
+			By("By create the dependency addon")
+			createAddonSpecWithRequiredAttributes(nil)
+			Eventually(func(g Gomega) {
+				doReconcileOnce(g)
+				addon = &extensionsv1alpha1.Addon{}
+				g.Expect(testCtx.Cli.Get(ctx, key, addon)).To(Not(HaveOccurred()))
+				g.Expect(addon.Status.Phase).Should(Equal(extensionsv1alpha1.AddonDisabled))
+			}).Should(Succeed())
+			depKey := key
+
+			By("By create the addon depending on it via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Dependencies = []extensionsv1alpha1.AddonDependency{{Name: depKey.Name}}
+			})
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				addon = &extensionsv1alpha1.Addon{}
+				g.Expect(testCtx.Cli.Get(ctx, key, addon)).To(Not(HaveOccurred()))
+				g.Expect(addon.Status.Phase).ShouldNot(Equal(extensionsv1alpha1.AddonEnabling))
+				cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Reason).Should(Equal(DependenciesNotSatisfied))
+			}).Should(Succeed())
+
+			By("By fake the dependency addon enabled")
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, depKey, func(obj *extensionsv1alpha1.Addon) {
+				obj.Status.Phase = extensionsv1alpha1.AddonEnabled
+			})()).Should(Succeed())
+			enablingPhaseCheck(2)
+
+			By("By deleting the dependency addon")
+			key = depKey
+			addon = &extensionsv1alpha1.Addon{}
+			Expect(testCtx.Cli.Get(ctx, key, addon)).To(Not(HaveOccurred()))
+			Expect(testCtx.Cli.Delete(ctx, addon)).To(Not(HaveOccurred()))
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				checkAddonNotDeleted(g)
+				cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Reason).Should(Equal(DisableBlockedByDependents))
+			}).Should(Succeed())
+		})
+
+		It("should not install Addon with circular dependencies", func() {
+			By("By create the dependency addon")
+			createAddonSpecWithRequiredAttributes(nil)
+			depAddon := addon
+
+			By("By create the addon depending on it via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Dependencies = []extensionsv1alpha1.AddonDependency{{Name: depAddon.Name}}
+			})
+
+			By("By making the dependency addon depend on the addon")
+			Expect(testapps.ChangeObj(&testCtx, depAddon, func(obj *extensionsv1alpha1.Addon) {
+				obj.Spec.Dependencies = []extensionsv1alpha1.AddonDependency{{Name: key.Name}}
+			})).Should(Succeed())
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				addon = &extensionsv1alpha1.Addon{}
+				g.Expect(testCtx.Cli.Get(ctx, key, addon)).To(Not(HaveOccurred()))
+				g.Expect(addon.Status.Phase).ShouldNot(Equal(extensionsv1alpha1.AddonEnabling))
+				cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeChecked)
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Reason).Should(Equal(DependencyCycleDetected))
+				g.Expect(cond.Message).Should(ContainSubstring(fmt.Sprintf("%s -> %s -> %s", key.Name, depAddon.Name, key.Name)))
+			}).Should(Succeed())
+		})
+
+		It("should block upgrading Addon which removes ComponentDefinitions used by clusters unless forced", func() {
+			mgrNS := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+			compDef := testapps.NewComponentDefinitionFactory("test-addon-compdef").
+				SetDefaultSpec().
+				AddLabels(testCtx.TestObjLabelKey, "true").
+				GetObject()
+			compDef.APIVersion = kbappsv1.GroupVersion.String()
+			compDef.Kind = kindComponentDefinition
+			compDefManifest, err := yaml.Marshal(compDef)
+			Expect(err).Should(Succeed())
+
+			By("By create the manifests ConfigMap")
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-addon-manifests",
+					Namespace: mgrNS,
+				},
+				Data: map[string]string{
+					"manifests.yaml": string(compDefManifest),
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, manifests)).Should(Succeed())
+
+			By("By addon enabled via auto-install")
+			createAddonSpecWithRequiredAttributes(func(newOjb *extensionsv1alpha1.Addon) {
+				newOjb.Spec.Installable.AutoInstall = true
+				newOjb.Spec.Type = extensionsv1alpha1.ManifestType
+				newOjb.Spec.Helm = nil
+				newOjb.Spec.Manifest = &extensionsv1alpha1.ManifestTypeInstallSpec{
+					ConfigMapRefs: []extensionsv1alpha1.DataObjectKeySelector{
+						{Name: manifests.Name, Key: "manifests.yaml"},
+					},
+				}
+			})
+			addonStatusPhaseCheck(2, extensionsv1alpha1.AddonEnabled, nil)
+
+			By("By creating component with the ComponentDefinition")
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, clusterName+"-comp", compDef.Name).
+				AddLabels(constant.AppInstanceLabelKey, clusterName).
+				SetReplicas(1).
+				Create(&testCtx)
+
+			By("By upgrading addon with the ComponentDefinition removed")
+			Expect(testapps.ChangeObj(&testCtx, manifests, func(cm *corev1.ConfigMap) {
+				cm.Data["manifests.yaml"] = ""
+			})).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, addon, func(obj *extensionsv1alpha1.Addon) {
+				obj.Spec.Version = "1.0.1"
+			})).Should(Succeed())
+			addonStatusPhaseCheck(3, extensionsv1alpha1.AddonEnabling, nil)
+			Expect(addon.Status.Conditions).Should(ContainElement(HaveField("Reason", UpgradeBlocked)))
+			Expect(testCtx.Cli.Get(ctx, client.ObjectKeyFromObject(compDef), &kbappsv1.ComponentDefinition{})).Should(Succeed())
+
+			By("By forcing the upgrade")
+			Expect(testapps.ChangeObj(&testCtx, addon, func(obj *extensionsv1alpha1.Addon) {
+				obj.Annotations = map[string]string{ForceUpgrade: trueVal}
+			})).Should(Succeed())
+			addonStatusPhaseCheck(3, extensionsv1alpha1.AddonEnabled, nil)
+			Eventually(func(g Gomega) {
+				err := testCtx.Cli.Get(ctx, client.ObjectKeyFromObject(compDef), &kbappsv1.ComponentDefinition{})
+				g.Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+			}).Should(Succeed())
+			Expect(addon.Annotations).ShouldNot(HaveKey(ForceUpgrade))
+			Expect(addon.Status.Conditions).ShouldNot(ContainElement(HaveField("Reason", UpgradeBlocked)))
+		})
+
+		It("should block upgrading Helm Addon which removes ComponentDefinitions used by clusters unless forced", func() {
+			By("By addon enabled via auto-install")
+			createAutoInstallAddon()
+			fakeInstallationCompletedJob(2)
+			fakeHelmRelease()
+
+			By("By creating component with the ComponentDefinition installed by the release")
+			compDef := testapps.NewComponentDefinitionFactory("test-addon-helm-compdef").
+				SetDefaultSpec().
+				AddLabels(testCtx.TestObjLabelKey, "true").
+				AddAnnotations(helmReleaseNameAnnotationKey, getHelmReleaseName(addon)).
+				Create(&testCtx).
+				GetObject()
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, clusterName+"-helm-comp", compDef.Name).
+				AddLabels(constant.AppInstanceLabelKey, clusterName).
+				SetReplicas(1).
+				Create(&testCtx)
+
+			By("By upgrading addon to a chart without the ComponentDefinition")
+			readJobPodLogs = func(context.Context, *stageCtx, *extensionsv1alpha1.Addon, string) ([]byte, error) {
+				return nil, nil
+			}
+			defer func() {
+				readJobPodLogs = readSucceededJobPodLogs
+			}()
+			Expect(testapps.ChangeObj(&testCtx, addon, func(obj *extensionsv1alpha1.Addon) {
+				obj.Spec.Version = "1.0.1"
+			})).Should(Succeed())
+			templateJobKey := client.ObjectKey{
+				Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+				Name:      getTemplateJobName(addon),
+			}
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				fakeCompletedJob(g, templateJobKey)
+			}).Should(Succeed())
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				addon = &extensionsv1alpha1.Addon{}
+				g.Expect(testCtx.Cli.Get(ctx, key, addon)).To(Not(HaveOccurred()))
+				g.Expect(addon.Status.Phase).Should(Equal(extensionsv1alpha1.AddonEnabling))
+				g.Expect(addon.Status.Conditions).Should(ContainElement(HaveField("Reason", UpgradeBlocked)))
+				checkedJobDeletion(g, templateJobKey)
+			}).Should(Succeed())
+
+			By("By forcing the upgrade")
+			Expect(testapps.ChangeObj(&testCtx, addon, func(obj *extensionsv1alpha1.Addon) {
+				obj.Annotations = map[string]string{ForceUpgrade: trueVal}
+			})).Should(Succeed())
+			Eventually(func(g Gomega) {
+				_, err := doReconcile()
+				g.Expect(err).To(Not(HaveOccurred()))
+				getJob(g, client.ObjectKey{
+					Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+					Name:      getInstallJobName(addon),
+				})
+			}).Should(Succeed())
+			fakeInstallationCompletedJob(3)
+			Expect(addon.Annotations).ShouldNot(HaveKey(ForceUpgrade))
+			Expect(addon.Status.Conditions).ShouldNot(ContainElement(HaveField("Reason", UpgradeBlocked)))
+		})
+
 		It("should set status to failed when install an Addon with annotations mismatching", func() {
 			viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
 			By("By create a new namespace called kb-system")
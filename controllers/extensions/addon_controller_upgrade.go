@@ -0,0 +1,315 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	kindComponentDefinition = "ComponentDefinition"
+	kindComponentVersion    = "ComponentVersion"
+)
+
+// upgradeImpacts returns the clusters that reference the ComponentDefinitions or ComponentVersions
+// which would be removed or changed incompatibly by applying the objects and pruning the stale resources.
+func upgradeImpacts(ctx context.Context, cli client.Client, objs []*unstructured.Unstructured,
+	stale []extensionsv1alpha1.AppliedResource) ([]string, error) {
+	var (
+		affectedCompDefs = map[string]string{}
+		oldCompVersions  []*appsv1.ComponentVersion
+		newCompVersions  []*appsv1.ComponentVersion
+	)
+	for _, obj := range objs {
+		if obj.GroupVersionKind().Group != appsv1.GroupVersion.Group {
+			continue
+		}
+		switch obj.GetKind() {
+		case kindComponentDefinition:
+			origin := &appsv1.ComponentDefinition{}
+			exist, err := getObject(ctx, cli, obj.GetName(), origin)
+			if err != nil {
+				return nil, err
+			}
+			if !exist {
+				continue
+			}
+			compDef := &appsv1.ComponentDefinition{}
+			if err = dryRunApply(ctx, cli, obj, compDef); err != nil {
+				return nil, err
+			}
+			if !equality.Semantic.DeepEqual(component.ImmutableCompDefSpec(origin), component.ImmutableCompDefSpec(compDef)) {
+				affectedCompDefs[obj.GetName()] = "changed"
+			}
+		case kindComponentVersion:
+			origin := &appsv1.ComponentVersion{}
+			if exist, err := getObject(ctx, cli, obj.GetName(), origin); err != nil {
+				return nil, err
+			} else if exist {
+				oldCompVersions = append(oldCompVersions, origin)
+			}
+			compVersion := &appsv1.ComponentVersion{}
+			if err := dryRunApply(ctx, cli, obj, compVersion); err != nil {
+				return nil, err
+			}
+			newCompVersions = append(newCompVersions, compVersion)
+		}
+	}
+	for _, res := range stale {
+		if res.Group != appsv1.GroupVersion.Group {
+			continue
+		}
+		switch res.Kind {
+		case kindComponentDefinition:
+			affectedCompDefs[res.Name] = "removed"
+		case kindComponentVersion:
+			origin := &appsv1.ComponentVersion{}
+			if exist, err := getObject(ctx, cli, res.Name, origin); err != nil {
+				return nil, err
+			} else if exist {
+				oldCompVersions = append(oldCompVersions, origin)
+			}
+		}
+	}
+	if len(affectedCompDefs) == 0 && len(oldCompVersions) == 0 {
+		return nil, nil
+	}
+
+	comps := &appsv1.ComponentList{}
+	if err := cli.List(ctx, comps); err != nil {
+		return nil, err
+	}
+	var impacts []string
+	for _, comp := range comps.Items {
+		var reason string
+		if change, ok := affectedCompDefs[comp.Spec.CompDef]; ok {
+			reason = fmt.Sprintf("ComponentDefinition %s is %s", comp.Spec.CompDef, change)
+		} else if serviceVersionSupported(oldCompVersions, comp.Spec.CompDef, comp.Spec.ServiceVersion) &&
+			!serviceVersionSupported(newCompVersions, comp.Spec.CompDef, comp.Spec.ServiceVersion) {
+			reason = fmt.Sprintf("service version %s of ComponentDefinition %s is removed", comp.Spec.ServiceVersion, comp.Spec.CompDef)
+		} else {
+			continue
+		}
+		impact := fmt.Sprintf("cluster %s/%s: %s", comp.Namespace, comp.Labels[constant.AppInstanceLabelKey], reason)
+		if !slices.Contains(impacts, impact) {
+			impacts = append(impacts, impact)
+		}
+	}
+	return impacts, nil
+}
+
+// serviceVersionSupported checks whether the service version of the ComponentDefinition is released by any of the ComponentVersions.
+func serviceVersionSupported(compVersions []*appsv1.ComponentVersion, compDef, serviceVersion string) bool {
+	if len(serviceVersion) == 0 {
+		return false
+	}
+	for _, compVersion := range compVersions {
+		releases := map[string]string{}
+		for _, release := range compVersion.Spec.Releases {
+			releases[release.Name] = release.ServiceVersion
+		}
+		for _, rule := range compVersion.Spec.CompatibilityRules {
+			if !slices.ContainsFunc(rule.CompDefs, func(pattern string) bool {
+				return component.PrefixOrRegexMatched(compDef, pattern)
+			}) {
+				continue
+			}
+			if slices.ContainsFunc(rule.Releases, func(name string) bool {
+				return releases[name] == serviceVersion
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getObject gets the cluster-scoped object, and returns false if it is not found.
+func getObject(ctx context.Context, cli client.Reader, name string, obj client.Object) (bool, error) {
+	if err := cli.Get(ctx, client.ObjectKey{Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// dryRunApply applies the object in dry-run mode, and converts the result into the hub version 'into',
+// so that the defaulted object is compared with the one in the cluster.
+func dryRunApply(ctx context.Context, cli client.Client, obj *unstructured.Unstructured, into conversion.Hub) error {
+	applyObj := obj.DeepCopy()
+	if err := cli.Patch(ctx, applyObj, client.Apply, client.FieldOwner(model.FieldManager),
+		client.ForceOwnership, client.DryRunAll); err != nil {
+		return err
+	}
+	if applyObj.GroupVersionKind().Version == appsv1.GroupVersion.Version {
+		return runtime.DefaultUnstructuredConverter.FromUnstructured(applyObj.Object, into)
+	}
+	typed, err := cli.Scheme().New(applyObj.GroupVersionKind())
+	if err != nil {
+		return err
+	}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(applyObj.Object, typed); err != nil {
+		return err
+	}
+	convertible, ok := typed.(conversion.Convertible)
+	if !ok {
+		return fmt.Errorf("unsupported version %s of %s", applyObj.GetAPIVersion(), applyObj.GetKind())
+	}
+	return convertible.ConvertTo(into)
+}
+
+// blockUpgrade blocks the upgrade of the add-on which affects the clusters, until it is forced.
+func blockUpgrade(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, impacts []string) {
+	setAddonErrorConditions(ctx, stageCtx, addon, false, true, UpgradeBlocked,
+		fmt.Sprintf("Upgrade affects the clusters, set annotation %s to true to force it: %s",
+			ForceUpgrade, strings.Join(impacts, "; ")))
+	// it will be reconciled again once the annotation is set, or the affected components are changed
+	stageCtx.setReconciled()
+}
+
+// finishUpgrade clears the UpgradeBlocked condition and the force-upgrade annotation once the add-on is upgraded,
+// so that the annotation won't force the later upgrades.
+func finishUpgrade(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon) error {
+	if err := clearAddonCheckedConditions(ctx, stageCtx, addon, UpgradeBlocked); err != nil {
+		return err
+	}
+	if _, ok := addon.Annotations[ForceUpgrade]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(addon.DeepCopy())
+	delete(addon.Annotations, ForceUpgrade)
+	return stageCtx.reconciler.Patch(ctx, addon, patch)
+}
+
+// helmUpgradeImpacts renders the Helm chart of the add-on by a `helm template` job derived from the install job,
+// and checks the upgrade impacts of the rendered objects as the 'Manifest' type add-ons do. The ComponentDefinitions
+// and ComponentVersions of the release which are not rendered any more are taken as the stale resources.
+// It returns false if the chart is still being rendered, and there is nothing to check if the release is not installed yet.
+func helmUpgradeImpacts(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon, installJob *batchv1.Job) ([]string, bool, error) {
+	cli := stageCtx.reconciler.Client
+	key := client.ObjectKey{
+		Namespace: installJob.Namespace,
+		Name:      getTemplateJobName(addon),
+	}
+	templateJob := &batchv1.Job{}
+	if err := cli.Get(ctx, key, templateJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, err
+		}
+		if exist, err := helmReleaseExists(ctx, cli, addon); err != nil || !exist {
+			return nil, !exist, err
+		}
+		templateJob = installJob.DeepCopy()
+		templateJob.Name = key.Name
+		container := &templateJob.Spec.Template.Spec.Containers[0]
+		// replace `upgrade --install` with `template --is-upgrade`, the rest arguments are shared
+		container.Args = append([]string{"template", "--is-upgrade"}, container.Args[2:]...)
+		return nil, false, cli.Create(ctx, templateJob)
+	}
+	if templateJob.Status.Succeeded == 0 {
+		if templateJob.Status.Failed > 0 && templateJob.Status.Active == 0 {
+			return nil, false, fmt.Errorf("render the Helm chart failed, do inspect error from jobs.batch %s", key.String())
+		}
+		return nil, false, nil
+	}
+
+	manifests, err := readJobPodLogs(ctx, stageCtx, addon, key.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	objs, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, false, err
+	}
+	stale, err := helmStaleResources(ctx, cli, addon, objs)
+	if err != nil {
+		return nil, false, err
+	}
+	impacts, err := upgradeImpacts(ctx, cli, objs, stale)
+	if err != nil {
+		return nil, false, err
+	}
+	// render the chart again in the next check
+	if err = cli.Delete(ctx, templateJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return nil, false, err
+	}
+	return impacts, true, nil
+}
+
+// helmStaleResources returns the ComponentDefinitions and ComponentVersions installed by the Helm release of the add-on,
+// which are not in the rendered objects.
+func helmStaleResources(ctx context.Context, cli client.Client, addon *extensionsv1alpha1.Addon,
+	objs []*unstructured.Unstructured) ([]extensionsv1alpha1.AppliedResource, error) {
+	rendered := func(kind, name string) bool {
+		return slices.ContainsFunc(objs, func(obj *unstructured.Unstructured) bool {
+			return obj.GroupVersionKind().Group == appsv1.GroupVersion.Group && obj.GetKind() == kind && obj.GetName() == name
+		})
+	}
+	var (
+		stale   []extensionsv1alpha1.AppliedResource
+		release = getHelmReleaseName(addon)
+	)
+	for kind, list := range map[string]client.ObjectList{
+		kindComponentDefinition: &appsv1.ComponentDefinitionList{},
+		kindComponentVersion:    &appsv1.ComponentVersionList{},
+	} {
+		if err := cli.List(ctx, list); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, _ := item.(client.Object)
+			if obj.GetAnnotations()[helmReleaseNameAnnotationKey] != release || rendered(kind, obj.GetName()) {
+				continue
+			}
+			stale = append(stale, extensionsv1alpha1.AppliedResource{
+				Group:   appsv1.GroupVersion.Group,
+				Version: appsv1.GroupVersion.Version,
+				Kind:    kind,
+				Name:    obj.GetName(),
+			})
+		}
+	}
+	return stale, nil
+}
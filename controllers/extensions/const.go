@@ -29,6 +29,10 @@ const (
 	NoDeleteJobs         = "extensions.kubeblocks.io/no-delete-jobs"
 	AddonDefaultIsEmpty  = "addons.extensions.kubeblocks.io/default-is-empty"
 	KBVersionValidate    = "addon.kubeblocks.io/kubeblocks-version"
+	ForceUpgrade         = "extensions.kubeblocks.io/force-upgrade"
+
+	// helmReleaseNameAnnotationKey is set by Helm on the objects installed by the release.
+	helmReleaseNameAnnotationKey = "meta.helm.sh/release-name"
 
 	// label keys
 	AddonProvider = "addon.kubeblocks.io/provider"
@@ -51,6 +55,10 @@ const (
 	UninstallationFailedLogs        = "UninstallationFailedLogs"
 	AddonRefObjError                = "ReferenceObjectError"
 	AddonCheckError                 = "AddonCheckError"
+	DependenciesNotSatisfied        = "DependenciesNotSatisfied"
+	DisableBlockedByDependents      = "DisableBlockedByDependents"
+	DependencyCycleDetected         = "DependencyCycleDetected"
+	UpgradeBlocked                  = "UpgradeBlocked"
 
 	// config keys used in viper
 	maxConcurrentReconcilesKey = "MAXCONCURRENTRECONCILES_ADDON"
@@ -333,3 +333,32 @@ Define default storage class name, if cloud provider is known, specify a default
 {{- .Values.image.registry }}
 {{- end}}
 {{- end}}
+
+{{/*
+Define the envs of the external secret store.
+*/}}
+{{- define "kubeblocks.secretStoreEnvs" -}}
+{{- with .Values.secretStore }}
+- name: SECRET_STORE_PROVIDER
+  value: {{ .provider | default "kubernetes" | quote }}
+{{- if eq .provider "vault" }}
+- name: VAULT_ADDR
+  value: {{ .vault.addr | quote }}
+{{- with .vault.tokenSecretRef }}
+- name: VAULT_TOKEN
+  valueFrom:
+    secretKeyRef:
+      name: {{ .name }}
+      key: {{ .key }}
+{{- end }}
+{{- with .vault.namespace }}
+- name: VAULT_NAMESPACE
+  value: {{ . | quote }}
+{{- end }}
+- name: VAULT_KV_MOUNT
+  value: {{ .vault.kvMount | default "secret" | quote }}
+- name: VAULT_PATH_PREFIX
+  value: {{ .vault.pathPrefix | default "kubeblocks" | quote }}
+{{- end }}
+{{- end }}
+{{- end }}
@@ -28,11 +28,13 @@ import (
 
 type AppsV1Interface interface {
 	RESTClient() rest.Interface
+	AccountsGetter
 	ClustersGetter
 	ClusterDefinitionsGetter
 	ComponentsGetter
 	ComponentDefinitionsGetter
 	ComponentVersionsGetter
+	MemberClustersGetter
 	ServiceDescriptorsGetter
 	ShardingDefinitionsGetter
 }
@@ -42,6 +44,10 @@ type AppsV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *AppsV1Client) Accounts(namespace string) AccountInterface {
+	return newAccounts(c, namespace)
+}
+
 func (c *AppsV1Client) Clusters(namespace string) ClusterInterface {
 	return newClusters(c, namespace)
 }
@@ -62,6 +68,10 @@ func (c *AppsV1Client) ComponentVersions() ComponentVersionInterface {
 	return newComponentVersions(c)
 }
 
+func (c *AppsV1Client) MemberClusters() MemberClusterInterface {
+	return newMemberClusters(c)
+}
+
 func (c *AppsV1Client) ServiceDescriptors(namespace string) ServiceDescriptorInterface {
 	return newServiceDescriptors(c, namespace)
 }
@@ -28,6 +28,10 @@ type FakeAppsV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeAppsV1) Accounts(namespace string) v1.AccountInterface {
+	return &FakeAccounts{c, namespace}
+}
+
 func (c *FakeAppsV1) Clusters(namespace string) v1.ClusterInterface {
 	return &FakeClusters{c, namespace}
 }
@@ -48,6 +52,10 @@ func (c *FakeAppsV1) ComponentVersions() v1.ComponentVersionInterface {
 	return &FakeComponentVersions{c}
 }
 
+func (c *FakeAppsV1) MemberClusters() v1.MemberClusterInterface {
+	return &FakeMemberClusters{c}
+}
+
 func (c *FakeAppsV1) ServiceDescriptors(namespace string) v1.ServiceDescriptorInterface {
 	return &FakeServiceDescriptors{c, namespace}
 }
@@ -18,6 +18,8 @@ limitations under the License.
 
 package v1
 
+type AccountExpansion interface{}
+
 type ClusterExpansion interface{}
 
 type ClusterDefinitionExpansion interface{}
@@ -28,6 +30,8 @@ type ComponentDefinitionExpansion interface{}
 
 type ComponentVersionExpansion interface{}
 
+type MemberClusterExpansion interface{}
+
 type ServiceDescriptorExpansion interface{}
 
 type ShardingDefinitionExpansion interface{}
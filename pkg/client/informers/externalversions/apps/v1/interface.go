@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Accounts returns a AccountInformer.
+	Accounts() AccountInformer
 	// Clusters returns a ClusterInformer.
 	Clusters() ClusterInformer
 	// ClusterDefinitions returns a ClusterDefinitionInformer.
@@ -34,6 +36,8 @@ type Interface interface {
 	ComponentDefinitions() ComponentDefinitionInformer
 	// ComponentVersions returns a ComponentVersionInformer.
 	ComponentVersions() ComponentVersionInformer
+	// MemberClusters returns a MemberClusterInformer.
+	MemberClusters() MemberClusterInformer
 	// ServiceDescriptors returns a ServiceDescriptorInformer.
 	ServiceDescriptors() ServiceDescriptorInformer
 	// ShardingDefinitions returns a ShardingDefinitionInformer.
@@ -51,6 +55,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Accounts returns a AccountInformer.
+func (v *version) Accounts() AccountInformer {
+	return &accountInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Clusters returns a ClusterInformer.
 func (v *version) Clusters() ClusterInformer {
 	return &clusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -76,6 +85,11 @@ func (v *version) ComponentVersions() ComponentVersionInformer {
 	return &componentVersionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// MemberClusters returns a MemberClusterInformer.
+func (v *version) MemberClusters() MemberClusterInformer {
+	return &memberClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceDescriptors returns a ServiceDescriptorInformer.
 func (v *version) ServiceDescriptors() ServiceDescriptorInformer {
 	return &serviceDescriptorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -60,6 +60,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=apps.kubeblocks.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("accounts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1().Accounts().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1().Clusters().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterdefinitions"):
@@ -70,6 +72,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1().ComponentDefinitions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("componentversions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1().ComponentVersions().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("memberclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1().MemberClusters().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("servicedescriptors"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1().ServiceDescriptors().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("shardingdefinitions"):
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1
 
+// AccountListerExpansion allows custom methods to be added to
+// AccountLister.
+type AccountListerExpansion interface{}
+
+// AccountNamespaceListerExpansion allows custom methods to be added to
+// AccountNamespaceLister.
+type AccountNamespaceListerExpansion interface{}
+
 // ClusterListerExpansion allows custom methods to be added to
 // ClusterLister.
 type ClusterListerExpansion interface{}
@@ -46,6 +54,10 @@ type ComponentDefinitionListerExpansion interface{}
 // ComponentVersionLister.
 type ComponentVersionListerExpansion interface{}
 
+// MemberClusterListerExpansion allows custom methods to be added to
+// MemberClusterLister.
+type MemberClusterListerExpansion interface{}
+
 // ServiceDescriptorListerExpansion allows custom methods to be added to
 // ServiceDescriptorLister.
 type ServiceDescriptorListerExpansion interface{}
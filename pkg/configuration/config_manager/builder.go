@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
 
 	"github.com/imdario/mergo"
@@ -86,6 +87,7 @@ func BuildConfigManagerContainerParams(cli client.Client, ctx context.Context, m
 		if err := buildConfigSpecHandleMeta(cli, ctx, buildParam, managerParams); err != nil {
 			return err
 		}
+		buildReloadAccountEnvs(buildParam, managerParams)
 		if err := buildLazyRenderedConfig(cli, ctx, buildParam, managerParams); err != nil {
 			return err
 		}
@@ -107,6 +109,10 @@ func getWatchedVolume(volumeDirs []corev1.VolumeMount, buildParams []ConfigSpecM
 				return core.IsWatchModuleForTplTrigger(param.ReloadAction.TPLScriptTrigger)
 			case appsv1beta1.ShellType:
 				return core.IsWatchModuleForShellTrigger(param.ReloadAction.ShellTrigger)
+			case appsv1beta1.HTTPType:
+				return core.IsWatchModuleForHTTPTrigger(param.ReloadAction.HTTPTrigger)
+			case appsv1beta1.SQLType:
+				return core.IsWatchModuleForSQLTrigger(param.ReloadAction.SQLTrigger)
 			default:
 				return true
 			}
@@ -270,6 +276,30 @@ func buildConfigSpecHandleMeta(cli client.Client, ctx context.Context, buildPara
 	return nil
 }
 
+// buildReloadAccountEnvs injects the credential of the system account used by the http or sql trigger.
+func buildReloadAccountEnvs(buildParam *ConfigSpecMeta, manager *CfgManagerBuildParams) {
+	accountName := reloadAccountName(buildParam.ReloadAction)
+	if accountName == "" {
+		return
+	}
+	secretName := constant.GenerateAccountSecretName(manager.Cluster.GetName(), manager.ComponentName, accountName)
+	for _, key := range []string{constant.AccountNameForSecret, constant.AccountPasswdForSecret} {
+		envName := reloadAccountEnvName(accountName, key)
+		if slices.ContainsFunc(manager.Envs, func(env corev1.EnvVar) bool { return env.Name == envName }) {
+			continue
+		}
+		manager.Envs = append(manager.Envs, corev1.EnvVar{
+			Name: envName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				},
+			},
+		})
+	}
+}
+
 func buildTPLScriptCM(configSpecBuildMeta *ConfigSpecMeta, manager *CfgManagerBuildParams, cli client.Client, ctx context.Context) error {
 	var (
 		options      = configSpecBuildMeta.TPLScriptTrigger
@@ -0,0 +1,300 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/gotemplate"
+)
+
+const (
+	reloadAccountEnvPrefix = "KB_RELOAD_ACCOUNT"
+
+	httpTriggerTimeout = 30 * time.Second
+)
+
+// sqlParameterNamePattern is the pattern of the parameter names that can be set by the default statement.
+var sqlParameterNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// sqlNumericValuePattern is the pattern of the finite decimal values that are set without quotes.
+var sqlNumericValuePattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+type reloadFunc = func(ctx context.Context, updatedParams map[string]string) error
+
+// triggerHandler applies the updated parameters through a native reload trigger, e.g. http or sql.
+type triggerHandler struct {
+	configVolumeHandleMeta
+
+	backupPath  string
+	filter      regexFilter
+	batchReload bool
+	reload      reloadFunc
+}
+
+func (t *triggerHandler) OnlineUpdate(ctx context.Context, _ string, updatedParams map[string]string) error {
+	logger.Info(fmt.Sprintf("updated parameters: %v", updatedParams))
+	if len(updatedParams) == 0 {
+		return nil
+	}
+	if t.batchReload {
+		return t.reload(ctx, updatedParams)
+	}
+	keys := make([]string, 0, len(updatedParams))
+	for k := range updatedParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := t.reload(ctx, map[string]string{k: updatedParams[k]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *triggerHandler) VolumeHandle(ctx context.Context, event fsnotify.Event) error {
+	if !isOwnerEvent(t.MountPoint(), event) {
+		logger.Info(fmt.Sprintf("ignore event: %s, current watch volume: %s", event.String(), t.mountPoint))
+		return nil
+	}
+	if t.backupPath == "" {
+		logger.Info("backup path is empty, skip")
+		return nil
+	}
+	updatedParams, files, err := t.prepare(t.backupPath, t.filter, event)
+	if err != nil {
+		return err
+	}
+	if len(updatedParams) == 0 {
+		logger.Info("not parameter updated, skip")
+		return nil
+	}
+	if err := t.OnlineUpdate(ctx, event.Name, updatedParams); err != nil {
+		return err
+	}
+	return backupLastConfigFiles(files, t.backupPath)
+}
+
+func createTriggerHandler(configMeta *ConfigSpecInfo, backupPath string, batchReload bool, reload reloadFunc) (ConfigHandler, error) {
+	filter, err := createFileRegex(fromConfigSpecInfo(configMeta))
+	if err != nil {
+		return nil, err
+	}
+	if backupPath != "" {
+		if err := checkAndBackup(*configMeta, []string{configMeta.MountPoint}, filter, backupPath); err != nil {
+			return nil, err
+		}
+	}
+	formatterConfig := configMeta.FormatterConfig
+	return &triggerHandler{
+		configVolumeHandleMeta: createConfigVolumeMeta(configMeta.ConfigSpec.Name, configMeta.ReloadType, []string{configMeta.MountPoint}, &formatterConfig),
+		backupPath:             backupPath,
+		filter:                 filter,
+		batchReload:            batchReload,
+		reload:                 reload,
+	}, nil
+}
+
+func CreateHTTPHandler(configMeta *ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
+	if configMeta.ReloadAction == nil || configMeta.HTTPTrigger == nil {
+		return nil, cfgcore.MakeError("http trigger is nil")
+	}
+	trigger := configMeta.HTTPTrigger
+	if err := checkHTTPTrigger(trigger); err != nil {
+		return nil, err
+	}
+	method := trigger.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	cli := &http.Client{Timeout: httpTriggerTimeout}
+	reload := func(ctx context.Context, updatedParams map[string]string) error {
+		body, err := renderHTTPTriggerBody(ctx, updatedParams, trigger.BodyTemplate)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, method, trigger.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		for k, v := range trigger.Headers {
+			req.Header.Set(k, v)
+		}
+		if trigger.AccountName != "" {
+			req.SetBasicAuth(reloadAccountCredential(trigger.AccountName))
+		}
+		resp, err := cli.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		output, _ := io.ReadAll(resp.Body)
+		logger.Info("do http reload action",
+			"url", trigger.URL,
+			"method", method,
+			"status", resp.StatusCode,
+			"response", string(output),
+		)
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return cfgcore.MakeError("failed to reload by http trigger, status: %d, response: %s", resp.StatusCode, string(output))
+		}
+		return nil
+	}
+	return createTriggerHandler(configMeta, backupPath, isTriggerBatchReload(trigger.BatchReload), reload)
+}
+
+func CreateSQLHandler(configMeta *ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
+	if configMeta.ReloadAction == nil || configMeta.SQLTrigger == nil {
+		return nil, cfgcore.MakeError("sql trigger is nil")
+	}
+	trigger := configMeta.SQLTrigger
+	if err := checkSQLTrigger(trigger); err != nil {
+		return nil, err
+	}
+	dataType := trigger.DataType
+	if dataType == "" {
+		dataType = mysql
+	}
+	reload := func(ctx context.Context, updatedParams map[string]string) error {
+		statement, err := renderSQLTriggerStatement(ctx, updatedParams, trigger.StatementTemplate)
+		if err != nil {
+			return err
+		}
+		username, password := reloadAccountCredential(trigger.AccountName)
+		dsn := buildSQLTriggerDSN(trigger.Address, username, password)
+		channel, err := newCommandChannel(ctx, dataType, dsn)
+		if err != nil {
+			return err
+		}
+		defer channel.Close()
+		output, err := channel.ExecCommand(ctx, statement)
+		logger.Info("do sql reload action",
+			"statement", statement,
+			"output", output,
+			"error", err,
+		)
+		return err
+	}
+	return createTriggerHandler(configMeta, backupPath, isTriggerBatchReload(trigger.BatchReload), reload)
+}
+
+func isTriggerBatchReload(batchReload *bool) bool {
+	return batchReload != nil && *batchReload
+}
+
+func renderHTTPTriggerBody(ctx context.Context, updatedParams map[string]string, bodyTemplate string) ([]byte, error) {
+	if bodyTemplate == "" {
+		return json.Marshal(updatedParams)
+	}
+	body, err := renderTriggerTemplate(ctx, updatedParams, bodyTemplate, "render-http-trigger-body")
+	return []byte(body), err
+}
+
+func renderSQLTriggerStatement(ctx context.Context, updatedParams map[string]string, statementTemplate string) (string, error) {
+	if statementTemplate != "" {
+		return renderTriggerTemplate(ctx, updatedParams, statementTemplate, "render-sql-trigger-statement")
+	}
+	keys := make([]string, 0, len(updatedParams))
+	for k := range updatedParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	assignments := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if !sqlParameterNamePattern.MatchString(k) {
+			return "", cfgcore.MakeError("invalid parameter name for sql trigger: %s", k)
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = %s", k, quoteSQLValue(updatedParams[k])))
+	}
+	return "SET GLOBAL " + strings.Join(assignments, ", "), nil
+}
+
+func renderTriggerTemplate(ctx context.Context, updatedParams map[string]string, tpl string, tplName string) (string, error) {
+	tplValues := gotemplate.TplValues{}
+	for k, v := range updatedParams {
+		tplValues[k] = v
+	}
+	engine := gotemplate.NewTplEngine(&tplValues, nil, tplName, nil, ctx)
+	rendered, err := engine.Render(tpl)
+	return strings.TrimSpace(rendered), err
+}
+
+// buildSQLTriggerDSN builds the DSN by the driver, so that the credential containing special characters is kept as is.
+func buildSQLTriggerDSN(address, username, password string) string {
+	cfg := mysqldriver.NewConfig()
+	cfg.User = username
+	cfg.Passwd = password
+	cfg.Net = "tcp"
+	cfg.Addr = address
+	return cfg.FormatDSN()
+}
+
+// quoteSQLValue quotes the value as a string literal, both the backslashes and the quotes are escaped, so that the value
+// can't escape the literal whether the NO_BACKSLASH_ESCAPES SQL mode is enabled or not. Only the finite decimal numbers
+// are kept as is, e.g. NaN, Inf and the hex numbers are quoted.
+func quoteSQLValue(value string) string {
+	if sqlNumericValuePattern.MatchString(value) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(value) + "'"
+}
+
+func reloadAccountName(reloadAction *appsv1beta1.ReloadAction) string {
+	switch {
+	case reloadAction == nil:
+		return ""
+	case reloadAction.HTTPTrigger != nil:
+		return reloadAction.HTTPTrigger.AccountName
+	case reloadAction.SQLTrigger != nil:
+		return reloadAction.SQLTrigger.AccountName
+	}
+	return ""
+}
+
+// reloadAccountEnvName returns the name of the env that holds the credential of the account, e.g. KB_RELOAD_ACCOUNT_ROOT_PASSWORD.
+func reloadAccountEnvName(accountName, key string) string {
+	name := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(accountName))
+	return strings.Join([]string{reloadAccountEnvPrefix, name, strings.ToUpper(key)}, "_")
+}
+
+func reloadAccountCredential(accountName string) (string, string) {
+	username := os.Getenv(reloadAccountEnvName(accountName, constant.AccountNameForSecret))
+	if username == "" {
+		username = accountName
+	}
+	return username, os.Getenv(reloadAccountEnvName(accountName, constant.AccountPasswdForSecret))
+}
@@ -0,0 +1,224 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configmanager
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/util"
+)
+
+type recordCommandChannel struct {
+	dsn      string
+	commands []string
+}
+
+func (r *recordCommandChannel) ExecCommand(_ context.Context, command string, _ ...string) (string, error) {
+	r.commands = append(r.commands, command)
+	return "", nil
+}
+
+func (r *recordCommandChannel) Close() {
+}
+
+var _ = Describe("Reload Trigger Handler Test", func() {
+	updatedParams := map[string]string{
+		"max_connections": "200",
+		"sql_mode":        "STRICT_TRANS_TABLES",
+	}
+
+	newConfigSpecInfo := func(reloadAction *appsv1beta1.ReloadAction) *ConfigSpecInfo {
+		return &ConfigSpecInfo{
+			ReloadAction: reloadAction,
+			ReloadType:   FromReloadTypeConfig(reloadAction),
+			MountPoint:   "/tmp/test",
+			ConfigSpec: appsv1.ComponentConfigSpec{
+				ComponentTemplateSpec: appsv1.ComponentTemplateSpec{
+					Name: "config",
+				},
+			},
+		}
+	}
+
+	Context("HTTP trigger", func() {
+		var (
+			server   *httptest.Server
+			bodies   []string
+			user     string
+			password string
+			status   int
+		)
+
+		BeforeEach(func() {
+			bodies = nil
+			status = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				user, password, _ = r.BasicAuth()
+				w.WriteHeader(status)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should send a request for each parameter", func() {
+			handler, err := CreateHTTPHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				HTTPTrigger: &appsv1beta1.HTTPTrigger{URL: server.URL},
+			}), "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), "config", updatedParams)).Should(Succeed())
+			Expect(bodies).Should(Equal([]string{
+				`{"max_connections":"200"}`,
+				`{"sql_mode":"STRICT_TRANS_TABLES"}`,
+			}))
+		})
+
+		It("should send all parameters in a batch with the body template and credential", func() {
+			GinkgoT().Setenv(reloadAccountEnvName("kb-admin", "username"), "admin")
+			GinkgoT().Setenv(reloadAccountEnvName("kb-admin", "password"), "secret")
+			handler, err := CreateHTTPHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				HTTPTrigger: &appsv1beta1.HTTPTrigger{
+					URL: server.URL,
+					BodyTemplate: `{{- range $pKey, $pValue := $ }}
+{{ printf "%s=%s" $pKey $pValue }}
+{{- end }}`,
+					BatchReload: util.ToPointer(true),
+					AccountName: "kb-admin",
+				},
+			}), "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), "config", updatedParams)).Should(Succeed())
+			Expect(bodies).Should(Equal([]string{"max_connections=200\nsql_mode=STRICT_TRANS_TABLES"}))
+			Expect(user).Should(Equal("admin"))
+			Expect(password).Should(Equal("secret"))
+		})
+
+		It("should fail if the endpoint responds with an error", func() {
+			status = http.StatusInternalServerError
+			handler, err := CreateHTTPHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				HTTPTrigger: &appsv1beta1.HTTPTrigger{URL: server.URL},
+			}), "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), "config", updatedParams)).ShouldNot(Succeed())
+		})
+	})
+
+	Context("SQL trigger", func() {
+		var channel *recordCommandChannel
+
+		BeforeEach(func() {
+			channel = &recordCommandChannel{}
+			newCommandChannel = func(ctx context.Context, dataType, dsn string) (DynamicParamUpdater, error) {
+				channel.dsn = dsn
+				return channel, nil
+			}
+			DeferCleanup(func() {
+				newCommandChannel = NewCommandChannel
+			})
+		})
+
+		It("should apply all parameters in one statement", func() {
+			GinkgoT().Setenv(reloadAccountEnvName("root", "password"), "secret")
+			handler, err := CreateSQLHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				SQLTrigger: &appsv1beta1.SQLTrigger{
+					Address:     "127.0.0.1:3306",
+					AccountName: "root",
+					BatchReload: util.ToPointer(true),
+				},
+			}), "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), "config", updatedParams)).Should(Succeed())
+			Expect(channel.dsn).Should(Equal("root:secret@tcp(127.0.0.1:3306)/"))
+			Expect(channel.commands).Should(Equal([]string{
+				"SET GLOBAL max_connections = 200, sql_mode = 'STRICT_TRANS_TABLES'",
+			}))
+		})
+
+		It("should apply each parameter with the statement template", func() {
+			handler, err := CreateSQLHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				SQLTrigger: &appsv1beta1.SQLTrigger{
+					Address:     "127.0.0.1:3306",
+					AccountName: "root",
+					StatementTemplate: `{{- range $pKey, $pValue := $ }}
+SET PERSIST {{ $pKey }} = {{ $pValue }}
+{{- end }}`,
+				},
+			}), "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), "config", updatedParams)).Should(Succeed())
+			Expect(channel.commands).Should(Equal([]string{
+				"SET PERSIST max_connections = 200",
+				"SET PERSIST sql_mode = STRICT_TRANS_TABLES",
+			}))
+		})
+
+		It("should escape the values and keep the credential as is", func() {
+			GinkgoT().Setenv(reloadAccountEnvName("root", "password"), "p@ss:w/rd")
+			handler, err := CreateSQLHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				SQLTrigger: &appsv1beta1.SQLTrigger{
+					Address:     "127.0.0.1:3306",
+					AccountName: "root",
+					BatchReload: util.ToPointer(true),
+				},
+			}), "")
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), "config", map[string]string{
+				"init_connect": `\'; DROP TABLE t; -- `,
+			})).Should(Succeed())
+			Expect(channel.dsn).Should(Equal("root:p@ss:w/rd@tcp(127.0.0.1:3306)/"))
+			Expect(channel.commands).Should(Equal([]string{
+				`SET GLOBAL init_connect = '\\''; DROP TABLE t; -- '`,
+			}))
+
+			channel.commands = nil
+			Expect(handler.OnlineUpdate(context.TODO(), "config", map[string]string{
+				"a": "-1.5e3",
+				"b": "NaN",
+				"c": "Inf",
+				"d": "0x1p4",
+			})).Should(Succeed())
+			Expect(channel.commands).Should(Equal([]string{
+				"SET GLOBAL a = -1.5e3, b = 'NaN', c = 'Inf', d = '0x1p4'",
+			}))
+
+			Expect(handler.OnlineUpdate(context.TODO(), "config", map[string]string{
+				"max_connections = 1; DROP TABLE t; SET x": "1",
+			})).ShouldNot(Succeed())
+		})
+
+		It("should fail if the account is not specified", func() {
+			_, err := CreateSQLHandler(newConfigSpecInfo(&appsv1beta1.ReloadAction{
+				SQLTrigger: &appsv1beta1.SQLTrigger{Address: "127.0.0.1:3306"},
+			}), "")
+			Expect(err).ShouldNot(Succeed())
+		})
+	})
+})
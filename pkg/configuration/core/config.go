@@ -22,6 +22,7 @@ package core
 import (
 	"encoding/json"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/StudioSol/set"
@@ -176,8 +177,14 @@ func (c *cfgWrapper) MergeFrom(params map[string]interface{}, option CfgOpOption
 	if cfg = c.getConfigObject(option); cfg == nil {
 		return MakeError("not found the config file:[%s]", option.FileName)
 	}
-	for paramKey, paramValue := range params {
-		if paramValue != nil {
+	// the new parameters are appended to the config file in order
+	keys := make([]string, 0, len(params))
+	for paramKey := range params {
+		keys = append(keys, paramKey)
+	}
+	sort.Strings(keys)
+	for _, paramKey := range keys {
+		if paramValue := params[paramKey]; paramValue != nil {
 			err = cfg.Update(c.generateKey(paramKey, option), paramValue)
 		} else {
 			err = cfg.RemoveKey(c.generateKey(paramKey, option))
@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package core
+
+import (
+	"sort"
+	"strings"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const parametersDumpFile = "parameters-dump"
+
+// DriftedParameter describes a parameter whose effective value in the running engine differs from the rendered one.
+type DriftedParameter struct {
+	File      string
+	Name      string
+	Expected  string
+	Effective string
+}
+
+// ParseParametersDump parses the output of the parametersDump action, which is formatted as "name=value" lines.
+func ParseParametersDump(output []byte) (map[string]string, error) {
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return map[string]string{}, nil
+	}
+	formatConfig := &appsv1beta1.FileFormatConfig{Format: appsv1beta1.Properties}
+	params, err := TransformConfigFileToKeyValueMap(parametersDumpFile, formatConfig, output)
+	if err != nil {
+		return nil, WrapError(err, "failed to parse the output of parameters dump")
+	}
+	return params, nil
+}
+
+// DetectParametersDrift compares the rendered configuration files with the effective parameters of the running engine,
+// and returns the drifted parameters sorted by file and parameter name.
+//
+// Parameters that are not reported by the engine are ignored, since they may be only meaningful to the configuration file.
+func DetectParametersDrift(rendered map[string]string, effective map[string]string, formatConfig *appsv1beta1.FileFormatConfig, keys []string) ([]DriftedParameter, error) {
+	var drifts []DriftedParameter
+
+	cmKeySet := FromCMKeysSelector(keys)
+	for file, content := range rendered {
+		if cmKeySet != nil && !cmKeySet.InArray(file) {
+			continue
+		}
+		expected, err := TransformConfigFileToKeyValueMap(file, formatConfig, []byte(content))
+		if err != nil {
+			return nil, WrapError(err, "failed to parse the configuration file: %s", file)
+		}
+		for name, value := range expected {
+			actual, ok := effective[name]
+			if !ok || equalParameterValue(value, actual) {
+				continue
+			}
+			drifts = append(drifts, DriftedParameter{
+				File:      file,
+				Name:      name,
+				Expected:  value,
+				Effective: actual,
+			})
+		}
+	}
+
+	sort.SliceStable(drifts, func(i, j int) bool {
+		if drifts[i].File != drifts[j].File {
+			return drifts[i].File < drifts[j].File
+		}
+		return drifts[i].Name < drifts[j].Name
+	})
+	return drifts, nil
+}
+
+func equalParameterValue(expected, effective string) bool {
+	trim := func(v string) string {
+		return strings.Trim(strings.TrimSpace(v), `"'`)
+	}
+	return strings.EqualFold(trim(expected), trim(effective))
+}
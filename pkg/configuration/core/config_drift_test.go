@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+func TestParametersDrift(t *testing.T) {
+	mysqlConfig := `
+[mysqld]
+max_connections=100
+innodb_buffer_pool_size=1G
+log_error=/data/mysql/logs/mysql.log
+slow_query_log=ON
+`
+	dump := `
+max_connections=200
+innodb_buffer_pool_size=1G
+slow_query_log=on
+read_only=OFF
+`
+	formatConfig := &appsv1beta1.FileFormatConfig{
+		Format: appsv1beta1.Ini,
+		FormatterAction: appsv1beta1.FormatterAction{
+			IniConfig: &appsv1beta1.IniConfig{
+				SectionName: "mysqld",
+			},
+		},
+	}
+
+	effective, err := ParseParametersDump([]byte(dump))
+	if err != nil {
+		t.Fatalf("ParseParametersDump() error = %v", err)
+	}
+	if len(effective) != 4 || effective["max_connections"] != "200" {
+		t.Errorf("ParseParametersDump() got = %v", effective)
+	}
+
+	tests := []struct {
+		name     string
+		rendered map[string]string
+		keys     []string
+		want     []DriftedParameter
+	}{{
+		name: "drifted",
+		rendered: map[string]string{
+			"my.cnf": mysqlConfig,
+		},
+		want: []DriftedParameter{{
+			File:      "my.cnf",
+			Name:      "max_connections",
+			Expected:  "100",
+			Effective: "200",
+		}},
+	}, {
+		name: "excluded by keys",
+		rendered: map[string]string{
+			"my.cnf": mysqlConfig,
+		},
+		keys: []string{"other.cnf"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectParametersDrift(tt.rendered, effective, formatConfig, tt.keys)
+			if err != nil {
+				t.Errorf("DetectParametersDrift() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectParametersDrift() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -155,3 +155,17 @@ func IsWatchModuleForTplTrigger(trigger *appsv1beta1.TPLScriptTrigger) bool {
 	}
 	return !*trigger.Sync
 }
+
+func IsWatchModuleForHTTPTrigger(trigger *appsv1beta1.HTTPTrigger) bool {
+	if trigger == nil || trigger.Sync == nil {
+		return true
+	}
+	return !*trigger.Sync
+}
+
+func IsWatchModuleForSQLTrigger(trigger *appsv1beta1.SQLTrigger) bool {
+	if trigger == nil || trigger.Sync == nil {
+		return true
+	}
+	return !*trigger.Sync
+}
@@ -215,10 +215,9 @@ test=test`),
 					}}},
 		},
 		want: `[test]
-a=b
-max_connections=600
 test=test
-`,
+a=b
+max_connections=600`,
 		wantErr: false,
 	}, {
 		name: "normal_test",
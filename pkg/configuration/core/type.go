@@ -186,3 +186,7 @@ func GenerateEnvFromName(originName string) string {
 func GenerateRevisionPhaseKey(revision string) string {
 	return strings.Join([]string{constant.LastConfigurationRevisionPhase, revision}, "-")
 }
+
+func GenerateRevisionParametersKey(revision string) string {
+	return strings.Join([]string{constant.ConfigurationRevisionParameters, revision}, "-")
+}
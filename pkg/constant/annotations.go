@@ -48,6 +48,32 @@ const (
 	NodeSelectorOnceAnnotationKey = "workloads.kubeblocks.io/node-selector-once"
 )
 
+// annotations for the Prometheus Operator monitors generated from the exporter of the component
+const (
+	MonitorKindAnnotationKey     = "monitor.kubeblocks.io/kind"     // MonitorKindAnnotationKey specifies the kind of the monitor to generate, PodMonitor or ServiceMonitor.
+	MonitorLabelsAnnotationKey   = "monitor.kubeblocks.io/labels"   // MonitorLabelsAnnotationKey specifies the extra labels of the monitor, e.g. "release=prometheus".
+	MonitorIntervalAnnotationKey = "monitor.kubeblocks.io/interval" // MonitorIntervalAnnotationKey specifies the scrape interval of the monitor, e.g. "30s".
+)
+
+// annotations for the log collection of the component
+const (
+	EnabledLogsAnnotationKey = "logs.kubeblocks.io/enabled-logs" // EnabledLogsAnnotationKey specifies the names of the log configs to collect, e.g. "error,slow", or "*" for all.
+	LogSinkAnnotationKey     = "logs.kubeblocks.io/sink"         // LogSinkAnnotationKey specifies the vector sink in JSON, e.g. {"type":"loki","endpoint":"http://loki:3100"}.
+)
+
+// annotations for the password rotation of the system accounts
+const (
+	AccountPasswordRotationRequestAnnotationKey = "accounts.kubeblocks.io/rotation-request" // AccountPasswordRotationRequestAnnotationKey requests to rotate the password of the account secret, the value is the name of the requester, e.g. the OpsRequest.
+	AccountPasswordRotatedByAnnotationKey       = "accounts.kubeblocks.io/rotated-by"       // AccountPasswordRotatedByAnnotationKey records the requester of the last rotation.
+	AccountPasswordRotatedAtAnnotationKey       = "accounts.kubeblocks.io/rotated-at"       // AccountPasswordRotatedAtAnnotationKey records the time of the last rotation in RFC3339, it is also set to the pod template to roll the pods.
+)
+
+// annotations for the TLS certificates
+const (
+	TLSCertificateHashAnnotationKey      = "tls.kubeblocks.io/certificate-hash" // TLSCertificateHashAnnotationKey records the hash of the TLS certificate in the pod template, to roll the pods when the certificate changes.
+	TLSCertificateRenewedAtAnnotationKey = "tls.kubeblocks.io/renewed-at"       // TLSCertificateRenewedAtAnnotationKey records the time of the last renewal of the TLS certificate issued by KubeBlocks in RFC3339.
+)
+
 // annotations for multi-cluster
 const (
 	KBAppMultiClusterPlacementKey   = "apps.kubeblocks.io/multi-cluster-placement"
@@ -61,5 +87,10 @@ func InheritedAnnotations() []string {
 		HostNetworkAnnotationKey,
 		FeatureReconciliationInCompactModeAnnotationKey,
 		KBAppMultiClusterPlacementKey,
+		MonitorKindAnnotationKey,
+		MonitorLabelsAnnotationKey,
+		MonitorIntervalAnnotationKey,
+		EnabledLogsAnnotationKey,
+		LogSinkAnnotationKey,
 	}
 }
@@ -32,6 +32,13 @@ const (
 	ConfigurationRevision          = "config.kubeblocks.io/configuration-revision"
 	LastConfigurationRevisionPhase = "config.kubeblocks.io/revision-reconcile-phase"
 
+	// ConfigurationRevisionParameters records the parameters of the configuration item at a revision
+	ConfigurationRevisionParameters = "config.kubeblocks.io/revision-parameters"
+
+	// ConfigurationRevisionDiff requests the parameter-level diff between two revisions of the configuration item,
+	// the value is "<from>,<to>", and the diff is reported in the status of the Configuration
+	ConfigurationRevisionDiff = "config.kubeblocks.io/revision-diff"
+
 	// Deprecated: only compatible with version 0.6, will be removed in 0.8
 	// CMInsEnableRerenderTemplateKey is used to enable rerender template
 	CMInsEnableRerenderTemplateKey = "config.kubeblocks.io/enable-rerender"
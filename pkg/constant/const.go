@@ -44,6 +44,10 @@ const (
 const (
 	AccountNameForSecret   = "username"
 	AccountPasswdForSecret = "password"
+
+	// AccountNextPasswdForSecret and AccountPrevPasswdForSecret are the keys used during the password rotation.
+	AccountNextPasswdForSecret = "next-password"
+	AccountPrevPasswdForSecret = "previous-password"
 )
 
 const (
@@ -34,4 +34,6 @@ const (
 	ReasonRunTaskFailed = "RunTaskFailed"
 	// ReasonDeleteFailed delete failed
 	ReasonDeleteFailed = "DeleteFailed"
+	// ReasonApplyConflict server-side apply conflicts with other field managers
+	ReasonApplyConflict = "ApplyConflict"
 )
@@ -26,4 +26,6 @@ const (
 	ConfigFinalizerName            = "config.kubeblocks.io/finalizer"
 	ServiceDescriptorFinalizerName = "servicedescriptor.kubeblocks.io/finalizer"
 	OpsRequestFinalizerName        = "opsrequest.kubeblocks.io/finalizer"
+	AccountFinalizerName           = "account.kubeblocks.io/finalizer"
+	MemberClusterFinalizerName     = "membercluster.kubeblocks.io/finalizer"
 )
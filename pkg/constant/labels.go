@@ -47,6 +47,7 @@ const (
 	PVCNameLabelKey                        = "apps.kubeblocks.io/pvc-name"
 	VolumeClaimTemplateNameLabelKey        = "apps.kubeblocks.io/vct-name"
 	KBAppPodNameLabelKey                   = "apps.kubeblocks.io/pod-name"
+	KBAppZoneLabelKey                      = "apps.kubeblocks.io/zone"
 
 	RoleLabelKey             = "kubeblocks.io/role" // RoleLabelKey consensusSet and replicationSet role label key
 	KBAppServiceVersionKey   = "apps.kubeblocks.io/service-version"
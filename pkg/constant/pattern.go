@@ -35,6 +35,11 @@ func GenerateAccountSecretName(clusterName, compName, name string) string {
 	return fmt.Sprintf("%s-%s-account-%s", clusterName, compName, replacedName)
 }
 
+// GenerateServiceRefCredentialSecretName generates the secret name of the credential copied from the cluster referenced by the service-ref.
+func GenerateServiceRefCredentialSecretName(clusterName, compName, serviceRefName string) string {
+	return fmt.Sprintf("%s-%s-serviceref-%s", clusterName, compName, serviceRefName)
+}
+
 // GenerateClusterServiceName generates the service name for cluster.
 func GenerateClusterServiceName(clusterName, svcName string) string {
 	if len(svcName) > 0 {
@@ -24,5 +24,8 @@ const (
 	CAName     = "ca.crt"
 	CertName   = "tls.crt"
 	KeyName    = "tls.key"
-	MountPath  = "/etc/pki/tls"
+	// CAKeyName is the key of the CA private key issued by KubeBlocks, which is kept to re-issue the certificate
+	// on renewal, it is never mounted into the pods.
+	CAKeyName = "ca.key"
+	MountPath = "/etc/pki/tls"
 )
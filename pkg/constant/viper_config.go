@@ -41,6 +41,25 @@ const (
 	CfgKeyDataPlaneTolerations = "DATA_PLANE_TOLERATIONS"
 	CfgKeyDataPlaneAffinity    = "DATA_PLANE_AFFINITY"
 
+	// log collector config keys
+	CfgKeyLogCollectorImage = "LOG_COLLECTOR_IMAGE"
+
+	// account config keys
+	CfgKeyAccountResyncInterval = "ACCOUNT_RESYNC_INTERVAL" // the interval to re-provision the accounts to correct the drift
+
+	// TLS config keys
+	CfgKeyTLSCertValidityDays = "TLS_CERT_VALIDITY_DAYS" // the validity in days of the TLS certificates issued by KubeBlocks, the CA is long-lived and reused on renewal
+	CfgKeyTLSCertRenewBefore  = "TLS_CERT_RENEW_BEFORE"  // the duration before the expiry to renew the TLS certificates issued by KubeBlocks, and to warn for the user provided ones
+
+	// secret store config keys
+	CfgKeySecretStoreProvider = "SECRET_STORE_PROVIDER" // the provider of the credentials: kubernetes (default) or vault
+	CfgKeyVaultAddr           = "VAULT_ADDR"
+	CfgKeyVaultToken          = "VAULT_TOKEN"
+	CfgKeyVaultTokenPath      = "VAULT_TOKEN_PATH" // the file to read the token from, it takes precedence over VAULT_TOKEN
+	CfgKeyVaultNamespace      = "VAULT_NAMESPACE"
+	CfgKeyVaultKVMount        = "VAULT_KV_MOUNT"    // the mount path of the KV v2 secrets engine
+	CfgKeyVaultPathPrefix     = "VAULT_PATH_PREFIX" // the path prefix under the mount to keep the credentials
+
 	// storage config keys
 	CfgKeyDefaultStorageClass = "DEFAULT_STORAGE_CLASS"
 
@@ -52,4 +71,24 @@ const (
 	CfgKBReconcileWorkers = "KUBEBLOCKS_RECONCILE_WORKERS"
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
+
+	// CfgKBPlanExecutionWorkers is the max number of objects applied concurrently when executing a reconcile plan,
+	// the plan is executed serially if it is less than or equal to 1.
+	CfgKBPlanExecutionWorkers = "KUBEBLOCKS_PLAN_EXECUTION_WORKERS"
+
+	// CfgKBServerSideApplyKinds is a comma-separated list of object kinds, e.g. "Service,PersistentVolumeClaim",
+	// which are written by server-side apply rather than update or merge-patch when executing a reconcile plan.
+	CfgKBServerSideApplyKinds = "KUBEBLOCKS_SERVER_SIDE_APPLY_KINDS"
+
+	// CfgKeyUserAgent is the user agent of the operator set by the flag --user-agent, the API server derives
+	// the field manager of the objects written by update from it.
+	CfgKeyUserAgent = "USER_AGENT"
+
+	// CfgKBAutoscalerPrometheusEndpoint is the URL of the Prometheus server that the ComponentAutoscalers
+	// evaluate their queries against, if no endpoint is specified in the autoscaler.
+	CfgKBAutoscalerPrometheusEndpoint = "KUBEBLOCKS_AUTOSCALER_PROMETHEUS_ENDPOINT"
+
+	// tracing config keys
+	CfgKeyTracingEndpoint    = "TRACING_ENDPOINT"     // the OTLP gRPC endpoint to export the spans, e.g. http://otel-collector:4317
+	CfgKeyTracingSampleRatio = "TRACING_SAMPLE_RATIO" // the sampling ratio of the reconciliations traced
 )
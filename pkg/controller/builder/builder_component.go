@@ -162,6 +162,11 @@ func (builder *ComponentBuilder) SetInstances(instances []appsv1.InstanceTemplat
 	return builder
 }
 
+func (builder *ComponentBuilder) SetZoneDistribution(zoneDistribution *appsv1.ZoneDistribution) *ComponentBuilder {
+	builder.get().Spec.ZoneDistribution = zoneDistribution
+	return builder
+}
+
 func (builder *ComponentBuilder) SetOfflineInstances(offlineInstances []string) *ComponentBuilder {
 	builder.get().Spec.OfflineInstances = offlineInstances
 	return builder
@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+)
+
+var _ = Describe("component_autoscaler builder", func() {
+	It("should work well", func() {
+		const (
+			name = "foo"
+			ns   = "default"
+		)
+		clusterName := "target-cluster-name"
+		componentName := "comp-1"
+		endpoint := "http://prometheus:9090"
+		horizontal := &experimental.HorizontalAutoscaling{
+			MaxReplicas: 5,
+			Metrics: []experimental.MetricSource{
+				{
+					Type:     experimental.ResourceMetricSourceType,
+					Resource: &experimental.ResourceMetricSource{Name: corev1.ResourceCPU, TargetAverageUtilization: 70},
+				},
+			},
+		}
+		vertical := &experimental.VerticalAutoscaling{
+			Resources: []experimental.VerticalResourcePolicy{
+				{Name: corev1.ResourceMemory, TargetAverageUtilization: 80},
+			},
+		}
+		storage := &experimental.StorageAutoscaling{
+			VolumeClaimTemplates: []experimental.VolumeAutoscalingPolicy{
+				{Name: "data", UsageThresholdPercent: 80, MaxSize: resource.MustParse("100Gi")},
+			},
+		}
+		window := experimental.MaintenanceWindow{StartTime: "02:00", DurationMinutes: 60}
+
+		autoscaler := NewComponentAutoscalerBuilder(ns, name).
+			SetTarget(clusterName, componentName).
+			SetHorizontal(horizontal).
+			SetVertical(vertical).
+			SetStorage(storage).
+			AddMaintenanceWindow(window).
+			SetPrometheusEndpoint(endpoint).
+			GetObject()
+
+		Expect(autoscaler.Name).Should(Equal(name))
+		Expect(autoscaler.Namespace).Should(Equal(ns))
+		Expect(autoscaler.Spec.TargetClusterName).Should(Equal(clusterName))
+		Expect(autoscaler.Spec.TargetComponentName).Should(Equal(componentName))
+		Expect(autoscaler.Spec.Horizontal).Should(Equal(horizontal))
+		Expect(autoscaler.Spec.Vertical).Should(Equal(vertical))
+		Expect(autoscaler.Spec.Storage).Should(Equal(storage))
+		Expect(autoscaler.Spec.MaintenanceWindows).Should(Equal([]experimental.MaintenanceWindow{window}))
+		Expect(autoscaler.Spec.PrometheusEndpoint).Should(Equal(endpoint))
+	})
+})
@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package builder
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
 )
 
@@ -42,3 +44,14 @@ func (builder *NodeCountScalerBuilder) SetTargetComponentNames(componentNames []
 	builder.get().Spec.TargetComponentNames = componentNames
 	return builder
 }
+
+func (builder *NodeCountScalerBuilder) SetNodeSelector(selector *metav1.LabelSelector) *NodeCountScalerBuilder {
+	builder.get().Spec.NodeSelector = selector
+	return builder
+}
+
+func (builder *NodeCountScalerBuilder) SetReplicasBounds(minReplicas, maxReplicas *int32) *NodeCountScalerBuilder {
+	builder.get().Spec.MinReplicas = minReplicas
+	builder.get().Spec.MaxReplicas = maxReplicas
+	return builder
+}
@@ -22,6 +22,9 @@ package builder
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("node_count_scaler builder", func() {
@@ -32,15 +35,21 @@ var _ = Describe("node_count_scaler builder", func() {
 		)
 		clusterName := "target-cluster-name"
 		componentNames := []string{"comp-1", "comp-2"}
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"role": "proxy"}}
 
 		ncs := NewNodeCountScalerBuilder(ns, name).
 			SetTargetClusterName(clusterName).
 			SetTargetComponentNames(componentNames).
+			SetNodeSelector(selector).
+			SetReplicasBounds(ptr.To[int32](1), ptr.To[int32](3)).
 			GetObject()
 
 		Expect(ncs.Name).Should(Equal(name))
 		Expect(ncs.Namespace).Should(Equal(ns))
 		Expect(ncs.Spec.TargetClusterName).Should(Equal(clusterName))
 		Expect(ncs.Spec.TargetComponentNames).Should(Equal(componentNames))
+		Expect(ncs.Spec.NodeSelector).Should(Equal(selector))
+		Expect(*ncs.Spec.MinReplicas).Should(BeEquivalentTo(1))
+		Expect(*ncs.Spec.MaxReplicas).Should(BeEquivalentTo(3))
 	})
 })
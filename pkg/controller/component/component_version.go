@@ -211,7 +211,9 @@ func actionsToResolveImage(compDef *appsv1.ComponentDefinition) map[string]*apps
 		normalize("dataDump"):         compDef.Spec.LifecycleActions.DataDump,
 		normalize("dataLoad"):         compDef.Spec.LifecycleActions.DataLoad,
 		normalize("reconfigure"):      compDef.Spec.LifecycleActions.Reconfigure,
+		normalize("parametersDump"):   compDef.Spec.LifecycleActions.ParametersDump,
 		normalize("accountProvision"): compDef.Spec.LifecycleActions.AccountProvision,
+		normalize("accountRotate"):    compDef.Spec.LifecycleActions.AccountRotate,
 	}
 	if compDef.Spec.LifecycleActions.RoleProbe != nil {
 		actions[normalize("roleProbe")] = &compDef.Spec.LifecycleActions.RoleProbe.Action
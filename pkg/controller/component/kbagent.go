@@ -125,6 +125,7 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 	if err = adaptKBAgentIfCustomImageNContainerDefined(synthesizedComp, container); err != nil {
 		return err
 	}
+	appendLogVolumeMounts4KBAgent(synthesizedComp, container)
 
 	// set kb-agent container ports to host network
 	if synthesizedComp.HostNetwork != nil {
@@ -169,7 +170,9 @@ func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.Env
 		synthesizedComp.LifecycleActions.DataDump,
 		synthesizedComp.LifecycleActions.DataLoad,
 		synthesizedComp.LifecycleActions.Reconfigure,
+		synthesizedComp.LifecycleActions.ParametersDump,
 		synthesizedComp.LifecycleActions.AccountProvision,
+		synthesizedComp.LifecycleActions.AccountRotate,
 	} {
 		checkedAppend(action)
 	}
@@ -216,9 +219,18 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.Reconfigure, "reconfigure"); a != nil {
 		actions = append(actions, *a)
 	}
+	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.ParametersDump, "parametersDump"); a != nil {
+		actions = append(actions, *a)
+	}
 	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.AccountProvision, "accountProvision"); a != nil {
 		actions = append(actions, *a)
 	}
+	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.AccountRotate, "accountRotate"); a != nil {
+		actions = append(actions, *a)
+	}
+	if a := buildAction4KBAgent(TailLogAction(synthesizedComp), TailLogActionName); a != nil {
+		actions = append(actions, *a)
+	}
 
 	if a, p := buildProbe4KBAgent(synthesizedComp.LifecycleActions.RoleProbe, "roleProbe"); a != nil && p != nil {
 		actions = append(actions, *a)
@@ -305,7 +317,9 @@ func customExecActionImageNContainer(synthesizedComp *SynthesizedComponent) (str
 		synthesizedComp.LifecycleActions.DataDump,
 		synthesizedComp.LifecycleActions.DataLoad,
 		synthesizedComp.LifecycleActions.Reconfigure,
+		synthesizedComp.LifecycleActions.ParametersDump,
 		synthesizedComp.LifecycleActions.AccountProvision,
+		synthesizedComp.LifecycleActions.AccountRotate,
 	}
 	if synthesizedComp.LifecycleActions.RoleProbe != nil && synthesizedComp.LifecycleActions.RoleProbe.Exec != nil {
 		actions = append(actions, &synthesizedComp.LifecycleActions.RoleProbe.Action)
@@ -108,6 +108,16 @@ func (a *kbagent) MemberLeave(ctx context.Context, cli client.Reader, opts *Opti
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.MemberLeave, lfa, opts))
 }
 
+func (a *kbagent) Readonly(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &readonly{}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.Readonly, lfa, opts))
+}
+
+func (a *kbagent) Readwrite(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &readwrite{}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.Readwrite, lfa, opts))
+}
+
 func (a *kbagent) DataDump(ctx context.Context, cli client.Reader, opts *Options) error {
 	lfa := &dataDump{}
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.DataDump, lfa, opts))
@@ -118,6 +128,11 @@ func (a *kbagent) DataLoad(ctx context.Context, cli client.Reader, opts *Options
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.DataLoad, lfa, opts))
 }
 
+func (a *kbagent) ParametersDump(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error) {
+	lfa := &parametersDump{}
+	return a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.ParametersDump, lfa, opts)
+}
+
 func (a *kbagent) AccountProvision(ctx context.Context, cli client.Reader, opts *Options, statement, user, password string) error {
 	lfa := &accountProvision{
 		statement: statement,
@@ -127,6 +142,16 @@ func (a *kbagent) AccountProvision(ctx context.Context, cli client.Reader, opts
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.AccountProvision, lfa, opts))
 }
 
+func (a *kbagent) AccountRotate(ctx context.Context, cli client.Reader, opts *Options, stage, user, password, previousPassword string) error {
+	lfa := &accountRotate{
+		stage:            stage,
+		user:             user,
+		password:         password,
+		previousPassword: previousPassword,
+	}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.AccountRotate, lfa, opts))
+}
+
 func (a *kbagent) ignoreOutput(_ []byte, err error) error {
 	return err
 }
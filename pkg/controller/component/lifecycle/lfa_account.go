@@ -29,6 +29,17 @@ const (
 	accountName      = "KB_ACCOUNT_NAME"
 	accountPassword  = "KB_ACCOUNT_PASSWORD"
 	accountStatement = "KB_ACCOUNT_STATEMENT"
+
+	accountPreviousPassword = "KB_ACCOUNT_PREVIOUS_PASSWORD"
+	accountRotationStage    = "KB_ACCOUNT_ROTATION_STAGE"
+)
+
+const (
+	// AccountRotationStageRotate is the stage to change the password of the account to the new one,
+	// the previous password should keep valid until the discard stage if the engine supports dual passwords.
+	AccountRotationStageRotate = "Rotate"
+	// AccountRotationStageDiscard is the stage to discard the previous password of the account.
+	AccountRotationStageDiscard = "Discard"
 )
 
 type accountProvision struct {
@@ -55,3 +66,31 @@ func (a *accountProvision) parameters(ctx context.Context, cli client.Reader) (m
 		accountStatement: a.statement,
 	}, nil
 }
+
+type accountRotate struct {
+	stage            string
+	user             string
+	password         string
+	previousPassword string
+}
+
+var _ lifecycleAction = &accountRotate{}
+
+func (a *accountRotate) name() string {
+	return "accountRotate"
+}
+
+func (a *accountRotate) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_ACCOUNT_NAME: The name of the system account to be rotated.
+	// - KB_ACCOUNT_PASSWORD: The new password for the system account.
+	// - KB_ACCOUNT_PREVIOUS_PASSWORD: The password currently (or previously) used by the system account.
+	// - KB_ACCOUNT_ROTATION_STAGE: The stage of the rotation, Rotate or Discard.
+	return map[string]string{
+		accountName:             a.user,
+		accountPassword:         a.password,
+		accountPreviousPassword: a.previousPassword,
+		accountRotationStage:    a.stage,
+	}, nil
+}
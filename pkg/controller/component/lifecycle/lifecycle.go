@@ -49,9 +49,9 @@ type Lifecycle interface {
 
 	MemberLeave(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readonly(ctx context.Context, cli client.Reader, opts *Options) error
+	Readonly(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
+	Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
 
 	DataDump(ctx context.Context, cli client.Reader, opts *Options) error
 
@@ -59,7 +59,11 @@ type Lifecycle interface {
 
 	// Reconfigure(ctx context.Context, cli client.Reader, opts *Options) error
 
+	ParametersDump(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error)
+
 	AccountProvision(ctx context.Context, cli client.Reader, opts *Options, statement, user, password string) error
+
+	AccountRotate(ctx context.Context, cli client.Reader, opts *Options, stage, user, password, previousPassword string) error
 }
 
 func New(synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod, pods ...*corev1.Pod) (Lifecycle, error) {
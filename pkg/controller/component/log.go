@@ -0,0 +1,156 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	TailLogActionName = "tailLog"
+
+	// TailLogNameVar and TailLogLinesVar are the parameters of the tailLog action.
+	TailLogNameVar  = "KB_LOG_NAME"
+	TailLogLinesVar = "KB_LOG_LINES"
+
+	defaultTailLogLines = 100
+)
+
+// EnabledLogConfigs returns the log configs whose names are listed in @enabledLogs, "*" means all of them.
+func EnabledLogConfigs(synthesizedComp *SynthesizedComponent, enabledLogs string) ([]appsv1.LogConfig, error) {
+	names := sets.New[string]()
+	for _, name := range strings.Split(enabledLogs, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names.Insert(name)
+		}
+	}
+	if names.Len() == 0 {
+		return nil, nil
+	}
+	var logConfigs []appsv1.LogConfig
+	for _, logConfig := range synthesizedComp.LogConfigs {
+		if names.Has("*") || names.Has(logConfig.Name) {
+			logConfigs = append(logConfigs, logConfig)
+			names.Delete(logConfig.Name)
+		}
+	}
+	names.Delete("*")
+	if names.Len() > 0 {
+		return nil, fmt.Errorf("log configs %s are not defined in the component definition", strings.Join(sets.List(names), ","))
+	}
+	return logConfigs, nil
+}
+
+// LogVolumeMounts returns the volume mounts of the component containers which the log files are located in.
+func LogVolumeMounts(synthesizedComp *SynthesizedComponent, logConfigs []appsv1.LogConfig) []corev1.VolumeMount {
+	if synthesizedComp.PodSpec == nil {
+		return nil
+	}
+	var mounts []corev1.VolumeMount
+	mountPaths := sets.New[string]()
+	for _, logConfig := range logConfigs {
+		dir := filepath.Dir(logConfig.FilePathPattern)
+		for _, c := range synthesizedComp.PodSpec.Containers {
+			if IsKBAgentContainer(&c) {
+				continue
+			}
+			for _, mount := range c.VolumeMounts {
+				if mountPaths.Has(mount.MountPath) || !isSubPath(dir, mount.MountPath) {
+					continue
+				}
+				mount.ReadOnly = true
+				mounts = append(mounts, mount)
+				mountPaths.Insert(mount.MountPath)
+			}
+		}
+	}
+	return mounts
+}
+
+func isSubPath(path, parent string) bool {
+	rel, err := filepath.Rel(parent, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// tailLogConfigs returns the log configs enabled by the component annotation, which the tailLog action is built for.
+// It's nil if the logs are not enabled, so that the pods of the components which don't opt in are kept untouched.
+func tailLogConfigs(synthesizedComp *SynthesizedComponent) []appsv1.LogConfig {
+	enabledLogs, ok := synthesizedComp.Annotations[constant.EnabledLogsAnnotationKey]
+	if !ok {
+		return nil
+	}
+	logConfigs, err := EnabledLogConfigs(synthesizedComp, enabledLogs)
+	if err != nil {
+		// the error is reported by the log collector transformer
+		return nil
+	}
+	return logConfigs
+}
+
+// TailLogAction returns the built-in action of kbagent to tail the log files enabled for the component,
+// the log is chosen by the parameter KB_LOG_NAME, and the number of lines is given by KB_LOG_LINES.
+// It's nil if no log is enabled or the kbagent is absent.
+func TailLogAction(synthesizedComp *SynthesizedComponent) *appsv1.Action {
+	logConfigs := tailLogConfigs(synthesizedComp)
+	if synthesizedComp.LifecycleActions == nil || len(logConfigs) == 0 {
+		return nil
+	}
+	script := &strings.Builder{}
+	fmt.Fprintf(script, "case \"${%s}\" in\n", TailLogNameVar)
+	for _, logConfig := range logConfigs {
+		// the pattern is left unquoted to be expanded by the shell
+		fmt.Fprintf(script, "  %s) files=$(ls -1 %s 2>/dev/null) ;;\n", shellQuote(logConfig.Name), logConfig.FilePathPattern)
+	}
+	fmt.Fprintf(script, "  *) echo \"unknown log ${%s}\" >&2; exit 1 ;;\n", TailLogNameVar)
+	script.WriteString("esac\n")
+	script.WriteString("if [ -z \"${files}\" ]; then echo \"no log file found\" >&2; exit 1; fi\n")
+	fmt.Fprintf(script, "tail -n \"${%s:-%d}\" ${files}\n", TailLogLinesVar, defaultTailLogLines)
+	return &appsv1.Action{
+		Exec: &appsv1.ExecAction{
+			Command: []string{"/bin/sh", "-c", script.String()},
+		},
+		TimeoutSeconds: 10,
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// appendLogVolumeMounts4KBAgent mounts the log volumes to the kbagent container for the tailLog action.
+func appendLogVolumeMounts4KBAgent(synthesizedComp *SynthesizedComponent, container *corev1.Container) {
+	existed := sets.New[string]()
+	for _, mount := range container.VolumeMounts {
+		existed.Insert(mount.MountPath)
+	}
+	for _, mount := range LogVolumeMounts(synthesizedComp, tailLogConfigs(synthesizedComp)) {
+		if !existed.Has(mount.MountPath) {
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+		}
+	}
+}
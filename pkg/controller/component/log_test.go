@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("log", func() {
+	var (
+		synthesizedComp *SynthesizedComponent
+	)
+
+	BeforeEach(func() {
+		synthesizedComp = &SynthesizedComponent{
+			LogConfigs: []appsv1.LogConfig{
+				{Name: "error", FilePathPattern: "/data/mysql/log/mysqld-error.log"},
+				{Name: "slow", FilePathPattern: "/data/mysql/log/mysqld-slowquery.log*"},
+			},
+			LifecycleActions: &appsv1.ComponentLifecycleActions{},
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "mysql",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "data", MountPath: "/data/mysql"},
+							{Name: "config", MountPath: "/etc/mysql"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("enabled log configs", func() {
+		It("enables the logs by names", func() {
+			logConfigs, err := EnabledLogConfigs(synthesizedComp, "slow")
+			Expect(err).Should(Succeed())
+			Expect(logConfigs).Should(HaveLen(1))
+			Expect(logConfigs[0].Name).Should(Equal("slow"))
+		})
+
+		It("enables all the logs", func() {
+			logConfigs, err := EnabledLogConfigs(synthesizedComp, "*")
+			Expect(err).Should(Succeed())
+			Expect(logConfigs).Should(HaveLen(2))
+		})
+
+		It("rejects the undefined logs", func() {
+			_, err := EnabledLogConfigs(synthesizedComp, "error, general")
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("general"))
+		})
+	})
+
+	Context("log volume mounts", func() {
+		It("mounts the volumes which the log files are located in", func() {
+			mounts := LogVolumeMounts(synthesizedComp, synthesizedComp.LogConfigs)
+			Expect(mounts).Should(HaveLen(1))
+			Expect(mounts[0].Name).Should(Equal("data"))
+			Expect(mounts[0].ReadOnly).Should(BeTrue())
+		})
+	})
+
+	Context("tail log action", func() {
+		BeforeEach(func() {
+			synthesizedComp.Annotations = map[string]string{constant.EnabledLogsAnnotationKey: "slow"}
+		})
+
+		It("builds the action", func() {
+			action := TailLogAction(synthesizedComp)
+			Expect(action).ShouldNot(BeNil())
+			Expect(action.Exec.Command).Should(HaveLen(3))
+			Expect(action.Exec.Command[2]).Should(ContainSubstring("'slow') files=$(ls -1 /data/mysql/log/mysqld-slowquery.log* 2>/dev/null) ;;"))
+			Expect(action.Exec.Command[2]).ShouldNot(ContainSubstring("'error')"))
+		})
+
+		It("has no action if the logs are not declared", func() {
+			synthesizedComp.LogConfigs = nil
+			Expect(TailLogAction(synthesizedComp)).Should(BeNil())
+		})
+
+		It("has no action and mounts if the logs are not enabled", func() {
+			synthesizedComp.Annotations = nil
+			Expect(TailLogAction(synthesizedComp)).Should(BeNil())
+
+			container := &corev1.Container{Name: "kbagent"}
+			appendLogVolumeMounts4KBAgent(synthesizedComp, container)
+			Expect(container.VolumeMounts).Should(BeEmpty())
+		})
+
+		It("mounts the log volumes to kbagent if the logs are enabled", func() {
+			container := &corev1.Container{Name: "kbagent"}
+			appendLogVolumeMounts4KBAgent(synthesizedComp, container)
+			Expect(container.VolumeMounts).Should(HaveLen(1))
+		})
+	})
+})
@@ -56,7 +56,10 @@ func (r *mockReader) List(ctx context.Context, list client.ObjectList, opts ...c
 		opt.ApplyToList(listOpts)
 	}
 	for i, o := range r.objs {
-		// ignore the GVK check
+		// ignore the GVK check, but skip the objects of other types
+		if reflect.ValueOf(o).Elem().Type() != items.Type().Elem() {
+			continue
+		}
 		if listOpts.LabelSelector != nil {
 			if listOpts.LabelSelector.Matches(labels.Set(o.GetLabels())) {
 				objects = reflect.Append(objects, reflect.ValueOf(r.objs[i]).Elem())
@@ -71,9 +71,9 @@ func buildServiceReferencesWithoutResolve(ctx context.Context, cli client.Reader
 		)
 		switch {
 		case serviceRef.Cluster != "":
-			sd, err = handleServiceRefFromCluster(ctx, cli, namespace, *serviceRef, serviceRefDecl, true)
+			sd, err = handleServiceRefFromCluster(ctx, cli, synthesizedComp, *serviceRef, serviceRefDecl, true)
 		case serviceRef.ClusterServiceSelector != nil:
-			sd, err = handleServiceRefFromCluster(ctx, cli, namespace, *serviceRef, serviceRefDecl, false)
+			sd, err = handleServiceRefFromCluster(ctx, cli, synthesizedComp, *serviceRef, serviceRefDecl, false)
 		case serviceRef.ServiceDescriptor != "":
 			sd, err = handleServiceRefFromServiceDescriptor(ctx, cli, namespace, *serviceRef, serviceRefDecl)
 		}
@@ -96,18 +96,35 @@ type serviceRefReferenceVars struct {
 	username *appsv1.CredentialVar
 	password *appsv1.CredentialVar
 	podFQDNs *appsv1.CredentialVar
+
+	// the credential data of the referenced cluster running in other contexts, which should be copied to the local one
+	credential map[string][]byte
 }
 
-func handleServiceRefFromCluster(ctx context.Context, cli client.Reader, namespace string,
+func handleServiceRefFromCluster(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
 	serviceRef appsv1.ServiceRef, serviceRefDecl appsv1.ServiceRefDeclaration, legacy bool) (*appsv1.ServiceDescriptor, error) {
 	resolver := referencedVars
 	if legacy {
 		resolver = referencedVars4Legacy
 	}
+	namespace := synthesizedComp.Namespace
 	vars := &serviceRefReferenceVars{}
 	if err := resolver(ctx, cli, namespace, serviceRef, vars); err != nil {
 		return nil, err
 	}
+	if len(vars.credential) > 0 {
+		// materialize the credential as a secret of the component, rather than passing the plain values.
+		secret := builder.NewSecretBuilder(namespace,
+			constant.GenerateServiceRefCredentialSecretName(synthesizedComp.ClusterName, synthesizedComp.Name, serviceRefDecl.Name)).
+			SetData(vars.credential).
+			GetObject()
+		copySecretDataToCredentialVar(namespace, secret, constant.AccountNameForSecret, &vars.username)
+		copySecretDataToCredentialVar(namespace, secret, constant.AccountPasswdForSecret, &vars.password)
+		if synthesizedComp.ServiceReferenceSecrets == nil {
+			synthesizedComp.ServiceReferenceSecrets = map[string]*corev1.Secret{}
+		}
+		synthesizedComp.ServiceReferenceSecrets[serviceRefDecl.Name] = secret
+	}
 
 	// just in-memory service descriptor object, the namespace and name are trivial
 	b := builder.NewServiceDescriptorBuilder(namespace, serviceRefDecl.Name).
@@ -128,20 +145,24 @@ func handleServiceRefFromCluster(ctx context.Context, cli client.Reader, namespa
 }
 
 func referencedVars(ctx context.Context, cli client.Reader, namespace string, serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars) error {
-	if err := referencedServiceVars(ctx, cli, namespace, serviceRef, vars); err != nil {
+	ctx, crossContext, err := serviceRefContext(ctx, cli, namespace, serviceRef)
+	if err != nil {
+		return err
+	}
+	if err := referencedServiceVars(ctx, cli, namespace, serviceRef, vars, crossContext); err != nil {
 		return err
 	}
-	if err := referencedPodFQDNsVar(ctx, cli, namespace, serviceRef, vars); err != nil {
+	if err := referencedPodFQDNsVar(ctx, cli, namespace, serviceRef, vars, crossContext); err != nil {
 		return err
 	}
-	if err := referencedCredentialVars(ctx, cli, namespace, serviceRef, vars); err != nil {
+	if err := referencedCredentialVars(ctx, cli, namespace, serviceRef, vars, crossContext); err != nil {
 		return err
 	}
 	return nil
 }
 
 func referencedServiceVars(ctx context.Context, cli client.Reader, namespace string,
-	serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars) error {
+	serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars, crossContext bool) error {
 	var (
 		selector = serviceRef.ClusterServiceSelector
 		obj      any
@@ -168,11 +189,18 @@ func referencedServiceVars(ctx context.Context, cli client.Reader, namespace str
 		return err
 	}
 
-	// use the service name when the referred service is in the same namespace, to keep it consistent with the vars.
-	fqdn := svcNamespace != namespace
-	vars.host = &appsv1.CredentialVar{Value: composeHostValueFromServices(obj, fqdn)}
-	if p := composePortValueFromServices(obj, selector.Service.Port); p != nil {
-		vars.port = &appsv1.CredentialVar{Value: *p}
+	if crossContext {
+		// the in-cluster names are not reachable from other k8s clusters, use the exposed addresses instead.
+		if err = exposedServiceVars(ctx, cli, obj, selector.Service.Port, vars); err != nil {
+			return err
+		}
+	} else {
+		// use the service name when the referred service is in the same namespace, to keep it consistent with the vars.
+		fqdn := svcNamespace != namespace
+		vars.host = &appsv1.CredentialVar{Value: composeHostValueFromServices(obj, fqdn)}
+		if p := composePortValueFromServices(obj, selector.Service.Port); p != nil {
+			vars.port = &appsv1.CredentialVar{Value: *p}
+		}
 	}
 
 	vars.endpoint = func() *appsv1.CredentialVar {
@@ -190,7 +218,7 @@ func referencedServiceVars(ctx context.Context, cli client.Reader, namespace str
 }
 
 func referencedPodFQDNsVar(ctx context.Context, cli client.Reader, namespace string,
-	serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars) error {
+	serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars, crossContext bool) error {
 	var (
 		selector = serviceRef.ClusterServiceSelector
 	)
@@ -208,20 +236,27 @@ func referencedPodFQDNsVar(ctx context.Context, cli client.Reader, namespace str
 		compName    = selector.PodFQDNs.Component
 	)
 	if selector.PodFQDNs.Role == nil {
-		fqdn, err = componentVarPodsGetter(ctx, cli, namespace, clusterName, compName, nil, true)
+		fqdn, err = componentVarPodsGetter(ctx, cli, namespace, clusterName, compName, nil, !crossContext)
 	} else {
-		fqdn, err = componentVarPodsWithRoleGetter(ctx, cli, namespace, clusterName, compName, *selector.PodFQDNs.Role, true)
+		fqdn, err = componentVarPodsWithRoleGetter(ctx, cli, namespace, clusterName, compName, *selector.PodFQDNs.Role, !crossContext)
 	}
 	if err != nil {
 		return err
 	}
+	if crossContext && len(fqdn) > 0 {
+		// the pod FQDNs are not resolvable from other k8s clusters, use the addresses of the exposed pod-services instead.
+		fqdn, err = exposedPodFQDNsVar(ctx, cli, namespace, clusterName, compName, strings.Split(fqdn, ","))
+		if err != nil {
+			return err
+		}
+	}
 	vars.podFQDNs = &appsv1.CredentialVar{Value: fqdn}
 
 	return nil
 }
 
 func referencedCredentialVars(ctx context.Context, cli client.Reader, namespace string,
-	serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars) error {
+	serviceRef appsv1.ServiceRef, vars *serviceRefReferenceVars, crossContext bool) error {
 	var (
 		selector = serviceRef.ClusterServiceSelector
 	)
@@ -243,6 +278,16 @@ func referencedCredentialVars(ctx context.Context, cli client.Reader, namespace
 		return err
 	}
 
+	if crossContext {
+		// the secret does not exist in the k8s clusters of the referencing cluster, copy the data instead.
+		vars.credential = map[string][]byte{}
+		for _, key := range []string{constant.AccountNameForSecret, constant.AccountPasswdForSecret} {
+			if val, ok := secret.Data[key]; ok {
+				vars.credential[key] = val
+			}
+		}
+		return nil
+	}
 	copySecretDataToCredentialVar(namespace, secret, constant.AccountNameForSecret, &vars.username)
 	copySecretDataToCredentialVar(namespace, secret, constant.AccountPasswdForSecret, &vars.password)
 
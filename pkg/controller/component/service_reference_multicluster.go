@@ -0,0 +1,209 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+)
+
+// serviceRefContext returns the context to resolve the objects of the Cluster referenced by the service-ref,
+// and whether the referenced Cluster runs in contexts other than the ones of the referencing Cluster.
+func serviceRefContext(ctx context.Context, cli client.Reader, namespace string, serviceRef appsv1.ServiceRef) (context.Context, bool, error) {
+	local, err := multicluster.FromContext(ctx)
+	if err != nil || len(local) == 0 {
+		return ctx, false, nil // not in multi-cluster mode
+	}
+
+	selector := serviceRef.ClusterServiceSelector
+	remote := selector.Context
+	if len(remote) == 0 {
+		if serviceRef.Namespace != "" {
+			namespace = serviceRef.Namespace
+		}
+		cluster := &appsv1.Cluster{}
+		if err = cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Cluster}, cluster); err != nil {
+			return nil, false, err
+		}
+		if cluster.Annotations != nil {
+			remote = cluster.Annotations[constant.KBAppMultiClusterPlacementKey]
+		}
+	}
+	if len(remote) == 0 {
+		return ctx, false, nil
+	}
+
+	cross := !sets.New(strings.Split(remote, ",")...).IsSuperset(sets.New(strings.Split(local, ",")...))
+	return multicluster.IntoContext(ctx, remote), cross, nil
+}
+
+// exposedServiceVars resolves the host and port of the referenced services from their exposed addresses,
+// which are reachable from other Kubernetes clusters.
+func exposedServiceVars(ctx context.Context, cli client.Reader, obj any, portName string, vars *serviceRefReferenceVars) error {
+	robj := obj.(*resolvedServiceObj)
+	services := []*corev1.Service{robj.service}
+	if robj.podServices != nil {
+		services = slices.Clone(robj.podServices)
+		slices.SortFunc(services, func(a, b *corev1.Service) int { return strings.Compare(a.Name, b.Name) })
+	}
+
+	hosts, ports := make([]string, 0), make([]string, 0)
+	for _, svc := range services {
+		host, err := exposedServiceHost(ctx, cli, svc)
+		if err != nil {
+			return err
+		}
+		port, err := exposedServicePort(svc, portName)
+		if err != nil {
+			return err
+		}
+		hosts = append(hosts, host)
+		ports = append(ports, port)
+	}
+
+	vars.host = &appsv1.CredentialVar{Value: strings.Join(hosts, ",")}
+	if robj.podServices == nil {
+		vars.port = &appsv1.CredentialVar{Value: ports[0]}
+	} else {
+		// keep the format consistent with the pod-service: host1:port1,host2:port2,...
+		endpoints := make([]string, 0)
+		for i := range hosts {
+			endpoints = append(endpoints, fmt.Sprintf("%s:%s", hosts[i], ports[i]))
+		}
+		vars.port = &appsv1.CredentialVar{Value: strings.Join(endpoints, ",")}
+	}
+	return nil
+}
+
+// exposedPodFQDNsVar resolves the addresses of the pods from their exposed pod-services.
+func exposedPodFQDNsVar(ctx context.Context, cli client.Reader, namespace, clusterName, compName string, podNames []string) (string, error) {
+	svcList := &corev1.ServiceList{}
+	if err := cli.List(ctx, svcList, client.InNamespace(namespace),
+		client.MatchingLabels(constant.GetCompLabels(clusterName, compName)), inDataContext()); err != nil {
+		return "", err
+	}
+	podServices := make(map[string]*corev1.Service)
+	for i, svc := range svcList.Items {
+		if podName, ok := svc.Spec.Selector[constant.KBAppPodNameLabelKey]; ok && isServiceExposed(&svc) {
+			podServices[podName] = &svcList.Items[i]
+		}
+	}
+
+	addresses := make([]string, 0)
+	for _, podName := range podNames {
+		svc, ok := podServices[podName]
+		if !ok {
+			return "", fmt.Errorf("there is no exposed pod-service for pod %s", podName)
+		}
+		host, err := exposedServiceHost(ctx, cli, svc)
+		if err != nil {
+			return "", err
+		}
+		addresses = append(addresses, host)
+	}
+	return strings.Join(addresses, ","), nil
+}
+
+func isServiceExposed(svc *corev1.Service) bool {
+	return svc.Spec.Type == corev1.ServiceTypeLoadBalancer || svc.Spec.Type == corev1.ServiceTypeNodePort
+}
+
+func exposedServiceHost(ctx context.Context, cli client.Reader, svc *corev1.Service) (string, error) {
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) > 0 {
+		ingress := svc.Status.LoadBalancer.Ingress[0]
+		if len(ingress.IP) > 0 {
+			return ingress.IP, nil
+		}
+		if len(ingress.Hostname) > 0 {
+			return ingress.Hostname, nil
+		}
+	}
+	if !hasServiceNodePort(svc) {
+		return "", fmt.Errorf("service %s/%s is not exposed outside of its Kubernetes cluster", svc.Namespace, svc.Name)
+	}
+	return nodeAddress(ctx, cli, svc)
+}
+
+func exposedServicePort(svc *corev1.Service, portName string) (string, error) {
+	var svcPort *corev1.ServicePort
+	for i, port := range svc.Spec.Ports {
+		if port.Name == portName || len(svc.Spec.Ports) == 1 && (len(port.Name) == 0 || len(portName) == 0) {
+			svcPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if svcPort == nil {
+		return "", fmt.Errorf("port %s is not found in service %s/%s", portName, svc.Namespace, svc.Name)
+	}
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) > 0 {
+		return strconv.Itoa(int(svcPort.Port)), nil
+	}
+	if svcPort.NodePort > 0 {
+		return strconv.Itoa(int(svcPort.NodePort)), nil
+	}
+	return "", fmt.Errorf("port %s of service %s/%s is not exposed outside of its Kubernetes cluster", portName, svc.Namespace, svc.Name)
+}
+
+func hasServiceNodePort(svc *corev1.Service) bool {
+	for _, port := range svc.Spec.Ports {
+		if port.NodePort > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeAddress returns an address of the nodes in the Kubernetes cluster where the service is placed,
+// the external address is preferred.
+func nodeAddress(ctx context.Context, cli client.Reader, svc *corev1.Service) (string, error) {
+	if svc.Annotations != nil && svc.Annotations[constant.KBAppMultiClusterPlacementKey] != "" {
+		ctx = multicluster.IntoContext(ctx, svc.Annotations[constant.KBAppMultiClusterPlacementKey])
+	}
+	nodes := &corev1.NodeList{}
+	if err := cli.List(ctx, nodes, inDataContext()); err != nil {
+		return "", err
+	}
+	slices.SortFunc(nodes.Items, func(a, b corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+	for _, addrType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeExternalDNS, corev1.NodeInternalIP} {
+		for _, node := range nodes.Items {
+			if node.Spec.Unschedulable {
+				continue
+			}
+			for _, addr := range node.Status.Addresses {
+				if addr.Type == addrType && len(addr.Address) > 0 {
+					return addr.Address, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("there is no node address available for the node-port of service %s/%s", svc.Namespace, svc.Name)
+}
@@ -34,6 +34,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
@@ -645,5 +646,182 @@ var _ = Describe("service references", func() {
 			Expect(serviceDescriptor.Spec.Port).Should(BeNil())
 			Expect(serviceDescriptor.Spec.Auth).Should(BeNil())
 		})
+
+		It("cross-context - service and credential vars", func() {
+			comp.Spec.ServiceRefs = []appsv1.ServiceRef{
+				{
+					Name: serviceRefDeclaration.Name,
+					ClusterServiceSelector: &appsv1.ServiceRefClusterSelector{
+						Cluster: etcdCluster,
+						Context: "data-2",
+						Service: &appsv1.ServiceRefServiceSelector{
+							Service: "client",
+							Port:    "client",
+						},
+						Credential: &appsv1.ServiceRefCredentialSelector{
+							Component: etcdComponent,
+							Name:      "default",
+						},
+					},
+				},
+			}
+			reader := &mockReader{
+				cli: testCtx.Cli,
+				objs: []client.Object{
+					&corev1.Service{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      constant.GenerateClusterServiceName(etcdCluster, "client"),
+						},
+						Spec: corev1.ServiceSpec{
+							Type: corev1.ServiceTypeLoadBalancer,
+							Ports: []corev1.ServicePort{
+								{
+									Name:     "client",
+									Port:     2379,
+									NodePort: 32379,
+								},
+							},
+						},
+						Status: corev1.ServiceStatus{
+							LoadBalancer: corev1.LoadBalancerStatus{
+								Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+							},
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      constant.GenerateAccountSecretName(etcdCluster, etcdComponent, "default"),
+						},
+						Data: map[string][]byte{
+							constant.AccountNameForSecret:   []byte("username"),
+							constant.AccountPasswdForSecret: []byte("password"),
+						},
+					},
+				},
+			}
+
+			ctx := multicluster.IntoContext(testCtx.Ctx, "data-1")
+			err := buildServiceReferencesWithoutResolve(ctx, reader, synthesizedComp, compDef, comp)
+			Expect(err).Should(Succeed())
+
+			serviceDescriptor := synthesizedComp.ServiceReferences[serviceRefDeclaration.Name]
+			Expect(serviceDescriptor).Should(Not(BeNil()))
+			Expect(serviceDescriptor.Spec.Host.Value).Should(Equal("10.0.0.1"))
+			Expect(serviceDescriptor.Spec.Port.Value).Should(Equal("2379"))
+			Expect(serviceDescriptor.Spec.Endpoint.Value).Should(Equal("10.0.0.1:2379"))
+
+			By("the credential is copied to a local secret rather than the plain values")
+			secret := synthesizedComp.ServiceReferenceSecrets[serviceRefDeclaration.Name]
+			Expect(secret).Should(Not(BeNil()))
+			Expect(secret.Name).Should(Equal(constant.GenerateServiceRefCredentialSecretName(synthesizedComp.ClusterName, synthesizedComp.Name, serviceRefDeclaration.Name)))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountNameForSecret, []byte("username")))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte("password")))
+			Expect(serviceDescriptor.Spec.Auth.Username.Value).Should(BeEmpty())
+			Expect(serviceDescriptor.Spec.Auth.Username.ValueFrom.SecretKeyRef.Name).Should(Equal(secret.Name))
+			Expect(serviceDescriptor.Spec.Auth.Username.ValueFrom.SecretKeyRef.Key).Should(Equal(constant.AccountNameForSecret))
+			Expect(serviceDescriptor.Spec.Auth.Password.Value).Should(BeEmpty())
+			Expect(serviceDescriptor.Spec.Auth.Password.ValueFrom.SecretKeyRef.Name).Should(Equal(secret.Name))
+			Expect(serviceDescriptor.Spec.Auth.Password.ValueFrom.SecretKeyRef.Key).Should(Equal(constant.AccountPasswdForSecret))
+		})
+
+		It("cross-context - service not exposed", func() {
+			comp.Spec.ServiceRefs = []appsv1.ServiceRef{
+				{
+					Name: serviceRefDeclaration.Name,
+					ClusterServiceSelector: &appsv1.ServiceRefClusterSelector{
+						Cluster: etcdCluster,
+						Context: "data-2",
+						Service: &appsv1.ServiceRefServiceSelector{
+							Service: "client",
+						},
+					},
+				},
+			}
+			reader := &mockReader{
+				cli: testCtx.Cli,
+				objs: []client.Object{
+					&corev1.Service{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      constant.GenerateClusterServiceName(etcdCluster, "client"),
+						},
+						Spec: corev1.ServiceSpec{
+							Ports: []corev1.ServicePort{{Name: "client", Port: 2379}},
+						},
+					},
+				},
+			}
+
+			// the referenced cluster runs in the same context, the in-cluster name is used
+			ctx := multicluster.IntoContext(testCtx.Ctx, "data-2")
+			Expect(buildServiceReferencesWithoutResolve(ctx, reader, synthesizedComp, compDef, comp)).Should(Succeed())
+			Expect(synthesizedComp.ServiceReferences[serviceRefDeclaration.Name].Spec.Host.Value).Should(Equal(reader.objs[0].GetName()))
+
+			ctx = multicluster.IntoContext(testCtx.Ctx, "data-1")
+			err := buildServiceReferencesWithoutResolve(ctx, reader, synthesizedComp, compDef, comp)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("is not exposed outside of its Kubernetes cluster"))
+		})
+
+		It("cross-context - pod FQDNs", func() {
+			comp.Spec.ServiceRefs = []appsv1.ServiceRef{
+				{
+					Name: serviceRefDeclaration.Name,
+					ClusterServiceSelector: &appsv1.ServiceRefClusterSelector{
+						Cluster: etcdCluster,
+						Context: "data-2",
+						PodFQDNs: &appsv1.ServiceRefPodFQDNsSelector{
+							Component: etcdComponent,
+							Role:      &[]string{"leader"}[0],
+						},
+					},
+				},
+			}
+			podName := constant.GeneratePodName(etcdCluster, etcdComponent, 0)
+			reader := &mockReader{
+				cli: testCtx.Cli,
+				objs: []client.Object{
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      podName,
+							Labels: map[string]string{
+								constant.AppManagedByLabelKey:   constant.AppName,
+								constant.AppInstanceLabelKey:    etcdCluster,
+								constant.KBAppComponentLabelKey: etcdComponent,
+								constant.RoleLabelKey:           "leader",
+							},
+						},
+					},
+					&corev1.Service{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      fmt.Sprintf("%s-0", constant.GenerateComponentServiceName(etcdCluster, etcdComponent, "lb")),
+							Labels:    constant.GetCompLabels(etcdCluster, etcdComponent),
+						},
+						Spec: corev1.ServiceSpec{
+							Type:     corev1.ServiceTypeLoadBalancer,
+							Selector: map[string]string{constant.KBAppPodNameLabelKey: podName},
+							Ports:    []corev1.ServicePort{{Name: "client", Port: 2379}},
+						},
+						Status: corev1.ServiceStatus{
+							LoadBalancer: corev1.LoadBalancerStatus{
+								Ingress: []corev1.LoadBalancerIngress{{Hostname: "etcd-0.example.com"}},
+							},
+						},
+					},
+				},
+			}
+
+			ctx := multicluster.IntoContext(testCtx.Ctx, "data-1")
+			err := buildServiceReferencesWithoutResolve(ctx, reader, synthesizedComp, compDef, comp)
+			Expect(err).Should(Succeed())
+
+			serviceDescriptor := synthesizedComp.ServiceReferences[serviceRefDeclaration.Name]
+			Expect(serviceDescriptor).Should(Not(BeNil()))
+			Expect(serviceDescriptor.Spec.PodFQDNs.Value).Should(Equal("etcd-0.example.com"))
+		})
 	})
 })
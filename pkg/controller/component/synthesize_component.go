@@ -117,6 +117,11 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 	// build scheduling policy for workload
 	buildSchedulingPolicy(synthesizeComp, comp)
 
+	// build instance templates for zones
+	if err = buildZoneDistribution(synthesizeComp, comp); err != nil {
+		return nil, err
+	}
+
 	// update resources
 	buildAndUpdateResources(synthesizeComp, comp)
 
@@ -49,17 +49,20 @@ type SynthesizedComponent struct {
 	TLSConfig                        *kbappsv1.TLSConfig                    `json:"tlsConfig"`
 	ServiceAccountName               string                                 `json:"serviceAccountName,omitempty"`
 	ServiceReferences                map[string]*kbappsv1.ServiceDescriptor `json:"serviceReferences,omitempty"`
+	ServiceReferenceSecrets          map[string]*corev1.Secret              // secrets of the credentials copied from the service-refs to clusters in other contexts
 	Labels                           map[string]string                      `json:"labels,omitempty"`
 	StaticLabels                     map[string]string                      // labels defined by the component definition
 	DynamicLabels                    map[string]string                      // labels defined by the cluster and component API
 	Annotations                      map[string]string                      `json:"annotations,omitempty"`
 	StaticAnnotations                map[string]string                      // annotations defined by the component definition
 	DynamicAnnotations               map[string]string                      // annotations defined by the cluster and component API
+	PodAnnotations                   map[string]string                      // annotations applied to the pod template only, e.g. to roll the pods
 	TemplateVars                     map[string]any                         `json:"templateVars,omitempty"`
 	EnvVars                          []corev1.EnvVar                        `json:"envVars,omitempty"`
 	EnvFromSources                   []corev1.EnvFromSource                 `json:"envFromSources,omitempty"`
 	Instances                        []kbappsv1.InstanceTemplate            `json:"instances,omitempty"`
 	OfflineInstances                 []string                               `json:"offlineInstances,omitempty"`
+	ZoneDistribution                 *kbappsv1.ZoneDistribution             `json:"zoneDistribution,omitempty"`
 	Roles                            []kbappsv1.ReplicaRole                 `json:"roles,omitempty"`
 	UpdateStrategy                   *kbappsv1.UpdateStrategy               `json:"updateStrategy,omitempty"`
 	PodManagementPolicy              *appsv1.PodManagementPolicyType        `json:"podManagementPolicy,omitempty"`
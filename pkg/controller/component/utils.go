@@ -43,6 +43,22 @@ func ValidateDefNameRegexp(defNamePattern string) error {
 	return err
 }
 
+// ImmutableCompDefSpec returns a copy of the spec of the ComponentDefinition with all mutable fields reset,
+// the ComponentDefinition can't be updated if the returned spec is changed.
+func ImmutableCompDefSpec(compDef *appsv1.ComponentDefinition) *appsv1.ComponentDefinitionSpec {
+	spec := compDef.Spec.DeepCopy()
+
+	// reset all mutable fields
+	spec.Provider = ""
+	spec.Description = ""
+	spec.Exporter = nil
+	spec.PodManagementPolicy = nil
+
+	// TODO: bpt
+
+	return spec
+}
+
 func PrefixOrRegexMatched(defName, defNamePattern string) bool {
 	if strings.HasPrefix(defName, defNamePattern) {
 		return true
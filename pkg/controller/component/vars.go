@@ -1148,9 +1148,13 @@ func componentVarPodsGetter(ctx context.Context, cli client.Reader,
 		}
 	}
 
+	instances, err := BuildInstanceTemplates(comp)
+	if err != nil {
+		return "", err
+	}
 	var templates []instanceset.InstanceTemplate
-	for i := range comp.Spec.Instances {
-		templates = append(templates, &comp.Spec.Instances[i])
+	for i := range instances {
+		templates = append(templates, &instances[i])
 	}
 	names, err := instanceset.GenerateAllInstanceNames(comp.Name, comp.Spec.Replicas, templates, comp.Spec.OfflineInstances, workloads.Ordinals{})
 	if err != nil {
@@ -164,6 +164,22 @@ func GenerateAllPodNamesToSet(
 	return podSet, nil
 }
 
+// GenerateAllPodNamesToSetWithZones generates all pod names for a component like GenerateAllPodNamesToSet,
+// and the instance templates generated by the zone distribution policy are taken into account.
+func GenerateAllPodNamesToSetWithZones(
+	compReplicas int32,
+	instances []appsv1.InstanceTemplate,
+	zoneDistribution *appsv1.ZoneDistribution,
+	offlineInstances []string,
+	clusterName,
+	fullCompName string) (map[string]string, error) {
+	templates, err := BuildInstanceTemplatesWithZones(compReplicas, instances, zoneDistribution)
+	if err != nil {
+		return nil, err
+	}
+	return GenerateAllPodNamesToSet(compReplicas, templates, offlineInstances, clusterName, fullCompName)
+}
+
 func GetTemplateNameAndOrdinal(workloadName, podName string) (string, int32, error) {
 	podSuffix := strings.Replace(podName, workloadName+"-", "", 1)
 	suffixArr := strings.Split(podSuffix, "-")
@@ -0,0 +1,206 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var (
+	invalidInstanceTemplateNameChars = regexp.MustCompile(`[^a-z0-9.\-]+`)
+)
+
+// BuildInstanceTemplates returns the instance templates of the component, including the templates generated
+// by the zone distribution policy.
+func BuildInstanceTemplates(comp *appsv1.Component) ([]appsv1.InstanceTemplate, error) {
+	if comp == nil {
+		return nil, nil
+	}
+	return BuildInstanceTemplatesWithZones(comp.Spec.Replicas, comp.Spec.Instances, comp.Spec.ZoneDistribution)
+}
+
+// ZoneInstanceTemplateName returns the name of the instance template generated for the zone.
+func ZoneInstanceTemplateName(zone string) string {
+	name := invalidInstanceTemplateNameChars.ReplaceAllString(strings.ToLower(zone), "-")
+	return strings.Trim(name, ".-")
+}
+
+func buildZoneDistribution(synthesizedComp *SynthesizedComponent, comp *appsv1.Component) error {
+	if comp.Spec.ZoneDistribution == nil {
+		return nil
+	}
+	instances, err := BuildInstanceTemplates(comp)
+	if err != nil {
+		return err
+	}
+	synthesizedComp.Instances = instances
+	synthesizedComp.ZoneDistribution = comp.Spec.ZoneDistribution
+	return nil
+}
+
+// BuildInstanceTemplatesWithZones returns the instance templates with the templates generated by the zone
+// distribution policy appended, it is used where only the component spec of the cluster is available.
+func BuildInstanceTemplatesWithZones(replicas int32, instances []appsv1.InstanceTemplate,
+	zoneDistribution *appsv1.ZoneDistribution) ([]appsv1.InstanceTemplate, error) {
+	if zoneDistribution == nil {
+		return instances, nil
+	}
+	if err := validateZoneDistribution(zoneDistribution, instances); err != nil {
+		return nil, err
+	}
+
+	remaining := replicas
+	for _, tpl := range instances {
+		remaining -= tpl.GetReplicas()
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	zones := sortedZones(zoneDistribution)
+	distribution, err := distributeReplicas(remaining, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	topologyKey := zoneDistribution.TopologyKey
+	if len(topologyKey) == 0 {
+		topologyKey = corev1.LabelTopologyZone
+	}
+	templates := make([]appsv1.InstanceTemplate, 0, len(instances)+len(zones))
+	templates = append(templates, instances...)
+	for i, zone := range zones {
+		templates = append(templates, appsv1.InstanceTemplate{
+			Name:     ZoneInstanceTemplateName(zone.Name),
+			Replicas: ptr.To(distribution[i]),
+			Labels: map[string]string{
+				constant.KBAppZoneLabelKey: zone.Name,
+			},
+			SchedulingPolicy: &appsv1.SchedulingPolicy{
+				NodeSelector: map[string]string{
+					topologyKey: zone.Name,
+				},
+			},
+		})
+	}
+	return templates, nil
+}
+
+func validateZoneDistribution(zoneDistribution *appsv1.ZoneDistribution, instances []appsv1.InstanceTemplate) error {
+	if len(zoneDistribution.Zones) == 0 {
+		return fmt.Errorf("zone distribution has no zones specified")
+	}
+	templates := sets.New[string]()
+	for _, tpl := range instances {
+		templates.Insert(tpl.Name)
+	}
+	zones, names := sets.New[string](), sets.New[string]()
+	for _, zone := range zoneDistribution.Zones {
+		if zones.Has(zone.Name) {
+			return fmt.Errorf("duplicated zone %s in zone distribution", zone.Name)
+		}
+		zones.Insert(zone.Name)
+		name := ZoneInstanceTemplateName(zone.Name)
+		if len(name) == 0 {
+			return fmt.Errorf("invalid zone name %s in zone distribution", zone.Name)
+		}
+		if names.Has(name) || templates.Has(name) {
+			return fmt.Errorf("the instance template name %s generated for zone %s is conflicted", name, zone.Name)
+		}
+		names.Insert(name)
+	}
+	if len(zoneDistribution.PrimaryZone) > 0 && !zones.Has(zoneDistribution.PrimaryZone) {
+		return fmt.Errorf("the primary zone %s is not defined in zone distribution", zoneDistribution.PrimaryZone)
+	}
+	return nil
+}
+
+// sortedZones returns the zones with the primary zone placed first, the order of other zones is retained.
+func sortedZones(zoneDistribution *appsv1.ZoneDistribution) []appsv1.Zone {
+	zones := make([]appsv1.Zone, len(zoneDistribution.Zones))
+	copy(zones, zoneDistribution.Zones)
+	sort.SliceStable(zones, func(i, j int) bool {
+		return zones[i].Name == zoneDistribution.PrimaryZone && zones[j].Name != zoneDistribution.PrimaryZone
+	})
+	return zones
+}
+
+// distributeReplicas assigns the minimum replicas to each zone first, and then distributes the rest replicas
+// proportionally to the weights of zones, the remainders go to the zones with the largest fractional parts.
+// The result is deterministic for the same input, so the distribution keeps balanced as the replicas change.
+func distributeReplicas(replicas int32, zones []appsv1.Zone) ([]int32, error) {
+	result := make([]int32, len(zones))
+
+	var minReplicas, totalWeight int64
+	for i, zone := range zones {
+		result[i] = zone.MinReplicas
+		minReplicas += int64(zone.MinReplicas)
+		totalWeight += int64(zoneWeight(zone))
+	}
+	if minReplicas > int64(replicas) {
+		return nil, fmt.Errorf("the sum of min replicas of zones %d exceeds the replicas %d to distribute", minReplicas, replicas)
+	}
+
+	rest := int64(replicas) - minReplicas
+	if rest == 0 {
+		return result, nil
+	}
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("there are %d replicas to distribute, but all the zones have zero weight", rest)
+	}
+
+	remainders := make([]int64, len(zones))
+	var assigned int64
+	for i, zone := range zones {
+		share := rest * int64(zoneWeight(zone))
+		result[i] += int32(share / totalWeight)
+		remainders[i] = share % totalWeight
+		assigned += share / totalWeight
+	}
+
+	indexes := make([]int, len(zones))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return remainders[indexes[i]] > remainders[indexes[j]]
+	})
+	for i := int64(0); i < rest-assigned; i++ {
+		result[indexes[i]]++
+	}
+	return result, nil
+}
+
+func zoneWeight(zone appsv1.Zone) int32 {
+	if zone.Weight == nil {
+		return 1
+	}
+	return *zone.Weight
+}
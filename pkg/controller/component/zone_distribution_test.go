@@ -0,0 +1,133 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("zone distribution", func() {
+	replicasOf := func(templates []appsv1.InstanceTemplate) map[string]int32 {
+		m := map[string]int32{}
+		for _, tpl := range templates {
+			m[tpl.Name] = tpl.GetReplicas()
+		}
+		return m
+	}
+
+	Context("distribute replicas", func() {
+		var zd *appsv1.ZoneDistribution
+
+		BeforeEach(func() {
+			zd = &appsv1.ZoneDistribution{
+				Zones: []appsv1.Zone{
+					{Name: "us-east-1a"},
+					{Name: "us-east-1b"},
+					{Name: "us-east-1c"},
+				},
+			}
+		})
+
+		It("evenly", func() {
+			templates, err := BuildInstanceTemplatesWithZones(7, nil, zd)
+			Expect(err).Should(BeNil())
+			Expect(templates).Should(HaveLen(3))
+			Expect(replicasOf(templates)).Should(Equal(map[string]int32{"us-east-1a": 3, "us-east-1b": 2, "us-east-1c": 2}))
+
+			tpl := templates[0]
+			Expect(tpl.Labels).Should(HaveKeyWithValue(constant.KBAppZoneLabelKey, "us-east-1a"))
+			Expect(tpl.SchedulingPolicy.NodeSelector).Should(HaveKeyWithValue(corev1.LabelTopologyZone, "us-east-1a"))
+		})
+
+		It("primary zone first", func() {
+			zd.PrimaryZone = "us-east-1c"
+			templates, err := BuildInstanceTemplatesWithZones(4, nil, zd)
+			Expect(err).Should(BeNil())
+			Expect(templates[0].Name).Should(Equal("us-east-1c"))
+			Expect(replicasOf(templates)).Should(Equal(map[string]int32{"us-east-1a": 1, "us-east-1b": 1, "us-east-1c": 2}))
+		})
+
+		It("with weights and min replicas", func() {
+			zd.Zones[0].Weight = ptr.To(int32(2))
+			zd.Zones[1].Weight = ptr.To(int32(0))
+			zd.Zones[1].MinReplicas = 1
+			templates, err := BuildInstanceTemplatesWithZones(7, nil, zd)
+			Expect(err).Should(BeNil())
+			Expect(replicasOf(templates)).Should(Equal(map[string]int32{"us-east-1a": 4, "us-east-1b": 1, "us-east-1c": 2}))
+		})
+
+		It("keep balanced when scaling", func() {
+			for replicas := int32(0); replicas <= 9; replicas++ {
+				templates, err := BuildInstanceTemplatesWithZones(replicas, nil, zd)
+				Expect(err).Should(BeNil())
+				minimum, maximum := replicas, int32(0)
+				for _, tpl := range templates {
+					minimum = min(minimum, tpl.GetReplicas())
+					maximum = max(maximum, tpl.GetReplicas())
+				}
+				Expect(maximum - minimum).Should(BeNumerically("<=", 1))
+			}
+		})
+
+		It("with user-defined templates", func() {
+			instances := []appsv1.InstanceTemplate{{Name: "custom", Replicas: ptr.To(int32(2))}}
+			templates, err := BuildInstanceTemplatesWithZones(5, instances, zd)
+			Expect(err).Should(BeNil())
+			Expect(templates).Should(HaveLen(4))
+			Expect(replicasOf(templates)).Should(Equal(map[string]int32{"custom": 2, "us-east-1a": 1, "us-east-1b": 1, "us-east-1c": 1}))
+		})
+
+		It("min replicas exceed", func() {
+			zd.Zones[0].MinReplicas = 3
+			_, err := BuildInstanceTemplatesWithZones(2, nil, zd)
+			Expect(err).ShouldNot(BeNil())
+		})
+
+		It("conflicted names", func() {
+			instances := []appsv1.InstanceTemplate{{Name: "us-east-1a"}}
+			_, err := BuildInstanceTemplatesWithZones(3, instances, zd)
+			Expect(err).ShouldNot(BeNil())
+		})
+
+		It("generate pod names", func() {
+			podSet, err := GenerateAllPodNamesToSetWithZones(4, nil, zd, nil, "test-cluster", "test-comp")
+			Expect(err).Should(BeNil())
+			Expect(podSet).Should(Equal(map[string]string{
+				"test-cluster-test-comp-us-east-1a-0": "us-east-1a",
+				"test-cluster-test-comp-us-east-1a-1": "us-east-1a",
+				"test-cluster-test-comp-us-east-1b-0": "us-east-1b",
+				"test-cluster-test-comp-us-east-1c-0": "us-east-1c",
+			}))
+		})
+
+		It("undefined primary zone", func() {
+			zd.PrimaryZone = "us-west-1a"
+			_, err := BuildInstanceTemplatesWithZones(3, nil, zd)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+})
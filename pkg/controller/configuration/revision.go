@@ -0,0 +1,176 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+)
+
+// maxRevisionParametersSize is the max total size of the parameters recorded for the revisions. The total size of
+// the annotations of an object is limited to 256KiB, so the parameters of the oldest revisions are pruned once exceeded.
+const maxRevisionParametersSize = 128 * 1024
+
+// GetRevisionParameters returns the parameters of the configuration item recorded at the revision.
+func GetRevisionParameters(cm *corev1.ConfigMap, revision string) (map[string]appsv1alpha1.ConfigParams, error) {
+	if cm == nil {
+		return nil, core.MakeError("configmap is nil")
+	}
+	data, ok := cm.GetAnnotations()[core.GenerateRevisionParametersKey(revision)]
+	if !ok {
+		return nil, core.MakeError("revision %s not found in configmap %s, it may have been garbage collected", revision, cm.Name)
+	}
+	params := make(map[string]appsv1alpha1.ConfigParams)
+	if err := json.Unmarshal([]byte(data), &params); err != nil {
+		return nil, core.WrapError(err, "failed to parse the parameters of revision %s", revision)
+	}
+	return params, nil
+}
+
+// SetRevisionParameters records the parameters of the configuration item at the revision.
+func SetRevisionParameters(cm *corev1.ConfigMap, revision string, params map[string]appsv1alpha1.ConfigParams) error {
+	if params == nil {
+		params = map[string]appsv1alpha1.ConfigParams{}
+	}
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string)
+	}
+	cm.Annotations[core.GenerateRevisionParametersKey(revision)] = string(b)
+	pruneRevisionParameters(cm)
+	return nil
+}
+
+// pruneRevisionParameters removes the parameters of the oldest revisions until the total size of the recorded
+// parameters is within maxRevisionParametersSize, the pruned revisions can't be rolled back to anymore.
+func pruneRevisionParameters(cm *corev1.ConfigMap) {
+	type recorded struct {
+		key      string
+		revision int64
+	}
+	var (
+		records []recorded
+		size    int
+	)
+	prefix := core.GenerateRevisionParametersKey("")
+	for key, value := range cm.Annotations {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		revision, err := strconv.ParseInt(strings.TrimPrefix(key, prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		records = append(records, recorded{key: key, revision: revision})
+		size += len(key) + len(value)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].revision < records[j].revision
+	})
+	for i := 0; i < len(records) && size > maxRevisionParametersSize; i++ {
+		size -= len(records[i].key) + len(cm.Annotations[records[i].key])
+		delete(cm.Annotations, records[i].key)
+	}
+}
+
+// DiffRevisions compares the parameters recorded at two revisions, and returns the parameter-level changes
+// from the revision 'from' to the revision 'to'.
+func DiffRevisions(cm *corev1.ConfigMap, from, to string) ([]appsv1alpha1.ParameterChange, error) {
+	base, err := GetRevisionParameters(cm, from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := GetRevisionParameters(cm, to)
+	if err != nil {
+		return nil, err
+	}
+	return DiffRevisionParameters(base, target), nil
+}
+
+// DiffRevisionParameters compares the parameters of two revisions, and returns the parameter-level changes
+// from the base to the target, sorted by file and parameter name.
+func DiffRevisionParameters(base, target map[string]appsv1alpha1.ConfigParams) []appsv1alpha1.ParameterChange {
+	var changes []appsv1alpha1.ParameterChange
+
+	files := make(map[string]bool)
+	for file := range base {
+		files[file] = true
+	}
+	for file := range target {
+		files[file] = true
+	}
+	for file := range files {
+		baseParams, targetParams := base[file], target[file]
+		changes = append(changes, diffValue(file, "", baseParams.Content, targetParams.Content)...)
+		names := make(map[string]bool)
+		for name := range baseParams.Parameters {
+			names[name] = true
+		}
+		for name := range targetParams.Parameters {
+			names[name] = true
+		}
+		for name := range names {
+			changes = append(changes, diffValue(file, name, baseParams.Parameters[name], targetParams.Parameters[name])...)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].File != changes[j].File {
+			return changes[i].File < changes[j].File
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func diffValue(file, name string, oldValue, newValue *string) []appsv1alpha1.ParameterChange {
+	change := appsv1alpha1.ParameterChange{
+		File: file,
+		Name: name,
+	}
+	switch {
+	case oldValue == nil && newValue == nil:
+		return nil
+	case oldValue == nil:
+		change.Type = appsv1alpha1.ParameterAdded
+	case newValue == nil:
+		change.Type = appsv1alpha1.ParameterDeleted
+	case *oldValue == *newValue:
+		return nil
+	default:
+		change.Type = appsv1alpha1.ParameterUpdated
+	}
+	// the content of file is not recorded, which may be too large
+	if len(name) > 0 {
+		change.OldValue = oldValue
+		change.NewValue = newValue
+	}
+	return []appsv1alpha1.ParameterChange{change}
+}
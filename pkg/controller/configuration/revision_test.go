@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+)
+
+var _ = Describe("configuration revision test", func() {
+	paramsOf := func(values map[string]string) map[string]appsv1alpha1.ConfigParams {
+		params := appsv1alpha1.ConfigParams{Parameters: map[string]*string{}}
+		for k, v := range values {
+			params.Parameters[k] = cfgutil.ToPointer(v)
+		}
+		return map[string]appsv1alpha1.ConfigParams{"my.cnf": params}
+	}
+
+	It("diff between any two revisions", func() {
+		cm := &corev1.ConfigMap{}
+		Expect(SetRevisionParameters(cm, "1", paramsOf(map[string]string{"max_connections": "100"}))).Should(Succeed())
+		Expect(SetRevisionParameters(cm, "2", paramsOf(map[string]string{"max_connections": "200"}))).Should(Succeed())
+		Expect(SetRevisionParameters(cm, "3", paramsOf(map[string]string{"max_connections": "200", "binlog_format": "ROW"}))).Should(Succeed())
+
+		changes, err := DiffRevisions(cm, "1", "3")
+		Expect(err).Should(Succeed())
+		Expect(changes).Should(Equal([]appsv1alpha1.ParameterChange{{
+			File:     "my.cnf",
+			Name:     "binlog_format",
+			Type:     appsv1alpha1.ParameterAdded,
+			NewValue: cfgutil.ToPointer("ROW"),
+		}, {
+			File:     "my.cnf",
+			Name:     "max_connections",
+			Type:     appsv1alpha1.ParameterUpdated,
+			OldValue: cfgutil.ToPointer("100"),
+			NewValue: cfgutil.ToPointer("200"),
+		}}))
+
+		_, err = DiffRevisions(cm, "0", "3")
+		Expect(err).ShouldNot(Succeed())
+	})
+
+	It("prune the parameters of the oldest revisions", func() {
+		cm := &corev1.ConfigMap{}
+		content := strings.Repeat("x", maxRevisionParametersSize/4)
+		for i := 1; i <= 8; i++ {
+			Expect(SetRevisionParameters(cm, strconv.Itoa(i), paramsOf(map[string]string{"content": content}))).Should(Succeed())
+		}
+		size := 0
+		for k, v := range cm.Annotations {
+			size += len(k) + len(v)
+		}
+		Expect(size).Should(BeNumerically("<=", maxRevisionParametersSize))
+		Expect(cm.Annotations).ShouldNot(HaveKey(core.GenerateRevisionParametersKey("1")))
+		Expect(cm.Annotations).Should(HaveKey(core.GenerateRevisionParametersKey("8")))
+		Expect(cm.Annotations).Should(HaveKey(core.GenerateRevisionParametersKey("7")))
+	})
+})
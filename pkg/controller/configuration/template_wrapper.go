@@ -166,6 +166,9 @@ func updateConfigMetaForCM(newCMObj *corev1.ConfigMap, item *appsv1alpha1.Config
 	annotations[constant.ConfigurationRevision] = revision
 	annotations[constant.CMConfigurationTemplateVersion] = item.Version
 	newCMObj.Annotations = annotations
+	if revision != "" {
+		return SetRevisionParameters(newCMObj, revision, item.ConfigFileParams)
+	}
 	return
 }
 
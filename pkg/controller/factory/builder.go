@@ -58,7 +58,8 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 		AddLabelsInMap(synthesizedComp.DynamicLabels).
 		AddLabelsInMap(synthesizedComp.StaticLabels).
 		AddAnnotationsInMap(synthesizedComp.DynamicAnnotations).
-		AddAnnotationsInMap(synthesizedComp.StaticAnnotations)
+		AddAnnotationsInMap(synthesizedComp.StaticAnnotations).
+		AddAnnotationsInMap(synthesizedComp.PodAnnotations)
 	template := corev1.PodTemplateSpec{
 		ObjectMeta: podBuilder.GetObject().ObjectMeta,
 		Spec:       *synthesizedComp.PodSpec.DeepCopy(),
@@ -313,6 +314,7 @@ func BuildCfgManagerContainer(sidecarRenderedParam *cfgcm.CfgManagerBuildParams)
 		AddArgs(getSidecarBinaryPath(sidecarRenderedParam)).
 		AddArgs(sidecarRenderedParam.Args...).
 		AddEnv(env...).
+		AddEnv(sidecarRenderedParam.Envs...).
 		AddPorts(corev1.ContainerPort{
 			Name:          constant.ConfigManagerPortName,
 			ContainerPort: sidecarRenderedParam.ContainerPort,
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package graph
+
+import (
+	"errors"
+)
+
+// WalkReverseTopoOrderConcurrently walks the DAG 'd' in reverse topology order with at most 'concurrency' vertices
+// being walked at the same time. A vertex is walked only after all the vertices it points to have been walked
+// successfully, so the ordering guarantee of WalkReverseTopoOrder is kept.
+// Once a walkFunc fails, no more vertices will be scheduled, the ones in-flight are waited for,
+// and all the errors encountered are aggregated.
+// 'less' decides which one goes first when there are more ready vertices than free workers.
+// It falls back to WalkReverseTopoOrder if 'concurrency' is less than or equal to 1.
+func (d *DAG) WalkReverseTopoOrderConcurrently(walkFunc WalkFunc, less func(v1, v2 Vertex) bool, concurrency int) error {
+	if concurrency <= 1 {
+		return d.WalkReverseTopoOrder(walkFunc, less)
+	}
+	if err := d.validate(); err != nil {
+		return err
+	}
+
+	// pending counts the dependencies that haven't been walked of each vertex,
+	// and dependents is the reverse index of the edges.
+	pending := make(map[Vertex]int, len(d.vertices))
+	dependents := make(map[Vertex][]Vertex, len(d.vertices))
+	for e := range d.edges {
+		pending[e.From()]++
+		dependents[e.To()] = append(dependents[e.To()], e.From())
+	}
+	return walkConcurrently(d.topologicalOrder(true, less), pending, dependents, walkFunc, concurrency)
+}
+
+// WalkConcurrently walks the independent 'vertices' with at most 'concurrency' of them being walked at the same time.
+// Vertices are scheduled in the order given, errors are handled the same as WalkReverseTopoOrderConcurrently.
+func WalkConcurrently(vertices []Vertex, walkFunc WalkFunc, concurrency int) error {
+	if concurrency <= 1 {
+		for _, v := range vertices {
+			if err := walkFunc(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walkConcurrently(vertices, nil, nil, walkFunc, concurrency)
+}
+
+type walkResult struct {
+	vertex Vertex
+	err    error
+}
+
+// walkConcurrently is the scheduler shared by the concurrent walkers.
+// 'orders' lists all the vertices in a legal walking order, and it is used to prioritize the ready ones.
+func walkConcurrently(orders []Vertex, pending map[Vertex]int, dependents map[Vertex][]Vertex, walkFunc WalkFunc, concurrency int) error {
+	priority := make(map[Vertex]int, len(orders))
+	for i, v := range orders {
+		priority[v] = i
+	}
+
+	// ready vertices are kept in priority order
+	ready := make([]Vertex, 0)
+	enqueue := func(v Vertex) {
+		i := len(ready)
+		for i > 0 && priority[ready[i-1]] > priority[v] {
+			i--
+		}
+		ready = append(ready, nil)
+		copy(ready[i+1:], ready[i:])
+		ready[i] = v
+	}
+	for _, v := range orders {
+		if pending[v] == 0 {
+			enqueue(v)
+		}
+	}
+
+	var (
+		results = make(chan walkResult, concurrency)
+		running = 0
+		errs    []error
+	)
+	for {
+		for len(errs) == 0 && len(ready) > 0 && running < concurrency {
+			v := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				results <- walkResult{vertex: v, err: walkFunc(v)}
+			}()
+		}
+		if running == 0 {
+			break
+		}
+		res := <-results
+		running--
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		for _, dependent := range dependents[res.vertex] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				enqueue(dependent)
+			}
+		}
+	}
+
+	// keep the error as-is if there is only one, so that the callers can still check its type.
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package graph
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestDependencyDAG() *DAG {
+	// 0 is the root, 1 and 2 depend on 3, 4 and 5 depend on nothing
+	dag := NewDAG()
+	for i := 0; i < 6; i++ {
+		dag.AddVertex(i)
+	}
+	dag.Connect(0, 1)
+	dag.Connect(0, 2)
+	dag.Connect(0, 4)
+	dag.Connect(0, 5)
+	dag.Connect(1, 3)
+	dag.Connect(2, 3)
+	return dag
+}
+
+func TestWalkReverseTopoOrderConcurrently(t *testing.T) {
+	dag := newTestDependencyDAG()
+	var (
+		lock     sync.Mutex
+		walked   = make(map[Vertex]bool)
+		inFlight int32
+		maxSeen  int32
+	)
+	walkFunc := func(v Vertex) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxSeen)
+			if n <= m || atomic.CompareAndSwapInt32(&maxSeen, m, n) {
+				break
+			}
+		}
+		lock.Lock()
+		for _, adj := range dag.outAdj(v) {
+			if !walked[adj] {
+				t.Errorf("vertex %v walked before its dependency %v", v, adj)
+			}
+		}
+		lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		lock.Lock()
+		walked[v] = true
+		lock.Unlock()
+		return nil
+	}
+	if err := dag.WalkReverseTopoOrderConcurrently(walkFunc, nil, 2); err != nil {
+		t.Error(err)
+	}
+	if len(walked) != 6 {
+		t.Errorf("unexpected walked vertices: %v", walked)
+	}
+	if maxSeen > 2 {
+		t.Errorf("concurrency exceeded: %d", maxSeen)
+	}
+	if maxSeen < 2 {
+		t.Errorf("vertices not walked concurrently")
+	}
+}
+
+func TestWalkReverseTopoOrderConcurrentlyWithError(t *testing.T) {
+	dag := newTestDependencyDAG()
+	errFoo := errors.New("foo")
+	errBar := errors.New("bar")
+	var (
+		lock   sync.Mutex
+		walked = make(map[Vertex]bool)
+	)
+	walkFunc := func(v Vertex) error {
+		lock.Lock()
+		walked[v] = true
+		lock.Unlock()
+		switch v {
+		case 3:
+			return errFoo
+		case 4, 5:
+			time.Sleep(10 * time.Millisecond)
+			return errBar
+		}
+		return nil
+	}
+	err := dag.WalkReverseTopoOrderConcurrently(walkFunc, nil, 3)
+	if !errors.Is(err, errFoo) || !errors.Is(err, errBar) {
+		t.Errorf("errors not aggregated: %v", err)
+	}
+	for _, v := range []Vertex{0, 1, 2} {
+		if walked[v] {
+			t.Errorf("vertex %v should not be walked", v)
+		}
+	}
+
+	// a single error should be returned as-is
+	walkFunc = func(v Vertex) error {
+		if v == 1 {
+			return errFoo
+		}
+		return nil
+	}
+	if err = dag.WalkReverseTopoOrderConcurrently(walkFunc, nil, 3); err != errFoo {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWalkReverseTopoOrderConcurrentlyFallback(t *testing.T) {
+	dag := newTestDependencyDAG()
+	less := func(v1, v2 Vertex) bool {
+		return v1.(int) < v2.(int)
+	}
+	var expected, actual []Vertex
+	if err := dag.WalkReverseTopoOrder(func(v Vertex) error {
+		expected = append(expected, v)
+		return nil
+	}, less); err != nil {
+		t.Error(err)
+	}
+	if err := dag.WalkReverseTopoOrderConcurrently(func(v Vertex) error {
+		actual = append(actual, v)
+		return nil
+	}, less, 1); err != nil {
+		t.Error(err)
+	}
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %v, actual %v", expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("expected %v, actual %v", expected, actual)
+		}
+	}
+
+	dag.Connect(3, 0)
+	if err := dag.WalkReverseTopoOrderConcurrently(func(v Vertex) error { return nil }, nil, 2); err == nil {
+		t.Error("cycle should be detected")
+	}
+}
+
+func TestWalkConcurrently(t *testing.T) {
+	var walked int32
+	vertices := []Vertex{0, 1, 2, 3, 4}
+	if err := WalkConcurrently(vertices, func(v Vertex) error {
+		atomic.AddInt32(&walked, 1)
+		return nil
+	}, 3); err != nil {
+		t.Error(err)
+	}
+	if walked != 5 {
+		t.Errorf("unexpected walked count: %d", walked)
+	}
+}
@@ -22,12 +22,16 @@ package graph
 import (
 	"context"
 	"errors"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // TransformContext is used by Transformer.Transform
@@ -43,6 +47,19 @@ type Transformer interface {
 	Transform(ctx TransformContext, dag *DAG) error
 }
 
+// TransformerObserver observes the failures of the transformers applied by TransformerChain, e.g. to count them.
+type TransformerObserver interface {
+	// TransformerFailed is called when the transformer returns an error other than the premature stop and requeues.
+	TransformerFailed(transformer string, err error)
+}
+
+var transformerObservers []TransformerObserver
+
+// RegisterTransformerObserver registers the observer of transformers, it should be called before the controllers start.
+func RegisterTransformerObserver(observer TransformerObserver) {
+	transformerObservers = append(transformerObservers, observer)
+}
+
 // TransformerChain chains a group Transformer together
 type TransformerChain []Transformer
 
@@ -54,19 +71,61 @@ var ErrPrematureStop = errors.New("Premature-Stop")
 func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	var delayedError error
 	for _, transformer := range r {
-		if err := transformer.Transform(ctx, dag); err != nil {
+		if err := applyTransformer(ctx, transformer, dag); err != nil {
 			if intctrlutil.IsDelayedRequeueError(err) {
 				if delayedError == nil {
 					delayedError = err
 				}
 				continue
 			}
+			// premature stops and requeues are ordinary results rather than errors
+			if err != ErrPrematureStop && !intctrlutil.IsRequeueError(err) {
+				for _, observer := range transformerObservers {
+					observer.TransformerFailed(transformerName(transformer), err)
+				}
+			}
 			return ignoredIfPrematureStop(err)
 		}
 	}
 	return delayedError
 }
 
+// applyTransformer applies the transformer in a span, and records its outcome for explaining.
+func applyTransformer(ctx TransformContext, transformer Transformer, dag *DAG) error {
+	name := transformerName(transformer)
+	_, span := tracing.StartSpan(ctx.GetContext(), name)
+	start := time.Now()
+	err := transformer.Transform(ctx, dag)
+
+	outcome := tracing.OutcomeSucceed
+	switch {
+	case err == nil:
+	case err == ErrPrematureStop:
+		outcome = tracing.OutcomePrematureStop
+	case intctrlutil.IsDelayedRequeueError(err):
+		outcome = tracing.OutcomeDelayedRequeue
+	default:
+		outcome = tracing.OutcomeFailed
+	}
+	span.SetAttributes(attribute.String("outcome", outcome))
+	if outcome == tracing.OutcomeFailed {
+		tracing.EndSpan(span, err)
+	} else {
+		span.End()
+	}
+	tracing.RecordTransformer(ctx.GetContext(), name, outcome, start, err)
+	return err
+}
+
+// transformerName returns the type name of the transformer, e.g. "clusterStatusTransformer".
+func transformerName(transformer Transformer) string {
+	t := reflect.TypeOf(transformer)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
 func ignoredIfPrematureStop(err error) error {
 	if err == ErrPrematureStop {
 		return nil
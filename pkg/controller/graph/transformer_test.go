@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type testTransformContext struct{}
+
+func (c *testTransformContext) GetContext() context.Context       { return context.Background() }
+func (c *testTransformContext) GetClient() client.Reader          { return nil }
+func (c *testTransformContext) GetRecorder() record.EventRecorder { return nil }
+func (c *testTransformContext) GetLogger() logr.Logger            { return logr.Discard() }
+
+type errorTransformer struct {
+	err error
+}
+
+func (t *errorTransformer) Transform(TransformContext, *DAG) error {
+	return t.err
+}
+
+type countingObserver struct {
+	failures map[string]int
+}
+
+func (o *countingObserver) TransformerFailed(transformer string, _ error) {
+	o.failures[transformer]++
+}
+
+func TestTransformerObserver(t *testing.T) {
+	observer := &countingObserver{failures: map[string]int{}}
+	RegisterTransformerObserver(observer)
+	defer func() {
+		transformerObservers = nil
+	}()
+
+	ctx := &testTransformContext{}
+	for _, err := range []error{
+		ErrPrematureStop,
+		intctrlutil.NewRequeueError(time.Second, "requeue"),
+		intctrlutil.NewDelayedRequeueError(time.Second, "delayed requeue"),
+	} {
+		_ = TransformerChain{&errorTransformer{err: err}}.ApplyTo(ctx, NewDAG())
+		assert.Equal(t, 0, observer.failures["errorTransformer"])
+	}
+
+	assert.NotNil(t, TransformerChain{&errorTransformer{err: errors.New("failed")}}.ApplyTo(ctx, NewDAG()))
+	assert.Equal(t, 1, observer.failures["errorTransformer"])
+}
@@ -56,6 +56,14 @@ func filterInPlaceFields(src *corev1.PodTemplateSpec) *corev1.PodTemplateSpec {
 		if restart, ok := template.Annotations[constant.RestartAnnotationKey]; ok {
 			annotations[constant.RestartAnnotationKey] = restart
 		}
+		// keep the password rotation annotation, the pods should be recreated to use the new password
+		if rotatedAt, ok := template.Annotations[constant.AccountPasswordRotatedAtAnnotationKey]; ok {
+			annotations[constant.AccountPasswordRotatedAtAnnotationKey] = rotatedAt
+		}
+		// keep the TLS certificate annotation, the pods should be recreated to load the new certificate
+		if hash, ok := template.Annotations[constant.TLSCertificateHashAnnotationKey]; ok {
+			annotations[constant.TLSCertificateHashAnnotationKey] = hash
+		}
 		// keep Reconfigure annotation
 		for k, v := range template.Annotations {
 			if strings.HasPrefix(k, constant.UpgradeRestartAnnotationKey) {
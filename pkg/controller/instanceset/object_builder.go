@@ -69,7 +69,7 @@ func buildHeadlessSvc(its workloads.InstanceSet, labels, selectors map[string]st
 				servicePort.Name = port.Name
 				servicePort.TargetPort = intstr.FromString(port.Name)
 			default:
-				servicePort.Name = fmt.Sprintf("%s-%d", strings.ToLower(string(port.Protocol)), port.ContainerPort)
+				servicePort.Name = HeadlessServicePortName(port)
 				servicePort.TargetPort = intstr.FromInt(int(port.ContainerPort))
 			}
 			hdlBuilder.AddPorts(servicePort)
@@ -78,6 +78,15 @@ func buildHeadlessSvc(its workloads.InstanceSet, labels, selectors map[string]st
 	return hdlBuilder.GetObject()
 }
 
+// HeadlessServicePortName returns the name of the port in the headless service for the container port.
+// The empty protocol is kept as is (e.g. "-3306") to keep the names of the existing headless services unchanged.
+func HeadlessServicePortName(port corev1.ContainerPort) string {
+	if len(port.Name) > 0 {
+		return port.Name
+	}
+	return fmt.Sprintf("%s-%d", strings.ToLower(string(port.Protocol)), port.ContainerPort)
+}
+
 func getHeadlessSvcName(itsName string) string {
 	return strings.Join([]string{itsName, "headless"}, "-")
 }
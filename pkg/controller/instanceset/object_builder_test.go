@@ -161,6 +161,15 @@ var _ = Describe("object generation transformer test.", func() {
 		})
 	})
 
+	Context("HeadlessServicePortName function", func() {
+		It("should work well", func() {
+			Expect(HeadlessServicePortName(corev1.ContainerPort{Name: "mysql", ContainerPort: 3306})).Should(Equal("mysql"))
+			Expect(HeadlessServicePortName(corev1.ContainerPort{Protocol: corev1.ProtocolUDP, ContainerPort: 53})).Should(Equal("udp-53"))
+			By("keep the legacy name for the empty protocol")
+			Expect(HeadlessServicePortName(corev1.ContainerPort{ContainerPort: 9104})).Should(Equal("-9104"))
+		})
+	})
+
 	Context("findSvcPort function", func() {
 		It("should work well", func() {
 			By("set port name")
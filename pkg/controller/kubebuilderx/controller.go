@@ -22,8 +22,11 @@ package kubebuilderx
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
@@ -31,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // TODO(free6om): this is a new reconciler framework in the very early stage leaving the following tasks to do:
@@ -89,19 +93,45 @@ func (c *controller) Do(reconcilers ...Reconciler) Controller {
 		return c
 	}
 
-	reconciler := reconcilers[0]
-	switch result := reconciler.PreCondition(c.tree); {
-	case result.Err != nil:
-		c.err = result.Err
-		return c
-	case !result.Satisfied:
+	if !c.doReconcile(reconcilers[0]) {
 		return c
 	}
-	c.res, c.err = reconciler.Reconcile(c.tree)
 
 	return c.Do(reconcilers[1:]...)
 }
 
+// doReconcile runs the reconciler in a span, and records its outcome for explaining.
+// It returns false if the precondition of the reconciler is not satisfied.
+func (c *controller) doReconcile(reconciler Reconciler) bool {
+	name := reflect.TypeOf(reconciler).String()
+	_, span := tracing.StartSpan(c.ctx, name)
+	start := time.Now()
+
+	outcome := tracing.OutcomeSucceed
+	switch result := reconciler.PreCondition(c.tree); {
+	case result.Err != nil:
+		c.err = result.Err
+	case !result.Satisfied:
+		outcome = tracing.OutcomeSkipped
+	default:
+		c.res, c.err = reconciler.Reconcile(c.tree)
+	}
+	switch {
+	case c.err != nil:
+		outcome = tracing.OutcomeFailed
+	case outcome == tracing.OutcomeSkipped:
+	case c.res.Next == cmmt:
+		outcome = tracing.OutcomePrematureStop
+	case c.res.Next == rtry:
+		outcome = tracing.OutcomeDelayedRequeue
+	}
+
+	span.SetAttributes(attribute.String("outcome", outcome))
+	tracing.EndSpan(span, c.err)
+	tracing.RecordTransformer(c.ctx, name, outcome, start, c.err)
+	return outcome != tracing.OutcomeSkipped
+}
+
 func (c *controller) Commit() (ctrl.Result, error) {
 	defer c.emitFailureEvent()
 
@@ -35,8 +35,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 type transformContext struct {
@@ -54,8 +57,9 @@ type PlanBuilder struct {
 }
 
 type Plan struct {
-	vertices []*model.ObjectVertex
-	walkFunc graph.WalkFunc
+	vertices    []*model.ObjectVertex
+	walkFunc    graph.WalkFunc
+	concurrency int
 }
 
 var _ graph.TransformContext = &transformContext{}
@@ -99,9 +103,11 @@ func (b *PlanBuilder) AddParallelTransformer(_ ...graph.Transformer) graph.PlanB
 func (b *PlanBuilder) Build() (graph.Plan, error) {
 	vertices := buildOrderedVertices(b.transCtx.GetContext(), b.currentTree, b.desiredTree)
 	plan := &Plan{
-		walkFunc: b.defaultWalkFunc,
-		vertices: vertices,
+		walkFunc:    model.TracedWalkFunc(b.transCtx.ctx, b.defaultWalkFunc),
+		vertices:    vertices,
+		concurrency: viper.GetInt(constant.CfgKBPlanExecutionWorkers),
 	}
+	tracing.RecordPlan(b.transCtx.ctx, plan)
 	return plan, nil
 }
 
@@ -143,10 +149,9 @@ func buildOrderedVertices(ctx context.Context, currentTree *ObjectTree, desiredT
 		workloadVertices  []*model.ObjectVertex
 	)
 	findAndAppend := func(vertex *model.ObjectVertex) {
-		switch vertex.Obj.(type) {
-		case *corev1.Service, *corev1.ConfigMap, *corev1.Secret, *corev1.PersistentVolumeClaim:
+		if isAssistantObject(vertex.Obj) {
 			assistantVertices = append(assistantVertices, vertex)
-		default:
+		} else {
 			workloadVertices = append(workloadVertices, vertex)
 		}
 	}
@@ -191,6 +196,40 @@ func buildOrderedVertices(ctx context.Context, currentTree *ObjectTree, desiredT
 	return vertices
 }
 
+// isAssistantObject tells whether the object should be ready before the workloads that reference it.
+func isAssistantObject(obj client.Object) bool {
+	switch obj.(type) {
+	case *corev1.Service, *corev1.ConfigMap, *corev1.Secret, *corev1.PersistentVolumeClaim:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildStages splits the vertices ordered by buildOrderedVertices into stages in execution order.
+// Vertices within a stage are independent of each other, and a stage starts only after the previous one succeeded.
+func buildStages(vertices []*model.ObjectVertex) [][]graph.Vertex {
+	stageKey := func(i int) int {
+		switch {
+		// the root and its meta patch, each of them is a stage
+		case i == 0, i == 1 && *vertices[i].Action == model.PATCH:
+			return -i - 1
+		case isAssistantObject(vertices[i].Obj):
+			return 1
+		default:
+			return 2
+		}
+	}
+	var stages [][]graph.Vertex
+	for i := len(vertices) - 1; i >= 0; i-- {
+		if len(stages) == 0 || stageKey(i) != stageKey(i+1) {
+			stages = append(stages, []graph.Vertex{})
+		}
+		stages[len(stages)-1] = append(stages[len(stages)-1], vertices[i])
+	}
+	return stages
+}
+
 func keepFinalizer(object client.Object, finalizer string) {
 	var finalizers []string
 	if len(finalizer) > 0 {
@@ -209,15 +248,25 @@ func getRemainingFinalizer(obj client.Object) string {
 // Plan implementation
 
 func (p *Plan) Execute() error {
-	var err error
-	for i := len(p.vertices) - 1; i >= 0; i-- {
-		if err = p.walkFunc(p.vertices[i]); err != nil {
+	for _, stage := range buildStages(p.vertices) {
+		if err := graph.WalkConcurrently(stage, p.walkFunc, p.concurrency); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// String returns the vertices of the plan in execution order
+func (p *Plan) String() string {
+	str := "|"
+	for _, stage := range buildStages(p.vertices) {
+		for _, v := range stage {
+			str += fmt.Sprintf("->%v", v)
+		}
+	}
+	return str
+}
+
 // Do the real works
 
 func (b *PlanBuilder) defaultWalkFunc(v graph.Vertex) error {
@@ -229,6 +278,9 @@ func (b *PlanBuilder) defaultWalkFunc(v graph.Vertex) error {
 		return errors.New("vertex action can't be nil")
 	}
 	ctx := b.transCtx.ctx
+	if model.IsServerSideApply(vertex) {
+		return b.applyObject(ctx, vertex)
+	}
 	switch *vertex.Action {
 	case model.CREATE:
 		return b.createObject(ctx, vertex)
@@ -272,6 +324,27 @@ func (b *PlanBuilder) patchObject(ctx context.Context, vertex *model.ObjectVerte
 	return nil
 }
 
+func (b *PlanBuilder) applyObject(ctx context.Context, vertex *model.ObjectVertex) error {
+	var onConflict model.ApplyConflictFunc
+	if b.currentTree != nil {
+		onConflict = model.ApplyConflictEventFunc(b.currentTree.EventRecorder, b.currentTree.GetRoot())
+	}
+	err := model.ServerSideApply(ctx, b.cli, vertex.OriObj, vertex.Obj, vertex.ForceApply, onConflict, clientOption(vertex))
+	switch {
+	case err == nil:
+	case *vertex.Action != model.CREATE && apierrors.IsNotFound(err):
+		return nil
+	default:
+		return err
+	}
+	if *vertex.Action == model.CREATE {
+		b.emitEvent(vertex.Obj, "SuccessfulCreate", model.CREATE)
+	} else {
+		b.emitEvent(vertex.Obj, "SuccessfulUpdate", model.UPDATE)
+	}
+	return nil
+}
+
 func (b *PlanBuilder) deleteObject(ctx context.Context, vertex *model.ObjectVertex) error {
 	finalizer := getRemainingFinalizer(vertex.Obj)
 	if len(finalizer) > 0 && controllerutil.RemoveFinalizer(vertex.Obj, finalizer) {
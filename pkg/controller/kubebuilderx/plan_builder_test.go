@@ -252,5 +252,33 @@ var _ = Describe("plan builder test", func() {
 				}
 			})
 		})
+
+		Context("buildStages", func() {
+			It("should work well", func() {
+				pod := builder.NewPodBuilder(namespace, name).GetObject()
+				svc := builder.NewServiceBuilder(namespace, name).GetObject()
+				env := builder.NewConfigMapBuilder(namespace, name+"-env").GetObject()
+
+				currentTree.SetRoot(its)
+				desiredIts := its.DeepCopy()
+				desiredIts.Labels["foo"] = "bar"
+				desiredTree.SetRoot(desiredIts)
+				Expect(desiredTree.Add(pod, svc, env)).Should(Succeed())
+				stages := buildStages(buildOrderedVertices(ctx, currentTree, desiredTree))
+
+				// assistant objects, workloads, root patch and root status
+				Expect(stages).Should(HaveLen(4))
+				Expect(stages[0]).Should(HaveLen(2))
+				for _, v := range stages[0] {
+					Expect(isAssistantObject(v.(*model.ObjectVertex).Obj)).Should(BeTrue())
+				}
+				Expect(stages[1]).Should(HaveLen(1))
+				Expect(stages[1][0].(*model.ObjectVertex).Obj).Should(Equal(pod))
+				Expect(stages[2]).Should(HaveLen(1))
+				Expect(*stages[2][0].(*model.ObjectVertex).Action).Should(Equal(model.PATCH))
+				Expect(stages[3]).Should(HaveLen(1))
+				Expect(*stages[3][0].(*model.ObjectVertex).Action).Should(Equal(model.STATUS))
+			})
+		})
 	})
 })
@@ -143,6 +143,8 @@ func (r *realGraphClient) Do(dag *graph.DAG, objOld, objNew client.Object, actio
 		Action:            action,
 		ClientOpt:         graphOpts.clientOpt,
 		PropagationPolicy: graphOpts.propagationPolicy,
+		ServerSideApply:   graphOpts.serverSideApply,
+		ForceApply:        graphOpts.forceApply,
 	}
 	switch {
 	case parent == nil:
@@ -225,12 +227,18 @@ func (r *realGraphClient) doWrite(dag *graph.DAG, objOld, objNew client.Object,
 			objVertex.Obj = objNew
 			objVertex.OriObj = objOld
 		}
+		if graphOpts.serverSideApply {
+			objVertex.ServerSideApply = true
+			objVertex.ForceApply = graphOpts.forceApply
+		}
 	default:
 		vertex = &ObjectVertex{
-			Obj:       objNew,
-			OriObj:    objOld,
-			Action:    action,
-			ClientOpt: graphOpts.clientOpt,
+			Obj:             objNew,
+			OriObj:          objOld,
+			Action:          action,
+			ClientOpt:       graphOpts.clientOpt,
+			ServerSideApply: graphOpts.serverSideApply,
+			ForceApply:      graphOpts.forceApply,
 		}
 		dag.AddConnectRoot(vertex)
 	}
@@ -26,6 +26,8 @@ type GraphOptions struct {
 	haveDifferentTypeWith bool
 	clientOpt             any
 	propagationPolicy     client.PropagationPolicy
+	serverSideApply       bool
+	forceApply            bool
 }
 
 type GraphOption interface {
@@ -82,3 +84,27 @@ func WithPropagationPolicy(policy client.PropagationPolicy) GraphOption {
 		propagationPolicy: policy,
 	}
 }
+
+// ServerSideApplyOption tells the plan executor to write the object by server-side apply,
+// regardless of whether its kind is opted in.
+// used in Action methods: Create, Update and Patch
+type ServerSideApplyOption struct {
+	// Force forces the ownership of the fields conflicting with other field managers.
+	Force bool
+}
+
+var _ GraphOption = &ServerSideApplyOption{}
+
+func (o *ServerSideApplyOption) ApplyTo(opts *GraphOptions) {
+	opts.serverSideApply = true
+	opts.forceApply = o.Force
+}
+
+func WithServerSideApply() GraphOption {
+	return &ServerSideApplyOption{}
+}
+
+// WithForcedServerSideApply is like WithServerSideApply, but takes over the fields conflicting with other field managers.
+func WithForcedServerSideApply() GraphOption {
+	return &ServerSideApplyOption{Force: true}
+}
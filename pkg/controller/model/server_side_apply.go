@@ -0,0 +1,329 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// FieldManager is the dedicated field manager used when applying objects by server-side apply.
+const FieldManager = "kubeblocks"
+
+// legacyFieldManagerPrefix is the prefix of the field managers derived from the default user agent of the operator,
+// which own the fields of the objects written by update before server-side apply is opted in.
+const legacyFieldManagerPrefix = "KubeBlocks "
+
+// ApplyConflictFunc is called when the server-side apply conflicts with other field managers,
+// 'forced' tells whether the object is applied again with the ownership of the conflicting fields forced.
+type ApplyConflictFunc func(obj client.Object, err error, forced bool)
+
+// ApplyConflictEventFunc returns an ApplyConflictFunc that records the conflicts as warning events of the 'owner'.
+func ApplyConflictEventFunc(recorder record.EventRecorder, owner client.Object) ApplyConflictFunc {
+	return func(obj client.Object, err error, forced bool) {
+		if recorder == nil || owner == nil {
+			return
+		}
+		kind := fmt.Sprintf("%T", obj)
+		if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+			kind = gvk.Kind
+		}
+		action := "leave the fields to them"
+		if forced {
+			action = "force the ownership"
+		}
+		recorder.Eventf(owner, corev1.EventTypeWarning, constant.ReasonApplyConflict,
+			"apply %s %s conflicts with other field managers, %s: %s", kind, obj.GetName(), action, err.Error())
+	}
+}
+
+// IsServerSideApply tells whether the object of vertex 'v' should be written by server-side apply.
+// It is true if the vertex is marked explicitly, or the kind of the object is opted in by the config.
+func IsServerSideApply(v *ObjectVertex) bool {
+	if v == nil || v.Obj == nil || v.Action == nil {
+		return false
+	}
+	switch *v.Action {
+	case CREATE, UPDATE, PATCH:
+	default:
+		return false
+	}
+	if v.ServerSideApply {
+		return true
+	}
+	kinds := viper.GetString(constant.CfgKBServerSideApplyKinds)
+	if len(kinds) == 0 {
+		return false
+	}
+	gvk, err := apiutil.GVKForObject(v.Obj, scheme)
+	if err != nil {
+		return false
+	}
+	for _, kind := range strings.Split(kinds, ",") {
+		if strings.EqualFold(strings.TrimSpace(kind), gvk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerSideApply writes the object 'obj' by server-side apply with FieldManager.
+// If the original object 'oriObj' is given, only the fields changed from it and the ones managed by FieldManager
+// are applied, so that the fields set by other field managers are left to them. The conflicts with other field
+// managers are reported to 'onConflict', and the ownership of the conflicting fields is forced only if 'force' is true.
+// The fields owned by the operator itself through update before are migrated to FieldManager first.
+func ServerSideApply(ctx context.Context, cli client.Writer, oriObj, obj client.Object, force bool,
+	onConflict ApplyConflictFunc, opts ...client.PatchOption) error {
+	if oriObj != nil {
+		migrated, err := migrateLegacyFieldManagers(ctx, cli, oriObj)
+		if err != nil {
+			return err
+		}
+		oriObj = migrated
+	}
+	applyObj, err := newApplyObject(oriObj, obj)
+	if err != nil {
+		return err
+	}
+	applyOpts := append([]client.PatchOption{client.FieldOwner(FieldManager)}, opts...)
+	err = cli.Patch(ctx, applyObj, client.Apply, applyOpts...)
+	if err == nil || !apierrors.IsConflict(err) {
+		return err
+	}
+	if onConflict != nil {
+		onConflict(obj, err, force)
+	}
+	if !force {
+		return err
+	}
+	return cli.Patch(ctx, applyObj, client.Apply, append(applyOpts, client.ForceOwnership)...)
+}
+
+// migrateLegacyFieldManagers moves the fields owned by the operator's own update managers to FieldManager,
+// otherwise applying them conflicts with the operator itself. It returns the object with the managed fields migrated.
+func migrateLegacyFieldManagers(ctx context.Context, cli client.Writer, oriObj client.Object) (client.Object, error) {
+	managers := legacyFieldManagers(oriObj)
+	if managers.Len() == 0 {
+		return oriObj, nil
+	}
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(oriObj, managers, FieldManager)
+	if err != nil || patch == nil {
+		return oriObj, err
+	}
+	obj, _ := oriObj.DeepCopyObject().(client.Object)
+	if err = cli.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+		return nil, err
+	}
+	// keep the other fields of the original object, only the managed fields are changed
+	migrated, _ := oriObj.DeepCopyObject().(client.Object)
+	if err = csaupgrade.UpgradeManagedFields(migrated, managers, FieldManager); err != nil {
+		return nil, err
+	}
+	return migrated, nil
+}
+
+// legacyFieldManagers returns the field managers of the operator itself that write the object by update.
+func legacyFieldManagers(obj client.Object) sets.Set[string] {
+	userAgentManager := strings.SplitN(viper.GetString(constant.CfgKeyUserAgent), "/", 2)[0]
+	managers := sets.New[string]()
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Operation != metav1.ManagedFieldsOperationUpdate || entry.Subresource != "" {
+			continue
+		}
+		if strings.HasPrefix(entry.Manager, legacyFieldManagerPrefix) ||
+			len(userAgentManager) > 0 && entry.Manager == userAgentManager {
+			managers.Insert(entry.Manager)
+		}
+	}
+	return managers
+}
+
+// newApplyObject returns the apply configuration of 'obj' that is suitable for server-side apply,
+// the type meta is filled and the server-managed metadata is cleared.
+func newApplyObject(oriObj, obj client.Object) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	payload := desired
+	if oriObj != nil {
+		original, err := runtime.DefaultUnstructuredConverter.ToUnstructured(oriObj)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := managedApplyFields(oriObj)
+		if err != nil {
+			return nil, err
+		}
+		changedFields(nil, original, desired, fields)
+		payload = map[string]any{}
+		fields.Iterate(func(path fieldpath.Path) {
+			copyField(payload, desired, path)
+		})
+	}
+
+	applyObj := &unstructured.Unstructured{Object: payload}
+	applyObj.SetGroupVersionKind(gvk)
+	applyObj.SetNamespace(obj.GetNamespace())
+	applyObj.SetName(obj.GetName())
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields"} {
+		unstructured.RemoveNestedField(applyObj.Object, "metadata", field)
+	}
+	return applyObj, nil
+}
+
+// managedApplyFields returns the fields of 'obj' managed by FieldManager through server-side apply.
+func managedApplyFields(obj client.Object) (*fieldpath.Set, error) {
+	fields := &fieldpath.Set{}
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, err
+		}
+		fields = fields.Union(set)
+	}
+	return fields, nil
+}
+
+// changedFields adds the paths of the fields changed from 'original' to 'desired' into 'fields',
+// the maps are compared field by field, and the lists and scalars are compared as a whole.
+func changedFields(path fieldpath.Path, original, desired any, fields *fieldpath.Set) {
+	originalMap, ok1 := original.(map[string]any)
+	desiredMap, ok2 := desired.(map[string]any)
+	if !ok1 || !ok2 {
+		if !equality.Semantic.DeepEqual(original, desired) {
+			fields.Insert(path)
+		}
+		return
+	}
+	for name, val := range desiredMap {
+		fieldName := name
+		changedFields(append(path.Copy(), fieldpath.PathElement{FieldName: &fieldName}), originalMap[name], val, fields)
+	}
+}
+
+// copyField copies the field at 'path' from 'src' to 'dst', the list items keyed are merged with the ones copied already.
+func copyField(dst, src map[string]any, path fieldpath.Path) {
+	if len(path) == 0 || path[0].FieldName == nil {
+		return
+	}
+	name := *path[0].FieldName
+	val, ok := src[name]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[name] = runtime.DeepCopyJSONValue(val)
+		return
+	}
+	switch v := val.(type) {
+	case map[string]any:
+		child, ok := dst[name].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			dst[name] = child
+		}
+		copyField(child, v, path[1:])
+	case []any:
+		dst[name] = copyListItem(dst[name], v, path[1:])
+	}
+}
+
+func copyListItem(dst any, src []any, path fieldpath.Path) any {
+	list, _ := dst.([]any)
+	pe := path[0]
+	switch {
+	case pe.Key != nil:
+		for _, item := range src {
+			srcItem, ok := item.(map[string]any)
+			if !ok || !listItemKeyMatched(srcItem, pe.Key) {
+				continue
+			}
+			idx := slices.IndexFunc(list, func(e any) bool {
+				m, ok := e.(map[string]any)
+				return ok && listItemKeyMatched(m, pe.Key)
+			})
+			if idx < 0 {
+				// the key fields are required to identify the item
+				dstItem := map[string]any{}
+				for _, field := range *pe.Key {
+					dstItem[field.Name] = runtime.DeepCopyJSONValue(srcItem[field.Name])
+				}
+				list = append(list, dstItem)
+				idx = len(list) - 1
+			}
+			if len(path) > 1 {
+				copyField(list[idx].(map[string]any), srcItem, path[1:])
+			}
+			return list
+		}
+		return dst
+	case pe.Value != nil:
+		for _, item := range src {
+			if value.Equals(value.NewValueInterface(item), *pe.Value) &&
+				!slices.ContainsFunc(list, func(e any) bool { return equality.Semantic.DeepEqual(e, item) }) {
+				return append(list, runtime.DeepCopyJSONValue(item))
+			}
+		}
+		return dst
+	default:
+		// the atomic list is copied as a whole
+		return runtime.DeepCopyJSONValue(src)
+	}
+}
+
+func listItemKeyMatched(item map[string]any, key *value.FieldList) bool {
+	for _, field := range *key {
+		val, ok := item[field.Name]
+		if !ok || !value.Equals(value.NewValueInterface(val), field.Value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+type applyWriter struct {
+	client.Writer
+	conflicts int
+	patched   []client.Object
+	options   []*client.PatchOptions
+	migrated  []client.Object
+}
+
+func (w *applyWriter) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() == types.JSONPatchType {
+		w.migrated = append(w.migrated, obj.DeepCopyObject().(client.Object))
+		return nil
+	}
+	Expect(patch.Type()).Should(Equal(types.ApplyPatchType))
+	options := &client.PatchOptions{}
+	options.ApplyOptions(opts)
+	w.patched = append(w.patched, obj.DeepCopyObject().(client.Object))
+	w.options = append(w.options, options)
+	if w.conflicts > 0 {
+		w.conflicts--
+		return apierrors.NewConflict(schema.GroupResource{Resource: "services"}, obj.GetName(), nil)
+	}
+	return nil
+}
+
+var _ = Describe("server-side apply test.", func() {
+	const (
+		namespace = "foo"
+		name      = "bar"
+	)
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKBServerSideApplyKinds, "")
+	})
+
+	Context("IsServerSideApply", func() {
+		It("should work well", func() {
+			svc := builder.NewServiceBuilder(namespace, name).GetObject()
+			pod := builder.NewPodBuilder(namespace, name).GetObject()
+
+			By("disabled by default")
+			Expect(IsServerSideApply(NewObjectVertex(nil, svc, ActionCreatePtr()))).Should(BeFalse())
+
+			By("enabled explicitly")
+			Expect(IsServerSideApply(NewObjectVertex(nil, svc, ActionCreatePtr(), WithServerSideApply()))).Should(BeTrue())
+			Expect(IsServerSideApply(NewObjectVertex(nil, svc, ActionDeletePtr(), WithServerSideApply()))).Should(BeFalse())
+
+			By("enabled by kinds")
+			viper.Set(constant.CfgKBServerSideApplyKinds, "service, PersistentVolumeClaim")
+			Expect(IsServerSideApply(NewObjectVertex(svc, svc, ActionUpdatePtr()))).Should(BeTrue())
+			Expect(IsServerSideApply(NewObjectVertex(svc, svc, ActionPatchPtr()))).Should(BeTrue())
+			Expect(IsServerSideApply(NewObjectVertex(svc, svc, ActionStatusPtr()))).Should(BeFalse())
+			Expect(IsServerSideApply(NewObjectVertex(pod, pod, ActionUpdatePtr()))).Should(BeFalse())
+		})
+	})
+
+	Context("ServerSideApply", func() {
+		It("should work well", func() {
+			svc := builder.NewServiceBuilder(namespace, name).GetObject()
+			svc.ResourceVersion = "1"
+			svc.UID = "uid"
+
+			By("apply without conflicts")
+			writer := &applyWriter{}
+			Expect(ServerSideApply(context.Background(), writer, nil, svc, false, nil)).Should(Succeed())
+			Expect(writer.patched).Should(HaveLen(1))
+			applied := writer.patched[0]
+			Expect(applied.GetObjectKind().GroupVersionKind().Kind).Should(Equal("Service"))
+			Expect(applied.GetName()).Should(Equal(name))
+			Expect(applied.GetResourceVersion()).Should(BeEmpty())
+			Expect(applied.GetUID()).Should(BeEmpty())
+			Expect(writer.options[0].FieldManager).Should(Equal(FieldManager))
+			Expect(writer.options[0].Force).Should(BeNil())
+			Expect(svc.ResourceVersion).Should(Equal("1"))
+
+			var (
+				conflicted client.Object
+				forced     bool
+			)
+			onConflict := func(obj client.Object, err error, force bool) {
+				Expect(apierrors.IsConflict(err)).Should(BeTrue())
+				conflicted, forced = obj, force
+			}
+
+			By("apply with conflicts, not forced by default")
+			writer = &applyWriter{conflicts: 1}
+			err := ServerSideApply(context.Background(), writer, nil, svc, false, onConflict)
+			Expect(apierrors.IsConflict(err)).Should(BeTrue())
+			Expect(conflicted).Should(Equal(client.Object(svc)))
+			Expect(forced).Should(BeFalse())
+			Expect(writer.patched).Should(HaveLen(1))
+
+			By("apply with conflicts, forced if opted in")
+			writer = &applyWriter{conflicts: 1}
+			Expect(ServerSideApply(context.Background(), writer, nil, svc, true, onConflict)).Should(Succeed())
+			Expect(forced).Should(BeTrue())
+			Expect(writer.patched).Should(HaveLen(2))
+			Expect(writer.options[1].Force).ShouldNot(BeNil())
+			Expect(*writer.options[1].Force).Should(BeTrue())
+
+			By("event on conflicts")
+			recorder := record.NewFakeRecorder(1)
+			owner := builder.NewPodBuilder(namespace, name).GetObject()
+			ApplyConflictEventFunc(recorder, owner)(svc, apierrors.NewConflict(schema.GroupResource{}, name, nil), false)
+			Expect(recorder.Events).Should(Receive(ContainSubstring(constant.ReasonApplyConflict)))
+		})
+
+		It("applies the changed and managed fields only", func() {
+			live := builder.NewServiceBuilder(namespace, name).
+				AddLabels("other", "value").
+				AddSelector("app", "foo").
+				AddPorts(corev1.ServicePort{Name: "client", Port: 2379, Protocol: corev1.ProtocolTCP},
+					corev1.ServicePort{Name: "peer", Port: 2380, Protocol: corev1.ProtocolTCP}).
+				GetObject()
+			live.Spec.ClusterIP = "10.0.0.1"
+			live.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:spec":{"f:ports":{"k:{\"port\":2379,\"protocol\":\"TCP\"}":{".":{},"f:name":{}}}}}`),
+					},
+				},
+				{
+					Manager:   "other",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:metadata":{"f:labels":{"f:other":{}}},"f:spec":{"f:clusterIP":{}}}`),
+					},
+				},
+			}
+			desired := live.DeepCopy()
+			desired.Spec.Selector["app"] = "bar"
+
+			writer := &applyWriter{}
+			Expect(ServerSideApply(context.Background(), writer, live, desired, false, nil)).Should(Succeed())
+			applied := writer.patched[0].(*unstructured.Unstructured)
+			Expect(applied.GetLabels()).Should(BeEmpty())
+			Expect(applied.Object).ShouldNot(HaveKey("status"))
+			spec := applied.Object["spec"].(map[string]any)
+			Expect(spec).ShouldNot(HaveKey("clusterIP"))
+			Expect(spec["selector"]).Should(Equal(map[string]any{"app": "bar"}))
+			Expect(spec["ports"]).Should(Equal([]any{map[string]any{"name": "client", "port": int64(2379), "protocol": "TCP"}}))
+		})
+
+		It("migrates the fields of the legacy field managers", func() {
+			live := builder.NewServiceBuilder(namespace, name).
+				AddLabels("other", "value").
+				AddSelector("app", "foo").
+				GetObject()
+			live.Spec.ClusterIP = "10.0.0.1"
+			live.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "KubeBlocks 0.9.0 (linux",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:metadata":{"f:labels":{"f:other":{}}},"f:spec":{"f:clusterIP":{},"f:selector":{}}}`),
+					},
+				},
+				{
+					Manager:   "kubectl-edit",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:spec":{"f:sessionAffinity":{}}}`),
+					},
+				},
+			}
+			desired := live.DeepCopy()
+			desired.Spec.Selector["app"] = "bar"
+
+			writer := &applyWriter{}
+			Expect(ServerSideApply(context.Background(), writer, live, desired, false, nil)).Should(Succeed())
+			Expect(writer.migrated).Should(HaveLen(1))
+			Expect(writer.patched).Should(HaveLen(1))
+			applied := writer.patched[0].(*unstructured.Unstructured)
+			Expect(applied.GetLabels()).Should(HaveKeyWithValue("other", "value"))
+			spec := applied.Object["spec"].(map[string]any)
+			Expect(spec).Should(HaveKeyWithValue("clusterIP", "10.0.0.1"))
+			Expect(spec).ShouldNot(HaveKey("sessionAffinity"))
+			Expect(spec["selector"]).Should(Equal(map[string]any{"app": "bar"}))
+
+			By("no migration if there is no legacy field manager")
+			live.ManagedFields = live.ManagedFields[1:]
+			writer = &applyWriter{}
+			Expect(ServerSideApply(context.Background(), writer, live, desired, false, nil)).Should(Succeed())
+			Expect(writer.migrated).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,55 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
+)
+
+// TracedWalkFunc wraps the 'walkFunc' to walk each vertex in a span, which is the child of the span in 'ctx'.
+func TracedWalkFunc(ctx context.Context, walkFunc graph.WalkFunc) graph.WalkFunc {
+	return func(v graph.Vertex) error {
+		vertex, ok := v.(*ObjectVertex)
+		if !ok || vertex.Obj == nil {
+			return walkFunc(v)
+		}
+		action := "nil"
+		if vertex.Action != nil {
+			action = string(*vertex.Action)
+		}
+		kind := ""
+		if gvk, err := GetGVKName(vertex.Obj); err == nil {
+			kind = gvk.Kind
+		}
+		_, span := tracing.StartSpan(ctx, action+" "+kind,
+			attribute.String("kind", kind),
+			attribute.String("namespace", vertex.Obj.GetNamespace()),
+			attribute.String("name", vertex.Obj.GetName()),
+			attribute.String("action", action))
+		err := walkFunc(v)
+		tracing.EndSpan(span, err)
+		return err
+	}
+}
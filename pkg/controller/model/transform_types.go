@@ -72,6 +72,8 @@ type ObjectVertex struct {
 	Action            *Action
 	ClientOpt         any
 	PropagationPolicy client.PropagationPolicy
+	ServerSideApply   bool
+	ForceApply        bool
 }
 
 func (v *ObjectVertex) String() string {
@@ -87,10 +89,12 @@ func NewObjectVertex(oldObj, newObj client.Object, action *Action, opts ...Graph
 		opt.ApplyTo(graphOpts)
 	}
 	return &ObjectVertex{
-		Obj:       newObj,
-		OriObj:    oldObj,
-		Action:    action,
-		ClientOpt: graphOpts.clientOpt,
+		Obj:             newObj,
+		OriObj:          oldObj,
+		Action:          action,
+		ClientOpt:       graphOpts.clientOpt,
+		ServerSideApply: graphOpts.serverSideApply,
+		ForceApply:      graphOpts.forceApply,
 	}
 }
 
@@ -23,15 +23,21 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func NewClient(control client.Client, workers map[string]client.Client) client.Client {
+	return newClient(control, newWorkers(workers))
+}
+
+func newClient(control client.Client, workers *workers) client.Client {
 	mctx := mcontext{
 		control: control,
 		workers: workers,
@@ -46,8 +52,56 @@ func NewClient(control client.Client, workers map[string]client.Client) client.C
 }
 
 type mcontext struct {
-	control client.Client            // client for control-plane k8s cluster
-	workers map[string]client.Client // clients for data-plane k8s clusters
+	control client.Client // client for control-plane k8s cluster
+	workers *workers      // clients for data-plane k8s clusters
+}
+
+// workers holds the clients for data-plane k8s clusters, which can be changed at runtime.
+type workers struct {
+	sync.RWMutex
+	clients map[string]client.Client
+	// dynamic is true if the data-plane k8s clusters can be registered at runtime, the control-plane k8s cluster
+	// is never used as the data-plane one even if there is no data-plane k8s cluster registered yet.
+	dynamic bool
+}
+
+func newWorkers(clients map[string]client.Client) *workers {
+	w := &workers{clients: make(map[string]client.Client)}
+	for k, c := range clients {
+		w.clients[k] = c
+	}
+	return w
+}
+
+func (w *workers) len() int {
+	w.RLock()
+	defer w.RUnlock()
+	return len(w.clients)
+}
+
+func (w *workers) names() []string {
+	w.RLock()
+	defer w.RUnlock()
+	return maps.Keys(w.clients)
+}
+
+func (w *workers) get(name string) (client.Client, bool) {
+	w.RLock()
+	defer w.RUnlock()
+	c, ok := w.clients[name]
+	return c, ok
+}
+
+func (w *workers) set(name string, c client.Client) {
+	w.Lock()
+	defer w.Unlock()
+	w.clients[name] = c
+}
+
+func (w *workers) delete(name string) {
+	w.Lock()
+	defer w.Unlock()
+	delete(w.clients, name)
 }
 
 type mclient struct {
@@ -159,6 +213,10 @@ func (c *clientWriter) Patch(ctx context.Context, obj client.Object, patch clien
 		if !ok {
 			return fmt.Errorf("not client object: %T", obj)
 		}
+		// server-side apply may create the object
+		if patch.Type() == types.ApplyPatchType {
+			setPlacementKey(o, cc.context)
+		}
 		return cc.cli.Patch(ctx, o, patch, opts...)
 	}
 	return allOf(c.mctx, ctx, obj, request, opts)
@@ -288,8 +346,12 @@ func anyOf(mctx mcontext, ctx context.Context, obj client.Object, request func(c
 }
 
 func anyOf_(mctx mcontext, ctx context.Context, obj client.Object, request func(contextCli, client.Object) error, opts any) error {
+	clients := resolvedClients(mctx, ctx, obj, opts)
+	if len(clients) == 0 {
+		return noClusterUnavailableError(obj)
+	}
 	var err, uerr error
-	for _, cc := range resolvedClients(mctx, ctx, obj, opts) {
+	for _, cc := range clients {
 		e := request(cc, obj)
 		switch {
 		case e == nil:
@@ -307,9 +369,13 @@ func anyOf_(mctx mcontext, ctx context.Context, obj client.Object, request func(
 }
 
 func anyOfWithMultiCheck(mctx mcontext, ctx context.Context, obj client.Object, request func(contextCli, client.Object) error, opts any) error {
+	clients := resolvedClients(mctx, ctx, obj, opts)
+	if len(clients) == 0 {
+		return noClusterUnavailableError(obj)
+	}
 	var err, uerr error
 	objs := make([]client.Object, 0)
-	for _, cc := range resolvedClients(mctx, ctx, obj, opts) {
+	for _, cc := range clients {
 		o := obj.DeepCopyObject().(client.Object)
 		e := request(cc, o)
 		switch {
@@ -337,7 +403,7 @@ type contextCli struct {
 
 func resolvedClients(mctx mcontext, ctx context.Context, obj client.Object, opts any) []contextCli {
 	// has no data-plane k8s clusters
-	if len(mctx.workers) == 0 {
+	if mctx.workers.len() == 0 && !mctx.workers.dynamic {
 		return []contextCli{{"", mctx.control}}
 	}
 
@@ -351,7 +417,7 @@ func resolvedClients(mctx mcontext, ctx context.Context, obj client.Object, opts
 	}
 
 	if o.unspecified {
-		return dataClients(mctx, maps.Keys(mctx.workers))
+		return dataClients(mctx, mctx.workers.names())
 	}
 
 	if o.universal {
@@ -385,8 +451,14 @@ func hasClientOption(opts any) *ClientOption {
 func dataClients(mctx mcontext, workers []string) []contextCli {
 	l := make([]contextCli, 0)
 	for _, c := range workers {
-		if cli, ok := mctx.workers[c]; ok {
+		if len(c) == 0 {
+			continue
+		}
+		if cli, ok := mctx.workers.get(c); ok {
 			l = append(l, contextCli{c, cli})
+		} else {
+			// the context is not registered yet, or has been unregistered
+			l = append(l, contextCli{c, newUnregisteredClient(c)})
 		}
 	}
 	return l
@@ -158,3 +158,73 @@ func (c *unavailableSubResourceWriter) Update(context.Context, client.Object, ..
 func (c *unavailableSubResourceWriter) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
 	return nil
 }
+
+// newUnregisteredClient returns the client for a context which is not registered yet, or has been unregistered.
+// Unlike the disabled one, the writes are failed too, they should be retried after the context is registered.
+func newUnregisteredClient(context string) client.Client {
+	return &unregisteredClient{
+		unavailableClient: newUnavailableClient(context).(*unavailableClient),
+	}
+}
+
+type unregisteredClient struct {
+	*unavailableClient
+}
+
+var _ client.Client = &unregisteredClient{}
+
+func (c *unregisteredClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	return writeUnavailableError(c.context, "Create", obj)
+}
+
+func (c *unregisteredClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	return writeUnavailableError(c.context, "Delete", obj)
+}
+
+func (c *unregisteredClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return writeUnavailableError(c.context, "Update", obj)
+}
+
+func (c *unregisteredClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return writeUnavailableError(c.context, "Patch", obj)
+}
+
+func (c *unregisteredClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	return writeUnavailableError(c.context, "DeleteAllOf", obj)
+}
+
+func (c *unregisteredClient) Status() client.SubResourceWriter {
+	return &unregisteredSubResourceWriter{c.context}
+}
+
+func (c *unregisteredClient) SubResource(subResource string) client.SubResourceClient {
+	return &unregisteredSubResourceClient{
+		unavailableSubResourceReader:  unavailableSubResourceReader{c.context},
+		unregisteredSubResourceWriter: unregisteredSubResourceWriter{c.context},
+	}
+}
+
+type unregisteredSubResourceClient struct {
+	unavailableSubResourceReader
+	unregisteredSubResourceWriter
+}
+
+var _ client.SubResourceClient = &unregisteredSubResourceClient{}
+
+type unregisteredSubResourceWriter struct {
+	context string
+}
+
+var _ client.SubResourceWriter = &unregisteredSubResourceWriter{}
+
+func (c *unregisteredSubResourceWriter) Create(_ context.Context, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return writeUnavailableError(c.context, "Create", obj)
+}
+
+func (c *unregisteredSubResourceWriter) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return writeUnavailableError(c.context, "Update", obj)
+}
+
+func (c *unregisteredSubResourceWriter) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	return writeUnavailableError(c.context, "Patch", obj)
+}
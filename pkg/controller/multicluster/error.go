@@ -63,6 +63,15 @@ func listUnavailableError(context string, obj runtime.Object) error {
 	return &unavailableError{context, "List", objectNameKind(obj, "")}
 }
 
+func writeUnavailableError(context, call string, obj client.Object) error {
+	return &unavailableError{context, call, objectNameKind(obj, obj.GetName())}
+}
+
+// noClusterUnavailableError is returned if there is no data-plane k8s cluster to serve the request.
+func noClusterUnavailableError(obj client.Object) error {
+	return &unavailableError{"<none>", "Get", objectNameKind(obj, obj.GetName())}
+}
+
 func objectNameKind(obj runtime.Object, name string) string {
 	gvk, _ := apiutil.GVKForObject(obj, scheme)
 	return fmt.Sprintf("%s@%s", name, gvk.Kind)
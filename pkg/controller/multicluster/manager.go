@@ -20,15 +20,22 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package multicluster
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sync"
 
 	"golang.org/x/exp/maps"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
-	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 )
 
 type Manager interface {
@@ -41,11 +48,33 @@ type Manager interface {
 	Own(b *builder.Builder, obj, owner client.Object) Manager
 
 	Watch(b *builder.Builder, obj client.Object, eventHandler handler.EventHandler) Manager
+
+	// Register adds a data-plane k8s cluster at runtime, or updates it if the config has been changed.
+	Register(context string, config *rest.Config, disabled bool) error
+
+	// Unregister removes a data-plane k8s cluster registered at runtime.
+	Unregister(context string) error
+
+	// SetAvailable marks a data-plane k8s cluster registered at runtime as available or unavailable.
+	SetAvailable(context string, available bool)
+
+	// Synced returns true if all the member clusters have been registered, or failed to register
+	// due to invalid configs. The objects in data-plane k8s clusters should not be reconciled before it.
+	Synced(ctx context.Context) (bool, error)
 }
 
 type manager struct {
-	cli    client.Client
-	caches map[string]cache.Cache
+	sync.Mutex
+
+	control  *rest.Config
+	cli      client.Client
+	ctrlCli  client.Client
+	workers  *workers
+	contexts map[string]*multiClusterContext
+	sources  []*dynamicSource
+	ctx      context.Context // the context of the running manager, it is nil before the manager starts
+
+	memberClusters bool // the data-plane k8s clusters are registered at runtime by the MemberCluster API
 }
 
 var _ Manager = &manager{}
@@ -55,35 +84,214 @@ func (m *manager) GetClient() client.Client {
 }
 
 func (m *manager) GetContexts() []string {
-	return maps.Keys(m.caches)
+	m.Lock()
+	defer m.Unlock()
+	return maps.Keys(m.contexts)
 }
 
 func (m *manager) Bind(mgr ctrl.Manager) error {
-	for k, c := range m.caches {
-		if c != nil {
-			if err := mgr.Add(m.caches[k]); err != nil {
+	m.Lock()
+	defer m.Unlock()
+	for _, cc := range m.contexts {
+		if cc.static && cc.cache != nil {
+			if err := mgr.Add(cc.cache); err != nil {
 				return fmt.Errorf("failed to bind cache to Manager: %s", err.Error())
 			}
 		}
 	}
-	return nil
+	// start the caches of contexts registered at runtime
+	return mgr.Add(ctrlmanager.RunnableFunc(func(ctx context.Context) error {
+		m.start(ctx)
+		<-ctx.Done()
+		return nil
+	}))
 }
 
 func (m *manager) Own(b *builder.Builder, obj, owner client.Object) Manager {
 	handler := handler.EnqueueRequestForOwner(m.cli.Scheme(), m.cli.RESTMapper(), owner, handler.OnlyControllerOwner())
-	for k, c := range m.caches {
-		if c != nil {
-			b.WatchesRawSource(source.Kind(m.caches[k], obj), handler)
-		}
-	}
+	b.WatchesRawSource(m.newSource(obj), handler)
 	return m
 }
 
 func (m *manager) Watch(b *builder.Builder, obj client.Object, eventHandler handler.EventHandler) Manager {
-	for k, c := range m.caches {
-		if c != nil {
-			b.WatchesRawSource(source.Kind(m.caches[k], obj), eventHandler)
+	b.WatchesRawSource(m.newSource(obj), eventHandler)
+	return m
+}
+
+func (m *manager) newSource(obj client.Object) *dynamicSource {
+	m.Lock()
+	defer m.Unlock()
+	s := &dynamicSource{mgr: m, obj: obj}
+	m.sources = append(m.sources, s)
+	return s
+}
+
+func (m *manager) start(ctx context.Context) {
+	m.Lock()
+	defer m.Unlock()
+	m.ctx = ctx
+	for _, cc := range m.contexts {
+		if !cc.static {
+			m.startCache(cc)
 		}
 	}
-	return m
+}
+
+func (m *manager) startCache(cc *multiClusterContext) {
+	if m.ctx == nil || cc.cache == nil || cc.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	cc.cancel = cancel
+	go func() {
+		if err := cc.cache.Start(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to start cache", "context", cc.context)
+		}
+	}()
+}
+
+func (m *manager) Register(context string, config *rest.Config, disabled bool) error {
+	m.Lock()
+	defer m.Unlock()
+
+	fingerprint := configFingerprint(config, disabled)
+	old, ok := m.contexts[context]
+	if ok {
+		if old.static {
+			return fmt.Errorf("context %s has been configured statically", context)
+		}
+		if old.fingerprint == fingerprint {
+			return nil
+		}
+	}
+
+	// build the new context before releasing the old one, the old one keeps working if it fails
+	cc, err := m.newContext(context, config, disabled, fingerprint)
+	if err != nil {
+		return err
+	}
+	if ok {
+		m.stop(old)
+	}
+	m.contexts[context] = cc
+	m.workers.set(context, cc.client)
+	m.startCache(cc)
+	return nil
+}
+
+func (m *manager) newContext(context string, config *rest.Config, disabled bool, fingerprint string) (*multiClusterContext, error) {
+	cc := &multiClusterContext{
+		context:     context,
+		id:          config.Host,
+		disabled:    disabled,
+		fingerprint: fingerprint,
+	}
+	switch {
+	case m.control != nil && m.control.Host == config.Host:
+		if disabled {
+			return nil, fmt.Errorf("control cluster %s is disabled", context)
+		}
+		// use the client of control cluster, and the objects are watched by the controllers already
+		cc.client = m.ctrlCli
+	case disabled:
+		cc.client = newUnavailableClient(context)
+	default:
+		if config.UserAgent == "" {
+			config.UserAgent = rest.DefaultKubernetesUserAgent()
+		}
+		cli, cache, err := createClientNCache(scheme, config, context)
+		if err != nil {
+			return nil, err
+		}
+		cc.client, cc.cache = cli, cache
+	}
+	if cc.cache != nil {
+		for _, s := range m.sources {
+			if err := s.watch(cc); err != nil {
+				for _, cancel := range cc.watches {
+					cancel()
+				}
+				return nil, err
+			}
+		}
+	}
+	return cc, nil
+}
+
+func (m *manager) Unregister(context string) error {
+	m.Lock()
+	defer m.Unlock()
+	cc, ok := m.contexts[context]
+	if !ok {
+		return nil
+	}
+	if cc.static {
+		return fmt.Errorf("context %s has been configured statically", context)
+	}
+	m.unregister(cc)
+	return nil
+}
+
+func (m *manager) unregister(cc *multiClusterContext) {
+	m.stop(cc)
+	delete(m.contexts, cc.context)
+	m.workers.delete(cc.context)
+}
+
+// stop stops the watches and the cache of the context.
+func (m *manager) stop(cc *multiClusterContext) {
+	for _, cancel := range cc.watches {
+		cancel()
+	}
+	if cc.cancel != nil {
+		cc.cancel()
+	}
+}
+
+func (m *manager) SetAvailable(context string, available bool) {
+	m.Lock()
+	defer m.Unlock()
+	cc, ok := m.contexts[context]
+	if !ok || cc.static || cc.disabled {
+		return
+	}
+	if available {
+		m.workers.set(context, cc.client)
+	} else {
+		m.workers.set(context, newUnavailableClient(context))
+	}
+}
+
+func (m *manager) Synced(ctx context.Context) (bool, error) {
+	if !m.memberClusters {
+		return true, nil
+	}
+	memberClusters := &appsv1.MemberClusterList{}
+	if err := m.ctrlCli.List(ctx, memberClusters); err != nil {
+		return false, err
+	}
+	m.Lock()
+	defer m.Unlock()
+	for _, memberCluster := range memberClusters.Items {
+		if !memberCluster.GetDeletionTimestamp().IsZero() || memberCluster.Status.Phase == appsv1.FailedMemberClusterPhase {
+			continue
+		}
+		if _, ok := m.contexts[memberCluster.Name]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// configFingerprint identifies the config to access a k8s cluster, to tell whether it has been changed.
+func configFingerprint(config *rest.Config, disabled bool) string {
+	h := sha256.New()
+	for _, v := range []string{config.Host, config.APIPath, config.BearerToken, config.BearerTokenFile,
+		config.Username, config.Password, config.ServerName, config.CertFile, config.KeyFile, config.CAFile,
+		string(config.CertData), string(config.KeyData), string(config.CAData),
+		fmt.Sprintf("%v", config.Insecure), fmt.Sprintf("%v", disabled)} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
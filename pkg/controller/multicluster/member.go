@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+const defaultKubeConfigKey = "kubeconfig"
+
+// MemberClusterConfig builds the config to access the member cluster from the kubeconfig secret referenced.
+func MemberClusterConfig(ctx context.Context, cli client.Reader, memberCluster *appsv1.MemberCluster) (*rest.Config, error) {
+	ref := memberCluster.Spec.KubeConfigSecretRef
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the kubeconfig secret %s/%s: %s", ref.Namespace, ref.Name, err.Error())
+	}
+	key := ref.Key
+	if len(key) == 0 {
+		key = defaultKubeConfigKey
+	}
+	kubeConfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("the kubeconfig key %s not found in secret %s/%s", key, ref.Namespace, ref.Name)
+	}
+	return configFromKubeConfig(kubeConfig, ref.Context)
+}
+
+// configFromKubeConfig builds the config from the content of a kubeconfig,
+// the current context of the kubeconfig is used if @context is empty.
+func configFromKubeConfig(kubeConfig []byte, context string) (*rest.Config, error) {
+	apiConfig, err := clientcmd.Load(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %s", err.Error())
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	config, err := clientcmd.NewNonInteractiveClientConfig(*apiConfig, context, overrides, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build config from kubeconfig: %s", err.Error())
+	}
+	return config, nil
+}
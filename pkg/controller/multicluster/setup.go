@@ -39,8 +39,11 @@ var (
 	scheme *runtime.Scheme
 )
 
-func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConfig, contexts, disabledContexts string) (Manager, error) {
-	if len(contexts) == 0 {
+// Setup sets up the multi-cluster manager with the contexts configured statically. If @memberClusters is enabled,
+// the manager is set up even if there is no static context, the data-plane k8s clusters can be registered at runtime.
+func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client,
+	kubeConfig, contexts, disabledContexts string, memberClusters bool) (Manager, error) {
+	if len(contexts) == 0 && !memberClusters {
 		return nil, nil
 	}
 
@@ -61,24 +64,24 @@ func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConf
 		}
 	}
 
-	clients := func() map[string]client.Client {
-		m := make(map[string]client.Client)
-		for _, c := range mcc {
-			m[c.context] = c.client
-		}
-		return m
-	}
-	caches := func() map[string]cache.Cache {
-		m := make(map[string]cache.Cache)
-		for _, c := range mcc {
-			m[c.context] = c.cache
-		}
-		return m
+	clients := make(map[string]client.Client)
+	ctxs := make(map[string]*multiClusterContext)
+	for k := range mcc {
+		cc := mcc[k]
+		cc.static = true
+		clients[cc.context] = cc.client
+		ctxs[cc.context] = &cc
 	}
 	setupScheme(scheme)
+	workers := newWorkers(clients)
+	workers.dynamic = memberClusters
 	return &manager{
-		cli:    NewClient(cli, clients()),
-		caches: caches(),
+		control:        cfg,
+		cli:            newClient(cli, workers),
+		ctrlCli:        cli,
+		workers:        workers,
+		contexts:       ctxs,
+		memberClusters: memberClusters,
 	}, nil
 }
 
@@ -106,6 +109,9 @@ func newClientNCache(scheme *runtime.Scheme, kubeConfig, contexts, disabledConte
 	}
 
 	mcc := make(map[string]multiClusterContext)
+	if len(contexts) == 0 {
+		return mcc, nil
+	}
 	for context, disabled := range merged {
 		cc, err := newClientNCache4Context(scheme, kubeConfig, context, disabled)
 		if err != nil {
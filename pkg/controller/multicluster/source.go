@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package multicluster
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// dynamicSource watches the objects in all data-plane k8s clusters, including the ones registered at runtime.
+type dynamicSource struct {
+	mgr *manager
+	obj client.Object
+
+	ctx        context.Context
+	handler    handler.EventHandler
+	queue      workqueue.RateLimitingInterface
+	predicates []predicate.Predicate
+
+	// the sources of static contexts, which should be synced before the controller starts
+	syncing []source.SyncingSource
+}
+
+var _ source.SyncingSource = &dynamicSource{}
+
+func (s *dynamicSource) Start(ctx context.Context, handler handler.EventHandler,
+	queue workqueue.RateLimitingInterface, predicates ...predicate.Predicate) error {
+	s.mgr.Lock()
+	defer s.mgr.Unlock()
+
+	s.ctx, s.handler, s.queue, s.predicates = ctx, handler, queue, predicates
+	for _, cc := range s.mgr.contexts {
+		if cc.cache == nil {
+			continue
+		}
+		if err := s.watch(cc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *dynamicSource) WaitForSync(ctx context.Context) error {
+	for _, src := range s.syncing {
+		if err := src.WaitForSync(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watch starts to watch the objects in the context, it should be called with the lock of manager held.
+func (s *dynamicSource) watch(cc *multiClusterContext) error {
+	if s.ctx == nil {
+		return nil // not started yet
+	}
+	src := source.Kind(cc.cache, s.obj)
+	if cc.static {
+		s.syncing = append(s.syncing, src)
+		return src.Start(s.ctx, s.handler, s.queue, s.predicates...)
+	}
+	// don't wait for the contexts registered at runtime to sync, they may be unreachable.
+	ctx, cancel := context.WithCancel(s.ctx)
+	cc.watches = append(cc.watches, cancel)
+	if err := src.Start(ctx, s.handler, s.queue, s.predicates...); err != nil {
+		cancel()
+		return err
+	}
+	go func() {
+		if err := src.WaitForSync(ctx); err != nil && ctx.Err() == nil {
+			log.FromContext(ctx).Error(err, "failed to wait for the watch to sync", "context", cc.context)
+		}
+	}()
+	return nil
+}
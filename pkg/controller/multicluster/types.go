@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package multicluster
 
 import (
+	"context"
+
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -29,4 +31,11 @@ type multiClusterContext struct {
 	id      string
 	cache   cache.Cache
 	client  client.Client
+
+	// static contexts are configured by flags, others are registered at runtime
+	static      bool
+	disabled    bool
+	fingerprint string
+	cancel      context.CancelFunc   // stops the cache of the context registered at runtime
+	watches     []context.CancelFunc // stops the watches on the cache of the context registered at runtime
 }
@@ -22,6 +22,11 @@ package plan
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"strings"
 	"text/template"
@@ -36,42 +41,93 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+const defaultTLSCertValidityDays = 36500
+
 // ComposeTLSSecret composes a TSL secret object.
 // REVIEW/TODO:
 //  1. missing public function doc
 //  2. should avoid using Go template to call a function, this is too hacky & costly,
 //     should just call underlying registered Go template function.
 func ComposeTLSSecret(synthesizedComp component.SynthesizedComponent) (*v1.Secret, error) {
+	// the CA is long-lived, so that it can be reused to re-issue the certificate on renewal
+	return composeTLSSecret(synthesizedComp, fmt.Sprintf(`{{- $ca := genCA "KubeBlocks" %d -}}`, defaultTLSCertValidityDays))
+}
+
+// ComposeTLSSecretWithCA composes a TLS secret object whose certificate is issued by the given CA,
+// the CA certificate and key are PEM encoded.
+func ComposeTLSSecretWithCA(synthesizedComp component.SynthesizedComponent, caCert, caKey []byte) (*v1.Secret, error) {
+	return composeTLSSecret(synthesizedComp, fmt.Sprintf(`{{- $ca := buildCustomCert "%s" "%s" -}}`,
+		base64.StdEncoding.EncodeToString(caCert), base64.StdEncoding.EncodeToString(caKey)))
+}
+
+func composeTLSSecret(synthesizedComp component.SynthesizedComponent, caTpl string) (*v1.Secret, error) {
 	secret := BuildTLSSecret(synthesizedComp)
 	// use ca gen cert
 	// IP: 127.0.0.1 and ::1
 	// DNS: localhost and *.<clusterName>-<componentName>-headless.<namespace>.svc.cluster.local
 	const spliter = "___spliter___"
 	SignedCertTpl := fmt.Sprintf(`
-	{{- $ca := genCA "KubeBlocks" 36500 -}}
-	{{- $cert := genSignedCert "%s peer" (list "127.0.0.1" "::1") (list "localhost" "*.%s-%s-headless.%s.svc.cluster.local") 36500 $ca -}}
+	%s
+	{{- $cert := genSignedCert "%s peer" (list "127.0.0.1" "::1") (list "localhost" "*.%s-%s-headless.%s.svc.cluster.local") %d $ca -}}
 	{{- $ca.Cert -}}
 	{{- print "%s" -}}
+	{{- $ca.Key -}}
+	{{- print "%s" -}}
 	{{- $cert.Cert -}}
 	{{- print "%s" -}}
 	{{- $cert.Key -}}
-`, synthesizedComp.Name, synthesizedComp.ClusterName, synthesizedComp.Name, synthesizedComp.Namespace, spliter, spliter)
+`, caTpl, synthesizedComp.Name, synthesizedComp.ClusterName, synthesizedComp.Name, synthesizedComp.Namespace, tlsCertValidityDays(), spliter, spliter, spliter)
 	out, err := buildFromTemplate(SignedCertTpl, nil)
 	if err != nil {
 		return nil, err
 	}
 	parts := strings.Split(out, spliter)
-	if len(parts) != 3 {
+	if len(parts) != 4 {
 		return nil, errors.Errorf("generate TLS certificates failed with cluster name %s, component name %s in namespace %s", synthesizedComp.ClusterName, synthesizedComp.Name, synthesizedComp.Namespace)
 	}
 	secret.StringData[constant.CAName] = parts[0]
-	secret.StringData[constant.CertName] = parts[1]
-	secret.StringData[constant.KeyName] = parts[2]
+	secret.StringData[constant.CAKeyName] = parts[1]
+	secret.StringData[constant.CertName] = parts[2]
+	secret.StringData[constant.KeyName] = parts[3]
 	return secret, nil
 }
 
+func tlsCertValidityDays() int {
+	validityDays := viper.GetInt(constant.CfgKeyTLSCertValidityDays)
+	if validityDays <= 0 {
+		return defaultTLSCertValidityDays
+	}
+	return validityDays
+}
+
+// ParseTLSCertificate parses the first certificate in the PEM encoded data.
+func ParseTLSCertificate(data []byte) (*x509.Certificate, error) {
+	for len(data) > 0 {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+	return nil, errors.New("no PEM encoded certificate found")
+}
+
+// TLSCertificateHash returns the hash of the TLS certificate data.
+func TLSCertificateHash(ca, cert, key []byte) string {
+	hash := sha256.New()
+	for _, data := range [][]byte{ca, cert, key} {
+		hash.Write(data)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
 func BuildTLSSecret(synthesizedComp component.SynthesizedComponent) *v1.Secret {
 	name := GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
 	return builder.NewSecretBuilder(synthesizedComp.Namespace, name).
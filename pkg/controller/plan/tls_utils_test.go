@@ -22,6 +22,7 @@ package plan
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -36,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	testutil "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("TLSUtilsTest", func() {
@@ -63,6 +65,63 @@ var _ = Describe("TLSUtilsTest", func() {
 		})
 	})
 
+	Context("ParseTLSCertificate function", func() {
+		It("should parse the validity of the composed certificate", func() {
+			viper.Set(constant.CfgKeyTLSCertValidityDays, 30)
+			defer viper.Set(constant.CfgKeyTLSCertValidityDays, nil)
+
+			synthesizedComp := component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: "bar",
+				Name:        "test",
+			}
+			secret, err := ComposeTLSSecret(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			cert, err := ParseTLSCertificate([]byte(secret.StringData[constant.CertName]))
+			Expect(err).Should(BeNil())
+			Expect(cert.NotAfter.Sub(cert.NotBefore)).Should(BeNumerically("~", 30*24*time.Hour, time.Hour))
+
+			By("no certificate in the data")
+			_, err = ParseTLSCertificate([]byte(secret.StringData[constant.KeyName]))
+			Expect(err).ShouldNot(BeNil())
+			_, err = ParseTLSCertificate(nil)
+			Expect(err).ShouldNot(BeNil())
+		})
+
+		It("should re-issue the certificate by the existing CA", func() {
+			synthesizedComp := component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: "bar",
+				Name:        "test",
+			}
+			secret, err := ComposeTLSSecret(synthesizedComp)
+			Expect(err).Should(BeNil())
+			Expect(secret.StringData[constant.CAKeyName]).ShouldNot(BeZero())
+
+			renewed, err := ComposeTLSSecretWithCA(synthesizedComp,
+				[]byte(secret.StringData[constant.CAName]), []byte(secret.StringData[constant.CAKeyName]))
+			Expect(err).Should(BeNil())
+			Expect(renewed.StringData[constant.CAName]).Should(Equal(secret.StringData[constant.CAName]))
+			Expect(renewed.StringData[constant.CAKeyName]).Should(Equal(secret.StringData[constant.CAKeyName]))
+			Expect(renewed.StringData[constant.CertName]).ShouldNot(Equal(secret.StringData[constant.CertName]))
+
+			By("the re-issued certificate is trusted by the CA")
+			ca, err := ParseTLSCertificate([]byte(secret.StringData[constant.CAName]))
+			Expect(err).Should(BeNil())
+			cert, err := ParseTLSCertificate([]byte(renewed.StringData[constant.CertName]))
+			Expect(err).Should(BeNil())
+			Expect(cert.CheckSignatureFrom(ca)).Should(Succeed())
+		})
+
+		It("should change the hash with the content", func() {
+			hash := TLSCertificateHash([]byte("ca"), []byte("cert"), []byte("key"))
+			Expect(hash).Should(Equal(TLSCertificateHash([]byte("ca"), []byte("cert"), []byte("key"))))
+			Expect(hash).ShouldNot(Equal(TLSCertificateHash([]byte("ca"), []byte("cert2"), []byte("key"))))
+			Expect(hash).ShouldNot(Equal(TLSCertificateHash([]byte("cac"), []byte("ert"), []byte("key"))))
+		})
+	})
+
 	Context("CheckTLSSecretRef function", func() {
 		It("should work well", func() {
 			ctx := context.Background()
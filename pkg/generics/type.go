@@ -88,6 +88,10 @@ var ClusterDefinitionSignature = func(_ appsv1.ClusterDefinition, _ *appsv1.Clus
 }
 var ShardingDefinitionSignature = func(appsv1.ShardingDefinition, *appsv1.ShardingDefinition, appsv1.ShardingDefinitionList, *appsv1.ShardingDefinitionList) {
 }
+var AccountSignature = func(_ appsv1.Account, _ *appsv1.Account, _ appsv1.AccountList, _ *appsv1.AccountList) {
+}
+var MemberClusterSignature = func(_ appsv1.MemberCluster, _ *appsv1.MemberCluster, _ appsv1.MemberClusterList, _ *appsv1.MemberClusterList) {
+}
 var ComponentDefinitionSignature = func(appsv1.ComponentDefinition, *appsv1.ComponentDefinition, appsv1.ComponentDefinitionList, *appsv1.ComponentDefinitionList) {
 }
 var ComponentVersionSignature = func(appsv1.ComponentVersion, *appsv1.ComponentVersion, appsv1.ComponentVersionList, *appsv1.ComponentVersionList) {
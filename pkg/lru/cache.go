@@ -45,8 +45,9 @@ func New(capacity int) *Cache {
 }
 
 func (c *Cache) Get(key string) (any, bool) {
-	c.m.RLock()
-	defer c.m.RUnlock()
+	// the recently used list is updated, so the write lock is required
+	c.m.Lock()
+	defer c.m.Unlock()
 
 	if elem, ok := c.items[key]; ok {
 		c.list.MoveToFront(elem)
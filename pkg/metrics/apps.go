@@ -0,0 +1,158 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var (
+	clusterPhaseDesc = newDesc("cluster", "phase",
+		"The current phase of the cluster, the value is always 1.",
+		"namespace", "cluster", "cluster_definition", "phase")
+	componentPhaseDesc = newDesc("component", "phase",
+		"The current phase of the component, the value is always 1.",
+		"namespace", "cluster", "component", "phase")
+
+	instanceSetReplicasDesc = newDesc("instanceset", "replicas",
+		"The number of desired replicas of the InstanceSet.",
+		"namespace", "instanceset")
+	instanceSetReadyReplicasDesc = newDesc("instanceset", "ready_replicas",
+		"The number of ready replicas of the InstanceSet.",
+		"namespace", "instanceset")
+	instanceSetUpdatedReplicasDesc = newDesc("instanceset", "updated_replicas",
+		"The number of replicas of the InstanceSet that are updated to the latest revision.",
+		"namespace", "instanceset")
+	instanceSetRoleMembersDesc = newDesc("instanceset", "role_members",
+		"The number of members of the InstanceSet in the role.",
+		"namespace", "instanceset", "role")
+
+	opsRequestsDesc = newDesc("opsrequest", "count",
+		"The number of OpsRequests by the type and the phase.",
+		"namespace", "type", "phase")
+	opsRequestDurationDesc = newDesc("opsrequest", "duration_seconds",
+		"The duration of the completed OpsRequests by the type and the phase.",
+		"type", "phase")
+)
+
+// NewClusterCollector returns a collector of the Cluster and the Component phases.
+func NewClusterCollector(reader client.Reader) prometheus.Collector {
+	return newObjectCollector("cluster", reader, collectClusterMetrics, clusterPhaseDesc, componentPhaseDesc)
+}
+
+// NewInstanceSetCollector returns a collector of the InstanceSet replicas and the role membership.
+func NewInstanceSetCollector(reader client.Reader) prometheus.Collector {
+	return newObjectCollector("instanceset", reader, collectInstanceSetMetrics,
+		instanceSetReplicasDesc, instanceSetReadyReplicasDesc, instanceSetUpdatedReplicasDesc, instanceSetRoleMembersDesc)
+}
+
+// NewOpsRequestCollector returns a collector of the OpsRequest counts and durations.
+func NewOpsRequestCollector(reader client.Reader) prometheus.Collector {
+	return newObjectCollector("opsrequest", reader, collectOpsRequestMetrics, opsRequestsDesc, opsRequestDurationDesc)
+}
+
+func collectClusterMetrics(ctx context.Context, reader client.Reader, ch chan<- prometheus.Metric) error {
+	clusters := &appsv1.ClusterList{}
+	if err := reader.List(ctx, clusters); err != nil {
+		return err
+	}
+	for _, cluster := range clusters.Items {
+		ch <- prometheus.MustNewConstMetric(clusterPhaseDesc, prometheus.GaugeValue, 1,
+			cluster.Namespace, cluster.Name, cluster.Spec.ClusterDef, string(cluster.Status.Phase))
+	}
+
+	comps := &appsv1.ComponentList{}
+	if err := reader.List(ctx, comps); err != nil {
+		return err
+	}
+	for _, comp := range comps.Items {
+		compName := comp.Labels[constant.KBAppComponentLabelKey]
+		if compName == "" {
+			compName = comp.Name
+		}
+		ch <- prometheus.MustNewConstMetric(componentPhaseDesc, prometheus.GaugeValue, 1,
+			comp.Namespace, comp.Labels[constant.AppInstanceLabelKey], compName, string(comp.Status.Phase))
+	}
+	return nil
+}
+
+func collectInstanceSetMetrics(ctx context.Context, reader client.Reader, ch chan<- prometheus.Metric) error {
+	itsList := &workloads.InstanceSetList{}
+	if err := reader.List(ctx, itsList); err != nil {
+		return err
+	}
+	for _, its := range itsList.Items {
+		var replicas int32 = 1
+		if its.Spec.Replicas != nil {
+			replicas = *its.Spec.Replicas
+		}
+		ch <- prometheus.MustNewConstMetric(instanceSetReplicasDesc, prometheus.GaugeValue, float64(replicas), its.Namespace, its.Name)
+		ch <- prometheus.MustNewConstMetric(instanceSetReadyReplicasDesc, prometheus.GaugeValue, float64(its.Status.ReadyReplicas), its.Namespace, its.Name)
+		ch <- prometheus.MustNewConstMetric(instanceSetUpdatedReplicasDesc, prometheus.GaugeValue, float64(its.Status.UpdatedReplicas), its.Namespace, its.Name)
+
+		members := newGaugeCounter()
+		for _, role := range its.Spec.Roles {
+			// report the roles without members as well
+			members.add(0, its.Namespace, its.Name, role.Name)
+		}
+		for _, member := range its.Status.MembersStatus {
+			if member.ReplicaRole != nil {
+				members.inc(its.Namespace, its.Name, member.ReplicaRole.Name)
+			}
+		}
+		members.collect(ch, instanceSetRoleMembersDesc)
+	}
+	return nil
+}
+
+func collectOpsRequestMetrics(ctx context.Context, reader client.Reader, ch chan<- prometheus.Metric) error {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := reader.List(ctx, opsList); err != nil {
+		return err
+	}
+	counts := newGaugeCounter()
+	durations := newHistogramSamples()
+	for _, ops := range opsList.Items {
+		opsType, phase := string(ops.Spec.Type), string(ops.Status.Phase)
+		counts.inc(ops.Namespace, opsType, phase)
+		if isOpsCompleted(ops.Status.Phase) && !ops.Status.StartTimestamp.IsZero() && !ops.Status.CompletionTimestamp.IsZero() {
+			durations.observe(ops.Status.CompletionTimestamp.Sub(ops.Status.StartTimestamp.Time).Seconds(), opsType, phase)
+		}
+	}
+	counts.collect(ch, opsRequestsDesc)
+	durations.collect(ch, opsRequestDurationDesc, durationBuckets)
+	return nil
+}
+
+func isOpsCompleted(phase opsv1alpha1.OpsPhase) bool {
+	switch phase {
+	case opsv1alpha1.OpsSucceedPhase, opsv1alpha1.OpsFailedPhase, opsv1alpha1.OpsCancelledPhase, opsv1alpha1.OpsAbortedPhase:
+		return true
+	}
+	return false
+}
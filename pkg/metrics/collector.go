@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "kubeblocks"
+
+	collectTimeout = 10 * time.Second
+)
+
+var (
+	// durationBuckets ranges from 10s to about 5.7h.
+	durationBuckets = prometheus.ExponentialBuckets(10, 2, 12)
+
+	// sizeBuckets ranges from 1Mi to 4Ti.
+	sizeBuckets = prometheus.ExponentialBuckets(1<<20, 4, 12)
+
+	logger = ctrl.Log.WithName("metrics")
+)
+
+type collectFunc func(ctx context.Context, reader client.Reader, ch chan<- prometheus.Metric) error
+
+// objectCollector collects the metrics of the objects listed from the cache at scrape time,
+// the metrics are always consistent with the objects and survive the restart of the operator.
+type objectCollector struct {
+	name    string
+	reader  client.Reader
+	descs   []*prometheus.Desc
+	collect collectFunc
+}
+
+var _ prometheus.Collector = &objectCollector{}
+
+func newObjectCollector(name string, reader client.Reader, collect collectFunc, descs ...*prometheus.Desc) *objectCollector {
+	return &objectCollector{
+		name:    name,
+		reader:  reader,
+		descs:   descs,
+		collect: collect,
+	}
+}
+
+func (c *objectCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+func (c *objectCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	if err := c.collect(ctx, c.reader, ch); err != nil {
+		// do not fail the whole scrape, the other metrics are still useful.
+		logger.Error(err, "failed to collect metrics", "collector", c.name)
+	}
+}
+
+// RegisterCollectors registers the collectors on the controller-runtime metrics registry,
+// which is served on the metrics endpoint of the manager.
+func RegisterCollectors(collectors ...prometheus.Collector) error {
+	for _, c := range collectors {
+		if err := crmetrics.Registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newDesc(subsystem, name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, subsystem, name), help, labels, nil)
+}
+
+// histogramSamples groups the observed samples by the label values.
+type histogramSamples struct {
+	labels  map[string][]string
+	samples map[string][]float64
+}
+
+func newHistogramSamples() *histogramSamples {
+	return &histogramSamples{
+		labels:  map[string][]string{},
+		samples: map[string][]float64{},
+	}
+}
+
+func (h *histogramSamples) observe(value float64, labels ...string) {
+	key := labelsKey(labels)
+	h.labels[key] = labels
+	h.samples[key] = append(h.samples[key], value)
+}
+
+func (h *histogramSamples) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc, buckets []float64) {
+	for key, samples := range h.samples {
+		var (
+			sum    float64
+			counts = make(map[float64]uint64, len(buckets))
+		)
+		for _, v := range samples {
+			sum += v
+			for _, b := range buckets {
+				if v <= b {
+					counts[b]++
+				}
+			}
+		}
+		ch <- prometheus.MustNewConstHistogram(desc, uint64(len(samples)), sum, counts, h.labels[key]...)
+	}
+}
+
+// gaugeCounter counts the objects by the label values.
+type gaugeCounter struct {
+	labels map[string][]string
+	counts map[string]float64
+}
+
+func newGaugeCounter() *gaugeCounter {
+	return &gaugeCounter{
+		labels: map[string][]string{},
+		counts: map[string]float64{},
+	}
+}
+
+func (g *gaugeCounter) inc(labels ...string) {
+	g.add(1, labels...)
+}
+
+func (g *gaugeCounter) add(delta float64, labels ...string) {
+	key := labelsKey(labels)
+	g.labels[key] = labels
+	g.counts[key] += delta
+}
+
+func (g *gaugeCounter) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	for key, count := range g.counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, count, g.labels[key]...)
+	}
+}
+
+func labelsKey(labels []string) string {
+	// the label values never contain the NUL character
+	return strings.Join(labels, "\x00")
+}
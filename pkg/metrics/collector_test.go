@@ -0,0 +1,186 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, appsv1.AddToScheme(scheme))
+	assert.Nil(t, workloads.AddToScheme(scheme))
+	assert.Nil(t, opsv1alpha1.AddToScheme(scheme))
+	assert.Nil(t, dpv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestClusterCollector(t *testing.T) {
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec:       appsv1.ClusterSpec{ClusterDef: "mysql"},
+		Status:     appsv1.ClusterStatus{Phase: appsv1.RunningClusterPhase},
+	}
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mycluster-mysql",
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    "mycluster",
+				constant.KBAppComponentLabelKey: "mysql",
+			},
+		},
+		Status: appsv1.ComponentStatus{Phase: appsv1.UpdatingClusterCompPhase},
+	}
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(cluster, comp).Build()
+
+	expected := `
+# HELP kubeblocks_cluster_phase The current phase of the cluster, the value is always 1.
+# TYPE kubeblocks_cluster_phase gauge
+kubeblocks_cluster_phase{cluster="mycluster",cluster_definition="mysql",namespace="default",phase="Running"} 1
+# HELP kubeblocks_component_phase The current phase of the component, the value is always 1.
+# TYPE kubeblocks_component_phase gauge
+kubeblocks_component_phase{cluster="mycluster",component="mysql",namespace="default",phase="Updating"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(NewClusterCollector(reader), strings.NewReader(expected)))
+}
+
+func TestInstanceSetCollector(t *testing.T) {
+	its := &workloads.InstanceSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql"},
+		Spec: workloads.InstanceSetSpec{
+			Replicas: pointer.Int32(3),
+			Roles:    []workloads.ReplicaRole{{Name: "leader"}, {Name: "follower"}, {Name: "learner"}},
+		},
+		Status: workloads.InstanceSetStatus{
+			ReadyReplicas:   3,
+			UpdatedReplicas: 2,
+			MembersStatus: []workloads.MemberStatus{
+				{PodName: "mycluster-mysql-0", ReplicaRole: &workloads.ReplicaRole{Name: "leader"}},
+				{PodName: "mycluster-mysql-1", ReplicaRole: &workloads.ReplicaRole{Name: "follower"}},
+				{PodName: "mycluster-mysql-2", ReplicaRole: &workloads.ReplicaRole{Name: "follower"}},
+			},
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(its).Build()
+	collector := NewInstanceSetCollector(reader)
+
+	expected := `
+# HELP kubeblocks_instanceset_role_members The number of members of the InstanceSet in the role.
+# TYPE kubeblocks_instanceset_role_members gauge
+kubeblocks_instanceset_role_members{instanceset="mycluster-mysql",namespace="default",role="follower"} 2
+kubeblocks_instanceset_role_members{instanceset="mycluster-mysql",namespace="default",role="leader"} 1
+kubeblocks_instanceset_role_members{instanceset="mycluster-mysql",namespace="default",role="learner"} 0
+# HELP kubeblocks_instanceset_updated_replicas The number of replicas of the InstanceSet that are updated to the latest revision.
+# TYPE kubeblocks_instanceset_updated_replicas gauge
+kubeblocks_instanceset_updated_replicas{instanceset="mycluster-mysql",namespace="default"} 2
+`
+	assert.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"kubeblocks_instanceset_role_members", "kubeblocks_instanceset_updated_replicas"))
+	assert.Equal(t, 4+2, testutil.CollectAndCount(collector))
+}
+
+func TestOpsRequestCollector(t *testing.T) {
+	now := time.Now()
+	newOps := func(name string, opsType opsv1alpha1.OpsType, phase opsv1alpha1.OpsPhase, duration time.Duration) *opsv1alpha1.OpsRequest {
+		ops := &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       opsv1alpha1.OpsRequestSpec{ClusterName: "mycluster", Type: opsType},
+			Status:     opsv1alpha1.OpsRequestStatus{Phase: phase},
+		}
+		if duration > 0 {
+			ops.Status.StartTimestamp = metav1.NewTime(now.Add(-duration))
+			ops.Status.CompletionTimestamp = metav1.NewTime(now)
+		}
+		return ops
+	}
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		newOps("ops-restart-1", opsv1alpha1.RestartType, opsv1alpha1.OpsSucceedPhase, 30*time.Second),
+		newOps("ops-restart-2", opsv1alpha1.RestartType, opsv1alpha1.OpsSucceedPhase, 90*time.Second),
+		newOps("ops-restart-3", opsv1alpha1.RestartType, opsv1alpha1.OpsRunningPhase, 0),
+	).Build()
+
+	expected := `
+# HELP kubeblocks_opsrequest_count The number of OpsRequests by the type and the phase.
+# TYPE kubeblocks_opsrequest_count gauge
+kubeblocks_opsrequest_count{namespace="default",phase="Running",type="Restart"} 1
+kubeblocks_opsrequest_count{namespace="default",phase="Succeed",type="Restart"} 2
+`
+	collector := NewOpsRequestCollector(reader)
+	assert.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "kubeblocks_opsrequest_count"))
+	assert.Equal(t, 3, testutil.CollectAndCount(collector))
+}
+
+func TestBackupCollector(t *testing.T) {
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup-1"},
+		Spec:       dpv1alpha1.BackupSpec{BackupPolicyName: "policy", BackupMethod: "xtrabackup"},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:          dpv1alpha1.BackupPhaseCompleted,
+			BackupRepoName: "s3-repo",
+			Duration:       &metav1.Duration{Duration: time.Minute},
+			TotalSize:      "1Gi",
+		},
+	}
+	failedBackup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup-2"},
+		Spec:       dpv1alpha1.BackupSpec{BackupPolicyName: "policy", BackupMethod: "xtrabackup"},
+		Status:     dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseFailed, BackupRepoName: "s3-repo"},
+	}
+	repo := &dpv1alpha1.BackupRepo{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-repo"},
+		Spec:       dpv1alpha1.BackupRepoSpec{StorageProviderRef: "s3"},
+		Status:     dpv1alpha1.BackupRepoStatus{Phase: dpv1alpha1.BackupRepoReady},
+	}
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(backup, failedBackup, repo).Build()
+
+	expected := `
+# HELP kubeblocks_backup_count The number of Backups by the method, the backup repo and the phase.
+# TYPE kubeblocks_backup_count gauge
+kubeblocks_backup_count{method="xtrabackup",namespace="default",phase="Completed",repo="s3-repo"} 1
+kubeblocks_backup_count{method="xtrabackup",namespace="default",phase="Failed",repo="s3-repo"} 1
+`
+	collector := NewBackupCollector(reader)
+	assert.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "kubeblocks_backup_count"))
+	// the counts, the duration and the size
+	assert.Equal(t, 4, testutil.CollectAndCount(collector))
+
+	expected = `
+# HELP kubeblocks_backuprepo_phase The current phase of the BackupRepo, the value is always 1.
+# TYPE kubeblocks_backuprepo_phase gauge
+kubeblocks_backuprepo_phase{phase="Ready",repo="s3-repo",storage_provider="s3"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(NewBackupRepoCollector(reader), strings.NewReader(expected)))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+var (
+	backupsDesc = newDesc("backup", "count",
+		"The number of Backups by the method, the backup repo and the phase.",
+		"namespace", "method", "repo", "phase")
+	backupDurationDesc = newDesc("backup", "duration_seconds",
+		"The duration of the completed Backups by the method and the backup repo.",
+		"method", "repo")
+	backupSizeDesc = newDesc("backup", "size_bytes",
+		"The total size of the completed Backups by the method and the backup repo.",
+		"method", "repo")
+
+	backupRepoPhaseDesc = newDesc("backuprepo", "phase",
+		"The current phase of the BackupRepo, the value is always 1.",
+		"repo", "storage_provider", "phase")
+)
+
+// NewBackupCollector returns a collector of the Backup results, durations and sizes.
+func NewBackupCollector(reader client.Reader) prometheus.Collector {
+	return newObjectCollector("backup", reader, collectBackupMetrics, backupsDesc, backupDurationDesc, backupSizeDesc)
+}
+
+// NewBackupRepoCollector returns a collector of the BackupRepo phases.
+func NewBackupRepoCollector(reader client.Reader) prometheus.Collector {
+	return newObjectCollector("backuprepo", reader, collectBackupRepoMetrics, backupRepoPhaseDesc)
+}
+
+func collectBackupMetrics(ctx context.Context, reader client.Reader, ch chan<- prometheus.Metric) error {
+	backups := &dpv1alpha1.BackupList{}
+	if err := reader.List(ctx, backups); err != nil {
+		return err
+	}
+	counts := newGaugeCounter()
+	durations := newHistogramSamples()
+	sizes := newHistogramSamples()
+	for _, backup := range backups.Items {
+		method, repo := backup.Spec.BackupMethod, backup.Status.BackupRepoName
+		counts.inc(backup.Namespace, method, repo, string(backup.Status.Phase))
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		if backup.Status.Duration != nil {
+			durations.observe(backup.Status.Duration.Seconds(), method, repo)
+		}
+		if size, err := resource.ParseQuantity(backup.Status.TotalSize); err == nil {
+			sizes.observe(float64(size.Value()), method, repo)
+		}
+	}
+	counts.collect(ch, backupsDesc)
+	durations.collect(ch, backupDurationDesc, durationBuckets)
+	sizes.collect(ch, backupSizeDesc, sizeBuckets)
+	return nil
+}
+
+func collectBackupRepoMetrics(ctx context.Context, reader client.Reader, ch chan<- prometheus.Metric) error {
+	repos := &dpv1alpha1.BackupRepoList{}
+	if err := reader.List(ctx, repos); err != nil {
+		return err
+	}
+	for _, repo := range repos.Items {
+		ch <- prometheus.MustNewConstMetric(backupRepoPhaseDesc, prometheus.GaugeValue, 1,
+			repo.Name, repo.Spec.StorageProviderRef, string(repo.Status.Phase))
+	}
+	return nil
+}
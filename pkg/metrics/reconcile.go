@@ -0,0 +1,48 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var transformerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Subsystem: "reconcile",
+	Name:      "transformer_errors_total",
+	Help:      "The total number of errors returned by the transformers.",
+}, []string{"transformer"})
+
+func init() {
+	crmetrics.Registry.MustRegister(transformerErrors)
+}
+
+// RecordTransformerError counts the error returned by the transformer.
+func RecordTransformerError(transformer string) {
+	transformerErrors.WithLabelValues(transformer).Inc()
+}
+
+// TransformerErrorObserver counts the errors of transformers, it is registered as the observer of transformers.
+type TransformerErrorObserver struct{}
+
+func (TransformerErrorObserver) TransformerFailed(transformer string, _ error) {
+	RecordTransformerError(transformer)
+}
@@ -99,8 +99,8 @@ func (hs horizontalScalingOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli
 		lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[obj.GetComponentName()]
 		if horizontalScaling.ScaleIn != nil && len(horizontalScaling.ScaleIn.OnlineInstancesToOffline) > 0 {
 			// check if the instances are online.
-			currPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(*lastCompConfiguration.Replicas, lastCompConfiguration.Instances, lastCompConfiguration.OfflineInstances,
-				opsRes.Cluster.Name, obj.GetComponentName())
+			currPodSet, err := intctrlcomp.GenerateAllPodNamesToSetWithZones(*lastCompConfiguration.Replicas, lastCompConfiguration.Instances,
+				compSpec.ZoneDistribution, lastCompConfiguration.OfflineInstances, opsRes.Cluster.Name, obj.GetComponentName())
 			if err != nil {
 				return err
 			}
@@ -179,8 +179,8 @@ func (hs horizontalScalingOpsHandler) getCreateAndDeletePodSet(opsRes *OpsResour
 	horizontalScaling opsv1alpha1.HorizontalScaling,
 	fullCompName string) (map[string]string, map[string]string, error) {
 	clusterName := opsRes.Cluster.Name
-	lastPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(*lastCompConfiguration.Replicas,
-		lastCompConfiguration.Instances, lastCompConfiguration.OfflineInstances, clusterName, fullCompName)
+	lastPodSet, err := intctrlcomp.GenerateAllPodNamesToSetWithZones(*lastCompConfiguration.Replicas,
+		lastCompConfiguration.Instances, currCompSpec.ZoneDistribution, lastCompConfiguration.OfflineInstances, clusterName, fullCompName)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -188,8 +188,8 @@ func (hs horizontalScalingOpsHandler) getCreateAndDeletePodSet(opsRes *OpsResour
 	if err != nil {
 		return nil, nil, err
 	}
-	currPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(expectReplicas, expectInstanceTpls,
-		expectOfflineInstances, clusterName, fullCompName)
+	currPodSet, err := intctrlcomp.GenerateAllPodNamesToSetWithZones(expectReplicas, expectInstanceTpls,
+		currCompSpec.ZoneDistribution, expectOfflineInstances, clusterName, fullCompName)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -295,7 +295,7 @@ func (hs horizontalScalingOpsHandler) getExpectedCompValues(
 	compInstanceTpls := slices.Clone(lastCompConfiguration.Instances)
 	compOfflineInstances := lastCompConfiguration.OfflineInstances
 	expectOfflineInstances := hs.getCompExpectedOfflineInstances(compOfflineInstances, horizontalScaling)
-	err := hs.autoSyncReplicaChanges(opsRes, horizontalScaling, compReplicas, compInstanceTpls, expectOfflineInstances)
+	err := hs.autoSyncReplicaChanges(opsRes, horizontalScaling, compReplicas, compInstanceTpls, compSpec.ZoneDistribution, expectOfflineInstances)
 	if err != nil {
 		return 0, nil, nil, err
 	}
@@ -310,6 +310,7 @@ func (hs horizontalScalingOpsHandler) autoSyncReplicaChanges(
 	horizontalScaling opsv1alpha1.HorizontalScaling,
 	compReplicas int32,
 	compInstanceTpls []appsv1.InstanceTemplate,
+	compZoneDistribution *appsv1.ZoneDistribution,
 	compExpectOfflineInstances []string) error {
 	// sync the replicaChanges for component and instance template.
 	getSyncedInstancesAndReplicaChanges := func(offlineOrOnlineInsCountMap map[string]int32,
@@ -348,8 +349,8 @@ func (hs horizontalScalingOpsHandler) autoSyncReplicaChanges(
 	scaleOut := horizontalScaling.ScaleOut
 	if scaleOut != nil {
 		// get the pod set when removing the specified instances from offlineInstances slice
-		podSet, err := intctrlcomp.GenerateAllPodNamesToSet(compReplicas, compInstanceTpls, compExpectOfflineInstances,
-			opsRes.Cluster.Name, horizontalScaling.ComponentName)
+		podSet, err := intctrlcomp.GenerateAllPodNamesToSetWithZones(compReplicas, compInstanceTpls, compZoneDistribution,
+			compExpectOfflineInstances, opsRes.Cluster.Name, horizontalScaling.ComponentName)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,221 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// passwordRotationOpsHandler requests the component controller to rotate the passwords of the system accounts,
+// by annotating the account secrets, and waits until the new passwords are applied.
+type passwordRotationOpsHandler struct{}
+
+var _ OpsHandler = passwordRotationOpsHandler{}
+
+func init() {
+	passwordRotationBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        passwordRotationOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.PasswordRotationType, passwordRotationBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the password rotation request.
+func (r passwordRotationOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewPasswordRotatingCondition(opsRes.OpsRequest), nil
+}
+
+// Action annotates the secrets of the accounts to rotate.
+func (r passwordRotationOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	secretKeys, err := r.accountSecretKeys(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	for _, key := range secretKeys {
+		secret := &corev1.Secret{}
+		if err = cli.Get(reqCtx.Ctx, key, secret); err != nil {
+			return err
+		}
+		// the immutable secrets are recreated as mutable ones by the component controller before rotating.
+		if err = r.requestRotation(reqCtx, cli, secret, opsRes.OpsRequest.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for password rotation opsRequest.
+func (r passwordRotationOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+		completedCount  int
+	)
+	secretKeys, err := r.accountSecretKeys(reqCtx, cli, opsRes)
+	if err != nil {
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return opsv1alpha1.OpsFailedPhase, 0, err
+		}
+		return opsRequestPhase, 0, err
+	}
+	for _, key := range secretKeys {
+		secret := &corev1.Secret{}
+		if err = cli.Get(reqCtx.Ctx, key, secret); err != nil {
+			return opsRequestPhase, 0, err
+		}
+		if r.isRotated(secret, opsRes.OpsRequest.Name) {
+			completedCount++
+			continue
+		}
+		// request again in case the annotation is lost, e.g. the secret is recreated concurrently.
+		if err = r.requestRotation(reqCtx, cli, secret, opsRes.OpsRequest.Name); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	}
+	if err = syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, len(secretKeys)); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completedCount != len(secretKeys) {
+		return opsRequestPhase, time.Second * 5, nil
+	}
+	return opsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration this operation only rotates the passwords of the accounts, no changes for Cluster.spec.
+// empty implementation here.
+func (r passwordRotationOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// requestRotation annotates the account secret to request the component controller to rotate the password.
+func (r passwordRotationOpsHandler) requestRotation(reqCtx intctrlutil.RequestCtx, cli client.Client, secret *corev1.Secret, opsName string) error {
+	if secret.Annotations[constant.AccountPasswordRotationRequestAnnotationKey] == opsName {
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.AccountPasswordRotationRequestAnnotationKey] = opsName
+	return cli.Patch(reqCtx.Ctx, secret, patch)
+}
+
+func (r passwordRotationOpsHandler) isRotated(secret *corev1.Secret, opsName string) bool {
+	return secret.Annotations[constant.AccountPasswordRotatedByAnnotationKey] == opsName &&
+		secret.Annotations[constant.AccountPasswordRotationRequestAnnotationKey] != opsName
+}
+
+// accountSecretKeys returns the keys of the account secrets to rotate.
+func (r passwordRotationOpsHandler) accountSecretKeys(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) ([]types.NamespacedName, error) {
+	var keys []types.NamespacedName
+	for _, rotation := range opsRes.OpsRequest.Spec.PasswordRotationList {
+		compNames := []string{rotation.ComponentName}
+		if opsRes.Cluster.Spec.GetShardingByName(rotation.ComponentName) != nil {
+			shardingComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, rotation.ComponentName)
+			if err != nil {
+				return nil, err
+			}
+			compNames = make([]string, 0, len(shardingComps))
+			for _, comp := range shardingComps {
+				compNames = append(compNames, comp.Labels[constant.KBAppComponentLabelKey])
+			}
+		}
+		for _, compName := range compNames {
+			accounts, err := r.accountsToRotate(reqCtx, cli, opsRes.Cluster, compName, rotation.Accounts)
+			if err != nil {
+				return nil, err
+			}
+			for _, account := range accounts {
+				keys = append(keys, types.NamespacedName{
+					Namespace: opsRes.Cluster.Namespace,
+					Name:      constant.GenerateAccountSecretName(opsRes.Cluster.Name, compName, account),
+				})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// accountsToRotate returns the accounts of the component to rotate, all the accounts whose password is generated
+// by KubeBlocks are returned if no account is specified.
+func (r passwordRotationOpsHandler) accountsToRotate(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1.Cluster, compName string, specified []string) ([]string, error) {
+	comp := &appsv1.Component{}
+	compKey := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      constant.GenerateClusterComponentName(cluster.Name, compName),
+	}
+	if err := cli.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		return nil, err
+	}
+	compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, comp.Spec.CompDef)
+	if err != nil {
+		return nil, err
+	}
+	if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.AccountRotate == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the accountRotate action is not defined by the component definition "%s"`, compDef.Name))
+	}
+
+	referred := func(name string) bool {
+		for _, account := range comp.Spec.SystemAccounts {
+			if account.Name == name && account.SecretRef != nil {
+				return true
+			}
+		}
+		return false
+	}
+	var accounts []string
+	for _, account := range compDef.Spec.SystemAccounts {
+		if len(specified) > 0 && !slices.Contains(specified, account.Name) {
+			continue
+		}
+		if account.SecretRef != nil || referred(account.Name) {
+			if len(specified) > 0 {
+				return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the password of account "%s" is provided by the referenced secret and can't be rotated`, account.Name))
+			}
+			continue
+		}
+		accounts = append(accounts, account.Name)
+	}
+	for _, name := range specified {
+		if !slices.Contains(accounts, name) {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the account "%s" is not found in component "%s"`, name, compName))
+		}
+	}
+	return accounts, nil
+}
@@ -387,6 +387,11 @@ func (r rebuildInstanceOpsHandler) scaleOutCompReplicasAndSyncProgress(reqCtx in
 	}
 	// update component spec to scale out required instances.
 	workloadName := constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compSpec.Name)
+	lastPodSet, err := component.GenerateAllPodNamesToSetWithZones(compSpec.Replicas, compSpec.Instances,
+		compSpec.ZoneDistribution, compSpec.OfflineInstances, opsRes.Cluster.Name, compSpec.Name)
+	if err != nil {
+		return err
+	}
 	var allTemplateReplicas int32
 	for j := range compSpec.Instances {
 		insTpl := &compSpec.Instances[j]
@@ -397,7 +402,13 @@ func (r rebuildInstanceOpsHandler) scaleOutCompReplicasAndSyncProgress(reqCtx in
 		allTemplateReplicas += insTpl.GetReplicas()
 	}
 	compSpec.Replicas += int32(len(rebuildInstance.Instances))
-	if wrapper, ok := rebuildInsWrapper[""]; ok {
+	if compSpec.ZoneDistribution != nil {
+		// the replicas out of the instance templates are distributed across the zone templates,
+		// so the new pods are resolved by comparing the pod names before and after scaling out.
+		if err = r.setZoneScaleOutInsMap(opsRes.Cluster.Name, compSpec, lastPodSet, rebuildInsWrapper, scaleOutInsMap); err != nil {
+			return err
+		}
+	} else if wrapper, ok := rebuildInsWrapper[""]; ok {
 		setScaleOutInsMap(workloadName, "", compSpec.Replicas-allTemplateReplicas, compSpec.OfflineInstances, wrapper)
 	}
 
@@ -449,8 +460,8 @@ func (r rebuildInstanceOpsHandler) checkProgressForScalingOutPods(reqCtx intctrl
 	if err != nil {
 		return 0, 0, nil, err
 	}
-	currPodSet, _ := component.GenerateAllPodNamesToSet(compSpec.Replicas, compSpec.Instances, compSpec.OfflineInstances,
-		opsRes.Cluster.Name, compSpec.Name)
+	currPodSet, _ := component.GenerateAllPodNamesToSetWithZones(compSpec.Replicas, compSpec.Instances, compSpec.ZoneDistribution,
+		compSpec.OfflineInstances, opsRes.Cluster.Name, compSpec.Name)
 	for _, instance := range rebuildInstance.Instances {
 		progressDetail := r.getInstanceProgressDetail(*compStatus, instance.Name)
 		scalingOutPodName := r.getScalingOutPodNameFromMessage(progressDetail.Message)
@@ -508,6 +519,45 @@ func (r rebuildInstanceOpsHandler) checkProgressForScalingOutPods(reqCtx intctrl
 	return completedCount, failedCount, instancesNeedToOffline, nil
 }
 
+// setZoneScaleOutInsMap maps the instances to rebuild which are out of the instance templates to the new pods
+// created in the zone templates.
+func (r rebuildInstanceOpsHandler) setZoneScaleOutInsMap(clusterName string,
+	compSpec *appsv1.ClusterComponentSpec,
+	lastPodSet map[string]string,
+	rebuildInsWrapper map[string]*rebuildInstanceWrapper,
+	scaleOutInsMap map[string]string) error {
+	currPodSet, err := component.GenerateAllPodNamesToSetWithZones(compSpec.Replicas, compSpec.Instances,
+		compSpec.ZoneDistribution, compSpec.OfflineInstances, clusterName, compSpec.Name)
+	if err != nil {
+		return err
+	}
+	isUserTemplate := func(tplName string) bool {
+		return slices.ContainsFunc(compSpec.Instances, func(tpl appsv1.InstanceTemplate) bool {
+			return tpl.Name == tplName
+		})
+	}
+	var newPodNames []string
+	for podName, tplName := range currPodSet {
+		if _, ok := lastPodSet[podName]; !ok && !isUserTemplate(tplName) {
+			newPodNames = append(newPodNames, podName)
+		}
+	}
+	var insNames []string
+	for tplName, wrapper := range rebuildInsWrapper {
+		if !isUserTemplate(tplName) {
+			insNames = append(insNames, wrapper.insNames...)
+		}
+	}
+	slices.Sort(newPodNames)
+	slices.Sort(insNames)
+	for i := range insNames {
+		if i < len(newPodNames) {
+			scaleOutInsMap[insNames[i]] = newPodNames[i]
+		}
+	}
+	return nil
+}
+
 // offlineSpecifiedInstances to take the specific instances offline.
 func (r rebuildInstanceOpsHandler) offlineSpecifiedInstances(compSpec *appsv1.ClusterComponentSpec, clusterName string, instancesNeedToOffline []string) {
 	for _, insName := range instancesNeedToOffline {
@@ -33,6 +33,7 @@ import (
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -146,6 +147,10 @@ func (p *pipeline) doMergeImpl(parameters opsv1alpha1.ConfigurationItem) error {
 	}
 
 	configSpec := p.configSpec
+	if parameters.RollbackToRevision != "" {
+		p.updatedObject = newConfigObj
+		return p.doRollback(item, parameters.RollbackToRevision)
+	}
 	if item.ConfigFileParams == nil {
 		item.ConfigFileParams = make(map[string]appsv1alpha1.ConfigParams)
 	}
@@ -175,6 +180,70 @@ func (p *pipeline) doMergeImpl(parameters opsv1alpha1.ConfigurationItem) error {
 	return p.createUpdatePatch(item, configSpec)
 }
 
+// doRollback restores the parameters recorded in the revision, and forces the configuration template to be re-rendered,
+// so that the parameters updated after the revision are restored to the values rendered by the template.
+func (p *pipeline) doRollback(item *appsv1alpha1.ConfigurationItemDetail, revision string) error {
+	params, err := configctrl.GetRevisionParameters(p.ConfigMapObj, revision)
+	if err != nil {
+		p.isFailed = true
+		return err
+	}
+
+	changes := configctrl.DiffRevisionParameters(item.ConfigFileParams, params)
+	if len(changes) == 0 {
+		return nil
+	}
+	if _, err = intctrlutil.CheckAndPatchPayload(item, constant.RollbackPayload, map[string]string{
+		"revision":   revision,
+		"opsRequest": p.resource.OpsRequest.Name,
+	}); err != nil {
+		return err
+	}
+
+	// the parameters removed by the rollback are shown as deleted in the patch
+	patchParams := make(map[string]appsv1alpha1.ConfigParams, len(params))
+	for file, param := range params {
+		patchParams[file] = *param.DeepCopy()
+	}
+	for _, change := range changes {
+		if len(change.Name) == 0 {
+			p.isFileUpdated = true
+			continue
+		}
+		if change.Type != appsv1alpha1.ParameterDeleted {
+			continue
+		}
+		param := patchParams[change.File]
+		if param.Parameters == nil {
+			param.Parameters = make(map[string]*string)
+		}
+		param.Parameters[change.Name] = nil
+		patchParams[change.File] = param
+	}
+	for file, param := range patchParams {
+		if len(param.Parameters) == 0 {
+			continue
+		}
+		updatedParams := make(map[string]interface{}, len(param.Parameters))
+		for key, value := range param.Parameters {
+			if value != nil {
+				updatedParams[key] = *value
+			} else {
+				updatedParams[key] = nil
+			}
+		}
+		p.updatedParameters = append(p.updatedParameters, cfgcore.ParamPairs{
+			Key:           file,
+			UpdatedParams: updatedParams,
+		})
+	}
+
+	item.ConfigFileParams = params
+	patchItem := item.DeepCopy()
+	patchItem.ConfigFileParams = patchParams
+	return p.createUpdatePatch(patchItem, p.configSpec)
+}
+
 func (p *pipeline) createUpdatePatch(item *appsv1alpha1.ConfigurationItemDetail, configSpec *appsv1.ComponentConfigSpec) error {
 	if p.configConstraint == nil {
 		return nil
@@ -256,6 +256,10 @@ func mergeMaps(m1 map[string]*string, m2 map[string]*string) map[string]*string
 }
 
 func hasFileUpdate(config opsv1alpha1.ConfigurationItem) bool {
+	// the rollback restores the whole files for the config without constraint
+	if config.RollbackToRevision != "" {
+		return true
+	}
 	for _, key := range config.Keys {
 		if key.FileContent != "" {
 			return true
@@ -78,8 +78,8 @@ func (start StartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 		pgRes *progressResource,
 		compStatus *opsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
 		var err error
-		pgRes.createdPodSet, err = intctrlcomp.GenerateAllPodNamesToSet(pgRes.clusterComponent.Replicas, pgRes.clusterComponent.Instances,
-			pgRes.clusterComponent.OfflineInstances, opsRes.Cluster.Name, pgRes.fullComponentName)
+		pgRes.createdPodSet, err = intctrlcomp.GenerateAllPodNamesToSetWithZones(pgRes.clusterComponent.Replicas, pgRes.clusterComponent.Instances,
+			pgRes.clusterComponent.ZoneDistribution, pgRes.clusterComponent.OfflineInstances, opsRes.Cluster.Name, pgRes.fullComponentName)
 		if err != nil {
 			return 0, 0, err
 		}
@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	KubernetesProviderName = "kubernetes"
+	VaultProviderName      = "vault"
+)
+
+// New returns the Provider configured by the manager, the kubernetes provider is returned if it is not configured.
+func New() (Provider, error) {
+	name := strings.ToLower(viper.GetString(constant.CfgKeySecretStoreProvider))
+	switch name {
+	case "", KubernetesProviderName:
+		return &kubernetesProvider{}, nil
+	case VaultProviderName:
+		return newVaultProviderFromConfig()
+	default:
+		return nil, fmt.Errorf("unknown secret store provider: %s", name)
+	}
+}
+
+// IsEnabled checks whether an external secret store is configured.
+func IsEnabled() bool {
+	name := strings.ToLower(viper.GetString(constant.CfgKeySecretStoreProvider))
+	return name != "" && name != KubernetesProviderName
+}
+
+// kubernetesProvider keeps the credentials in the Kubernetes Secrets only, which is the behavior without a secret store:
+// nothing is found in the store so that the credentials are always generated or read from the Secrets by the callers.
+type kubernetesProvider struct{}
+
+var _ Provider = &kubernetesProvider{}
+
+func (p *kubernetesProvider) Name() string {
+	return KubernetesProviderName
+}
+
+func (p *kubernetesProvider) Get(_ context.Context, _ string) (map[string][]byte, error) {
+	return nil, ErrNotFound
+}
+
+func (p *kubernetesProvider) Put(_ context.Context, _ string, _ map[string][]byte) error {
+	return nil
+}
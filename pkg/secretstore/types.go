@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+)
+
+// ErrNotFound is returned by the Provider when the secret doesn't exist in the store.
+var ErrNotFound = errors.New("secret not found in the secret store")
+
+// Provider is the store where the credentials generated or read by KubeBlocks are kept, e.g., the passwords of the
+// system accounts, the TLS certificates and the backup encryption keys.
+//
+// The Kubernetes Secrets are still created to expose the credentials to the workloads, but the source of truth
+// is the store: the credentials are loaded from the store if present, and the generated ones are saved into it.
+type Provider interface {
+	// Name returns the name of the provider.
+	Name() string
+
+	// Get returns the data of the secret at the path, ErrNotFound is returned if it doesn't exist.
+	Get(ctx context.Context, path string) (map[string][]byte, error)
+
+	// Put creates or overwrites the secret at the path.
+	Put(ctx context.Context, path string, data map[string][]byte) error
+}
+
+// GetOrCreate loads the secret at the path from the store, or generates it with @generate and saves it into the store.
+func GetOrCreate(ctx context.Context, p Provider, path string, generate func() (map[string][]byte, error)) (map[string][]byte, error) {
+	data, err := p.Get(ctx, path)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to get secret %s from the %s secret store: %w", path, p.Name(), err)
+	}
+	if data, err = generate(); err != nil {
+		return nil, err
+	}
+	if err = p.Put(ctx, path, data); err != nil {
+		return nil, fmt.Errorf("failed to put secret %s into the %s secret store: %w", path, p.Name(), err)
+	}
+	return data, nil
+}
+
+// AccountPath returns the path of the secret of a component system account.
+func AccountPath(namespace, clusterName, compName, accountName string) string {
+	return path.Join(namespace, clusterName, compName, "accounts", accountName)
+}
+
+// UserAccountPath returns the path of the secret of an Account object.
+func UserAccountPath(namespace, name string) string {
+	return path.Join(namespace, "accounts", name)
+}
+
+// TLSPath returns the path of the TLS certificates of a component.
+func TLSPath(namespace, clusterName, compName string) string {
+	return path.Join(namespace, clusterName, compName, "tls")
+}
+
+// SecretPath returns the path of the secret which is referred by the name of a Kubernetes Secret, e.g., the backup encryption key.
+func SecretPath(namespace, name string) string {
+	return path.Join(namespace, "secrets", name)
+}
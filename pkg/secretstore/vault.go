@@ -0,0 +1,191 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	defaultVaultKVMount     = "secret"
+	defaultVaultPathPrefix  = "kubeblocks"
+	defaultVaultHTTPTimeout = 10 * time.Second
+)
+
+// vaultProvider keeps the credentials in the KV v2 secrets engine of HashiCorp Vault, by the HTTP API.
+type vaultProvider struct {
+	addr      string
+	token     string
+	tokenPath string
+	namespace string
+	mount     string
+	prefix    string
+	client    *http.Client
+}
+
+var _ Provider = &vaultProvider{}
+
+// vaultKVv2Response is the response of reading a secret from the KV v2 secrets engine.
+type vaultKVv2Response struct {
+	Data *struct {
+		Data     map[string]string `json:"data"`
+		Metadata struct {
+			DeletionTime string `json:"deletion_time"`
+			Destroyed    bool   `json:"destroyed"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+func newVaultProviderFromConfig() (*vaultProvider, error) {
+	p := &vaultProvider{
+		addr:      strings.TrimSuffix(viper.GetString(constant.CfgKeyVaultAddr), "/"),
+		token:     viper.GetString(constant.CfgKeyVaultToken),
+		tokenPath: viper.GetString(constant.CfgKeyVaultTokenPath),
+		namespace: viper.GetString(constant.CfgKeyVaultNamespace),
+		mount:     viper.GetString(constant.CfgKeyVaultKVMount),
+		prefix:    viper.GetString(constant.CfgKeyVaultPathPrefix),
+		client:    &http.Client{Timeout: defaultVaultHTTPTimeout},
+	}
+	if p.addr == "" {
+		return nil, fmt.Errorf("the address of vault is required, set it by %s", constant.CfgKeyVaultAddr)
+	}
+	if p.token == "" && p.tokenPath == "" {
+		return nil, fmt.Errorf("the token of vault is required, set it by %s or %s", constant.CfgKeyVaultToken, constant.CfgKeyVaultTokenPath)
+	}
+	if p.mount == "" {
+		p.mount = defaultVaultKVMount
+	}
+	if p.prefix == "" {
+		p.prefix = defaultVaultPathPrefix
+	}
+	return p, nil
+}
+
+func (p *vaultProvider) Name() string {
+	return VaultProviderName
+}
+
+func (p *vaultProvider) Get(ctx context.Context, secretPath string) (map[string][]byte, error) {
+	body, status, err := p.do(ctx, http.MethodGet, secretPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if status != http.StatusOK {
+		return nil, p.error(status, body)
+	}
+	rsp := &vaultKVv2Response{}
+	if err = json.Unmarshal(body, rsp); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of vault: %w", err)
+	}
+	// the latest version of the secret is deleted or destroyed
+	if rsp.Data == nil || rsp.Data.Data == nil || rsp.Data.Metadata.DeletionTime != "" || rsp.Data.Metadata.Destroyed {
+		return nil, ErrNotFound
+	}
+	data := make(map[string][]byte, len(rsp.Data.Data))
+	for k, v := range rsp.Data.Data {
+		data[k] = []byte(v)
+	}
+	return data, nil
+}
+
+func (p *vaultProvider) Put(ctx context.Context, secretPath string, data map[string][]byte) error {
+	values := make(map[string]string, len(data))
+	for k, v := range data {
+		values[k] = string(v)
+	}
+	payload, err := json.Marshal(map[string]any{"data": values})
+	if err != nil {
+		return err
+	}
+	body, status, err := p.do(ctx, http.MethodPost, secretPath, payload)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return p.error(status, body)
+	}
+	return nil
+}
+
+func (p *vaultProvider) do(ctx context.Context, method, secretPath string, payload []byte) ([]byte, int, error) {
+	token, err := p.getToken()
+	if err != nil {
+		return nil, 0, err
+	}
+	url := fmt.Sprintf("%s/v1/%s", p.addr, path.Join(p.mount, "data", p.prefix, secretPath))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rsp.Body.Close()
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, rsp.StatusCode, nil
+}
+
+// getToken reads the token from the file each time if configured, since it may be renewed by an agent.
+func (p *vaultProvider) getToken() (string, error) {
+	if p.tokenPath == "" {
+		return p.token, nil
+	}
+	token, err := os.ReadFile(p.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the token of vault: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+func (p *vaultProvider) error(status int, body []byte) error {
+	rsp := &vaultErrorResponse{}
+	if err := json.Unmarshal(body, rsp); err == nil && len(rsp.Errors) > 0 {
+		return fmt.Errorf("vault responded with status %d: %s", status, strings.Join(rsp.Errors, "; "))
+	}
+	return fmt.Errorf("vault responded with status %d", status)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// fakeVaultKVv2 serves the read and write API of the KV v2 secrets engine.
+type fakeVaultKVv2 struct {
+	sync.Mutex
+	token   string
+	secrets map[string]map[string]string
+}
+
+func (f *fakeVaultKVv2) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.Header.Get("X-Vault-Token") != f.token {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/v1/")
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := f.secrets[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data, "metadata": map[string]any{"version": 1}}})
+	case http.MethodPost, http.MethodPut:
+		req := map[string]map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.secrets[key] = req["data"]
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": 1}})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func setupVault(t *testing.T, addr, token string) {
+	viper.Set(constant.CfgKeySecretStoreProvider, VaultProviderName)
+	viper.Set(constant.CfgKeyVaultAddr, addr)
+	viper.Set(constant.CfgKeyVaultToken, token)
+	t.Cleanup(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+		viper.Set(constant.CfgKeyVaultAddr, "")
+		viper.Set(constant.CfgKeyVaultToken, "")
+		viper.Set(constant.CfgKeyVaultTokenPath, "")
+	})
+}
+
+func testVaultProvider(t *testing.T, p Provider) {
+	ctx := context.Background()
+	secretPath := AccountPath("default", "mycluster", "mysql", t.Name())
+
+	if _, err := p.Get(ctx, secretPath); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expect not found error, but got: %v", err)
+	}
+
+	generated := 0
+	generate := func() (map[string][]byte, error) {
+		generated++
+		return map[string][]byte{
+			constant.AccountNameForSecret:   []byte("root"),
+			constant.AccountPasswdForSecret: []byte("p@ssw0rd"),
+		}, nil
+	}
+	for i := 0; i < 2; i++ {
+		data, err := GetOrCreate(ctx, p, secretPath, generate)
+		if err != nil {
+			t.Fatalf("failed to get or create the secret: %v", err)
+		}
+		if string(data[constant.AccountPasswdForSecret]) != "p@ssw0rd" {
+			t.Errorf("unexpected password: %s", data[constant.AccountPasswdForSecret])
+		}
+	}
+	if generated != 1 {
+		t.Errorf("expect the secret is generated once, but got %d", generated)
+	}
+
+	if err := p.Put(ctx, secretPath, map[string][]byte{constant.AccountPasswdForSecret: []byte("rotated")}); err != nil {
+		t.Fatalf("failed to put the secret: %v", err)
+	}
+	data, err := p.Get(ctx, secretPath)
+	if err != nil {
+		t.Fatalf("failed to get the secret: %v", err)
+	}
+	if string(data[constant.AccountPasswdForSecret]) != "rotated" {
+		t.Errorf("unexpected password: %s", data[constant.AccountPasswdForSecret])
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	fake := &fakeVaultKVv2{token: "root", secrets: map[string]map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	setupVault(t, server.URL, "root")
+	p, err := New()
+	if err != nil {
+		t.Fatalf("failed to create the provider: %v", err)
+	}
+	if p.Name() != VaultProviderName {
+		t.Fatalf("expect vault provider, but got %s", p.Name())
+	}
+	testVaultProvider(t, p)
+
+	key := "secret/data/kubeblocks/default/mycluster/mysql/accounts/" + t.Name()
+	if _, ok := fake.secrets[key]; !ok {
+		t.Errorf("the secret is not saved at %s", key)
+	}
+}
+
+func TestVaultProviderTokenPath(t *testing.T) {
+	fake := &fakeVaultKVv2{token: "file-token", secrets: map[string]map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	setupVault(t, server.URL, "")
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte("wrong-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set(constant.CfgKeyVaultTokenPath, tokenPath)
+	p, err := New()
+	if err != nil {
+		t.Fatalf("failed to create the provider: %v", err)
+	}
+	if _, err = p.Get(context.Background(), "default/secrets/key"); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expect permission denied error, but got: %v", err)
+	}
+
+	// the renewed token is picked up
+	if err = os.WriteFile(tokenPath, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.Get(context.Background(), "default/secrets/key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expect not found error, but got: %v", err)
+	}
+}
+
+// TestVaultDevServer runs against a vault dev server, e.g. started by `vault server -dev -dev-root-token-id=root`,
+// it is skipped unless the address of the server is set by KB_TEST_VAULT_ADDR.
+func TestVaultDevServer(t *testing.T) {
+	addr := os.Getenv("KB_TEST_VAULT_ADDR")
+	if addr == "" {
+		t.Skip("KB_TEST_VAULT_ADDR is not set")
+	}
+	token := os.Getenv("KB_TEST_VAULT_TOKEN")
+	if token == "" {
+		token = "root"
+	}
+	setupVault(t, addr, token)
+	p, err := New()
+	if err != nil {
+		t.Fatalf("failed to create the provider: %v", err)
+	}
+	testVaultProvider(t, p)
+}
+
+func TestKubernetesProvider(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("failed to create the provider: %v", err)
+	}
+	if p.Name() != KubernetesProviderName || IsEnabled() {
+		t.Fatalf("expect the kubernetes provider by default, but got %s", p.Name())
+	}
+	generated := 0
+	for i := 0; i < 2; i++ {
+		if _, err = GetOrCreate(context.Background(), p, "default/secrets/key", func() (map[string][]byte, error) {
+			generated++
+			return map[string][]byte{"key": []byte("value")}, nil
+		}); err != nil {
+			t.Fatalf("failed to get or create the secret: %v", err)
+		}
+	}
+	if generated != 2 {
+		t.Errorf("expect the secret is always generated, but got %d", generated)
+	}
+}
+
+func TestUnknownProvider(t *testing.T) {
+	viper.Set(constant.CfgKeySecretStoreProvider, "unknown")
+	defer viper.Set(constant.CfgKeySecretStoreProvider, "")
+	if _, err := New(); err == nil {
+		t.Error("expect error for the unknown provider")
+	}
+}
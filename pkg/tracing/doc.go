@@ -0,0 +1,26 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package tracing traces the reconciliations by OpenTelemetry, a reconciliation is traced as a span
+with the transformers and the plan vertices as its children.
+It also keeps the explanations of the last reconciliations, which tell the outcome of each transformer,
+the plan built and the requeue reason, and serves them by a debug endpoint.
+*/
+package tracing
@@ -0,0 +1,217 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/lru"
+)
+
+// ExplainPath is the path of the debug endpoint to explain the last reconciliation of an object.
+const ExplainPath = "/debug/explain"
+
+const maxExplanations = 1024
+
+// the outcomes of a transformer
+const (
+	OutcomeSucceed        = "Succeed"
+	OutcomeFailed         = "Failed"
+	OutcomePrematureStop  = "PrematureStop"
+	OutcomeDelayedRequeue = "DelayedRequeue"
+	OutcomeSkipped        = "Skipped"
+)
+
+var (
+	explainEnabled atomic.Bool
+	explanations   = lru.New(maxExplanations)
+)
+
+// EnableExplain enables to keep the explanations of the last reconciliations.
+func EnableExplain() {
+	explainEnabled.Store(true)
+}
+
+// Explanation explains what a reconciliation of an object did.
+type Explanation struct {
+	lock sync.Mutex
+
+	Kind         string               `json:"kind"`
+	Namespace    string               `json:"namespace"`
+	Name         string               `json:"name"`
+	StartTime    time.Time            `json:"startTime"`
+	Duration     string               `json:"duration,omitempty"`
+	Transformers []TransformerOutcome `json:"transformers,omitempty"`
+	Plan         string               `json:"plan,omitempty"`
+	Requeue      string               `json:"requeue,omitempty"`
+	Error        string               `json:"error,omitempty"`
+}
+
+// TransformerOutcome is the outcome of a transformer, or a reconciler of the kubebuilderx.
+type TransformerOutcome struct {
+	Name     string `json:"name"`
+	Outcome  string `json:"outcome"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type explanationKey struct{}
+
+func explanationName(kind, namespace, name string) string {
+	return strings.ToLower(kind) + "/" + namespace + "/" + name
+}
+
+func newExplanation(kind string, key types.NamespacedName) *Explanation {
+	if !explainEnabled.Load() {
+		return nil
+	}
+	return &Explanation{
+		Kind:      kind,
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		StartTime: time.Now(),
+	}
+}
+
+func explanationFrom(ctx context.Context) *Explanation {
+	if ctx == nil {
+		return nil
+	}
+	explanation, _ := ctx.Value(explanationKey{}).(*Explanation)
+	return explanation
+}
+
+// RecordTransformer records the outcome of a transformer to the explanation in 'ctx'.
+func RecordTransformer(ctx context.Context, name, outcome string, start time.Time, err error) {
+	explanation := explanationFrom(ctx)
+	if explanation == nil {
+		return
+	}
+	result := TransformerOutcome{
+		Name:     name,
+		Outcome:  outcome,
+		Duration: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	explanation.lock.Lock()
+	defer explanation.lock.Unlock()
+	explanation.Transformers = append(explanation.Transformers, result)
+}
+
+// RecordPlan records the plan built to the explanation in 'ctx'.
+func RecordPlan(ctx context.Context, plan fmt.Stringer) {
+	explanation := explanationFrom(ctx)
+	if explanation == nil || plan == nil {
+		return
+	}
+	explanation.lock.Lock()
+	defer explanation.lock.Unlock()
+	explanation.Plan = plan.String()
+}
+
+// RecordRequeue records the reason of the requeue to the explanation in 'ctx'.
+func RecordRequeue(ctx context.Context, reason string) {
+	explanation := explanationFrom(ctx)
+	if explanation == nil {
+		return
+	}
+	explanation.lock.Lock()
+	defer explanation.lock.Unlock()
+	explanation.Requeue = reason
+}
+
+func (e *Explanation) finish(res ctrl.Result, err error) {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.Duration = time.Since(e.StartTime).String()
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if len(e.Requeue) == 0 {
+		switch {
+		case res.RequeueAfter > 0:
+			e.Requeue = fmt.Sprintf("requeue after %s", res.RequeueAfter)
+		case res.Requeue:
+			e.Requeue = "requeue"
+		}
+	}
+	explanations.Put(explanationName(e.Kind, e.Namespace, e.Name), e)
+}
+
+// Explain returns the explanation of the last reconciliation of the object.
+func Explain(kind, namespace, name string) (*Explanation, bool) {
+	value, ok := explanations.Get(explanationName(kind, namespace, name))
+	if !ok {
+		return nil, false
+	}
+	explanation := value.(*Explanation)
+	explanation.lock.Lock()
+	defer explanation.lock.Unlock()
+	return &Explanation{
+		Kind:         explanation.Kind,
+		Namespace:    explanation.Namespace,
+		Name:         explanation.Name,
+		StartTime:    explanation.StartTime,
+		Duration:     explanation.Duration,
+		Transformers: append([]TransformerOutcome{}, explanation.Transformers...),
+		Plan:         explanation.Plan,
+		Requeue:      explanation.Requeue,
+		Error:        explanation.Error,
+	}, true
+}
+
+// ExplainHandler serves the explanations, e.g. GET /debug/explain?kind=Cluster&namespace=default&name=mycluster
+func ExplainHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		kind, namespace, name := query.Get("kind"), query.Get("namespace"), query.Get("name")
+		if len(kind) == 0 || len(name) == 0 {
+			http.Error(w, "the kind and name of the object are required", http.StatusBadRequest)
+			return
+		}
+		if len(namespace) == 0 {
+			namespace = "default"
+		}
+		explanation, ok := Explain(kind, namespace, name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("no reconciliation of %s %s/%s recorded", kind, namespace, name), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(explanation)
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type testPlan string
+
+func (p testPlan) String() string {
+	return string(p)
+}
+
+func TestExplain(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "mycluster"}
+
+	// nothing recorded if not enabled
+	explainEnabled.Store(false)
+	ctx, end := StartReconcile(context.Background(), "Cluster", key)
+	RecordTransformer(ctx, "clusterMetaTransformer", OutcomeSucceed, time.Now(), nil)
+	end(ctrl.Result{}, nil)
+	_, ok := Explain("Cluster", key.Namespace, key.Name)
+	assert.False(t, ok)
+
+	EnableExplain()
+	ctx, end = StartReconcile(context.Background(), "Cluster", key)
+	RecordTransformer(ctx, "clusterMetaTransformer", OutcomeSucceed, time.Now(), nil)
+	RecordTransformer(ctx, "clusterStatusTransformer", OutcomeFailed, time.Now(), errors.New("failed"))
+	RecordPlan(ctx, testPlan("|->cluster"))
+	end(ctrl.Result{RequeueAfter: time.Second}, nil)
+
+	explanation, ok := Explain("cluster", key.Namespace, key.Name)
+	assert.True(t, ok)
+	assert.Equal(t, "Cluster", explanation.Kind)
+	assert.Len(t, explanation.Transformers, 2)
+	assert.Equal(t, OutcomeFailed, explanation.Transformers[1].Outcome)
+	assert.Equal(t, "failed", explanation.Transformers[1].Error)
+	assert.Equal(t, "|->cluster", explanation.Plan)
+	assert.Equal(t, "requeue after 1s", explanation.Requeue)
+
+	// the requeue reason recorded takes precedence
+	ctx, end = StartReconcile(context.Background(), "Cluster", key)
+	RecordRequeue(ctx, "wait for the components")
+	end(ctrl.Result{RequeueAfter: time.Second}, nil)
+	explanation, _ = Explain("Cluster", key.Namespace, key.Name)
+	assert.Equal(t, "wait for the components", explanation.Requeue)
+	assert.Empty(t, explanation.Transformers)
+}
+
+func TestExplainHandler(t *testing.T) {
+	EnableExplain()
+	key := types.NamespacedName{Namespace: "default", Name: "myits"}
+	_, end := StartReconcile(context.Background(), "InstanceSet", key)
+	end(ctrl.Result{}, errors.New("conflict"))
+
+	handler := ExplainHandler()
+	serve := func(url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusBadRequest, serve(ExplainPath+"?kind=InstanceSet").Code)
+	assert.Equal(t, http.StatusNotFound, serve(ExplainPath+"?kind=InstanceSet&name=foo").Code)
+
+	resp := serve(ExplainPath + "?kind=InstanceSet&name=myits")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	explanation := &Explanation{}
+	assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), explanation))
+	assert.Equal(t, "myits", explanation.Name)
+	assert.Equal(t, "conflict", explanation.Error)
+}
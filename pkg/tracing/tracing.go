@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const tracerName = "github.com/apecloud/kubeblocks"
+
+// Tracer returns the tracer of KubeBlocks, it is a no-op one if the tracing is not set up.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Setup sets up the global tracer provider which exports the spans to the OTLP gRPC 'endpoint',
+// e.g. "http://otel-collector:4317". 'ratio' is the sampling ratio of the root spans.
+// It returns a func to flush and shut down the provider.
+func Setup(ctx context.Context, service, endpoint string, ratio float64) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(service)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartReconcile starts the span of reconciling the object of 'kind' and 'key', and attaches an explanation
+// of this reconciliation to the returned context if the explaining is enabled.
+// The returned func should be called with the reconcile result to end them.
+func StartReconcile(ctx context.Context, kind string, key types.NamespacedName) (context.Context, func(ctrl.Result, error)) {
+	ctx, span := Tracer().Start(ctx, "Reconcile "+kind, trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("namespace", key.Namespace),
+		attribute.String("name", key.Name),
+	))
+	explanation := newExplanation(kind, key)
+	if explanation != nil {
+		ctx = context.WithValue(ctx, explanationKey{}, explanation)
+	}
+	return ctx, func(res ctrl.Result, err error) {
+		explanation.finish(res, err)
+		EndSpan(span, err)
+		if res.RequeueAfter > 0 {
+			span.SetAttributes(attribute.String("requeueAfter", res.RequeueAfter.String()))
+		}
+	}
+}
+
+// StartSpan starts a child span of the span in 'ctx'.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the 'span' with the status decided by 'err'.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
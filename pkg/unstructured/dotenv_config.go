@@ -0,0 +1,159 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const dotenvExportPrefix = "export "
+
+// dotenvConfig is a format-preserving dotenv config, the comments, the order and the quotes of
+// the untouched lines are kept as they are.
+type dotenvConfig struct {
+	name string
+	doc  *lineDocument
+}
+
+func init() {
+	CfgObjectRegistry().RegisterConfigCreator(appsv1beta1.Dotenv, func(name string) ConfigObject {
+		return &dotenvConfig{name: name, doc: &lineDocument{}}
+	})
+}
+
+func (d *dotenvConfig) Update(key string, value any) error {
+	v := cast.ToString(value)
+	if line := d.doc.lookup("", key); line != nil {
+		line.setValue(encodeDotenvValue(v, line.rawValue), v)
+		return nil
+	}
+	indent, sep := d.doc.paramStyle("", "", "=")
+	d.doc.append(newParamLine("", key, indent, sep, encodeDotenvValue(v, ""), v))
+	return nil
+}
+
+func (d *dotenvConfig) RemoveKey(key string) error {
+	if line := d.doc.lookup("", key); line != nil {
+		d.doc.remove(line)
+	}
+	return nil
+}
+
+func (d *dotenvConfig) Get(key string) interface{} {
+	if line := d.doc.lookup("", key); line != nil {
+		return line.value
+	}
+	return nil
+}
+
+func (d *dotenvConfig) GetString(key string) (string, error) {
+	return cast.ToStringE(d.Get(key))
+}
+
+func (d *dotenvConfig) GetAllParameters() map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, line := range d.doc.params() {
+		params[strings.ToLower(line.key)] = line.value
+	}
+	return params
+}
+
+func (d *dotenvConfig) SubConfig(key string) ConfigObject {
+	return nil
+}
+
+func (d *dotenvConfig) Marshal() (string, error) {
+	return d.doc.String(), nil
+}
+
+func (d *dotenvConfig) Unmarshal(str string) error {
+	lines, trailingNewline := splitLines(str)
+	doc := &lineDocument{trailingNewline: trailingNewline}
+	for i, raw := range lines {
+		text, cr := trimCR(raw)
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed[0] == '#' {
+			doc.lines = append(doc.lines, newRawLine("", raw))
+			continue
+		}
+		line, err := parseDotenvLine(text)
+		if err != nil {
+			return fmt.Errorf("failed to parse the dotenv config at line %d: %s", i+1, err.Error())
+		}
+		line.raw = raw
+		line.suffix += cr
+		doc.lines = append(doc.lines, line)
+	}
+	d.doc = doc
+	return nil
+}
+
+func parseDotenvLine(text string) (*configLine, error) {
+	body := strings.TrimLeft(text, " \t")
+	if strings.HasPrefix(body, dotenvExportPrefix) {
+		body = strings.TrimLeft(body[len(dotenvExportPrefix):], " \t")
+	}
+	prefix, key, value, ok := splitParamLine(body, "=:")
+	if !ok {
+		return nil, fmt.Errorf("key-value delimiter not found: %s", strings.TrimSpace(text))
+	}
+	prefix = text[:len(text)-len(body)] + prefix
+
+	var decoded, rawValue, suffix string
+	if quoted, rest, ok := splitQuotedValue(value); ok {
+		rawValue, suffix = quoted, rest
+		decoded = quoted[1 : len(quoted)-1]
+		if quoted[0] == '"' {
+			decoded = unescapeDotenv(decoded)
+		}
+	} else {
+		rawValue, suffix = splitInlineComment(strings.TrimRight(value, " \t"), "#")
+		suffix += value[len(strings.TrimRight(value, " \t")):]
+		decoded = rawValue
+	}
+	return &configLine{
+		kind:     paramLine,
+		key:      key,
+		value:    decoded,
+		prefix:   prefix,
+		rawValue: rawValue,
+		suffix:   suffix,
+	}, nil
+}
+
+func unescapeDotenv(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(s)
+}
+
+// encodeDotenvValue quotes the value if needed, and keeps the quote style of the original value.
+func encodeDotenvValue(v string, original string) string {
+	if strings.HasPrefix(original, "'") && !strings.ContainsAny(v, "'\n") {
+		return "'" + v + "'"
+	}
+	if strings.HasPrefix(original, `"`) || strings.ContainsAny(v, " \t\n\r#\"'\\$") {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(v) + `"`
+	}
+	return v
+}
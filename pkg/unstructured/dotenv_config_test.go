@@ -0,0 +1,66 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+func TestDotenvConfig(t *testing.T) {
+	const dotenvContext = `# weaviate modules
+ENABLE_MODULES=text2vec-openai   # the vectorizer
+export QUERY_DEFAULTS_LIMIT="25"
+GREETING='hello world'
+`
+	cfg, err := LoadConfig("dotenv_test", dotenvContext, appsv1beta1.Dotenv)
+	assert.Nil(t, err)
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, dotenvContext, dumpContext)
+	assert.EqualValues(t, map[string]interface{}{
+		"enable_modules":       "text2vec-openai",
+		"query_defaults_limit": "25",
+		"greeting":             "hello world",
+	}, cfg.GetAllParameters())
+
+	assert.Nil(t, cfg.Update("ENABLE_MODULES", "text2vec-cohere"))
+	assert.Nil(t, cfg.Update("QUERY_DEFAULTS_LIMIT", "100"))
+	assert.Nil(t, cfg.Update("GREETING", "hi"))
+	assert.Nil(t, cfg.Update("AUTHENTICATION_APIKEY_USERS", "admin user"))
+	assert.Nil(t, cfg.RemoveKey("NOT_EXIST"))
+
+	dumpContext, err = cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, `# weaviate modules
+ENABLE_MODULES=text2vec-cohere   # the vectorizer
+export QUERY_DEFAULTS_LIMIT="100"
+GREETING='hi'
+AUTHENTICATION_APIKEY_USERS="admin user"
+`, dumpContext)
+	assert.EqualValues(t, "admin user", cfg.Get("AUTHENTICATION_APIKEY_USERS"))
+
+	assert.Nil(t, cfg.RemoveKey("GREETING"))
+	assert.Nil(t, cfg.Get("GREETING"))
+}
@@ -0,0 +1,339 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spf13/cast"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const hclIndent = "  "
+
+// hclConfig is a format-preserving hcl config, the comments, the order and the formatting of
+// the untouched lines are kept as they are, and the values are decoded by the hcl decoder.
+type hclConfig struct {
+	name   string
+	doc    *lineDocument
+	values map[string]interface{}
+}
+
+func init() {
+	CfgObjectRegistry().RegisterConfigCreator(appsv1beta1.HCL, func(name string) ConfigObject {
+		return &hclConfig{name: name, doc: &lineDocument{}, values: map[string]interface{}{}}
+	})
+}
+
+func (h *hclConfig) Update(key string, value any) error {
+	return h.modify(func() error {
+		if line := h.findParam(key); line != nil {
+			rawValue, err := encodeHCLValue(value, line.rawValue)
+			if err != nil {
+				return err
+			}
+			line.setValue(rawValue, "")
+			return nil
+		}
+
+		rawValue, err := encodeHCLValue(value, "")
+		if err != nil {
+			return err
+		}
+		block, paramKey := h.splitKey(key)
+		if block == "" && !strings.Contains(paramKey, DelimiterDot) {
+			indent, sep := h.doc.paramStyle("", "", " = ")
+			h.doc.insert(h.doc.rootEnd(), newParamLine("", paramKey, indent, sep, rawValue, ""))
+			return nil
+		}
+		if block == "" {
+			if !h.doc.isLastLineBlank() {
+				h.doc.append(newRawLine("", ""))
+			}
+			h.doc.append(h.newBlockLines(block, paramKey, "", rawValue)...)
+			return nil
+		}
+		pos, indent := h.blockEnd(block)
+		h.doc.insert(pos, h.newBlockLines(block, paramKey, indent, rawValue)...)
+		return nil
+	})
+}
+
+func (h *hclConfig) RemoveKey(key string) error {
+	return h.modify(func() error {
+		if line := h.findParam(key); line != nil {
+			h.doc.remove(line)
+		}
+		return nil
+	})
+}
+
+func (h *hclConfig) Get(key string) interface{} {
+	return searchNestedValue(h.values, key)
+}
+
+func (h *hclConfig) GetString(key string) (string, error) {
+	return cast.ToStringE(h.Get(key))
+}
+
+func (h *hclConfig) GetAllParameters() map[string]interface{} {
+	return h.values
+}
+
+func (h *hclConfig) SubConfig(key string) ConfigObject {
+	return newSubConfig(h, key)
+}
+
+func (h *hclConfig) Marshal() (string, error) {
+	return h.doc.String(), nil
+}
+
+func (h *hclConfig) Unmarshal(str string) error {
+	lines, trailingNewline := splitLines(str)
+	doc := &lineDocument{trailingNewline: trailingNewline}
+	var (
+		blocks    []string
+		inComment bool
+	)
+	currentBlock := func() string {
+		return strings.Join(blocks, DelimiterDot)
+	}
+	for i := 0; i < len(lines); i++ {
+		raw := lines[i]
+		text, cr := trimCR(raw)
+		trimmed := strings.TrimSpace(text)
+		body, _ := splitHCLComment(trimmed)
+		switch {
+		case inComment:
+			inComment = !strings.Contains(trimmed, "*/")
+			doc.lines = append(doc.lines, newRawLine(currentBlock(), raw))
+			continue
+		case strings.HasPrefix(trimmed, "/*"):
+			inComment = !strings.Contains(trimmed[2:], "*/")
+			doc.lines = append(doc.lines, newRawLine(currentBlock(), raw))
+			continue
+		case body == "":
+			doc.lines = append(doc.lines, newRawLine(currentBlock(), raw))
+			continue
+		case body == "}" || body == "},":
+			doc.lines = append(doc.lines, &configLine{kind: blockEndLine, raw: raw, section: currentBlock()})
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+			continue
+		case strings.HasSuffix(body, "{"):
+			header := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(strings.TrimSuffix(body, "{")), "="))
+			blocks = append(blocks, parseHCLBlockHeader(header))
+			doc.lines = append(doc.lines, &configLine{kind: sectionLine, raw: raw, section: currentBlock()})
+			continue
+		}
+
+		eq := indexOutsideQuotes(text, '=')
+		if eq < 0 {
+			return fmt.Errorf("failed to parse the hcl config at line %d: key-value delimiter not found", i+1)
+		}
+		value := strings.TrimLeft(text[eq+1:], " \t")
+		prefix := text[:len(text)-len(value)]
+		// the value of the heredoc or the multi-line list
+		for !isValidHCLValue(value) && i+1 < len(lines) {
+			i++
+			var next string
+			next, cr = trimCR(lines[i])
+			raw += "\n" + lines[i]
+			value += "\n" + next
+		}
+		rawValue, suffix := splitHCLComment(value)
+		if strings.HasPrefix(rawValue, "<<") {
+			rawValue, suffix = value, ""
+		}
+		doc.lines = append(doc.lines, &configLine{
+			kind:     paramLine,
+			raw:      raw,
+			section:  currentBlock(),
+			key:      unquoteHCLKey(strings.TrimSpace(text[:eq])),
+			prefix:   prefix,
+			rawValue: rawValue,
+			suffix:   suffix + cr,
+		})
+	}
+	h.doc = doc
+	return h.refresh()
+}
+
+// modify applies the modification, and reverts it if the modified config is invalid.
+func (h *hclConfig) modify(fn func() error) error {
+	snapshot := h.doc.snapshot()
+	err := fn()
+	if err == nil {
+		err = h.refresh()
+	}
+	if err != nil {
+		h.doc.restore(snapshot)
+	}
+	return err
+}
+
+func (h *hclConfig) refresh() error {
+	values := make(map[string]interface{})
+	if err := hcl.Unmarshal([]byte(h.doc.String()), &values); err != nil {
+		return err
+	}
+	h.values = lowerCaseKeys(values)
+	return nil
+}
+
+func (h *hclConfig) findParam(key string) *configLine {
+	for i := len(h.doc.lines) - 1; i >= 0; i-- {
+		l := h.doc.lines[i]
+		if l.kind == paramLine && strings.EqualFold(joinSectionKey(l.section, l.key), key) {
+			return l
+		}
+	}
+	return nil
+}
+
+// splitKey splits the key into the deepest existing block and the remaining dotted key.
+func (h *hclConfig) splitKey(key string) (string, string) {
+	block := ""
+	for _, s := range h.doc.sections() {
+		if len(s) > len(block) && len(key) > len(s)+1 && strings.EqualFold(key[:len(s)], s) && key[len(s):len(s)+1] == DelimiterDot {
+			block = s
+		}
+	}
+	if block != "" {
+		return block, key[len(block)+1:]
+	}
+	return "", key
+}
+
+// blockEnd returns the position of the closing brace of the last block, and the indent of its parameters.
+func (h *hclConfig) blockEnd(block string) (int, string) {
+	pos, indent := len(h.doc.lines), ""
+	for i, l := range h.doc.lines {
+		switch {
+		case l.kind == sectionLine && strings.EqualFold(l.section, block):
+			indent = leadingSpaces(l.String()) + hclIndent
+		case l.kind == blockEndLine && strings.EqualFold(l.section, block):
+			pos = i
+		}
+	}
+	for _, l := range h.doc.lines[:pos] {
+		if l.kind == paramLine && strings.EqualFold(l.section, block) {
+			indent = leadingSpaces(l.prefix)
+		}
+	}
+	return pos, indent
+}
+
+// newBlockLines renders the parameter, and the nested blocks for the dotted key.
+func (h *hclConfig) newBlockLines(block, key, indent, rawValue string) []*configLine {
+	segments := strings.Split(key, DelimiterDot)
+	var (
+		lines  []*configLine
+		closes []*configLine
+		path   = block
+	)
+	for _, s := range segments[:len(segments)-1] {
+		path = joinSectionKey(path, s)
+		lines = append(lines, &configLine{kind: sectionLine, raw: indent + s + " {", section: path})
+		closes = append([]*configLine{{kind: blockEndLine, raw: indent + "}", section: path}}, closes...)
+		indent += hclIndent
+	}
+	lines = append(lines, newParamLine(path, segments[len(segments)-1], indent, " = ", rawValue, ""))
+	return append(lines, closes...)
+}
+
+func leadingSpaces(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+}
+
+func parseHCLBlockHeader(header string) string {
+	var parts []string
+	for _, part := range strings.Fields(header) {
+		parts = append(parts, unquoteHCLKey(part))
+	}
+	return strings.Join(parts, DelimiterDot)
+}
+
+func unquoteHCLKey(key string) string {
+	if len(key) >= 2 && key[0] == '"' && key[len(key)-1] == '"' {
+		return key[1 : len(key)-1]
+	}
+	return key
+}
+
+// splitHCLComment splits the comment from the value, the "#" and "//" in the strings are not comments.
+func splitHCLComment(value string) (string, string) {
+	inString := false
+	for i := 0; i < len(value); i++ {
+		switch {
+		case inString && value[i] == '\\':
+			i++
+		case value[i] == '"':
+			inString = !inString
+		case inString:
+		case value[i] == '#' || strings.HasPrefix(value[i:], "//"):
+			trimmed := strings.TrimRight(value[:i], " \t")
+			return trimmed, value[len(trimmed):]
+		}
+	}
+	trimmed := strings.TrimRight(value, " \t")
+	return trimmed, value[len(trimmed):]
+}
+
+func isValidHCLValue(value string) bool {
+	_, err := decodeHCLValue(value)
+	return err == nil
+}
+
+func decodeHCLValue(value string) (interface{}, error) {
+	m := make(map[string]interface{})
+	if err := hcl.Unmarshal([]byte("v = "+value+"\n"), &m); err != nil {
+		return nil, err
+	}
+	return m["v"], nil
+}
+
+// encodeHCLValue encodes the value as a hcl literal, the string value is written as it is
+// if the original value is not a string, e.g. "100" for a number parameter.
+func encodeHCLValue(value any, original string) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if original != "" && original[0] != '"' && !strings.HasPrefix(original, "<<") {
+			if decoded, err := decodeHCLValue(v); err == nil {
+				if _, isString := decoded.(string); !isString {
+					return v, nil
+				}
+			}
+		}
+		b, err := json.Marshal(v)
+		return string(b), err
+	case map[string]interface{}:
+		return "", fmt.Errorf("not supported to update the block by the parameter: %v", value)
+	case []interface{}, []string, []int, []int64, []float64, []bool:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+	return cast.ToStringE(value)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const hclPreservingContext = `# vault config
+ui = true
+max_lease_ttl = "768h"
+
+/* the listener */
+listener "tcp" {
+  address     = "0.0.0.0:8200" // the listen address
+  tls_disable = 1
+}
+`
+
+func TestHCLConfigRoundTrip(t *testing.T) {
+	cfg, err := LoadConfig("hcl_test", hclPreservingContext, appsv1beta1.HCL)
+	assert.Nil(t, err)
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, hclPreservingContext, dumpContext)
+
+	assert.EqualValues(t, true, cfg.Get("ui"))
+	assert.EqualValues(t, "768h", cfg.Get("max_lease_ttl"))
+	assert.EqualValues(t, "0.0.0.0:8200", cfg.Get("listener.tcp.address"))
+}
+
+func TestHCLConfigUpdate(t *testing.T) {
+	cfg, err := LoadConfig("hcl_test", hclPreservingContext, appsv1beta1.HCL)
+	assert.Nil(t, err)
+
+	assert.Nil(t, cfg.Update("ui", "false"))
+	assert.Nil(t, cfg.Update("max_lease_ttl", "24h"))
+	assert.Nil(t, cfg.Update("listener.tcp.tls_disable", 0))
+	assert.Nil(t, cfg.Update("listener.tcp.cluster_address", "0.0.0.0:8201"))
+	assert.Nil(t, cfg.Update("disable_mlock", true))
+	assert.Nil(t, cfg.Update("storage.raft.path", "/vault/data"))
+	assert.Nil(t, cfg.RemoveKey("listener.tcp.address"))
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, `# vault config
+ui = false
+max_lease_ttl = "24h"
+disable_mlock = true
+
+/* the listener */
+listener "tcp" {
+  tls_disable = 0
+  cluster_address = "0.0.0.0:8201"
+}
+
+storage {
+  raft {
+    path = "/vault/data"
+  }
+}
+`, dumpContext)
+	assert.EqualValues(t, false, cfg.Get("ui"))
+	assert.EqualValues(t, "/vault/data", cfg.Get("storage.raft.path"))
+}
@@ -0,0 +1,224 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const (
+	iniDefaultSection = "default"
+	iniCommentChars   = "#;"
+)
+
+// iniConfig is a format-preserving ini config, the comments, the order and the formatting of
+// the untouched lines are kept as they are.
+type iniConfig struct {
+	name string
+	doc  *lineDocument
+}
+
+func init() {
+	CfgObjectRegistry().RegisterConfigCreator(appsv1beta1.Ini, func(name string) ConfigObject {
+		return &iniConfig{name: name, doc: &lineDocument{}}
+	})
+}
+
+func (c *iniConfig) Update(key string, value any) error {
+	section, paramKey := c.splitKey(key)
+	if paramKey == "" {
+		return fmt.Errorf("invalid ini parameter: %s", key)
+	}
+	rawValue := cast.ToString(value)
+	if line := c.doc.lookup(section, paramKey); line != nil {
+		line.setValue(rawValue, rawValue)
+		return nil
+	}
+
+	indent, sep := c.doc.paramStyle(section, "", "=")
+	param := newParamLine(section, paramKey, indent, sep, rawValue, rawValue)
+	if pos, ok := c.doc.sectionEnd(section); ok {
+		c.doc.insert(pos, param)
+		return nil
+	}
+	if section == "" {
+		c.doc.insert(c.doc.rootEnd(), param)
+		return nil
+	}
+	if !c.doc.isLastLineBlank() {
+		c.doc.append(newRawLine(section, ""))
+	}
+	c.doc.append(&configLine{kind: sectionLine, raw: "[" + section + "]", section: section}, param)
+	return nil
+}
+
+func (c *iniConfig) RemoveKey(key string) error {
+	if line := c.doc.lookup(c.splitKey(key)); line != nil {
+		c.doc.remove(line)
+	}
+	return nil
+}
+
+func (c *iniConfig) Get(key string) interface{} {
+	if section, ok := c.lookupSection(key); ok {
+		return c.sectionParameters(section)
+	}
+	if line := c.doc.lookup(c.splitKey(key)); line != nil {
+		return line.value
+	}
+	return nil
+}
+
+func (c *iniConfig) GetString(key string) (string, error) {
+	return cast.ToStringE(c.Get(key))
+}
+
+func (c *iniConfig) GetAllParameters() map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, line := range c.doc.params() {
+		section := strings.ToLower(line.section)
+		if section == "" {
+			section = iniDefaultSection
+		}
+		m, ok := params[section].(map[string]interface{})
+		if !ok {
+			m = make(map[string]interface{})
+			params[section] = m
+		}
+		m[strings.ToLower(line.key)] = line.value
+	}
+	return params
+}
+
+func (c *iniConfig) sectionParameters(section string) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, line := range c.doc.params() {
+		if strings.EqualFold(line.section, section) {
+			params[strings.ToLower(line.key)] = line.value
+		}
+	}
+	return params
+}
+
+func (c *iniConfig) SubConfig(key string) ConfigObject {
+	return newSubConfig(c, key)
+}
+
+func (c *iniConfig) Marshal() (string, error) {
+	return c.doc.String(), nil
+}
+
+func (c *iniConfig) Unmarshal(str string) error {
+	lines, trailingNewline := splitLines(str)
+	doc := &lineDocument{trailingNewline: trailingNewline}
+	section := ""
+	for i, raw := range lines {
+		line, err := parseIniLine(raw, section)
+		if err != nil {
+			return fmt.Errorf("failed to parse the ini config at line %d: %s", i+1, err.Error())
+		}
+		if line.kind == sectionLine {
+			section = line.section
+		}
+		doc.lines = append(doc.lines, line)
+	}
+	c.doc = doc
+	return nil
+}
+
+func parseIniLine(raw string, section string) (*configLine, error) {
+	text, cr := trimCR(raw)
+	trimmed := strings.TrimSpace(text)
+	switch {
+	case trimmed == "" || strings.ContainsRune(iniCommentChars, rune(trimmed[0])) || trimmed[0] == '!':
+		return newRawLine(section, raw), nil
+	case trimmed[0] == '[':
+		end := strings.IndexByte(trimmed, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed section: %s", trimmed)
+		}
+		name := strings.TrimSpace(trimmed[1:end])
+		if strings.EqualFold(name, iniDefaultSection) {
+			name = ""
+		}
+		return &configLine{kind: sectionLine, raw: raw, section: name}, nil
+	}
+
+	prefix, key, value, ok := splitParamLine(text, "=:")
+	if !ok {
+		return nil, fmt.Errorf("key-value delimiter not found: %s", trimmed)
+	}
+	rawValue, suffix := splitIniValue(value)
+	return &configLine{
+		kind:     paramLine,
+		raw:      raw,
+		section:  section,
+		key:      key,
+		value:    rawValue,
+		prefix:   prefix,
+		rawValue: rawValue,
+		suffix:   suffix + cr,
+	}, nil
+}
+
+func splitIniValue(value string) (string, string) {
+	if quoted, rest, ok := splitQuotedValue(value); ok {
+		return quoted, rest
+	}
+	return splitInlineComment(strings.TrimRight(value, " \t"), iniCommentChars)
+}
+
+// splitKey splits the key into the section and the parameter name, the section is matched against the existing sections first,
+// since the name of the section or the parameter may contain the delimiter.
+func (c *iniConfig) splitKey(key string) (string, string) {
+	section, paramKey := "", key
+	for _, s := range c.doc.sections() {
+		if len(s) > len(section) && len(key) > len(s) && strings.EqualFold(key[:len(s)], s) && key[len(s):len(s)+1] == DelimiterDot {
+			section, paramKey = s, key[len(s)+1:]
+		}
+	}
+	if section != "" {
+		return section, paramKey
+	}
+	if pos := strings.Index(key, DelimiterDot); pos > 0 {
+		section, paramKey = key[:pos], key[pos+1:]
+	}
+	if strings.EqualFold(section, iniDefaultSection) {
+		section = ""
+	}
+	return section, paramKey
+}
+
+func (c *iniConfig) lookupSection(key string) (string, bool) {
+	if strings.EqualFold(key, iniDefaultSection) {
+		return "", true
+	}
+	for _, s := range c.doc.sections() {
+		if strings.EqualFold(s, key) {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const iniPreservingContext = `# global settings
+user = mysql
+
+[client]
+; the client socket
+socket=/data/mysql/tmp/mysqld.sock
+
+[mysqld]
+# innodb
+innodb_buffer_pool_size = 512M   # 50% of the memory
+max_connections = 1000
+plugin-load = "rpl_semi_sync_master=semisync_master.so;rpl_semi_sync_slave=semisync_slave.so"
+`
+
+func TestIniConfigRoundTrip(t *testing.T) {
+	cfg, err := LoadConfig("ini_test", iniPreservingContext, appsv1beta1.Ini)
+	assert.Nil(t, err)
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, iniPreservingContext, dumpContext)
+
+	assert.EqualValues(t, "512M", cfg.Get("mysqld.innodb_buffer_pool_size"))
+	assert.EqualValues(t, "mysql", cfg.Get("default.user"))
+	assert.EqualValues(t, map[string]interface{}{
+		"default": map[string]interface{}{"user": "mysql"},
+		"client":  map[string]interface{}{"socket": "/data/mysql/tmp/mysqld.sock"},
+		"mysqld": map[string]interface{}{
+			"innodb_buffer_pool_size": "512M",
+			"max_connections":         "1000",
+			"plugin-load":             `"rpl_semi_sync_master=semisync_master.so;rpl_semi_sync_slave=semisync_slave.so"`,
+		},
+	}, cfg.GetAllParameters())
+}
+
+func TestIniConfigUpdate(t *testing.T) {
+	cfg, err := LoadConfig("ini_test", iniPreservingContext, appsv1beta1.Ini)
+	assert.Nil(t, err)
+
+	assert.Nil(t, cfg.Update("mysqld.innodb_buffer_pool_size", "1G"))
+	assert.Nil(t, cfg.Update("mysqld.binlog_format", "ROW"))
+	assert.Nil(t, cfg.Update("default.port", "3306"))
+	assert.Nil(t, cfg.Update("mysqld_safe.nice", "0"))
+	assert.Nil(t, cfg.RemoveKey("client.socket"))
+	assert.Nil(t, cfg.RemoveKey("mysqld.not_exist"))
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, `# global settings
+user = mysql
+port = 3306
+
+[client]
+; the client socket
+
+[mysqld]
+# innodb
+innodb_buffer_pool_size = 1G   # 50% of the memory
+max_connections = 1000
+plugin-load = "rpl_semi_sync_master=semisync_master.so;rpl_semi_sync_slave=semisync_slave.so"
+binlog_format = ROW
+
+[mysqld_safe]
+nice = 0
+`, dumpContext)
+
+	subConfig := cfg.SubConfig("mysqld")
+	assert.NotNil(t, subConfig)
+	assert.EqualValues(t, "1G", subConfig.Get("innodb_buffer_pool_size"))
+	assert.EqualValues(t, "ROW", subConfig.Get("binlog_format"))
+}
+
+func TestIniConfigBadCase(t *testing.T) {
+	_, err := LoadConfig("ini_test", "[mysqld\nmax_connections=1000", appsv1beta1.Ini)
+	assert.NotNil(t, err)
+
+	_, err = LoadConfig("ini_test", "[mysqld]\nmax_connections", appsv1beta1.Ini)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,455 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"fmt"
+	"strings"
+)
+
+type lineKind int
+
+const (
+	// rawLine is a blank line, a comment or any other line that is not interpreted, e.g. the mysql "!includedir".
+	rawLine lineKind = iota
+	sectionLine
+	paramLine
+	// blockEndLine is the line that closes a block, e.g. the "}" of hcl.
+	blockEndLine
+)
+
+// configLine is a logical line of a configuration file, it may span multiple physical lines.
+//
+// The original text of the line is kept, and only the lines that have been updated are re-rendered,
+// so that the comments, the order and the formatting of the untouched lines are preserved.
+type configLine struct {
+	kind lineKind
+	raw  string
+
+	// section is the name of the section (ini), table (toml) or block path (hcl) that the line belongs to,
+	// or the name of the section itself for the section line.
+	section string
+	key     string
+	value   string
+
+	// prefix is the text before the value, e.g. `port = `, and suffix is the text after the value, e.g. the inline comment.
+	prefix   string
+	rawValue string
+	suffix   string
+	updated  bool
+}
+
+func (l *configLine) String() string {
+	if l.updated {
+		return l.prefix + l.rawValue + l.suffix
+	}
+	return l.raw
+}
+
+func (l *configLine) setValue(rawValue, value string) {
+	l.rawValue = rawValue
+	l.value = value
+	l.updated = true
+}
+
+type lineDocument struct {
+	lines           []*configLine
+	trailingNewline bool
+}
+
+func splitLines(content string) ([]string, bool) {
+	if strings.TrimSpace(content) == "" {
+		return nil, false
+	}
+	trailingNewline := strings.HasSuffix(content, "\n")
+	if trailingNewline {
+		content = content[:len(content)-1]
+	}
+	return strings.Split(content, "\n"), trailingNewline
+}
+
+func (d *lineDocument) String() string {
+	if len(d.lines) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	for i, l := range d.lines {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(l.String())
+	}
+	if d.trailingNewline {
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func (d *lineDocument) empty() bool {
+	return len(d.lines) == 0
+}
+
+func (d *lineDocument) params() []*configLine {
+	var params []*configLine
+	for _, l := range d.lines {
+		if l.kind == paramLine {
+			params = append(params, l)
+		}
+	}
+	return params
+}
+
+// lookup returns the last occurrence of the parameter, the last one takes effect if the parameter is duplicated.
+func (d *lineDocument) lookup(section, key string) *configLine {
+	for i := len(d.lines) - 1; i >= 0; i-- {
+		l := d.lines[i]
+		if l.kind == paramLine && strings.EqualFold(l.section, section) && strings.EqualFold(l.key, key) {
+			return l
+		}
+	}
+	return nil
+}
+
+func (d *lineDocument) hasSection(section string) bool {
+	for _, l := range d.lines {
+		if l.kind == sectionLine && strings.EqualFold(l.section, section) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *lineDocument) sections() []string {
+	var sections []string
+	for _, l := range d.lines {
+		if l.kind == sectionLine {
+			sections = append(sections, l.section)
+		}
+	}
+	return sections
+}
+
+func (d *lineDocument) remove(line *configLine) {
+	for i, l := range d.lines {
+		if l == line {
+			d.lines = append(d.lines[:i], d.lines[i+1:]...)
+			return
+		}
+	}
+}
+
+func (d *lineDocument) insert(index int, lines ...*configLine) {
+	d.lines = append(d.lines[:index], append(lines, d.lines[index:]...)...)
+}
+
+func (d *lineDocument) append(lines ...*configLine) {
+	if len(d.lines) == 0 {
+		d.trailingNewline = true
+	}
+	d.lines = append(d.lines, lines...)
+}
+
+// sectionEnd returns the position after the last parameter or the header of the section.
+func (d *lineDocument) sectionEnd(section string) (int, bool) {
+	end, found := 0, false
+	for i, l := range d.lines {
+		if (l.kind == paramLine || l.kind == sectionLine) && strings.EqualFold(l.section, section) {
+			end, found = i+1, true
+		}
+	}
+	return end, found
+}
+
+// paramStyle returns the indent and the separator of an existing parameter,
+// so that the new parameters are rendered in the same style as the existing ones.
+// The padding used to align the separators is collapsed to a single space.
+func (d *lineDocument) paramStyle(section string, defaultIndent, defaultSep string) (string, string) {
+	indent, sep, found := defaultIndent, defaultSep, false
+	for _, l := range d.lines {
+		if l.kind != paramLine {
+			continue
+		}
+		trimmed := strings.TrimLeft(l.prefix, " \t")
+		if !strings.HasPrefix(trimmed, l.key) || len(trimmed) == len(l.key) {
+			continue
+		}
+		if !found || strings.EqualFold(l.section, section) {
+			sep = collapseSpaces(trimmed[len(l.key):])
+		}
+		if strings.EqualFold(l.section, section) {
+			indent = l.prefix[:len(l.prefix)-len(trimmed)]
+			return indent, sep
+		}
+		found = true
+	}
+	return indent, sep
+}
+
+func collapseSpaces(sep string) string {
+	delimiter := strings.TrimSpace(sep)
+	if delimiter == "" {
+		return " "
+	}
+	var buf strings.Builder
+	if strings.TrimLeft(sep, " \t") != sep {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(delimiter)
+	if strings.TrimRight(sep, " \t") != sep {
+		buf.WriteByte(' ')
+	}
+	return buf.String()
+}
+
+func newParamLine(section, key, indent, sep, rawValue, value string) *configLine {
+	return &configLine{
+		kind:     paramLine,
+		section:  section,
+		key:      key,
+		value:    value,
+		prefix:   indent + key + sep,
+		rawValue: rawValue,
+		updated:  true,
+	}
+}
+
+func newRawLine(section, raw string) *configLine {
+	return &configLine{
+		kind:    rawLine,
+		raw:     raw,
+		section: section,
+	}
+}
+
+// rootEnd returns the position for the new parameter that does not belong to any section,
+// which is after the last parameter before the first section, or before the first section.
+func (d *lineDocument) rootEnd() int {
+	pos := -1
+	for i, l := range d.lines {
+		switch {
+		case l.kind == sectionLine && pos < 0:
+			return i
+		case l.kind == sectionLine:
+			return pos
+		case l.kind == paramLine:
+			pos = i + 1
+		}
+	}
+	if pos < 0 {
+		return len(d.lines)
+	}
+	return pos
+}
+
+// snapshot returns a copy of the lines, which is used to revert the invalid modification.
+func (d *lineDocument) snapshot() []configLine {
+	lines := make([]configLine, 0, len(d.lines))
+	for _, l := range d.lines {
+		lines = append(lines, *l)
+	}
+	return lines
+}
+
+func (d *lineDocument) restore(snapshot []configLine) {
+	d.lines = make([]*configLine, 0, len(snapshot))
+	for i := range snapshot {
+		d.lines = append(d.lines, &snapshot[i])
+	}
+}
+
+// isLastLineBlank returns true if the document is empty or ends with a blank line.
+func (d *lineDocument) isLastLineBlank() bool {
+	return len(d.lines) == 0 || strings.TrimSpace(d.lines[len(d.lines)-1].String()) == ""
+}
+
+// splitInlineComment splits the inline comment from the value, the comment must be preceded by whitespace.
+func splitInlineComment(value string, commentChars string) (string, string) {
+	for i := 1; i < len(value); i++ {
+		if strings.IndexByte(commentChars, value[i]) >= 0 && (value[i-1] == ' ' || value[i-1] == '\t') {
+			trimmed := strings.TrimRight(value[:i], " \t")
+			return trimmed, value[len(trimmed):]
+		}
+	}
+	return value, ""
+}
+
+// splitQuotedValue splits the quoted string at the beginning of the value, and returns the remaining text.
+func splitQuotedValue(value string) (string, string, bool) {
+	if value == "" || (value[0] != '"' && value[0] != '\'') {
+		return "", "", false
+	}
+	quote := value[0]
+	for i := 1; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && quote == '"':
+			i++
+		case value[i] == quote:
+			return value[:i+1], value[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// splitParamLine splits a "key = value" line into the prefix, the key and the value.
+func splitParamLine(line string, separators string) (prefix, key, value string, ok bool) {
+	pos := strings.IndexAny(line, separators)
+	if pos < 0 {
+		return "", "", "", false
+	}
+	key = strings.TrimSpace(line[:pos])
+	if key == "" {
+		return "", "", "", false
+	}
+	rest := line[pos+1:]
+	value = strings.TrimLeft(rest, " \t")
+	prefix = line[:len(line)-len(value)]
+	return prefix, key, value, true
+}
+
+func lowerCaseKeys(m map[string]interface{}) map[string]interface{} {
+	r := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		r[strings.ToLower(k)] = lowerCaseValue(v)
+	}
+	return r
+}
+
+func lowerCaseValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return lowerCaseKeys(t)
+	case []map[string]interface{}:
+		r := make([]map[string]interface{}, 0, len(t))
+		for _, m := range t {
+			r = append(r, lowerCaseKeys(m))
+		}
+		return r
+	case []interface{}:
+		r := make([]interface{}, 0, len(t))
+		for _, e := range t {
+			r = append(r, lowerCaseValue(e))
+		}
+		return r
+	}
+	return v
+}
+
+// searchNestedValue returns the value of the dotted key in the nested map, the keys are case-insensitive.
+func searchNestedValue(m map[string]interface{}, key string) interface{} {
+	if m == nil {
+		return nil
+	}
+	if v, ok := m[strings.ToLower(key)]; ok {
+		return v
+	}
+	for i := strings.Index(key, DelimiterDot); i > 0; i = nextDelimiter(key, i) {
+		v, ok := m[strings.ToLower(key[:i])]
+		if !ok {
+			continue
+		}
+		if sub, ok := toNestedMap(v); ok {
+			if r := searchNestedValue(sub, key[i+1:]); r != nil {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+func nextDelimiter(key string, pos int) int {
+	next := strings.Index(key[pos+1:], DelimiterDot)
+	if next < 0 {
+		return -1
+	}
+	return pos + 1 + next
+}
+
+// subConfig is a view of the nested parameters of a ConfigObject, the updates are applied to the parent.
+type subConfig struct {
+	parent ConfigObject
+	prefix string
+}
+
+func newSubConfig(parent ConfigObject, prefix string) ConfigObject {
+	if _, ok := toNestedMap(parent.Get(prefix)); !ok {
+		return nil
+	}
+	return &subConfig{parent: parent, prefix: prefix}
+}
+
+// toNestedMap returns the nested parameters, the hcl block is decoded as a slice of maps.
+func toNestedMap(v interface{}) (map[string]interface{}, bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return t, true
+	case []map[string]interface{}:
+		if len(t) == 1 {
+			return t[0], true
+		}
+	}
+	return nil, false
+}
+
+func (s *subConfig) fullKey(key string) string {
+	return s.prefix + DelimiterDot + key
+}
+
+func (s *subConfig) Update(key string, value any) error {
+	return s.parent.Update(s.fullKey(key), value)
+}
+
+func (s *subConfig) RemoveKey(key string) error {
+	return s.parent.RemoveKey(s.fullKey(key))
+}
+
+func (s *subConfig) Get(key string) interface{} {
+	return s.parent.Get(s.fullKey(key))
+}
+
+func (s *subConfig) GetString(key string) (string, error) {
+	return s.parent.GetString(s.fullKey(key))
+}
+
+func (s *subConfig) GetAllParameters() map[string]interface{} {
+	if params, ok := toNestedMap(s.parent.Get(s.prefix)); ok {
+		return params
+	}
+	return map[string]interface{}{}
+}
+
+func (s *subConfig) SubConfig(key string) ConfigObject {
+	return newSubConfig(s.parent, s.fullKey(key))
+}
+
+func (s *subConfig) Marshal() (string, error) {
+	return "", fmt.Errorf("not supported to marshal the sub config: %s", s.prefix)
+}
+
+func (s *subConfig) Unmarshal(str string) error {
+	return fmt.Errorf("not supported to unmarshal the sub config: %s", s.prefix)
+}
+
+// trimCR splits the carriage return of the line, which is kept as it is when the line is re-rendered.
+func trimCR(line string) (string, string) {
+	if strings.HasSuffix(line, "\r") {
+		return line[:len(line)-1], "\r"
+	}
+	return line, ""
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+// propertiesLineConfig is a format-preserving properties config, e.g. the postgresql.conf.
+// Unlike the propertiesConfig of PropertiesPlus, the comments and the order of the parameters are kept as they are.
+type propertiesLineConfig struct {
+	name string
+	doc  *lineDocument
+}
+
+func init() {
+	CfgObjectRegistry().RegisterConfigCreator(appsv1beta1.Properties, func(name string) ConfigObject {
+		return &propertiesLineConfig{name: name, doc: &lineDocument{}}
+	})
+}
+
+func (p *propertiesLineConfig) Update(key string, value any) error {
+	v := cast.ToString(value)
+	rawValue := encodePropertiesValue(v)
+	if line := p.doc.lookup("", key); line != nil {
+		line.setValue(rawValue, v)
+		return nil
+	}
+	indent, sep := p.doc.paramStyle("", "", " = ")
+	p.doc.append(newParamLine("", key, indent, sep, rawValue, v))
+	return nil
+}
+
+func (p *propertiesLineConfig) RemoveKey(key string) error {
+	if line := p.doc.lookup("", key); line != nil {
+		p.doc.remove(line)
+	}
+	return nil
+}
+
+func (p *propertiesLineConfig) Get(key string) interface{} {
+	if line := p.doc.lookup("", key); line != nil {
+		return line.value
+	}
+	return nil
+}
+
+func (p *propertiesLineConfig) GetString(key string) (string, error) {
+	return cast.ToStringE(p.Get(key))
+}
+
+func (p *propertiesLineConfig) GetAllParameters() map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, line := range p.doc.params() {
+		params[strings.ToLower(line.key)] = line.value
+	}
+	return params
+}
+
+func (p *propertiesLineConfig) SubConfig(key string) ConfigObject {
+	return nil
+}
+
+func (p *propertiesLineConfig) Marshal() (string, error) {
+	return p.doc.String(), nil
+}
+
+func (p *propertiesLineConfig) Unmarshal(str string) error {
+	lines, trailingNewline := splitLines(str)
+	doc := &lineDocument{trailingNewline: trailingNewline}
+	for i := 0; i < len(lines); i++ {
+		raw := lines[i]
+		text, _ := trimCR(raw)
+		trimmed := strings.TrimLeft(text, " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			doc.lines = append(doc.lines, newRawLine("", raw))
+			continue
+		}
+		// join the continuation lines
+		logical, cr := trimCR(raw)
+		for hasContinuation(logical) && i+1 < len(lines) {
+			i++
+			var next string
+			next, cr = trimCR(lines[i])
+			raw += "\n" + lines[i]
+			logical = logical[:len(logical)-1] + strings.TrimLeft(next, " \t\f")
+		}
+		line := parsePropertiesLine(raw, logical)
+		line.suffix += cr
+		doc.lines = append(doc.lines, line)
+	}
+	p.doc = doc
+	return nil
+}
+
+func parsePropertiesLine(raw, logical string) *configLine {
+	trimmed := strings.TrimLeft(logical, " \t\f")
+	indent := logical[:len(logical)-len(trimmed)]
+
+	keyEnd := len(trimmed)
+	for i := 0; i < len(trimmed); i++ {
+		if trimmed[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte("=: \t\f", trimmed[i]) >= 0 {
+			keyEnd = i
+			break
+		}
+	}
+	rest := strings.TrimLeft(trimmed[keyEnd:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	rawValue := strings.TrimRight(rest, " \t\f")
+	return &configLine{
+		kind:     paramLine,
+		raw:      raw,
+		key:      unescapeProperties(trimmed[:keyEnd]),
+		value:    unescapeProperties(rawValue),
+		prefix:   indent + trimmed[:len(trimmed)-len(rest)],
+		rawValue: rawValue,
+		suffix:   rest[len(rawValue):],
+	}
+}
+
+func hasContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+func unescapeProperties(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			buf.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			buf.WriteByte('\t')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					buf.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			buf.WriteByte(s[i])
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return buf.String()
+}
+
+func encodePropertiesValue(v string) string {
+	return strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r", "\t", "\\t", "\f", "\\f").Replace(v)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+func TestPropertiesLineConfig(t *testing.T) {
+	const propertiesContext = `# -----------------------------
+# PostgreSQL configuration file
+# -----------------------------
+listen_addresses = '*'
+shared_buffers = '128MB'
+!comment
+search_path = '"$user", \
+    public'
+`
+	cfg, err := LoadConfig("prop_test", propertiesContext, appsv1beta1.Properties)
+	assert.Nil(t, err)
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, propertiesContext, dumpContext)
+	assert.EqualValues(t, `'"$user", public'`, cfg.Get("search_path"))
+
+	assert.Nil(t, cfg.Update("shared_buffers", "'1GB'"))
+	assert.Nil(t, cfg.Update("max_connections", "200"))
+	assert.Nil(t, cfg.RemoveKey("listen_addresses"))
+
+	dumpContext, err = cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, `# -----------------------------
+# PostgreSQL configuration file
+# -----------------------------
+shared_buffers = '1GB'
+!comment
+search_path = '"$user", \
+    public'
+max_connections = 200
+`, dumpContext)
+	assert.EqualValues(t, map[string]interface{}{
+		"shared_buffers":  "'1GB'",
+		"search_path":     `'"$user", public'`,
+		"max_connections": "200",
+	}, cfg.GetAllParameters())
+}
@@ -0,0 +1,336 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cast"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+// tomlArrayTableSuffix marks the section of the array of tables, e.g. [[servers]],
+// whose parameters are not addressable by the dotted key.
+const tomlArrayTableSuffix = "[]"
+
+// tomlConfig is a format-preserving toml config, the comments, the order and the formatting of
+// the untouched lines are kept as they are, and the values are decoded by the toml decoder.
+type tomlConfig struct {
+	name   string
+	doc    *lineDocument
+	values map[string]interface{}
+}
+
+func init() {
+	CfgObjectRegistry().RegisterConfigCreator(appsv1beta1.TOML, func(name string) ConfigObject {
+		return &tomlConfig{name: name, doc: &lineDocument{}, values: map[string]interface{}{}}
+	})
+}
+
+func (t *tomlConfig) Update(key string, value any) error {
+	return t.modify(func() error {
+		if line := t.findParam(key); line != nil {
+			rawValue, err := encodeTOMLValue(value, line.rawValue)
+			if err != nil {
+				return err
+			}
+			line.setValue(rawValue, "")
+			return nil
+		}
+
+		rawValue, err := encodeTOMLValue(value, "")
+		if err != nil {
+			return err
+		}
+		section, paramKey := t.splitKey(key)
+		indent, sep := t.doc.paramStyle(section, "", " = ")
+		param := newParamLine(section, paramKey, indent, sep, rawValue, "")
+		switch pos, ok := t.doc.sectionEnd(section); {
+		case section == "":
+			t.doc.insert(t.doc.rootEnd(), param)
+		case ok:
+			t.doc.insert(pos, param)
+		default:
+			if !t.doc.isLastLineBlank() {
+				t.doc.append(newRawLine(section, ""))
+			}
+			t.doc.append(&configLine{kind: sectionLine, raw: "[" + section + "]", section: section}, param)
+		}
+		return nil
+	})
+}
+
+func (t *tomlConfig) RemoveKey(key string) error {
+	return t.modify(func() error {
+		if line := t.findParam(key); line != nil {
+			t.doc.remove(line)
+		}
+		return nil
+	})
+}
+
+func (t *tomlConfig) Get(key string) interface{} {
+	return searchNestedValue(t.values, key)
+}
+
+func (t *tomlConfig) GetString(key string) (string, error) {
+	return cast.ToStringE(t.Get(key))
+}
+
+func (t *tomlConfig) GetAllParameters() map[string]interface{} {
+	return t.values
+}
+
+func (t *tomlConfig) SubConfig(key string) ConfigObject {
+	return newSubConfig(t, key)
+}
+
+func (t *tomlConfig) Marshal() (string, error) {
+	return t.doc.String(), nil
+}
+
+func (t *tomlConfig) Unmarshal(str string) error {
+	lines, trailingNewline := splitLines(str)
+	doc := &lineDocument{trailingNewline: trailingNewline}
+	section := ""
+	for i := 0; i < len(lines); i++ {
+		raw := lines[i]
+		text, cr := trimCR(raw)
+		trimmed := strings.TrimSpace(text)
+		switch {
+		case trimmed == "" || trimmed[0] == '#':
+			doc.lines = append(doc.lines, newRawLine(section, raw))
+			continue
+		case strings.HasPrefix(trimmed, "[["):
+			header, _ := splitTOMLComment(trimmed)
+			section = joinTOMLKey(strings.TrimSuffix(strings.TrimPrefix(header, "[["), "]]")) + tomlArrayTableSuffix
+			doc.lines = append(doc.lines, &configLine{kind: sectionLine, raw: raw, section: section})
+			continue
+		case trimmed[0] == '[':
+			header, _ := splitTOMLComment(trimmed)
+			section = joinTOMLKey(strings.TrimSuffix(strings.TrimPrefix(header, "["), "]"))
+			doc.lines = append(doc.lines, &configLine{kind: sectionLine, raw: raw, section: section})
+			continue
+		}
+
+		eq := indexOutsideQuotes(text, '=')
+		if eq < 0 {
+			return fmt.Errorf("failed to parse the toml config at line %d: key-value delimiter not found", i+1)
+		}
+		value := strings.TrimLeft(text[eq+1:], " \t")
+		prefix := text[:len(text)-len(value)]
+		// the value of the multi-line string or array
+		for !isValidTOMLValue(value) && i+1 < len(lines) {
+			i++
+			var next string
+			next, cr = trimCR(lines[i])
+			raw += "\n" + lines[i]
+			value += "\n" + next
+		}
+		rawValue, suffix := splitTOMLComment(value)
+		doc.lines = append(doc.lines, &configLine{
+			kind:     paramLine,
+			raw:      raw,
+			section:  section,
+			key:      joinTOMLKey(text[:eq]),
+			prefix:   prefix,
+			rawValue: rawValue,
+			suffix:   suffix + cr,
+		})
+	}
+	t.doc = doc
+	return t.refresh()
+}
+
+// modify applies the modification, and reverts it if the modified config is invalid.
+func (t *tomlConfig) modify(fn func() error) error {
+	snapshot := t.doc.snapshot()
+	err := fn()
+	if err == nil {
+		err = t.refresh()
+	}
+	if err != nil {
+		t.doc.restore(snapshot)
+	}
+	return err
+}
+
+func (t *tomlConfig) refresh() error {
+	values := make(map[string]interface{})
+	if err := toml.Unmarshal([]byte(t.doc.String()), &values); err != nil {
+		return err
+	}
+	t.values = lowerCaseKeys(values)
+	return nil
+}
+
+func (t *tomlConfig) findParam(key string) *configLine {
+	for i := len(t.doc.lines) - 1; i >= 0; i-- {
+		l := t.doc.lines[i]
+		if l.kind == paramLine && strings.EqualFold(joinSectionKey(l.section, l.key), key) {
+			return l
+		}
+	}
+	return nil
+}
+
+// splitKey splits the key into the deepest existing table and the remaining dotted key,
+// if no table matches, a new table is created for the key with the delimiter.
+func (t *tomlConfig) splitKey(key string) (string, string) {
+	section := ""
+	for _, s := range t.doc.sections() {
+		if len(s) > len(section) && len(key) > len(s)+1 && strings.EqualFold(key[:len(s)], s) && key[len(s):len(s)+1] == DelimiterDot {
+			section = s
+		}
+	}
+	if section != "" {
+		return section, key[len(section)+1:]
+	}
+	if pos := strings.LastIndex(key, DelimiterDot); pos > 0 {
+		return key[:pos], key[pos+1:]
+	}
+	return "", key
+}
+
+func joinSectionKey(section, key string) string {
+	if section == "" {
+		return key
+	}
+	return section + DelimiterDot + key
+}
+
+// joinTOMLKey normalizes the dotted key, e.g. `a . "b"` to `a.b`.
+func joinTOMLKey(key string) string {
+	var parts []string
+	for _, part := range splitOutsideQuotes(key, '.') {
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && (part[0] == '"' || part[0] == '\'') && part[len(part)-1] == part[0] {
+			part = part[1 : len(part)-1]
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, DelimiterDot)
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	for {
+		pos := indexOutsideQuotes(s, sep)
+		if pos < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:pos])
+		s = s[pos+1:]
+	}
+}
+
+func indexOutsideQuotes(s string, c byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\' && quote == '"':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote != 0:
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTOMLComment splits the comment from the value, the '#' in the strings is not a comment.
+func splitTOMLComment(value string) (string, string) {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case strings.HasPrefix(value[i:], `"""`) || strings.HasPrefix(value[i:], `'''`):
+			end := strings.Index(value[i+3:], value[i:i+3])
+			if end < 0 {
+				return value, ""
+			}
+			i += end + 5
+		case value[i] == '"' || value[i] == '\'':
+			end := indexOutsideQuotes(value[i:], value[i])
+			if end < 0 {
+				// the closing quote is treated as the opening one, skip to the next quote
+				next := strings.IndexByte(value[i+1:], value[i])
+				if next < 0 {
+					return value, ""
+				}
+				end = next + 1
+			}
+			i += end
+		case value[i] == '#':
+			trimmed := strings.TrimRight(value[:i], " \t")
+			return trimmed, value[len(trimmed):]
+		}
+	}
+	trimmed := strings.TrimRight(value, " \t")
+	return trimmed, value[len(trimmed):]
+}
+
+func isValidTOMLValue(value string) bool {
+	_, err := decodeTOMLValue(value)
+	return err == nil
+}
+
+func decodeTOMLValue(value string) (interface{}, error) {
+	m := make(map[string]interface{})
+	if err := toml.Unmarshal([]byte("v = "+value), &m); err != nil {
+		return nil, err
+	}
+	return m["v"], nil
+}
+
+// encodeTOMLValue encodes the value as a toml literal, the string value is written as it is
+// if the original value is not a string, e.g. "100" for an integer parameter.
+func encodeTOMLValue(value any, original string) (string, error) {
+	if s, ok := value.(string); ok {
+		if original != "" && original[0] != '"' && original[0] != '\'' {
+			if v, err := decodeTOMLValue(s); err == nil {
+				if _, isString := v.(string); !isString {
+					return s, nil
+				}
+			}
+		}
+		if strings.HasPrefix(original, "'") && !strings.ContainsAny(s, "'\r\n") {
+			return "'" + s + "'", nil
+		}
+		return quoteTOMLString(s), nil
+	}
+	if _, ok := value.(map[string]interface{}); ok {
+		return "", fmt.Errorf("not supported to update the table by the parameter: %v", value)
+	}
+	b, err := toml.Marshal(map[string]interface{}{"v": value})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(string(b), "v = ")), nil
+}
+
+func quoteTOMLString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s) + `"`
+}
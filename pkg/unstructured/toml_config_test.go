@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package unstructured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+const tomlPreservingContext = `# tidb config
+path = "pd:2379"   # the pd address
+mem-quota-query = 1073741824
+
+[log]
+level = 'info'
+slow-threshold = 300
+
+[performance]
+max-procs = 0
+plan-replayer-gc-lease = """
+10m"""
+`
+
+func TestTOMLConfigRoundTrip(t *testing.T) {
+	cfg, err := LoadConfig("toml_test", tomlPreservingContext, appsv1beta1.TOML)
+	assert.Nil(t, err)
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, tomlPreservingContext, dumpContext)
+
+	assert.EqualValues(t, "pd:2379", cfg.Get("path"))
+	assert.EqualValues(t, 1073741824, cfg.Get("mem-quota-query"))
+	assert.EqualValues(t, "info", cfg.Get("log.level"))
+	assert.EqualValues(t, "10m", cfg.Get("performance.plan-replayer-gc-lease"))
+}
+
+func TestTOMLConfigUpdate(t *testing.T) {
+	cfg, err := LoadConfig("toml_test", tomlPreservingContext, appsv1beta1.TOML)
+	assert.Nil(t, err)
+
+	assert.Nil(t, cfg.Update("path", "pd-0:2379"))
+	assert.Nil(t, cfg.Update("log.level", "warn"))
+	assert.Nil(t, cfg.Update("log.slow-threshold", "600"))
+	assert.Nil(t, cfg.Update("performance.txn-total-size-limit", 104857600))
+	assert.Nil(t, cfg.Update("oom-action", "cancel"))
+	assert.Nil(t, cfg.Update("security.ssl-ca", "/etc/tls/ca.crt"))
+	assert.Nil(t, cfg.RemoveKey("performance.plan-replayer-gc-lease"))
+
+	dumpContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, `# tidb config
+path = "pd-0:2379"   # the pd address
+mem-quota-query = 1073741824
+oom-action = "cancel"
+
+[log]
+level = 'warn'
+slow-threshold = 600
+
+[performance]
+max-procs = 0
+txn-total-size-limit = 104857600
+
+[security]
+ssl-ca = "/etc/tls/ca.crt"
+`, dumpContext)
+	assert.EqualValues(t, 600, cfg.Get("log.slow-threshold"))
+
+	// the invalid value is rejected and the config is kept as it was
+	assert.NotNil(t, cfg.Update("log", map[string]interface{}{"level": "info"}))
+	assert.NotNil(t, cfg.Update("log.level.file", "tidb.log"))
+	revertedContext, err := cfg.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, dumpContext, revertedContext)
+}
@@ -87,7 +87,7 @@ autovacuum_naptime = '1min'
 
 	dumpContext, err := propConfigObj.Marshal()
 	assert.Nil(t, err)
-	assert.EqualValues(t, dumpContext, propertiesContext[1:]) // trim "\n"
+	assert.EqualValues(t, dumpContext, propertiesContext)
 
 	assert.Nil(t, propConfigObj.Update("autovacuum_naptime", "'6min'"))
 	assert.EqualValues(t, propConfigObj.Get("autovacuum_naptime"), "'6min'")